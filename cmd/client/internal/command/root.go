@@ -37,10 +37,16 @@ func buildSendCommand(dependencies Dependencies) *cobra.Command {
 	var (
 		typeInput      string
 		recipientInput string
+		ccInputs       []string
+		bccInputs      []string
 		subjectInput   string
 		messageInput   string
+		htmlInput      string
 		scheduledInput string
 		attachmentArgs []string
+		idempotencyKey string
+		categoryInput  string
+		deliveryMode   string
 	)
 
 	command := &cobra.Command{
@@ -57,7 +63,27 @@ func buildSendCommand(dependencies Dependencies) *cobra.Command {
 				Recipient:        recipientInput,
 				Subject:          subjectInput,
 				Message:          messageInput,
+				HtmlMessage:      htmlInput,
+				IdempotencyKey:   strings.TrimSpace(idempotencyKey),
+				Category:         strings.TrimSpace(categoryInput),
+				DeliveryMode:     strings.ToLower(strings.TrimSpace(deliveryMode)),
 			}
+			switch request.DeliveryMode {
+			case "", "enqueue", "inline":
+			default:
+				return fmt.Errorf("invalid delivery mode %q", deliveryMode)
+			}
+			if notificationType == grpcapi.NotificationType_SMS && htmlInput != "" {
+				return fmt.Errorf("html messages are only supported for email notifications")
+			}
+			if notificationType == grpcapi.NotificationType_SMS && request.Category != "" {
+				return fmt.Errorf("categories are only supported for email notifications")
+			}
+			if notificationType == grpcapi.NotificationType_SMS && len(ccInputs)+len(bccInputs) > 0 {
+				return fmt.Errorf("cc and bcc recipients are only supported for email notifications")
+			}
+			request.Cc = ccInputs
+			request.Bcc = bccInputs
 
 			attachmentPayloads, attachmentErr := attachments.Load(attachmentArgs)
 			if attachmentErr != nil {
@@ -110,9 +136,15 @@ func buildSendCommand(dependencies Dependencies) *cobra.Command {
 
 	command.Flags().StringVar(&typeInput, "type", "", "Notification type (email or sms)")
 	command.Flags().StringVar(&recipientInput, "recipient", "", "Notification recipient")
+	command.Flags().StringArrayVar(&ccInputs, "cc", nil, "Email Cc recipient (repeatable)")
+	command.Flags().StringArrayVar(&bccInputs, "bcc", nil, "Email Bcc recipient (repeatable)")
 	command.Flags().StringVar(&subjectInput, "subject", "", "Email subject (ignored for sms)")
 	command.Flags().StringVar(&messageInput, "message", "", "Notification message")
+	command.Flags().StringVar(&htmlInput, "html-message", "", "Optional HTML body sent alongside the plain-text message (email only)")
 	command.Flags().StringVar(&scheduledInput, "scheduled-time", "", "RFC3339 timestamp for scheduled delivery")
+	command.Flags().StringVar(&categoryInput, "category", "", "Message category; adds one-click unsubscribe headers (email only)")
+	command.Flags().StringVar(&deliveryMode, "delivery-mode", "", "enqueue or inline; defaults to the server's dispatch mode")
+	command.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Idempotency key; generated automatically when omitted")
 	command.Flags().StringArrayVar(&attachmentArgs, "attachment", nil, "Attachment path (repeatable). Use path::content-type to override MIME type")
 
 	markRequired(command, "type")
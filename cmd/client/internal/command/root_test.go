@@ -38,6 +38,8 @@ func TestSendCommandBuildsRequest(t *testing.T) {
 		expectedErr    string
 		expectSchedule bool
 		expectedTime   time.Time
+		expectedCat    string
+		expectedMode   string
 	}{
 		{
 			name: "email without schedule",
@@ -50,6 +52,55 @@ func TestSendCommandBuildsRequest(t *testing.T) {
 			},
 			expectedType: grpcapi.NotificationType_EMAIL,
 		},
+		{
+			name: "email with category",
+			args: []string{
+				"send",
+				"--type", "email",
+				"--recipient", "user@example.com",
+				"--subject", "Subj",
+				"--message", "Body",
+				"--category", "promotions",
+			},
+			expectedType: grpcapi.NotificationType_EMAIL,
+			expectedCat:  "promotions",
+		},
+		{
+			name: "email delivered inline",
+			args: []string{
+				"send",
+				"--type", "email",
+				"--recipient", "user@example.com",
+				"--subject", "Subj",
+				"--message", "Body",
+				"--delivery-mode", "Inline",
+			},
+			expectedType: grpcapi.NotificationType_EMAIL,
+			expectedMode: "inline",
+		},
+		{
+			name: "invalid delivery mode fails",
+			args: []string{
+				"send",
+				"--type", "email",
+				"--recipient", "user@example.com",
+				"--subject", "Subj",
+				"--message", "Body",
+				"--delivery-mode", "later",
+			},
+			expectedErr: "invalid delivery mode \"later\"",
+		},
+		{
+			name: "sms with category fails",
+			args: []string{
+				"send",
+				"--type", "sms",
+				"--recipient", "+15551234567",
+				"--message", "OTP",
+				"--category", "promotions",
+			},
+			expectedErr: "categories are only supported for email notifications",
+		},
 		{
 			name: "sms with schedule",
 			args: []string{
@@ -131,6 +182,12 @@ func TestSendCommandBuildsRequest(t *testing.T) {
 			if request.NotificationType != testCase.expectedType {
 				t.Fatalf("expected type %v, got %v", testCase.expectedType, request.NotificationType)
 			}
+			if request.Category != testCase.expectedCat {
+				t.Fatalf("expected category %q, got %q", testCase.expectedCat, request.Category)
+			}
+			if request.DeliveryMode != testCase.expectedMode {
+				t.Fatalf("expected delivery mode %q, got %q", testCase.expectedMode, request.DeliveryMode)
+			}
 			if testCase.expectSchedule && request.ScheduledTime == nil {
 				t.Fatalf("expected schedule to be set")
 			}
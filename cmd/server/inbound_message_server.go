@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"github.com/temirov/pinguin/pkg/grpcapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+const (
+	defaultInboundMessageLimit = 100
+	maxInboundMessageLimit     = 500
+)
+
+// inboundMessageServiceServer implements grpcapi.InboundMessageServiceServer.
+type inboundMessageServiceServer struct {
+	grpcapi.UnimplementedInboundMessageServiceServer
+	inboundMessageService service.InboundMessageService
+	logger                *slog.Logger
+}
+
+func (server *inboundMessageServiceServer) ListInboundMessages(ctx context.Context, req *grpcapi.ListInboundMessagesRequest) (*grpcapi.ListInboundMessagesResponse, error) {
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0 || limit > maxInboundMessageLimit:
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", maxInboundMessageLimit)
+	case limit == 0:
+		limit = defaultInboundMessageLimit
+	}
+	messages, err := server.inboundMessageService.ListInboundMessages(ctx, model.InboundMessageListFilters{
+		FromNumber: req.GetFromNumber(),
+		Limit:      limit,
+	})
+	if err != nil {
+		server.logger.Error("Service ListInboundMessages error", "error", err)
+		return nil, err
+	}
+	grpcMessages := make([]*grpcapi.InboundMessage, 0, len(messages))
+	for _, message := range messages {
+		grpcMessages = append(grpcMessages, &grpcapi.InboundMessage{
+			Provider:          message.Provider,
+			ProviderMessageId: message.ProviderMessageID,
+			FromNumber:        message.FromNumber,
+			ToNumber:          message.ToNumber,
+			Body:              message.Body,
+			Keyword:           string(message.Keyword),
+			ReceivedAt:        timestamppb.New(message.ReceivedAt),
+		})
+	}
+	return &grpcapi.ListInboundMessagesResponse{InboundMessages: grpcMessages}, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/db"
+	"github.com/temirov/pinguin/internal/service"
+	"github.com/temirov/pinguin/pkg/grpcapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+func TestInboundMessageServerList(t *testing.T) {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	database, dbErr := db.InitDB(filepath.Join(t.TempDir(), "inbound.db"), logger)
+	if dbErr != nil {
+		t.Fatalf("init db error: %v", dbErr)
+	}
+	inboundService := service.NewInboundMessageService(database, service.NewSuppressionService(database, logger), nil, "", logger)
+	server := &inboundMessageServiceServer{inboundMessageService: inboundService, logger: logger}
+	ctx := context.Background()
+
+	for _, inbound := range []service.InboundSMS{
+		{Provider: "twilio", ProviderMessageID: "SM1", From: "+15550001111", To: "+15559990000", Body: "STOP"},
+		{Provider: "twilio", ProviderMessageID: "SM2", From: "+15550002222", To: "+15559990000", Body: "Thanks!"},
+	} {
+		if _, err := inboundService.ReceiveMessage(ctx, inbound); err != nil {
+			t.Fatalf("receive error: %v", err)
+		}
+	}
+
+	listed, listErr := server.ListInboundMessages(ctx, &grpcapi.ListInboundMessagesRequest{FromNumber: "+1 555 000 1111"})
+	if listErr != nil || len(listed.GetInboundMessages()) != 1 {
+		t.Fatalf("unexpected list response %#v (%v)", listed, listErr)
+	}
+	message := listed.GetInboundMessages()[0]
+	if message.GetProviderMessageId() != "SM1" || message.GetKeyword() != "stop" || message.GetReceivedAt() == nil {
+		t.Fatalf("unexpected inbound message %#v", message)
+	}
+
+	all, allErr := server.ListInboundMessages(ctx, &grpcapi.ListInboundMessagesRequest{})
+	if allErr != nil || len(all.GetInboundMessages()) != 2 {
+		t.Fatalf("expected both messages, got %#v (%v)", all, allErr)
+	}
+
+	_, invalidErr := server.ListInboundMessages(ctx, &grpcapi.ListInboundMessagesRequest{Limit: maxInboundMessageLimit + 1})
+	if status.Code(invalidErr) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for oversized limit, got %v", invalidErr)
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/temirov/pinguin/internal/config"
@@ -27,6 +29,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 	"log/slog"
 )
 
@@ -38,52 +41,30 @@ type notificationServiceServer struct {
 }
 
 func (server *notificationServiceServer) SendNotification(ctx context.Context, req *grpcapi.NotificationRequest) (*grpcapi.NotificationResponse, error) {
-	var internalType model.NotificationType
-	switch req.NotificationType {
-	case grpcapi.NotificationType_EMAIL:
-		internalType = model.NotificationEmail
-	case grpcapi.NotificationType_SMS:
-		internalType = model.NotificationSMS
-	default:
-		server.logger.Error("Unsupported notification type", "type", req.NotificationType)
-		return nil, fmt.Errorf("unsupported notification type: %v", req.NotificationType)
-	}
-
-	var scheduledFor *time.Time
-	if req.ScheduledTime != nil {
-		if err := req.ScheduledTime.CheckValid(); err != nil {
-			server.logger.Error("Invalid scheduled timestamp", "error", err)
-			return nil, status.Errorf(codes.InvalidArgument, "invalid scheduled_time: %v", err)
-		}
-		normalizedScheduled := req.ScheduledTime.AsTime().UTC()
-		scheduledFor = &normalizedScheduled
+	modelRequest, mapErr := server.mapGrpcNotificationRequest(req)
+	if mapErr != nil {
+		return nil, mapErr
 	}
 
 	recipientDigest := digestForLogging(req.Recipient)
-	subjectDigest := digestForLogging(req.Subject)
-	attachments := mapGrpcAttachments(req.GetAttachments())
 	server.logger.Info(
 		"notification_request_received",
 		"notification_type", req.NotificationType.String(),
-		"subject_digest", subjectDigest,
+		"subject_digest", digestForLogging(req.Subject),
 		"recipient_digest", recipientDigest,
-		"scheduled", scheduledFor != nil,
-		"attachment_count", len(attachments),
+		"scheduled", modelRequest.ScheduledFor != nil,
+		"attachment_count", len(modelRequest.Attachments),
+		"cc_count", len(req.GetCc()),
+		"bcc_count", len(req.GetBcc()),
+		"template_id", req.GetTemplateId(),
+		"idempotent", req.GetIdempotencyKey() != "",
+		"delivery_mode", req.GetDeliveryMode(),
 	)
 
-	modelRequest := model.NotificationRequest{
-		NotificationType: internalType,
-		Recipient:        req.Recipient,
-		Subject:          req.Subject,
-		Message:          req.Message,
-		ScheduledFor:     scheduledFor,
-		Attachments:      attachments,
-	}
-
 	modelResponse, err := server.notificationService.SendNotification(ctx, modelRequest)
 	if err != nil {
 		server.logger.Error("Service SendNotification error", "error", err)
-		return nil, err
+		return nil, mapSendNotificationError(err)
 	}
 
 	server.logger.Info(
@@ -110,24 +91,135 @@ func (server *notificationServiceServer) GetNotificationStatus(ctx context.Conte
 	return mapModelToGrpcResponse(modelResponse), nil
 }
 
+func (server *notificationServiceServer) GetNotificationAttempts(ctx context.Context, req *grpcapi.GetNotificationAttemptsRequest) (*grpcapi.GetNotificationAttemptsResponse, error) {
+	if req.GetNotificationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "notification_id is required")
+	}
+
+	attempts, err := server.notificationService.GetNotificationAttempts(ctx, req.GetNotificationId())
+	if err != nil {
+		if errors.Is(err, model.ErrNotificationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		server.logger.Error("Service GetNotificationAttempts error", "error", err)
+		return nil, err
+	}
+	return &grpcapi.GetNotificationAttemptsResponse{Attempts: mapModelAttempts(attempts)}, nil
+}
+
 func (server *notificationServiceServer) ListNotifications(ctx context.Context, req *grpcapi.ListNotificationsRequest) (*grpcapi.ListNotificationsResponse, error) {
-	filters := model.NotificationListFilters{}
-	if req != nil {
-		filters.Statuses = mapGrpcStatuses(req.GetStatuses())
+	filters, err := mapGrpcListFilters(req)
+	if err != nil {
+		return nil, err
 	}
 
-	responses, err := server.notificationService.ListNotifications(ctx, filters)
+	page, err := server.notificationService.ListNotifications(ctx, filters)
 	if err != nil {
+		if errors.Is(err, model.ErrInvalidNotificationListFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		server.logger.Error("Service ListNotifications error", "error", err)
 		return nil, err
 	}
 
-	grpcNotifications := make([]*grpcapi.NotificationResponse, 0, len(responses))
-	for _, response := range responses {
+	grpcNotifications := make([]*grpcapi.NotificationResponse, 0, len(page.Notifications))
+	for _, response := range page.Notifications {
 		grpcNotifications = append(grpcNotifications, mapModelToGrpcResponse(response))
 	}
 
-	return &grpcapi.ListNotificationsResponse{Notifications: grpcNotifications}, nil
+	return &grpcapi.ListNotificationsResponse{Notifications: grpcNotifications, NextPageToken: page.NextPageToken}, nil
+}
+
+// watchResyncInterval is how often a single-notification watch re-reads its notification. Events
+// only cover writes made by this process, so the re-read picks up transitions written by other
+// instances sharing the database.
+var watchResyncInterval = 5 * time.Second
+
+func (server *notificationServiceServer) WatchNotifications(req *grpcapi.WatchNotificationsRequest, stream grpcapi.NotificationService_WatchNotificationsServer) error {
+	types, err := mapGrpcNotificationTypes(req.GetTypes())
+	if err != nil {
+		return err
+	}
+	filter := service.NotificationWatchFilter{
+		NotificationID: req.GetNotificationId(),
+		BatchID:        req.GetBatchId(),
+		Statuses:       mapGrpcStatuses(req.GetStatuses()),
+		Types:          types,
+	}
+
+	ctx := stream.Context()
+	subscription, err := server.notificationService.WatchNotifications(ctx, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrNotificationNotFound):
+			return status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, service.ErrNotificationEventsClosed):
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+		server.logger.Error("Service WatchNotifications error", "error", err)
+		return err
+	}
+	defer subscription.Close()
+
+	var lastStatus model.NotificationStatus
+	send := func(notification model.NotificationResponse, snapshot bool) error {
+		lastStatus = notification.Status
+		return stream.Send(&grpcapi.NotificationEvent{Notification: mapModelToGrpcResponse(notification), Snapshot: snapshot})
+	}
+	var resync <-chan time.Time
+	if subscription.Current != nil {
+		if err := send(*subscription.Current, true); err != nil {
+			return err
+		}
+		ticker := time.NewTicker(watchResyncInterval)
+		defer ticker.Stop()
+		resync = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification, open := <-subscription.Events():
+			if !open {
+				return mapWatchEndError(subscription.Err())
+			}
+			// A single-notification watch may see a transition both in its snapshot and as an event.
+			if subscription.Current != nil && notification.Status == lastStatus {
+				continue
+			}
+			if err := send(notification, false); err != nil {
+				return err
+			}
+		case <-resync:
+			current, err := server.notificationService.GetNotificationStatus(ctx, filter.NotificationID)
+			if err != nil {
+				server.logger.Warn("Watch resync failed", "notification_id", filter.NotificationID, "error", err)
+				continue
+			}
+			if current.Status == lastStatus {
+				continue
+			}
+			for index := range current.Attachments {
+				current.Attachments[index].Data = nil
+			}
+			if err := send(current, false); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// mapWatchEndError reports why the service ended a watch subscription.
+func mapWatchEndError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotificationWatchOverflow):
+		return status.Error(codes.Aborted, "watch fell behind; resubscribe")
+	case errors.Is(err, service.ErrNotificationEventsClosed):
+		return status.Error(codes.Unavailable, "server is shutting down")
+	default:
+		return nil
+	}
 }
 
 func (server *notificationServiceServer) RescheduleNotification(ctx context.Context, req *grpcapi.RescheduleNotificationRequest) (*grpcapi.NotificationResponse, error) {
@@ -167,6 +259,204 @@ func (server *notificationServiceServer) CancelNotification(ctx context.Context,
 	return mapModelToGrpcResponse(modelResponse), nil
 }
 
+func (server *notificationServiceServer) RequeueNotification(ctx context.Context, req *grpcapi.RequeueNotificationRequest) (*grpcapi.NotificationResponse, error) {
+	if req.GetNotificationId() == "" {
+		server.logger.Error("Missing notification ID for requeue")
+		return nil, status.Error(codes.InvalidArgument, "notification_id is required")
+	}
+
+	modelResponse, err := server.notificationService.RequeueNotification(ctx, req.GetNotificationId())
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrNotificationNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, model.ErrNotificationNotRequeueable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		server.logger.Error("Service RequeueNotification error", "error", err)
+		return nil, err
+	}
+	return mapModelToGrpcResponse(modelResponse), nil
+}
+
+func (server *notificationServiceServer) RequeueNotifications(ctx context.Context, req *grpcapi.RequeueNotificationsRequest) (*grpcapi.RequeueNotificationsResponse, error) {
+	// The requeue filters are a subset of the list filters and are validated the same way.
+	filters, err := mapGrpcListFilters(&grpcapi.ListNotificationsRequest{
+		Statuses:        req.GetStatuses(),
+		Types:           req.GetTypes(),
+		Recipient:       req.GetRecipient(),
+		RecipientPrefix: req.GetRecipientPrefix(),
+		CreatedAfter:    req.GetCreatedAfter(),
+		CreatedBefore:   req.GetCreatedBefore(),
+		Text:            req.GetText(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	requeuedCount, err := server.notificationService.RequeueNotifications(ctx, filters)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidNotificationListFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		server.logger.Error("Service RequeueNotifications error", "error", err)
+		return nil, err
+	}
+	return &grpcapi.RequeueNotificationsResponse{RequeuedCount: int32(requeuedCount)}, nil
+}
+
+func (server *notificationServiceServer) SendNotificationBatch(ctx context.Context, req *grpcapi.NotificationBatchRequest) (*grpcapi.NotificationBatchResponse, error) {
+	grpcRequests := req.GetRequests()
+	if len(grpcRequests) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "requests must not be empty")
+	}
+	if len(grpcRequests) > service.MaxNotificationBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch exceeds %d requests", service.MaxNotificationBatchSize)
+	}
+
+	// Requests that cannot be mapped are reported as rejected items instead of failing the batch, so
+	// the remaining items keep their indexes.
+	items := make([]*grpcapi.NotificationBatchItem, len(grpcRequests))
+	modelRequests := make([]model.NotificationRequest, 0, len(grpcRequests))
+	requestIndexes := make([]int, 0, len(grpcRequests))
+	for index, grpcRequest := range grpcRequests {
+		modelRequest, mapErr := server.mapGrpcNotificationRequest(grpcRequest)
+		if mapErr != nil {
+			items[index] = mapBatchItemError(index, mapErr)
+			continue
+		}
+		modelRequests = append(modelRequests, modelRequest)
+		requestIndexes = append(requestIndexes, index)
+	}
+	server.logger.Info("notification_batch_received", "request_count", len(grpcRequests), "valid_count", len(modelRequests))
+
+	response := &grpcapi.NotificationBatchResponse{}
+	if len(modelRequests) > 0 {
+		result, err := server.notificationService.SendNotificationBatch(ctx, modelRequests)
+		if err != nil {
+			server.logger.Error("Service SendNotificationBatch error", "error", err)
+			return nil, mapSendNotificationError(err)
+		}
+		response.BatchId = result.BatchID
+		for position, item := range result.Items {
+			index := requestIndexes[position]
+			grpcItem := &grpcapi.NotificationBatchItem{Index: int32(index)}
+			if item.Err != nil {
+				grpcItem = mapBatchItemError(index, item.Err)
+			}
+			if item.Notification != nil {
+				grpcItem.Notification = mapModelToGrpcResponse(*item.Notification)
+			}
+			items[index] = grpcItem
+		}
+	}
+	for _, item := range items {
+		if item.GetError() != "" {
+			response.RejectedCount++
+		} else {
+			response.AcceptedCount++
+		}
+	}
+	response.Items = items
+
+	server.logger.Info(
+		"notification_batch_completed",
+		"batch_id", response.BatchId,
+		"accepted_count", response.AcceptedCount,
+		"rejected_count", response.RejectedCount,
+	)
+	return response, nil
+}
+
+func (server *notificationServiceServer) GetNotificationBatch(ctx context.Context, req *grpcapi.GetNotificationBatchRequest) (*grpcapi.GetNotificationBatchResponse, error) {
+	if req.GetBatchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "batch_id is required")
+	}
+	responses, err := server.notificationService.GetNotificationBatch(ctx, req.GetBatchId())
+	if err != nil {
+		server.logger.Error("Service GetNotificationBatch error", "error", err)
+		if errors.Is(err, service.ErrNotificationBatchNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	grpcNotifications := make([]*grpcapi.NotificationResponse, 0, len(responses))
+	for _, response := range responses {
+		grpcNotifications = append(grpcNotifications, mapModelToGrpcResponse(response))
+	}
+	return &grpcapi.GetNotificationBatchResponse{BatchId: req.GetBatchId(), Notifications: grpcNotifications}, nil
+}
+
+// mapGrpcNotificationRequest converts a grpcapi.NotificationRequest to the service request shape.
+func (server *notificationServiceServer) mapGrpcNotificationRequest(req *grpcapi.NotificationRequest) (model.NotificationRequest, error) {
+	var internalType model.NotificationType
+	switch req.GetNotificationType() {
+	case grpcapi.NotificationType_EMAIL:
+		internalType = model.NotificationEmail
+	case grpcapi.NotificationType_SMS:
+		internalType = model.NotificationSMS
+	default:
+		server.logger.Error("Unsupported notification type", "type", req.GetNotificationType())
+		return model.NotificationRequest{}, status.Errorf(codes.InvalidArgument, "unsupported notification type: %v", req.GetNotificationType())
+	}
+
+	var scheduledFor *time.Time
+	if req.GetScheduledTime() != nil {
+		if err := req.GetScheduledTime().CheckValid(); err != nil {
+			server.logger.Error("Invalid scheduled timestamp", "error", err)
+			return model.NotificationRequest{}, status.Errorf(codes.InvalidArgument, "invalid scheduled_time: %v", err)
+		}
+		normalizedScheduled := req.GetScheduledTime().AsTime().UTC()
+		scheduledFor = &normalizedScheduled
+	}
+
+	return model.NotificationRequest{
+		NotificationType: internalType,
+		Recipient:        req.GetRecipient(),
+		To:               req.GetTo(),
+		Cc:               req.GetCc(),
+		Bcc:              req.GetBcc(),
+		Subject:          req.GetSubject(),
+		Message:          req.GetMessage(),
+		HTMLMessage:      req.GetHtmlMessage(),
+		ScheduledFor:     scheduledFor,
+		Attachments:      mapGrpcAttachments(req.GetAttachments()),
+		TemplateID:       req.GetTemplateId(),
+		TemplateVersion:  int(req.GetTemplateVersion()),
+		TemplateData:     req.GetTemplateData(),
+		IdempotencyKey:   req.GetIdempotencyKey(),
+		Category:         req.GetCategory(),
+		DeliveryMode:     model.DeliveryMode(req.GetDeliveryMode()),
+	}, nil
+}
+
+// mapSendNotificationError translates service errors from a send into gRPC status errors.
+func mapSendNotificationError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrIdempotencyKeyConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrRecipientSuppressed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidNotificationBatch), errors.Is(err, service.ErrInvalidDeliveryMode), errors.Is(err, service.ErrInvalidNotificationRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrSMSDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return mapTemplateError(err)
+	}
+}
+
+// mapBatchItemError reports a rejected batch item with the status code a single send would return.
+// Mapping failures already carry a gRPC status and keep it.
+func mapBatchItemError(index int, err error) *grpcapi.NotificationBatchItem {
+	mapped := status.Convert(mapSendNotificationError(err))
+	return &grpcapi.NotificationBatchItem{
+		Index:     int32(index),
+		Error:     mapped.Message(),
+		ErrorCode: mapped.Code().String(),
+	}
+}
+
 // mapModelToGrpcResponse converts a model.NotificationResponse to a grpcapi.NotificationResponse.
 func mapModelToGrpcResponse(modelResp model.NotificationResponse) *grpcapi.NotificationResponse {
 	var grpcNotifType grpcapi.NotificationType
@@ -179,41 +469,121 @@ func mapModelToGrpcResponse(modelResp model.NotificationResponse) *grpcapi.Notif
 		grpcNotifType = grpcapi.NotificationType_EMAIL
 	}
 
-	var grpcStatus grpcapi.Status
-	switch modelResp.Status {
+	var scheduledTime *timestamppb.Timestamp
+	if modelResp.ScheduledFor != nil {
+		scheduledTime = timestamppb.New(modelResp.ScheduledFor.UTC())
+	}
+
+	return &grpcapi.NotificationResponse{
+		NotificationId:      modelResp.NotificationID,
+		NotificationType:    grpcNotifType,
+		Recipient:           modelResp.Recipient,
+		Subject:             modelResp.Subject,
+		Message:             modelResp.Message,
+		Status:              mapModelStatus(modelResp.Status),
+		ProviderMessageId:   modelResp.ProviderMessageID,
+		Provider:            modelResp.Provider,
+		ProviderStatus:      modelResp.ProviderStatus,
+		SegmentCount:        int32(modelResp.SegmentCount),
+		Price:               modelResp.Price,
+		PriceUnit:           modelResp.PriceUnit,
+		RetryCount:          int32(modelResp.RetryCount),
+		LastError:           modelResp.LastError,
+		CreatedAt:           modelResp.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           modelResp.UpdatedAt.Format(time.RFC3339),
+		ScheduledTime:       scheduledTime,
+		Attachments:         mapModelAttachments(modelResp.Attachments),
+		To:                  modelResp.To,
+		Cc:                  modelResp.Cc,
+		Bcc:                 modelResp.Bcc,
+		RecipientDeliveries: mapModelRecipientDeliveries(modelResp.RecipientDeliveries),
+		HtmlMessage:         modelResp.HTMLMessage,
+		TemplateId:          modelResp.TemplateID,
+		TemplateVersion:     int32(modelResp.TemplateVersion),
+		IdempotencyKey:      modelResp.IdempotencyKey,
+		Category:            modelResp.Category,
+		BatchId:             modelResp.BatchID,
+		BatchIndex:          int32(modelResp.BatchIndex),
+	}
+}
+
+func mapModelStatus(modelStatus model.NotificationStatus) grpcapi.Status {
+	switch modelStatus {
 	case model.StatusQueued:
-		grpcStatus = grpcapi.Status_QUEUED
+		return grpcapi.Status_QUEUED
 	case model.StatusSent:
-		grpcStatus = grpcapi.Status_SENT
+		return grpcapi.Status_SENT
 	case model.StatusCancelled:
-		grpcStatus = grpcapi.Status_CANCELLED
+		return grpcapi.Status_CANCELLED
 	case model.StatusErrored:
-		grpcStatus = grpcapi.Status_ERRORED
+		return grpcapi.Status_ERRORED
+	case model.StatusDelivered:
+		return grpcapi.Status_DELIVERED
+	case model.StatusUndelivered:
+		return grpcapi.Status_UNDELIVERED
+	case model.StatusBounced:
+		return grpcapi.Status_BOUNCED
+	case model.StatusSuppressed:
+		return grpcapi.Status_SUPPRESSED
+	case model.StatusDead:
+		return grpcapi.Status_DEAD
 	case model.StatusFailed:
-		grpcStatus = grpcapi.Status_FAILED
+		return grpcapi.Status_FAILED
 	default:
-		grpcStatus = grpcapi.Status_UNKNOWN
+		return grpcapi.Status_UNKNOWN
 	}
+}
 
-	var scheduledTime *timestamppb.Timestamp
-	if modelResp.ScheduledFor != nil {
-		scheduledTime = timestamppb.New(modelResp.ScheduledFor.UTC())
+func mapModelAttempts(source []model.NotificationAttempt) []*grpcapi.NotificationAttempt {
+	attempts := make([]*grpcapi.NotificationAttempt, 0, len(source))
+	for _, attempt := range source {
+		attempts = append(attempts, &grpcapi.NotificationAttempt{
+			AttemptedAt:   timestamppb.New(attempt.AttemptedAt.UTC()),
+			Provider:      attempt.Provider,
+			DurationMs:    attempt.DurationMs,
+			Outcome:       mapModelStatus(attempt.Outcome),
+			ErrorClass:    string(attempt.ErrorClass),
+			ErrorMessage:  attempt.ErrorMessage,
+			SmtpReplyCode: int32(attempt.SMTPReplyCode),
+		})
 	}
+	return attempts
+}
 
-	return &grpcapi.NotificationResponse{
-		NotificationId:    modelResp.NotificationID,
-		NotificationType:  grpcNotifType,
-		Recipient:         modelResp.Recipient,
-		Subject:           modelResp.Subject,
-		Message:           modelResp.Message,
-		Status:            grpcStatus,
-		ProviderMessageId: modelResp.ProviderMessageID,
-		RetryCount:        int32(modelResp.RetryCount),
-		CreatedAt:         modelResp.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:         modelResp.UpdatedAt.Format(time.RFC3339),
-		ScheduledTime:     scheduledTime,
-		Attachments:       mapModelAttachments(modelResp.Attachments),
+func mapModelRecipientDeliveries(source []model.RecipientDelivery) []*grpcapi.RecipientDelivery {
+	if len(source) == 0 {
+		return nil
+	}
+	result := make([]*grpcapi.RecipientDelivery, 0, len(source))
+	for _, delivery := range source {
+		var grpcKind grpcapi.RecipientKind
+		switch delivery.Kind {
+		case model.RecipientCc:
+			grpcKind = grpcapi.RecipientKind_CC
+		case model.RecipientBcc:
+			grpcKind = grpcapi.RecipientKind_BCC
+		default:
+			grpcKind = grpcapi.RecipientKind_TO
+		}
+		var grpcStatus grpcapi.RecipientStatus
+		switch delivery.Status {
+		case model.RecipientAccepted:
+			grpcStatus = grpcapi.RecipientStatus_ACCEPTED
+		case model.RecipientRejected:
+			grpcStatus = grpcapi.RecipientStatus_REJECTED
+		case model.RecipientSkipped:
+			grpcStatus = grpcapi.RecipientStatus_SKIPPED
+		default:
+			grpcStatus = grpcapi.RecipientStatus_PENDING
+		}
+		result = append(result, &grpcapi.RecipientDelivery{
+			Address: delivery.Address,
+			Kind:    grpcKind,
+			Status:  grpcStatus,
+			Error:   delivery.Error,
+		})
 	}
+	return result
 }
 
 func digestForLogging(value string) string {
@@ -257,11 +627,66 @@ func mapModelAttachments(source []model.EmailAttachment) []*grpcapi.EmailAttachm
 			Filename:    attachment.Filename,
 			ContentType: attachment.ContentType,
 			Data:        clonedData,
+			SizeBytes:   attachment.SizeBytes,
 		})
 	}
 	return result
 }
 
+// mapGrpcListFilters converts a list request into model filters, rejecting unknown types and
+// invalid timestamps.
+func mapGrpcListFilters(req *grpcapi.ListNotificationsRequest) (model.NotificationListFilters, error) {
+	filters := model.NotificationListFilters{
+		Statuses:        mapGrpcStatuses(req.GetStatuses()),
+		Recipient:       req.GetRecipient(),
+		RecipientPrefix: req.GetRecipientPrefix(),
+		Text:            req.GetText(),
+		PageSize:        int(req.GetPageSize()),
+		PageToken:       req.GetPageToken(),
+	}
+	types, err := mapGrpcNotificationTypes(req.GetTypes())
+	if err != nil {
+		return model.NotificationListFilters{}, err
+	}
+	filters.Types = types
+	timestamps := []struct {
+		name   string
+		source *timestamppb.Timestamp
+		target **time.Time
+	}{
+		{name: "created_after", source: req.GetCreatedAfter(), target: &filters.CreatedAfter},
+		{name: "created_before", source: req.GetCreatedBefore(), target: &filters.CreatedBefore},
+		{name: "scheduled_after", source: req.GetScheduledAfter(), target: &filters.ScheduledAfter},
+		{name: "scheduled_before", source: req.GetScheduledBefore(), target: &filters.ScheduledBefore},
+	}
+	for _, timestamp := range timestamps {
+		if timestamp.source == nil {
+			continue
+		}
+		if err := timestamp.source.CheckValid(); err != nil {
+			return model.NotificationListFilters{}, status.Errorf(codes.InvalidArgument, "invalid %s: %v", timestamp.name, err)
+		}
+		normalized := timestamp.source.AsTime().UTC()
+		*timestamp.target = &normalized
+	}
+	return filters, nil
+}
+
+func mapGrpcNotificationTypes(source []grpcapi.NotificationType) ([]model.NotificationType, error) {
+	var result []model.NotificationType
+	for _, notificationType := range source {
+		switch notificationType {
+		case grpcapi.NotificationType_EMAIL:
+			result = append(result, model.NotificationEmail)
+		case grpcapi.NotificationType_SMS:
+			result = append(result, model.NotificationSMS)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported notification type: %v", notificationType)
+		}
+	}
+	return result, nil
+}
+
 func mapGrpcStatuses(source []grpcapi.Status) []model.NotificationStatus {
 	if len(source) == 0 {
 		return nil
@@ -281,6 +706,16 @@ func mapGrpcStatuses(source []grpcapi.Status) []model.NotificationStatus {
 			result = append(result, model.StatusErrored)
 		case grpcapi.Status_UNKNOWN:
 			result = append(result, model.StatusUnknown)
+		case grpcapi.Status_DELIVERED:
+			result = append(result, model.StatusDelivered)
+		case grpcapi.Status_UNDELIVERED:
+			result = append(result, model.StatusUndelivered)
+		case grpcapi.Status_BOUNCED:
+			result = append(result, model.StatusBounced)
+		case grpcapi.Status_SUPPRESSED:
+			result = append(result, model.StatusSuppressed)
+		case grpcapi.Status_DEAD:
+			result = append(result, model.StatusDead)
 		}
 	}
 	if len(result) == 0 {
@@ -291,34 +726,59 @@ func mapGrpcStatuses(source []grpcapi.Status) []model.NotificationStatus {
 
 func buildAuthInterceptor(logger *slog.Logger, requiredToken string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		metadataValues, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			logger.Error("Missing metadata in gRPC request")
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
-		}
-		authorizationHeaders := metadataValues.Get("authorization")
-		if len(authorizationHeaders) == 0 {
-			logger.Error("Missing authorization header")
-			return nil, status.Error(codes.Unauthenticated, "missing authorization header")
-		}
-		headerValue := authorizationHeaders[0]
-		if !strings.HasPrefix(headerValue, "Bearer ") {
-			logger.Error("Invalid authorization header format")
-			return nil, status.Error(codes.Unauthenticated, "invalid authorization header")
-		}
-		token := strings.TrimPrefix(headerValue, "Bearer ")
-		if token != requiredToken {
-			logger.Error("Invalid token provided")
-			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		if err := authorizeRequest(ctx, logger, requiredToken); err != nil {
+			return nil, err
 		}
 		return handler(ctx, req)
 	}
 }
 
+// buildStreamAuthInterceptor applies the bearer token check of buildAuthInterceptor to streaming RPCs.
+func buildStreamAuthInterceptor(logger *slog.Logger, requiredToken string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorizeRequest(stream.Context(), logger, requiredToken); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func authorizeRequest(ctx context.Context, logger *slog.Logger, requiredToken string) error {
+	metadataValues, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Error("Missing metadata in gRPC request")
+		return status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	authorizationHeaders := metadataValues.Get("authorization")
+	if len(authorizationHeaders) == 0 {
+		logger.Error("Missing authorization header")
+		return status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+	headerValue := authorizationHeaders[0]
+	if !strings.HasPrefix(headerValue, "Bearer ") {
+		logger.Error("Invalid authorization header format")
+		return status.Error(codes.Unauthenticated, "invalid authorization header")
+	}
+	token := strings.TrimPrefix(headerValue, "Bearer ")
+	if token != requiredToken {
+		logger.Error("Invalid token provided")
+		return status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return nil
+}
+
 func main() {
 	disableWebFlag := flag.Bool("disable-web-interface", false, "disable the HTTP web interface and static asset server (env: DISABLE_WEB_INTERFACE)")
 	flag.Parse()
 
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrateCommand(context.Background(), flag.Args()[1:], os.Stdout); err != nil {
+			logging.NewLogger("INFO").Error("Migration command failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	configuration, configErr := config.LoadConfig(*disableWebFlag)
 	if configErr != nil {
 		fallbackLogger := logging.NewLogger("INFO")
@@ -331,18 +791,57 @@ func main() {
 	mainLogger := logging.NewLogger(configuration.LogLevel)
 	mainLogger.Info("Starting gRPC Notification Server on :50051")
 
-	databaseInstance, dbErr := db.InitDB(configuration.DatabasePath, mainLogger)
+	var databaseInstance *gorm.DB
+	var dbErr error
+	switch configuration.DatabaseDriver {
+	case config.DatabaseDriverPostgres:
+		databaseInstance, dbErr = db.InitPostgres(configuration.DatabaseURL, mainLogger)
+	default:
+		databaseInstance, dbErr = db.InitDB(configuration.DatabasePath, mainLogger)
+	}
 	if dbErr != nil {
 		mainLogger.Error("Failed to initialize DB", "error", dbErr)
 		os.Exit(1)
 	}
 
-	notificationSvc := service.NewNotificationService(databaseInstance, mainLogger, configuration)
+	notificationEvents := service.NewNotificationEventBus()
+	notificationSvc := service.NewNotificationService(databaseInstance, mainLogger, configuration, notificationEvents)
+	templateSvc := service.NewTemplateService(databaseInstance, mainLogger)
+	deliveryStatusSvc := service.NewDeliveryStatusService(databaseInstance, mainLogger, notificationEvents)
+	feedbackSvc := service.NewFeedbackService(databaseInstance, mainLogger)
+	suppressionSvc := service.NewSuppressionService(databaseInstance, mainLogger)
+	var unsubscribeSvc service.UnsubscribeService
+	if unsubscribeSigner := service.NewUnsubscribeSigner(configuration.PublicBaseURL, configuration.UnsubscribeSigningKey); unsubscribeSigner != nil {
+		unsubscribeSvc = service.NewUnsubscribeService(unsubscribeSigner, suppressionSvc, mainLogger)
+	}
+	// HELP auto-replies go out through the configured SMS provider; without one they are skipped.
+	helpReplySender, helpReplySenderErr := service.NewSmsSender(configuration, mainLogger)
+	if helpReplySenderErr != nil {
+		helpReplySender = nil
+	}
+	inboundMessageSvc := service.NewInboundMessageService(databaseInstance, suppressionSvc, helpReplySender, configuration.SMSHelpReply, mainLogger)
+	outboundWebhookSvc := service.NewOutboundWebhookService(databaseInstance, mainLogger, configuration)
+	notificationEvents.Observe(outboundWebhookSvc)
 
-	// Start the background retry worker.
+	// Start the background worker that dispatches queued notifications and retries failures.
 	workerCtx, cancelWorker := context.WithCancel(context.Background())
-	defer cancelWorker()
-	go notificationSvc.StartRetryWorker(workerCtx)
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		notificationSvc.StartRetryWorker(workerCtx)
+	}()
+	webhookWorkerDone := make(chan struct{})
+	go func() {
+		defer close(webhookWorkerDone)
+		outboundWebhookSvc.StartDeliveryWorker(workerCtx)
+	}()
+	defer func() {
+		// The workers stop taking new jobs and return once their in-flight attempts are recorded.
+		cancelWorker()
+		<-workerDone
+		<-webhookWorkerDone
+		mainLogger.Info("Background worker drained")
+	}()
 
 	if configuration.WebInterfaceEnabled {
 		sessionValidator, validatorErr := sessionvalidator.New(sessionvalidator.Config{
@@ -355,14 +854,32 @@ func main() {
 			os.Exit(1)
 		}
 
+		healthReporter, _ := notificationSvc.(service.HealthReporter)
 		httpServer, httpServerErr := httpapi.NewServer(httpapi.Config{
-			ListenAddr:          configuration.HTTPListenAddr,
-			StaticRoot:          configuration.HTTPStaticRoot,
-			AllowedOrigins:      configuration.HTTPAllowedOrigins,
-			AdminEmails:         configuration.AdminEmails,
-			SessionValidator:    sessionValidator,
-			NotificationService: notificationSvc,
-			Logger:              mainLogger,
+			ListenAddr:             configuration.HTTPListenAddr,
+			StaticRoot:             configuration.HTTPStaticRoot,
+			AllowedOrigins:         configuration.HTTPAllowedOrigins,
+			AdminEmails:            configuration.AdminEmails,
+			SessionValidator:       sessionValidator,
+			NotificationService:    notificationSvc,
+			TemplateService:        templateSvc,
+			HealthReporter:         healthReporter,
+			DeliveryStatusService:  deliveryStatusSvc,
+			FeedbackService:        feedbackSvc,
+			SuppressionService:     suppressionSvc,
+			InboundMessageService:  inboundMessageSvc,
+			UnsubscribeService:     unsubscribeSvc,
+			OutboundWebhookService: outboundWebhookSvc,
+			Webhooks: httpapi.WebhookConfig{
+				PublicBaseURL:     configuration.PublicBaseURL,
+				TwilioAuthToken:   configuration.TwilioAuthToken,
+				SendGridPublicKey: configuration.SendGridWebhookPublicKey,
+				MailgunSigningKey: configuration.MailgunWebhookSigningKey,
+				MaxSignatureAge:   time.Duration(configuration.DeliveryWebhookMaxAgeSec) * time.Second,
+				DSNToken:          configuration.DSNWebhookToken,
+				SESTopicARN:       configuration.SESSNSTopicARN,
+			},
+			Logger: mainLogger,
 		})
 		if httpServerErr != nil {
 			mainLogger.Error("Failed to initialize HTTP server", "error", httpServerErr)
@@ -393,11 +910,24 @@ func main() {
 		grpc.MaxRecvMsgSize(grpcutil.MaxMessageSizeBytes),
 		grpc.MaxSendMsgSize(grpcutil.MaxMessageSizeBytes),
 		grpc.UnaryInterceptor(buildAuthInterceptor(mainLogger, configuration.GRPCAuthToken)),
+		grpc.StreamInterceptor(buildStreamAuthInterceptor(mainLogger, configuration.GRPCAuthToken)),
 	)
 	grpcapi.RegisterNotificationServiceServer(grpcServer, &notificationServiceServer{
 		notificationService: notificationSvc,
 		logger:              mainLogger,
 	})
+	grpcapi.RegisterTemplateServiceServer(grpcServer, &templateServiceServer{
+		templateService: templateSvc,
+		logger:          mainLogger,
+	})
+	grpcapi.RegisterSuppressionServiceServer(grpcServer, &suppressionServiceServer{
+		suppressionService: suppressionSvc,
+		logger:             mainLogger,
+	})
+	grpcapi.RegisterInboundMessageServiceServer(grpcServer, &inboundMessageServiceServer{
+		inboundMessageService: inboundMessageSvc,
+		logger:                mainLogger,
+	})
 
 	listener, listenErr := net.Listen("tcp", ":50051")
 	if listenErr != nil {
@@ -406,6 +936,16 @@ func main() {
 	}
 	mainLogger.Info("gRPC server listening on :50051")
 
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	go func() {
+		<-signalCtx.Done()
+		mainLogger.Info("Shutdown signal received")
+		// Ending the watch streams first keeps them from holding up the graceful stop.
+		notificationEvents.Close()
+		grpcServer.GracefulStop()
+	}()
+
 	if serveErr := grpcServer.Serve(listener); serveErr != nil {
 		mainLogger.Error("gRPC server crashed", "error", serveErr)
 		os.Exit(1)
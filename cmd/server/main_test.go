@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"strings"
@@ -130,6 +131,209 @@ func TestSendNotificationRejectsInvalidScheduledTimestamp(t *testing.T) {
 	}
 }
 
+func TestSendNotificationBatchKeepsItemIndexes(t *testing.T) {
+	t.Helper()
+
+	suppressedResponse := model.NotificationResponse{NotificationID: "notif-3", Status: model.StatusSuppressed, BatchID: "batch-1", BatchIndex: 3}
+	notificationService := &stubNotificationService{
+		batchResult: service.NotificationBatchResult{
+			BatchID: "batch-1",
+			Items: []service.NotificationBatchItem{
+				{Index: 0, Notification: &model.NotificationResponse{NotificationID: "notif-0", Status: model.StatusQueued, BatchID: "batch-1"}},
+				{Index: 1, Err: fmt.Errorf("%w: order-1", service.ErrIdempotencyKeyConflict)},
+				{Index: 2, Notification: &suppressedResponse, Err: &service.SuppressionError{Channel: model.NotificationEmail, Recipients: []string{"blocked@example.com"}}},
+				{Index: 3, Err: fmt.Errorf("%w: missing required fields: recipient or message", service.ErrInvalidNotificationRequest)},
+			},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server := &notificationServiceServer{notificationService: notificationService, logger: logger}
+
+	response, err := server.SendNotificationBatch(context.Background(), &grpcapi.NotificationBatchRequest{
+		Requests: []*grpcapi.NotificationRequest{
+			{NotificationType: grpcapi.NotificationType_EMAIL, Recipient: "first@example.com", Message: "One"},
+			{NotificationType: grpcapi.NotificationType_EMAIL, Recipient: "first@example.com", Message: "Two", IdempotencyKey: "order-1"},
+			{NotificationType: grpcapi.NotificationType_EMAIL, Recipient: "late@example.com", Message: "Bad", ScheduledTime: &timestamppb.Timestamp{Seconds: 1, Nanos: 1_000_000_000}},
+			{NotificationType: grpcapi.NotificationType_EMAIL, Recipient: "blocked@example.com", Message: "Three"},
+			{NotificationType: grpcapi.NotificationType(99), Recipient: "first@example.com", Message: "Unknown type"},
+			{NotificationType: grpcapi.NotificationType_EMAIL, Message: "No recipient"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("batch error: %v", err)
+	}
+	if len(notificationService.batchCalls) != 1 || len(notificationService.batchCalls[0]) != 4 {
+		t.Fatalf("expected the four mappable requests to reach the service, got %v", notificationService.batchCalls)
+	}
+	if response.GetBatchId() != "batch-1" || response.GetAcceptedCount() != 1 || response.GetRejectedCount() != 5 {
+		t.Fatalf("unexpected batch summary %v", response)
+	}
+
+	expectations := []struct {
+		notificationID string
+		errorCode      string
+	}{
+		{notificationID: "notif-0"},
+		{errorCode: codes.AlreadyExists.String()},
+		{errorCode: codes.InvalidArgument.String()},
+		{notificationID: "notif-3", errorCode: codes.FailedPrecondition.String()},
+		{errorCode: codes.InvalidArgument.String()},
+		{errorCode: codes.InvalidArgument.String()},
+	}
+	for index, expectation := range expectations {
+		item := response.GetItems()[index]
+		if item.GetIndex() != int32(index) {
+			t.Fatalf("item %d: unexpected index %d", index, item.GetIndex())
+		}
+		if item.GetNotification().GetNotificationId() != expectation.notificationID || item.GetErrorCode() != expectation.errorCode {
+			t.Fatalf("item %d: unexpected item %v", index, item)
+		}
+		if strings.HasPrefix(item.GetError(), "rpc error") {
+			t.Fatalf("item %d: expected the status message without its prefix, got %q", index, item.GetError())
+		}
+	}
+
+	_, emptyErr := server.SendNotificationBatch(context.Background(), &grpcapi.NotificationBatchRequest{})
+	if status.Code(emptyErr) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an empty batch, got %v", emptyErr)
+	}
+	_, missingErr := server.GetNotificationBatch(context.Background(), &grpcapi.GetNotificationBatchRequest{BatchId: "batch-404"})
+	if status.Code(missingErr) != codes.NotFound {
+		t.Fatalf("expected NotFound for an unknown batch, got %v", missingErr)
+	}
+}
+
+func TestSendNotificationForwardsRecipientLists(t *testing.T) {
+	t.Helper()
+
+	notificationService := &stubNotificationService{
+		sendResponse: model.NotificationResponse{
+			NotificationID:   "notif-multi",
+			NotificationType: model.NotificationEmail,
+			Recipient:        "to@example.com",
+			To:               []string{"to@example.com"},
+			Cc:               []string{"cc@example.com"},
+			Bcc:              []string{"bcc@example.com"},
+			Status:           model.StatusSent,
+			RecipientDeliveries: []model.RecipientDelivery{
+				{Address: "to@example.com", Kind: model.RecipientTo, Status: model.RecipientAccepted},
+				{Address: "cc@example.com", Kind: model.RecipientCc, Status: model.RecipientRejected, Error: "550"},
+				{Address: "bcc@example.com", Kind: model.RecipientBcc, Status: model.RecipientAccepted},
+			},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server := &notificationServiceServer{notificationService: notificationService, logger: logger}
+
+	response, sendError := server.SendNotification(context.Background(), &grpcapi.NotificationRequest{
+		NotificationType: grpcapi.NotificationType_EMAIL,
+		To:               []string{"to@example.com"},
+		Cc:               []string{"cc@example.com"},
+		Bcc:              []string{"bcc@example.com"},
+		Message:          "Hello",
+	})
+	if sendError != nil {
+		t.Fatalf("send error: %v", sendError)
+	}
+	if len(notificationService.sendCalls) != 1 {
+		t.Fatalf("expected one service call")
+	}
+	forwarded := notificationService.sendCalls[0]
+	if len(forwarded.To) != 1 || len(forwarded.Cc) != 1 || len(forwarded.Bcc) != 1 {
+		t.Fatalf("recipient lists not forwarded: %#v", forwarded)
+	}
+	if len(response.GetBcc()) != 1 || response.GetBcc()[0] != "bcc@example.com" {
+		t.Fatalf("unexpected bcc mapping %#v", response.GetBcc())
+	}
+	deliveries := response.GetRecipientDeliveries()
+	if len(deliveries) != 3 {
+		t.Fatalf("unexpected recipient deliveries %#v", deliveries)
+	}
+	if deliveries[1].GetKind() != grpcapi.RecipientKind_CC || deliveries[1].GetStatus() != grpcapi.RecipientStatus_REJECTED || deliveries[1].GetError() != "550" {
+		t.Fatalf("unexpected cc delivery mapping %#v", deliveries[1])
+	}
+	if deliveries[2].GetKind() != grpcapi.RecipientKind_BCC || deliveries[2].GetStatus() != grpcapi.RecipientStatus_ACCEPTED {
+		t.Fatalf("unexpected bcc delivery mapping %#v", deliveries[2])
+	}
+}
+
+func TestSendNotificationMapsIdempotencyOutcomes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name         string
+		serviceError error
+		expectedCode codes.Code
+	}{
+		{name: "Replay", expectedCode: codes.OK},
+		{name: "Conflict", serviceError: fmt.Errorf("%w: order-42", service.ErrIdempotencyKeyConflict), expectedCode: codes.AlreadyExists},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notificationService := &stubNotificationService{
+				sendResponse: model.NotificationResponse{NotificationID: "notif-1", IdempotencyKey: "order-42", Status: model.StatusSent},
+				sendError:    testCase.serviceError,
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+			server := &notificationServiceServer{notificationService: notificationService, logger: logger}
+
+			response, sendErr := server.SendNotification(context.Background(), &grpcapi.NotificationRequest{
+				NotificationType: grpcapi.NotificationType_EMAIL,
+				Recipient:        "user@example.com",
+				Message:          "Hello",
+				IdempotencyKey:   "order-42",
+			})
+			if status.Code(sendErr) != testCase.expectedCode {
+				t.Fatalf("expected code %s, got %v", testCase.expectedCode, sendErr)
+			}
+			if len(notificationService.sendCalls) != 1 || notificationService.sendCalls[0].IdempotencyKey != "order-42" {
+				t.Fatalf("expected idempotency key to be forwarded, got %#v", notificationService.sendCalls)
+			}
+			if sendErr == nil && response.GetIdempotencyKey() != "order-42" {
+				t.Fatalf("expected idempotency key on response, got %q", response.GetIdempotencyKey())
+			}
+		})
+	}
+}
+
+func TestSendNotificationForwardsDeliveryMode(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name         string
+		serviceError error
+		expectedCode codes.Code
+	}{
+		{name: "Forwarded", expectedCode: codes.OK},
+		{name: "Invalid", serviceError: fmt.Errorf("%w: later", service.ErrInvalidDeliveryMode), expectedCode: codes.InvalidArgument},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notificationService := &stubNotificationService{
+				sendResponse: model.NotificationResponse{NotificationID: "notif-1", Status: model.StatusQueued},
+				sendError:    testCase.serviceError,
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+			server := &notificationServiceServer{notificationService: notificationService, logger: logger}
+
+			_, sendErr := server.SendNotification(context.Background(), &grpcapi.NotificationRequest{
+				NotificationType: grpcapi.NotificationType_EMAIL,
+				Recipient:        "user@example.com",
+				Message:          "Hello",
+				DeliveryMode:     "inline",
+			})
+			if status.Code(sendErr) != testCase.expectedCode {
+				t.Fatalf("expected code %s, got %v", testCase.expectedCode, sendErr)
+			}
+			if len(notificationService.sendCalls) != 1 || notificationService.sendCalls[0].DeliveryMode != model.DeliveryModeInline {
+				t.Fatalf("expected delivery mode to be forwarded, got %#v", notificationService.sendCalls)
+			}
+		})
+	}
+}
+
 func TestListNotificationsTranslatesStatusesAndResponses(t *testing.T) {
 	t.Helper()
 
@@ -150,11 +354,17 @@ func TestListNotificationsTranslatesStatusesAndResponses(t *testing.T) {
 				grpcapi.Status_SENT,
 				grpcapi.Status_FAILED,
 				grpcapi.Status_CANCELLED,
+				grpcapi.Status_DELIVERED,
+				grpcapi.Status_BOUNCED,
+				grpcapi.Status_SUPPRESSED,
 			},
 			expectedStatuses: []model.NotificationStatus{
 				model.StatusSent,
 				model.StatusFailed,
 				model.StatusCancelled,
+				model.StatusDelivered,
+				model.StatusBounced,
+				model.StatusSuppressed,
 			},
 		},
 	}
@@ -230,6 +440,89 @@ func TestListNotificationsTranslatesStatusesAndResponses(t *testing.T) {
 	}
 }
 
+func TestListNotificationsForwardsFiltersAndPageToken(t *testing.T) {
+	t.Helper()
+
+	createdAfter := time.Date(2024, 1, 2, 3, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	stubService := &stubNotificationService{
+		listResponses:     []model.NotificationResponse{{NotificationID: "notif-1", Attachments: []model.EmailAttachment{{Filename: "a.pdf", SizeBytes: 2048}}}},
+		listNextPageToken: "next-token",
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server := &notificationServiceServer{notificationService: stubService, logger: logger}
+
+	response, err := server.ListNotifications(context.Background(), &grpcapi.ListNotificationsRequest{
+		Types:           []grpcapi.NotificationType{grpcapi.NotificationType_EMAIL},
+		Recipient:       "alpha@example.com",
+		RecipientPrefix: "alp",
+		CreatedAfter:    timestamppb.New(createdAfter),
+		Text:            "invoice",
+		PageSize:        25,
+		PageToken:       "page-token",
+	})
+	if err != nil {
+		t.Fatalf("ListNotifications error: %v", err)
+	}
+	if response.GetNextPageToken() != "next-token" {
+		t.Fatalf("expected the next page token to be returned, got %q", response.GetNextPageToken())
+	}
+	if attachments := response.GetNotifications()[0].GetAttachments(); len(attachments) != 1 || attachments[0].GetSizeBytes() != 2048 {
+		t.Fatalf("expected attachment metadata to be returned, got %v", attachments)
+	}
+
+	filters := stubService.listCalls[0]
+	if len(filters.Types) != 1 || filters.Types[0] != model.NotificationEmail {
+		t.Fatalf("expected the email type filter, got %v", filters.Types)
+	}
+	if filters.Recipient != "alpha@example.com" || filters.RecipientPrefix != "alp" || filters.Text != "invoice" || filters.PageSize != 25 || filters.PageToken != "page-token" {
+		t.Fatalf("unexpected filters %+v", filters)
+	}
+	if filters.CreatedAfter == nil || !filters.CreatedAfter.Equal(createdAfter) || filters.CreatedAfter.Location() != time.UTC {
+		t.Fatalf("expected created_after in UTC, got %v", filters.CreatedAfter)
+	}
+	if filters.CreatedBefore != nil || filters.ScheduledAfter != nil || filters.ScheduledBefore != nil {
+		t.Fatalf("expected unset ranges to stay nil, got %+v", filters)
+	}
+}
+
+func TestListNotificationsRejectsInvalidFilters(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name      string
+		request   *grpcapi.ListNotificationsRequest
+		listError error
+	}{
+		{
+			name:    "UnknownType",
+			request: &grpcapi.ListNotificationsRequest{Types: []grpcapi.NotificationType{grpcapi.NotificationType(9)}},
+		},
+		{
+			name:    "InvalidTimestamp",
+			request: &grpcapi.ListNotificationsRequest{ScheduledBefore: &timestamppb.Timestamp{Seconds: 1, Nanos: -1}},
+		},
+		{
+			name:      "FilterRejectedByService",
+			request:   &grpcapi.ListNotificationsRequest{PageToken: "bogus"},
+			listError: fmt.Errorf("%w: malformed page token", model.ErrInvalidNotificationListFilter),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Helper()
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+			server := &notificationServiceServer{notificationService: &stubNotificationService{listError: testCase.listError}, logger: logger}
+
+			_, err := server.ListNotifications(context.Background(), testCase.request)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
 func TestRescheduleNotificationValidatesAndForwardsRequest(t *testing.T) {
 	t.Helper()
 
@@ -441,6 +734,225 @@ func TestCancelNotificationValidatesAndForwardsRequest(t *testing.T) {
 	}
 }
 
+func TestRequeueNotificationMapsServiceOutcomes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name              string
+		notificationID    string
+		serviceError      error
+		expectedCode      codes.Code
+		expectServiceCall bool
+	}{
+		{name: "MissingNotificationID", expectedCode: codes.InvalidArgument},
+		{name: "Requeued", notificationID: "notif-1", expectedCode: codes.OK, expectServiceCall: true},
+		{name: "NotFound", notificationID: "notif-1", serviceError: fmt.Errorf("%w: notif-1", model.ErrNotificationNotFound), expectedCode: codes.NotFound, expectServiceCall: true},
+		{name: "NotRequeueable", notificationID: "notif-1", serviceError: fmt.Errorf("%w: notif-1", model.ErrNotificationNotRequeueable), expectedCode: codes.FailedPrecondition, expectServiceCall: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Helper()
+
+			stubService := &stubNotificationService{
+				requeueResponse: model.NotificationResponse{NotificationID: "notif-1", Status: model.StatusQueued, LastError: "smtp timeout"},
+				requeueError:    testCase.serviceError,
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+			server := &notificationServiceServer{notificationService: stubService, logger: logger}
+
+			response, err := server.RequeueNotification(context.Background(), &grpcapi.RequeueNotificationRequest{NotificationId: testCase.notificationID})
+			if status.Code(err) != testCase.expectedCode {
+				t.Fatalf("expected code %v, got %v", testCase.expectedCode, err)
+			}
+			if (len(stubService.requeueCalls) == 1) != testCase.expectServiceCall {
+				t.Fatalf("unexpected service calls %#v", stubService.requeueCalls)
+			}
+			if err == nil && (response.GetStatus() != grpcapi.Status_QUEUED || response.GetLastError() != "smtp timeout") {
+				t.Fatalf("unexpected response %#v", response)
+			}
+		})
+	}
+}
+
+func TestGetNotificationAttemptsMapsAttempts(t *testing.T) {
+	t.Helper()
+
+	attemptedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	stubService := &stubNotificationService{attempts: []model.NotificationAttempt{
+		{NotificationID: "notif-1", AttemptedAt: attemptedAt, Provider: "smtp", DurationMs: 80, Outcome: model.StatusErrored, ErrorClass: model.AttemptErrorUnavailable, ErrorMessage: "421 try later", SMTPReplyCode: 421},
+		{NotificationID: "notif-1", AttemptedAt: attemptedAt.Add(time.Minute), Provider: "smtp", DurationMs: 40, Outcome: model.StatusSent},
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server := &notificationServiceServer{notificationService: stubService, logger: logger}
+
+	response, err := server.GetNotificationAttempts(context.Background(), &grpcapi.GetNotificationAttemptsRequest{NotificationId: "notif-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	attempts := response.GetAttempts()
+	if len(attempts) != 2 {
+		t.Fatalf("expected two attempts, got %d", len(attempts))
+	}
+	first := attempts[0]
+	if !first.GetAttemptedAt().AsTime().Equal(attemptedAt) || first.GetOutcome() != grpcapi.Status_ERRORED || first.GetErrorClass() != "unavailable" || first.GetSmtpReplyCode() != 421 || first.GetDurationMs() != 80 {
+		t.Fatalf("unexpected first attempt %v", first)
+	}
+	if attempts[1].GetOutcome() != grpcapi.Status_SENT || attempts[1].GetErrorMessage() != "" {
+		t.Fatalf("unexpected second attempt %v", attempts[1])
+	}
+
+	if _, err := server.GetNotificationAttempts(context.Background(), &grpcapi.GetNotificationAttemptsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a missing ID, got %v", err)
+	}
+	stubService.attemptsError = fmt.Errorf("%w: notif-2", model.ErrNotificationNotFound)
+	if _, err := server.GetNotificationAttempts(context.Background(), &grpcapi.GetNotificationAttemptsRequest{NotificationId: "notif-2"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestRequeueNotificationsForwardsFilters(t *testing.T) {
+	t.Helper()
+
+	stubService := &stubNotificationService{requeueAllCount: 3}
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server := &notificationServiceServer{notificationService: stubService, logger: logger}
+
+	response, err := server.RequeueNotifications(context.Background(), &grpcapi.RequeueNotificationsRequest{
+		Statuses:  []grpcapi.Status{grpcapi.Status_DEAD},
+		Types:     []grpcapi.NotificationType{grpcapi.NotificationType_EMAIL},
+		Recipient: "user@example.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.GetRequeuedCount() != 3 {
+		t.Fatalf("expected 3 requeued notifications, got %d", response.GetRequeuedCount())
+	}
+	if len(stubService.requeueAllCalls) != 1 {
+		t.Fatalf("expected one requeue call, got %d", len(stubService.requeueAllCalls))
+	}
+	filters := stubService.requeueAllCalls[0]
+	if len(filters.Statuses) != 1 || filters.Statuses[0] != model.StatusDead {
+		t.Fatalf("unexpected statuses %#v", filters.Statuses)
+	}
+	if len(filters.Types) != 1 || filters.Types[0] != model.NotificationEmail || filters.Recipient != "user@example.com" {
+		t.Fatalf("unexpected filters %#v", filters)
+	}
+
+	_, err = server.RequeueNotifications(context.Background(), &grpcapi.RequeueNotificationsRequest{Types: []grpcapi.NotificationType{grpcapi.NotificationType(9)}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an unknown type, got %v", err)
+	}
+}
+
+func TestWatchNotificationsStreamsSnapshotAndTransitions(t *testing.T) {
+	t.Helper()
+
+	authToken := "watch-token"
+	events := service.NewNotificationEventBus()
+	now := time.Now().UTC()
+	notificationService := &stubNotificationService{
+		watchEvents: events,
+		watchCurrent: &model.NotificationResponse{
+			NotificationID:   "notif-watch",
+			NotificationType: model.NotificationEmail,
+			Recipient:        "user@example.com",
+			Message:          "Hello",
+			Status:           model.StatusQueued,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		},
+	}
+	serverAddress, shutdown := startTestNotificationServer(t, notificationService, authToken)
+	defer shutdown()
+
+	newClient := func(token string) *client.NotificationClient {
+		settings, err := client.NewSettings(serverAddress, token, 5, 5)
+		if err != nil {
+			t.Fatalf("settings error: %v", err)
+		}
+		notificationClient, err := client.NewNotificationClient(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})), settings)
+		if err != nil {
+			t.Fatalf("create client error: %v", err)
+		}
+		t.Cleanup(func() { notificationClient.Close() })
+		return notificationClient
+	}
+	notificationClient := newClient(authToken)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := newClient("wrong-token").WatchNotifications(ctx, &grpcapi.WatchNotificationsRequest{NotificationId: "notif-watch"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected an unauthenticated watch to be rejected, got %v", err)
+	}
+
+	stream, err = notificationClient.WatchNotifications(ctx, &grpcapi.WatchNotificationsRequest{NotificationId: "notif-watch", Types: []grpcapi.NotificationType{grpcapi.NotificationType(42)}})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected an unknown type to be rejected, got %v", err)
+	}
+
+	stream, err = notificationClient.WatchNotifications(ctx, &grpcapi.WatchNotificationsRequest{NotificationId: "notif-watch"})
+	if err != nil {
+		t.Fatalf("watch error: %v", err)
+	}
+	snapshot, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("snapshot error: %v", err)
+	}
+	if !snapshot.GetSnapshot() || snapshot.GetNotification().GetStatus() != grpcapi.Status_QUEUED {
+		t.Fatalf("expected a queued snapshot, got %+v", snapshot)
+	}
+
+	record := model.Notification{
+		NotificationID:   "notif-watch",
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Message:          "Hello",
+		Status:           model.StatusSent,
+	}
+	events.PublishTransition(context.Background(), model.StatusQueued, record)
+	// The repeated sent status is dropped before the stream reaches the client.
+	events.PublishTransition(context.Background(), model.StatusErrored, record)
+	record.Status = model.StatusDelivered
+	events.PublishTransition(context.Background(), model.StatusSent, record)
+
+	for _, expected := range []grpcapi.Status{grpcapi.Status_SENT, grpcapi.Status_DELIVERED} {
+		event, recvErr := stream.Recv()
+		if recvErr != nil {
+			t.Fatalf("receive error: %v", recvErr)
+		}
+		if event.GetSnapshot() || event.GetNotification().GetStatus() != expected {
+			t.Fatalf("expected a %s transition, got %+v", expected, event)
+		}
+	}
+
+	events.Close()
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected the stream to end as unavailable on shutdown, got %v", err)
+	}
+
+	notificationService.mutex.Lock()
+	notificationService.watchCurrent = nil
+	notificationService.watchEvents = service.NewNotificationEventBus()
+	notificationService.mutex.Unlock()
+	stream, err = notificationClient.WatchNotifications(ctx, &grpcapi.WatchNotificationsRequest{NotificationId: "notif-missing"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected a missing notification to be not found, got %v", err)
+	}
+}
+
 func TestBuildAuthInterceptorRejectsUnauthorizedRequests(t *testing.T) {
 	t.Helper()
 
@@ -557,7 +1069,10 @@ func startTestNotificationServer(t *testing.T, svc service.NotificationService,
 	}
 
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(buildAuthInterceptor(logger, token)))
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(buildAuthInterceptor(logger, token)),
+		grpc.StreamInterceptor(buildStreamAuthInterceptor(logger, token)),
+	)
 	grpcapi.RegisterNotificationServiceServer(grpcServer, &notificationServiceServer{
 		notificationService: svc,
 		logger:              logger,
@@ -580,21 +1095,41 @@ type stubNotificationService struct {
 	sendCalls          []model.NotificationRequest
 	statusCalls        []string
 	sendResponse       model.NotificationResponse
+	sendError          error
 	statusResponses    []model.NotificationResponse
 	listCalls          []model.NotificationListFilters
 	listResponses      []model.NotificationResponse
+	listNextPageToken  string
+	listError          error
 	rescheduleCalls    []rescheduleInvocation
 	rescheduleResponse model.NotificationResponse
 	rescheduleError    error
 	cancelCalls        []string
 	cancelResponse     model.NotificationResponse
 	cancelError        error
+	requeueCalls       []string
+	requeueResponse    model.NotificationResponse
+	requeueError       error
+	requeueAllCalls    []model.NotificationListFilters
+	requeueAllCount    int
+	attempts           []model.NotificationAttempt
+	attemptsError      error
+	batchCalls         [][]model.NotificationRequest
+	batchResult        service.NotificationBatchResult
+	batchError         error
+	batchResponses     []model.NotificationResponse
+	watchEvents        *service.NotificationEventBus
+	watchCurrent       *model.NotificationResponse
+	watchCalls         []service.NotificationWatchFilter
 }
 
 func (stub *stubNotificationService) SendNotification(ctx context.Context, request model.NotificationRequest) (model.NotificationResponse, error) {
 	stub.mutex.Lock()
 	defer stub.mutex.Unlock()
 	stub.sendCalls = append(stub.sendCalls, request)
+	if stub.sendError != nil {
+		return model.NotificationResponse{}, stub.sendError
+	}
 	return stub.sendResponse, nil
 }
 
@@ -610,11 +1145,20 @@ func (stub *stubNotificationService) GetNotificationStatus(ctx context.Context,
 	return response, nil
 }
 
-func (stub *stubNotificationService) ListNotifications(ctx context.Context, filters model.NotificationListFilters) ([]model.NotificationResponse, error) {
+func (stub *stubNotificationService) GetNotificationAttempts(ctx context.Context, notificationID string) ([]model.NotificationAttempt, error) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	return stub.attempts, stub.attemptsError
+}
+
+func (stub *stubNotificationService) ListNotifications(ctx context.Context, filters model.NotificationListFilters) (service.NotificationPage, error) {
 	stub.mutex.Lock()
 	defer stub.mutex.Unlock()
 	stub.listCalls = append(stub.listCalls, filters)
-	return stub.listResponses, nil
+	if stub.listError != nil {
+		return service.NotificationPage{}, stub.listError
+	}
+	return service.NotificationPage{Notifications: stub.listResponses, NextPageToken: stub.listNextPageToken}, nil
 }
 
 func (stub *stubNotificationService) RescheduleNotification(ctx context.Context, notificationID string, scheduledFor time.Time) (model.NotificationResponse, error) {
@@ -637,6 +1181,64 @@ func (stub *stubNotificationService) CancelNotification(ctx context.Context, not
 	return stub.cancelResponse, nil
 }
 
+func (stub *stubNotificationService) RequeueNotification(ctx context.Context, notificationID string) (model.NotificationResponse, error) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.requeueCalls = append(stub.requeueCalls, notificationID)
+	if stub.requeueError != nil {
+		return model.NotificationResponse{}, stub.requeueError
+	}
+	return stub.requeueResponse, nil
+}
+
+func (stub *stubNotificationService) RequeueNotifications(ctx context.Context, filters model.NotificationListFilters) (int, error) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.requeueAllCalls = append(stub.requeueAllCalls, filters)
+	if stub.requeueError != nil {
+		return 0, stub.requeueError
+	}
+	return stub.requeueAllCount, nil
+}
+
+func (stub *stubNotificationService) SendNotificationBatch(ctx context.Context, requests []model.NotificationRequest) (service.NotificationBatchResult, error) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.batchCalls = append(stub.batchCalls, requests)
+	if stub.batchError != nil {
+		return service.NotificationBatchResult{}, stub.batchError
+	}
+	return stub.batchResult, nil
+}
+
+func (stub *stubNotificationService) WatchNotifications(ctx context.Context, filter service.NotificationWatchFilter) (*service.NotificationSubscription, error) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.watchCalls = append(stub.watchCalls, filter)
+	subscription, err := stub.watchEvents.Subscribe(filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.NotificationID != "" {
+		if stub.watchCurrent == nil {
+			subscription.Close()
+			return nil, fmt.Errorf("%w: %s", model.ErrNotificationNotFound, filter.NotificationID)
+		}
+		current := *stub.watchCurrent
+		subscription.Current = &current
+	}
+	return subscription, nil
+}
+
+func (stub *stubNotificationService) GetNotificationBatch(ctx context.Context, batchID string) ([]model.NotificationResponse, error) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	if len(stub.batchResponses) == 0 {
+		return nil, fmt.Errorf("%w: %s", service.ErrNotificationBatchNotFound, batchID)
+	}
+	return stub.batchResponses, nil
+}
+
 func (stub *stubNotificationService) StartRetryWorker(ctx context.Context) {}
 
 type rescheduleInvocation struct {
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/db"
+	"github.com/temirov/pinguin/pkg/logging"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+const migrateUsage = "usage: pinguin migrate up | down [--steps N] | status"
+
+// runMigrateCommand implements `pinguin migrate up|down|status` against the database named
+// by DATABASE_URL or DATABASE_PATH. The server itself applies pending migrations on startup; the
+// command lets operators apply, inspect, or roll them back without starting it.
+func runMigrateCommand(ctx context.Context, args []string, output io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	downFlags := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+	downFlags.SetOutput(io.Discard)
+	steps := downFlags.Int("steps", 1, "number of migrations to revert")
+	switch args[0] {
+	case "up", "status":
+		if len(args) > 1 {
+			return fmt.Errorf("unexpected arguments %v; %s", args[1:], migrateUsage)
+		}
+	case "down":
+		if err := downFlags.Parse(args[1:]); err != nil {
+			return fmt.Errorf("%v; %s", err, migrateUsage)
+		}
+		if downFlags.NArg() > 0 || *steps < 1 {
+			return fmt.Errorf("down takes a positive --steps; %s", migrateUsage)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q; %s", args[0], migrateUsage)
+	}
+
+	configuration, err := config.LoadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+	logger := logging.NewLogger(configuration.LogLevel)
+	database, err := openUnmigratedDatabase(configuration, logger)
+	if err != nil {
+		return err
+	}
+	if sqlDatabase, sqlErr := database.DB(); sqlErr == nil {
+		defer sqlDatabase.Close()
+	}
+
+	switch args[0] {
+	case "up":
+		applied, upErr := db.MigrateUp(ctx, database, logger)
+		if upErr != nil {
+			return upErr
+		}
+		fmt.Fprintf(output, "applied %d migration(s)\n", applied)
+	case "down":
+		reverted, downErr := db.MigrateDown(ctx, database, *steps, logger)
+		if downErr != nil {
+			return downErr
+		}
+		fmt.Fprintf(output, "reverted %d migration(s)\n", reverted)
+	case "status":
+		statuses, statusErr := db.MigrationStatuses(ctx, database)
+		if statusErr != nil {
+			return statusErr
+		}
+		writeMigrationStatuses(output, statuses)
+	}
+	return nil
+}
+
+func openUnmigratedDatabase(configuration config.Config, logger *slog.Logger) (*gorm.DB, error) {
+	if configuration.DatabaseDriver == config.DatabaseDriverPostgres {
+		return db.OpenPostgres(configuration.DatabaseURL, logger)
+	}
+	return db.OpenSQLite(configuration.DatabasePath, logger)
+}
+
+func writeMigrationStatuses(output io.Writer, statuses []db.MigrationStatus) {
+	table := tabwriter.NewWriter(output, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if status.Unknown {
+			state = "unknown"
+		}
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	table.Flush()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunMigrateCommand(t *testing.T) {
+	t.Helper()
+	t.Setenv("DATABASE_URL", "")
+	t.Setenv("DATABASE_PATH", filepath.Join(t.TempDir(), "migrate.db"))
+	t.Setenv("LOG_LEVEL", "ERROR")
+
+	steps := []struct {
+		args           []string
+		expectedOutput []string
+		expectedError  string
+	}{
+		{args: []string{"status"}, expectedOutput: []string{"VERSION", "1  ", "baseline", "pending"}},
+		{args: []string{"up"}, expectedOutput: []string{"applied 9 migration(s)"}},
+		{args: []string{"up"}, expectedOutput: []string{"applied 0 migration(s)"}},
+		{args: []string{"down", "--steps", "2"}, expectedOutput: []string{"reverted 2 migration(s)"}},
+		{args: []string{"status"}, expectedOutput: []string{"baseline", "applied", "notification_last_error", "pending"}},
+		{args: []string{}, expectedError: "usage"},
+		{args: []string{"sideways"}, expectedError: "unknown migrate command"},
+		{args: []string{"down", "--steps", "0"}, expectedError: "positive --steps"},
+		{args: []string{"status", "extra"}, expectedError: "unexpected arguments"},
+	}
+
+	for _, step := range steps {
+		var output bytes.Buffer
+		err := runMigrateCommand(context.Background(), step.args, &output)
+		if step.expectedError != "" {
+			if err == nil || !strings.Contains(err.Error(), step.expectedError) {
+				t.Fatalf("migrate %v: expected error containing %q, got %v", step.args, step.expectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("migrate %v: unexpected error %v", step.args, err)
+		}
+		for _, expected := range step.expectedOutput {
+			if !strings.Contains(output.String(), expected) {
+				t.Fatalf("migrate %v: expected output to contain %q, got:\n%s", step.args, expected, output.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"github.com/temirov/pinguin/pkg/grpcapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+// suppressionServiceServer implements grpcapi.SuppressionServiceServer.
+type suppressionServiceServer struct {
+	grpcapi.UnimplementedSuppressionServiceServer
+	suppressionService service.SuppressionService
+	logger             *slog.Logger
+}
+
+func (server *suppressionServiceServer) ListSuppressions(ctx context.Context, req *grpcapi.ListSuppressionsRequest) (*grpcapi.ListSuppressionsResponse, error) {
+	filters := model.SuppressionListFilters{IncludeExpired: req.GetIncludeExpired()}
+	for _, channel := range req.GetChannels() {
+		filters.Channels = append(filters.Channels, mapGrpcChannel(channel))
+	}
+	suppressions, err := server.suppressionService.ListSuppressions(ctx, filters)
+	if err != nil {
+		server.logger.Error("Service ListSuppressions error", "error", err)
+		return nil, err
+	}
+	grpcSuppressions := make([]*grpcapi.Suppression, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		grpcSuppressions = append(grpcSuppressions, mapModelSuppression(suppression))
+	}
+	return &grpcapi.ListSuppressionsResponse{Suppressions: grpcSuppressions}, nil
+}
+
+func (server *suppressionServiceServer) AddSuppression(ctx context.Context, req *grpcapi.AddSuppressionRequest) (*grpcapi.Suppression, error) {
+	request := model.SuppressionRequest{
+		Channel:   mapGrpcChannel(req.GetChannel()),
+		Recipient: req.GetRecipient(),
+		Reason:    model.SuppressionReason(req.GetReason()),
+		Source:    req.GetSource(),
+		Category:  req.GetCategory(),
+	}
+	if req.GetExpiresAt() != nil {
+		if err := req.GetExpiresAt().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at: %v", err)
+		}
+		expiresAt := req.GetExpiresAt().AsTime()
+		request.ExpiresAt = &expiresAt
+	}
+	suppression, err := server.suppressionService.AddSuppression(ctx, request)
+	if err != nil {
+		server.logger.Error("Service AddSuppression error", "error", err)
+		return nil, mapSuppressionError(err)
+	}
+	return mapModelSuppression(suppression), nil
+}
+
+func (server *suppressionServiceServer) RemoveSuppression(ctx context.Context, req *grpcapi.RemoveSuppressionRequest) (*grpcapi.RemoveSuppressionResponse, error) {
+	if req.GetRecipient() == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipient is required")
+	}
+	if err := server.suppressionService.RemoveSuppression(ctx, mapGrpcChannel(req.GetChannel()), req.GetRecipient(), req.GetCategory()); err != nil {
+		server.logger.Error("Service RemoveSuppression error", "error", err)
+		return nil, mapSuppressionError(err)
+	}
+	return &grpcapi.RemoveSuppressionResponse{Channel: req.GetChannel(), Recipient: req.GetRecipient(), Category: req.GetCategory()}, nil
+}
+
+// mapSuppressionError converts suppression sentinel errors into gRPC status codes and leaves other errors untouched.
+func mapSuppressionError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrSuppressionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidSuppression):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
+
+func mapGrpcChannel(channel grpcapi.NotificationType) model.NotificationType {
+	if channel == grpcapi.NotificationType_SMS {
+		return model.NotificationSMS
+	}
+	return model.NotificationEmail
+}
+
+func mapModelSuppression(suppression model.Suppression) *grpcapi.Suppression {
+	grpcChannel := grpcapi.NotificationType_EMAIL
+	if suppression.Channel == model.NotificationSMS {
+		grpcChannel = grpcapi.NotificationType_SMS
+	}
+	var expiresAt *timestamppb.Timestamp
+	if suppression.ExpiresAt != nil {
+		expiresAt = timestamppb.New(*suppression.ExpiresAt)
+	}
+	return &grpcapi.Suppression{
+		Channel:   grpcChannel,
+		Recipient: suppression.Recipient,
+		Reason:    string(suppression.Reason),
+		Source:    suppression.Source,
+		Category:  suppression.Category,
+		ExpiresAt: expiresAt,
+		CreatedAt: suppression.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: suppression.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/db"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"github.com/temirov/pinguin/pkg/grpcapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+func TestSuppressionServerLifecycle(t *testing.T) {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	database, dbErr := db.InitDB(filepath.Join(t.TempDir(), "suppressions.db"), logger)
+	if dbErr != nil {
+		t.Fatalf("init db error: %v", dbErr)
+	}
+	server := &suppressionServiceServer{suppressionService: service.NewSuppressionService(database, logger), logger: logger}
+	ctx := context.Background()
+
+	added, addErr := server.AddSuppression(ctx, &grpcapi.AddSuppressionRequest{
+		Channel:   grpcapi.NotificationType_EMAIL,
+		Recipient: "User@Example.com",
+		Reason:    "complaint",
+	})
+	if addErr != nil {
+		t.Fatalf("add error: %v", addErr)
+	}
+	if added.GetRecipient() != "user@example.com" || added.GetSource() != "api" || added.GetExpiresAt() != nil {
+		t.Fatalf("unexpected suppression %#v", added)
+	}
+
+	expiresAt := time.Now().Add(time.Hour)
+	if _, smsErr := server.AddSuppression(ctx, &grpcapi.AddSuppressionRequest{
+		Channel:   grpcapi.NotificationType_SMS,
+		Recipient: "+1 555 000 1111",
+		ExpiresAt: timestamppb.New(expiresAt),
+	}); smsErr != nil {
+		t.Fatalf("add sms error: %v", smsErr)
+	}
+
+	_, invalidErr := server.AddSuppression(ctx, &grpcapi.AddSuppressionRequest{Recipient: "user@example.com", Reason: "because"})
+	if status.Code(invalidErr) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for unknown reason, got %v", invalidErr)
+	}
+
+	listed, listErr := server.ListSuppressions(ctx, &grpcapi.ListSuppressionsRequest{Channels: []grpcapi.NotificationType{grpcapi.NotificationType_SMS}})
+	if listErr != nil || len(listed.GetSuppressions()) != 1 {
+		t.Fatalf("unexpected list response %#v (%v)", listed, listErr)
+	}
+	if sms := listed.GetSuppressions()[0]; sms.GetRecipient() != "+15550001111" || sms.GetReason() != "manual" || sms.GetExpiresAt() == nil {
+		t.Fatalf("unexpected sms suppression %#v", sms)
+	}
+
+	if _, removeErr := server.RemoveSuppression(ctx, &grpcapi.RemoveSuppressionRequest{Recipient: "user@example.com"}); removeErr != nil {
+		t.Fatalf("remove error: %v", removeErr)
+	}
+	_, missingErr := server.RemoveSuppression(ctx, &grpcapi.RemoveSuppressionRequest{Recipient: "user@example.com"})
+	if status.Code(missingErr) != codes.NotFound {
+		t.Fatalf("expected NotFound after remove, got %v", missingErr)
+	}
+	_, emptyErr := server.RemoveSuppression(ctx, &grpcapi.RemoveSuppressionRequest{})
+	if status.Code(emptyErr) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for empty recipient, got %v", emptyErr)
+	}
+}
+
+func TestSendNotificationRejectsSuppressedRecipient(t *testing.T) {
+	t.Helper()
+
+	notificationService := &stubNotificationService{
+		sendResponse: model.NotificationResponse{NotificationID: "notif-1", Status: model.StatusSuppressed},
+		sendError: &service.SuppressionError{
+			Channel:    model.NotificationEmail,
+			Recipients: []string{"user@example.com"},
+			Reason:     model.SuppressionUnsubscribed,
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server := &notificationServiceServer{notificationService: notificationService, logger: logger}
+
+	_, sendErr := server.SendNotification(context.Background(), &grpcapi.NotificationRequest{
+		NotificationType: grpcapi.NotificationType_EMAIL,
+		Recipient:        "user@example.com",
+		Message:          "Hello",
+	})
+	if status.Code(sendErr) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", sendErr)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"github.com/temirov/pinguin/pkg/grpcapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+// templateServiceServer implements grpcapi.TemplateServiceServer.
+type templateServiceServer struct {
+	grpcapi.UnimplementedTemplateServiceServer
+	templateService service.TemplateService
+	logger          *slog.Logger
+}
+
+func (server *templateServiceServer) CreateTemplate(ctx context.Context, req *grpcapi.TemplateRequest) (*grpcapi.Template, error) {
+	template, err := server.templateService.CreateTemplate(ctx, mapGrpcTemplateRequest(req))
+	if err != nil {
+		server.logger.Error("Service CreateTemplate error", "error", err)
+		return nil, mapTemplateError(err)
+	}
+	return mapModelTemplate(template), nil
+}
+
+func (server *templateServiceServer) UpdateTemplate(ctx context.Context, req *grpcapi.TemplateRequest) (*grpcapi.Template, error) {
+	template, err := server.templateService.UpdateTemplate(ctx, mapGrpcTemplateRequest(req))
+	if err != nil {
+		server.logger.Error("Service UpdateTemplate error", "error", err)
+		return nil, mapTemplateError(err)
+	}
+	return mapModelTemplate(template), nil
+}
+
+func (server *templateServiceServer) GetTemplate(ctx context.Context, req *grpcapi.GetTemplateRequest) (*grpcapi.Template, error) {
+	if req.GetTemplateId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_id is required")
+	}
+	template, err := server.templateService.GetTemplate(ctx, req.GetTemplateId(), int(req.GetVersion()))
+	if err != nil {
+		server.logger.Error("Service GetTemplate error", "error", err)
+		return nil, mapTemplateError(err)
+	}
+	return mapModelTemplate(template), nil
+}
+
+func (server *templateServiceServer) ListTemplates(ctx context.Context, _ *grpcapi.ListTemplatesRequest) (*grpcapi.ListTemplatesResponse, error) {
+	templates, err := server.templateService.ListTemplates(ctx)
+	if err != nil {
+		server.logger.Error("Service ListTemplates error", "error", err)
+		return nil, err
+	}
+	grpcTemplates := make([]*grpcapi.Template, 0, len(templates))
+	for _, template := range templates {
+		grpcTemplates = append(grpcTemplates, mapModelTemplate(template))
+	}
+	return &grpcapi.ListTemplatesResponse{Templates: grpcTemplates}, nil
+}
+
+func (server *templateServiceServer) DeleteTemplate(ctx context.Context, req *grpcapi.DeleteTemplateRequest) (*grpcapi.DeleteTemplateResponse, error) {
+	if req.GetTemplateId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_id is required")
+	}
+	if err := server.templateService.DeleteTemplate(ctx, req.GetTemplateId()); err != nil {
+		server.logger.Error("Service DeleteTemplate error", "error", err)
+		return nil, mapTemplateError(err)
+	}
+	return &grpcapi.DeleteTemplateResponse{TemplateId: req.GetTemplateId()}, nil
+}
+
+func (server *templateServiceServer) PreviewTemplate(ctx context.Context, req *grpcapi.PreviewTemplateRequest) (*grpcapi.PreviewTemplateResponse, error) {
+	if req.GetTemplateId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_id is required")
+	}
+	notificationType := model.NotificationEmail
+	if req.GetNotificationType() == grpcapi.NotificationType_SMS {
+		notificationType = model.NotificationSMS
+	}
+	rendered, err := server.templateService.PreviewTemplate(ctx, req.GetTemplateId(), int(req.GetVersion()), notificationType, req.GetTemplateData())
+	if err != nil {
+		server.logger.Error("Service PreviewTemplate error", "error", err)
+		return nil, mapTemplateError(err)
+	}
+	return &grpcapi.PreviewTemplateResponse{
+		TemplateId: rendered.TemplateID,
+		Version:    int32(rendered.Version),
+		Subject:    rendered.Subject,
+		PlainBody:  rendered.PlainBody,
+		HtmlBody:   rendered.HTMLBody,
+		SmsBody:    rendered.SMSBody,
+	}, nil
+}
+
+// mapTemplateError converts template sentinel errors into gRPC status codes and leaves other errors untouched.
+func mapTemplateError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrTemplateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrTemplateExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrInvalidTemplate), errors.Is(err, service.ErrTemplateRender):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
+
+func mapGrpcTemplateRequest(req *grpcapi.TemplateRequest) model.TemplateRequest {
+	return model.TemplateRequest{
+		TemplateID:  req.GetTemplateId(),
+		Description: req.GetDescription(),
+		Subject:     req.GetSubject(),
+		PlainBody:   req.GetPlainBody(),
+		HTMLBody:    req.GetHtmlBody(),
+		SMSBody:     req.GetSmsBody(),
+	}
+}
+
+func mapModelTemplate(template model.Template) *grpcapi.Template {
+	return &grpcapi.Template{
+		TemplateId:  template.TemplateID,
+		Version:     int32(template.Version),
+		Description: template.Description,
+		Subject:     template.Subject,
+		PlainBody:   template.PlainBody,
+		HtmlBody:    template.HTMLBody,
+		SmsBody:     template.SMSBody,
+		CreatedAt:   template.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   template.UpdatedAt.Format(time.RFC3339),
+	}
+}
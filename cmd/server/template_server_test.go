@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/db"
+	"github.com/temirov/pinguin/internal/service"
+	"github.com/temirov/pinguin/pkg/grpcapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+func TestTemplateServerLifecycle(t *testing.T) {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	database, dbErr := db.InitDB(filepath.Join(t.TempDir(), "templates.db"), logger)
+	if dbErr != nil {
+		t.Fatalf("init db error: %v", dbErr)
+	}
+	server := &templateServiceServer{templateService: service.NewTemplateService(database, logger), logger: logger}
+	ctx := context.Background()
+
+	created, createErr := server.CreateTemplate(ctx, &grpcapi.TemplateRequest{TemplateId: "welcome", Subject: "Hi {{.name}}", PlainBody: "Hello {{.name}}", SmsBody: "Hi {{.name}}"})
+	if createErr != nil {
+		t.Fatalf("create error: %v", createErr)
+	}
+	if created.GetVersion() != 1 || created.GetCreatedAt() == "" {
+		t.Fatalf("unexpected created template %#v", created)
+	}
+
+	_, duplicateErr := server.CreateTemplate(ctx, &grpcapi.TemplateRequest{TemplateId: "welcome", PlainBody: "Again"})
+	if status.Code(duplicateErr) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", duplicateErr)
+	}
+
+	updated, updateErr := server.UpdateTemplate(ctx, &grpcapi.TemplateRequest{TemplateId: "welcome", PlainBody: "Welcome {{.name}}", SmsBody: "Welcome {{.name}}"})
+	if updateErr != nil || updated.GetVersion() != 2 {
+		t.Fatalf("expected version 2, got %#v (%v)", updated, updateErr)
+	}
+
+	fetched, getErr := server.GetTemplate(ctx, &grpcapi.GetTemplateRequest{TemplateId: "welcome", Version: 1})
+	if getErr != nil || fetched.GetPlainBody() != "Hello {{.name}}" {
+		t.Fatalf("unexpected fetched template %#v (%v)", fetched, getErr)
+	}
+
+	listed, listErr := server.ListTemplates(ctx, &grpcapi.ListTemplatesRequest{})
+	if listErr != nil || len(listed.GetTemplates()) != 1 || listed.GetTemplates()[0].GetVersion() != 2 {
+		t.Fatalf("unexpected list response %#v (%v)", listed, listErr)
+	}
+
+	preview, previewErr := server.PreviewTemplate(ctx, &grpcapi.PreviewTemplateRequest{
+		TemplateId:       "welcome",
+		NotificationType: grpcapi.NotificationType_SMS,
+		TemplateData:     map[string]string{"name": "Ada"},
+	})
+	if previewErr != nil || preview.GetSmsBody() != "Welcome Ada" || preview.GetVersion() != 2 {
+		t.Fatalf("unexpected preview %#v (%v)", preview, previewErr)
+	}
+
+	_, missingVariableErr := server.PreviewTemplate(ctx, &grpcapi.PreviewTemplateRequest{TemplateId: "welcome"})
+	if status.Code(missingVariableErr) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for missing variable, got %v", missingVariableErr)
+	}
+
+	if _, deleteErr := server.DeleteTemplate(ctx, &grpcapi.DeleteTemplateRequest{TemplateId: "welcome"}); deleteErr != nil {
+		t.Fatalf("delete error: %v", deleteErr)
+	}
+	_, missingErr := server.GetTemplate(ctx, &grpcapi.GetTemplateRequest{TemplateId: "welcome"})
+	if status.Code(missingErr) != codes.NotFound {
+		t.Fatalf("expected NotFound after delete, got %v", missingErr)
+	}
+
+	_, emptyIDErr := server.GetTemplate(ctx, &grpcapi.GetTemplateRequest{})
+	if status.Code(emptyIDErr) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for empty id, got %v", emptyIDErr)
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -10,12 +11,117 @@ import (
 
 const defaultHTTPStaticRoot = "/web"
 
+// Supported values for EMAIL_PROVIDER.
+const (
+	EmailProviderSMTP     = "smtp"
+	EmailProviderSendGrid = "sendgrid"
+	EmailProviderMailgun  = "mailgun"
+	EmailProviderSES      = "ses"
+	EmailProviderPostmark = "postmark"
+)
+
+// Supported values for SMS_PROVIDER.
+const (
+	SMSProviderTwilio      = "twilio"
+	SMSProviderVonage      = "vonage"
+	SMSProviderMessageBird = "messagebird"
+	SMSProviderSNS         = "sns"
+	SMSProviderWebhook     = "webhook"
+)
+
+// Storage backends selected by DATABASE_URL.
+const (
+	// DatabaseDriverSQLite stores notifications in the SQLite file named by DATABASE_PATH.
+	DatabaseDriverSQLite = "sqlite"
+	// DatabaseDriverPostgres connects to the PostgreSQL database named by a postgres:// DATABASE_URL.
+	DatabaseDriverPostgres = "postgres"
+)
+
+// Supported values for DISPATCH_MODE.
+const (
+	// DispatchModeEnqueue stores notifications as queued and leaves delivery to the dispatch worker.
+	DispatchModeEnqueue = "enqueue"
+	// DispatchModeInline calls the provider inside SendNotification before it returns.
+	DispatchModeInline = "inline"
+)
+
+// Supported values for RETRY_JITTER and its per-channel overrides.
+const (
+	// RetryJitterNone waits exactly the exponential backoff.
+	RetryJitterNone = "none"
+	// RetryJitterFull waits a random delay between zero and the exponential backoff.
+	RetryJitterFull = "full"
+	// RetryJitterDecorrelated waits a random delay between the retry interval and three times the
+	// previous delay.
+	RetryJitterDecorrelated = "decorrelated"
+)
+
+const (
+	defaultProviderBreakerThreshold   = 5
+	defaultProviderBreakerCooldownSec = 30
+	defaultDispatchPollIntervalMs     = 1000
+	defaultDispatchEmailConcurrency   = 4
+	defaultDispatchSMSConcurrency     = 4
+	defaultDispatchMaxInFlight        = 8
+	defaultDispatchLeaseSec           = 300
+	defaultWebhookMaxAttempts         = 8
+	defaultWebhookRetryIntervalSec    = 30
+	defaultWebhookTimeoutSec          = 10
+	defaultDeliveryWebhookMaxAgeSec   = 300
+)
+
+// RetryPolicy describes how failed notifications of one channel back off: the delay starts at
+// IntervalSec, doubles after every failed attempt up to MaxDelaySec (zero leaves it uncapped), and is
+// randomized according to Jitter.
+type RetryPolicy struct {
+	IntervalSec int
+	MaxDelaySec int
+	Jitter      string
+}
+
+// ProviderRoute is one entry of EMAIL_PROVIDERS or SMS_PROVIDERS. A positive Weight makes the
+// provider eligible for the weighted first attempt; providers without a weight only receive failover traffic.
+type ProviderRoute struct {
+	Name   string
+	Weight int
+}
+
 type Config struct {
+	// DatabaseDriver is DatabaseDriverPostgres when DATABASE_URL is set, in which case DatabaseURL
+	// holds the connection URL; otherwise it is DatabaseDriverSQLite and DatabasePath is required.
+	DatabaseDriver   string
+	DatabaseURL      string
 	DatabasePath     string
 	GRPCAuthToken    string
 	LogLevel         string
 	MaxRetries       int
 	RetryIntervalSec int
+	// RetryMaxDelaySec and RetryJitter complete the backoff that starts at RetryIntervalSec.
+	// EmailRetryPolicy and SMSRetryPolicy are that backoff with the RETRY_EMAIL_* and RETRY_SMS_*
+	// overrides applied.
+	RetryMaxDelaySec int
+	RetryJitter      string
+	EmailRetryPolicy RetryPolicy
+	SMSRetryPolicy   RetryPolicy
+
+	// DispatchMode selects how SendNotification delivers requests that do not ask for a mode;
+	// DispatchPollIntervalMs bounds how long an enqueued notification waits for the dispatch worker.
+	DispatchMode           string
+	DispatchPollIntervalMs int
+	// The dispatch worker attempts up to DispatchEmailConcurrency emails and DispatchSMSConcurrency
+	// SMS messages at once, and never more than DispatchMaxInFlight notifications in total.
+	DispatchEmailConcurrency int
+	DispatchSMSConcurrency   int
+	DispatchMaxInFlight      int
+	// DispatchWorkerID names this instance's leases on queued notifications (generated when empty);
+	// a lease lapses after DispatchLeaseSec so another instance can take over the notification.
+	DispatchWorkerID string
+	DispatchLeaseSec int
+	// Outbound webhook deliveries are attempted up to WebhookMaxAttempts times with exponential
+	// backoff from WebhookRetryIntervalSec; each POST gives up after WebhookTimeoutSec.
+	WebhookMaxAttempts      int
+	WebhookRetryIntervalSec int
+	WebhookTimeoutSec       int
 
 	WebInterfaceEnabled bool
 	HTTPListenAddr      string
@@ -27,15 +133,87 @@ type Config struct {
 	TAuthIssuer     string
 	TAuthCookieName string
 
+	EmailProvider string
+	FromEmail     string
+
 	SMTPUsername string
 	SMTPPassword string
 	SMTPHost     string
 	SMTPPort     int
-	FromEmail    string
+
+	SendGridAPIKey  string
+	SendGridBaseURL string
+
+	MailgunAPIKey  string
+	MailgunDomain  string
+	MailgunBaseURL string
+
+	SESRegion  string
+	SESBaseURL string
+
+	PostmarkServerToken   string
+	PostmarkMessageStream string
+	PostmarkBaseURL       string
+
+	// SMSProvider names the SMS backend. When neither SMS_PROVIDER nor SMS_PROVIDERS is set it defaults
+	// to Twilio and SMSProviderExplicit stays false, so missing Twilio credentials disable SMS instead
+	// of failing startup.
+	SMSProvider         string
+	SMSProviderExplicit bool
+
+	// EmailProviders and SMSProviders list every provider in failover order. EmailProvider and
+	// SMSProvider always name the first entry.
+	EmailProviders []ProviderRoute
+	SMSProviders   []ProviderRoute
+
+	// Circuit breaker settings applied to each provider when more than one is configured.
+	ProviderBreakerThreshold   int
+	ProviderBreakerCooldownSec int
 
 	TwilioAccountSID string
 	TwilioAuthToken  string
 	TwilioFromNumber string
+	TwilioBaseURL    string
+
+	VonageAPIKey     string
+	VonageAPISecret  string
+	VonageFromNumber string
+	VonageBaseURL    string
+
+	MessageBirdAccessKey  string
+	MessageBirdOriginator string
+	MessageBirdBaseURL    string
+
+	SNSRegion   string
+	SNSSenderID string
+	SNSBaseURL  string
+
+	SMSWebhookURL        string
+	SMSWebhookToken      string
+	SMSWebhookFromNumber string
+
+	// AWS credentials shared by the SES email and SNS SMS providers.
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+
+	// Delivery status webhooks. PublicBaseURL is the externally reachable origin of the HTTP server;
+	// Twilio is asked to post status callbacks there and signs requests against that URL.
+	PublicBaseURL            string
+	SendGridWebhookPublicKey string
+	MailgunWebhookSigningKey string
+	// SendGrid and Mailgun requests whose signed timestamp is more than DeliveryWebhookMaxAgeSec
+	// away from the current time are rejected as replays.
+	DeliveryWebhookMaxAgeSec int
+	// Bounce and complaint ingestion. DSNWebhookToken authenticates raw DSN messages forwarded by
+	// the mail infrastructure; SESSNSTopicARN is the SNS topic SES publishes its events to.
+	DSNWebhookToken string
+	SESSNSTopicARN  string
+	// UnsubscribeSigningKey signs the one-click unsubscribe links carried by categorized emails.
+	// Links are only issued when PublicBaseURL is set as well.
+	UnsubscribeSigningKey string
+	// SMSHelpReply is sent back to recipients who text HELP; empty disables the auto-reply.
+	SMSHelpReply string
 
 	// Simplified timeout settings (in seconds)
 	ConnectionTimeoutSec int
@@ -50,20 +228,27 @@ func LoadConfig(disableWebInterface bool) (Config, error) {
 	var waitGroup sync.WaitGroup
 
 	taskFunctions := []func() error{
-		loadEnvString("DATABASE_PATH", &configuration.DatabasePath),
 		loadEnvString("GRPC_AUTH_TOKEN", &configuration.GRPCAuthToken),
 		loadEnvString("LOG_LEVEL", &configuration.LogLevel),
 		loadEnvInt("MAX_RETRIES", &configuration.MaxRetries),
 		loadEnvInt("RETRY_INTERVAL_SEC", &configuration.RetryIntervalSec),
-		loadEnvString("SMTP_USERNAME", &configuration.SMTPUsername),
-		loadEnvString("SMTP_PASSWORD", &configuration.SMTPPassword),
-		loadEnvString("SMTP_HOST", &configuration.SMTPHost),
-		loadEnvInt("SMTP_PORT", &configuration.SMTPPort),
 		loadEnvString("FROM_EMAIL", &configuration.FromEmail),
 		loadEnvInt("CONNECTION_TIMEOUT_SEC", &configuration.ConnectionTimeoutSec),
 		loadEnvInt("OPERATION_TIMEOUT_SEC", &configuration.OperationTimeoutSec),
 	}
 
+	databaseTasks, databaseErr := configuration.loadDatabaseSettings()
+	if databaseErr != nil {
+		return Config{}, databaseErr
+	}
+	taskFunctions = append(taskFunctions, databaseTasks...)
+
+	providerTasks, providerErr := configuration.loadProviderSettings()
+	if providerErr != nil {
+		return Config{}, providerErr
+	}
+	taskFunctions = append(taskFunctions, providerTasks...)
+
 	if configuration.WebInterfaceEnabled {
 		taskFunctions = append(taskFunctions,
 			loadEnvString("HTTP_LISTEN_ADDR", &configuration.HTTPListenAddr),
@@ -94,6 +279,50 @@ func LoadConfig(disableWebInterface bool) (Config, error) {
 		return Config{}, fmt.Errorf("configuration errors: %s", strings.Join(errorMessages, ", "))
 	}
 
+	configuration.PublicBaseURL = strings.TrimRight(strings.TrimSpace(os.Getenv("PUBLIC_BASE_URL")), "/")
+	configuration.SendGridWebhookPublicKey = strings.TrimSpace(os.Getenv("SENDGRID_WEBHOOK_PUBLIC_KEY"))
+	configuration.MailgunWebhookSigningKey = strings.TrimSpace(os.Getenv("MAILGUN_WEBHOOK_SIGNING_KEY"))
+	configuration.DSNWebhookToken = strings.TrimSpace(os.Getenv("DSN_WEBHOOK_TOKEN"))
+	configuration.SESSNSTopicARN = strings.TrimSpace(os.Getenv("SES_SNS_TOPIC_ARN"))
+	configuration.UnsubscribeSigningKey = strings.TrimSpace(os.Getenv("UNSUBSCRIBE_SIGNING_KEY"))
+	configuration.SMSHelpReply = strings.TrimSpace(os.Getenv("SMS_HELP_REPLY"))
+
+	configuration.DispatchWorkerID = strings.TrimSpace(os.Getenv("DISPATCH_WORKER_ID"))
+	configuration.DispatchMode = strings.ToLower(strings.TrimSpace(os.Getenv("DISPATCH_MODE")))
+	switch configuration.DispatchMode {
+	case "":
+		configuration.DispatchMode = DispatchModeEnqueue
+	case DispatchModeEnqueue, DispatchModeInline:
+	default:
+		return Config{}, fmt.Errorf("configuration errors: unsupported DISPATCH_MODE %q", configuration.DispatchMode)
+	}
+	dispatchSettings := []struct {
+		environmentKey string
+		fallback       int
+		destination    *int
+	}{
+		{environmentKey: "DISPATCH_POLL_INTERVAL_MS", fallback: defaultDispatchPollIntervalMs, destination: &configuration.DispatchPollIntervalMs},
+		{environmentKey: "DISPATCH_EMAIL_CONCURRENCY", fallback: defaultDispatchEmailConcurrency, destination: &configuration.DispatchEmailConcurrency},
+		{environmentKey: "DISPATCH_SMS_CONCURRENCY", fallback: defaultDispatchSMSConcurrency, destination: &configuration.DispatchSMSConcurrency},
+		{environmentKey: "DISPATCH_MAX_IN_FLIGHT", fallback: defaultDispatchMaxInFlight, destination: &configuration.DispatchMaxInFlight},
+		{environmentKey: "DISPATCH_LEASE_SEC", fallback: defaultDispatchLeaseSec, destination: &configuration.DispatchLeaseSec},
+		{environmentKey: "WEBHOOK_MAX_ATTEMPTS", fallback: defaultWebhookMaxAttempts, destination: &configuration.WebhookMaxAttempts},
+		{environmentKey: "WEBHOOK_RETRY_INTERVAL_SEC", fallback: defaultWebhookRetryIntervalSec, destination: &configuration.WebhookRetryIntervalSec},
+		{environmentKey: "WEBHOOK_TIMEOUT_SEC", fallback: defaultWebhookTimeoutSec, destination: &configuration.WebhookTimeoutSec},
+		{environmentKey: "DELIVERY_WEBHOOK_MAX_AGE_SEC", fallback: defaultDeliveryWebhookMaxAgeSec, destination: &configuration.DeliveryWebhookMaxAgeSec},
+	}
+	for _, setting := range dispatchSettings {
+		parsedValue, parseErr := parseOptionalInt(setting.environmentKey, setting.fallback)
+		if parseErr != nil {
+			return Config{}, parseErr
+		}
+		*setting.destination = parsedValue
+	}
+
+	if retryErr := configuration.loadRetrySettings(); retryErr != nil {
+		return Config{}, retryErr
+	}
+
 	if configuration.WebInterfaceEnabled {
 		configuration.HTTPStaticRoot = strings.TrimSpace(os.Getenv("HTTP_STATIC_ROOT"))
 		if configuration.HTTPStaticRoot == "" {
@@ -121,13 +350,271 @@ func LoadConfig(disableWebInterface bool) (Config, error) {
 		configuration.TAuthCookieName = ""
 	}
 
-	configuration.TwilioAccountSID = strings.TrimSpace(os.Getenv("TWILIO_ACCOUNT_SID"))
-	configuration.TwilioAuthToken = strings.TrimSpace(os.Getenv("TWILIO_AUTH_TOKEN"))
-	configuration.TwilioFromNumber = strings.TrimSpace(os.Getenv("TWILIO_FROM_NUMBER"))
+	return configuration, nil
+}
 
+// LoadDatabaseConfig reads only the settings needed to open the database (DATABASE_URL or
+// DATABASE_PATH, and LOG_LEVEL when set), for commands such as `migrate` that do not start the server.
+func LoadDatabaseConfig() (Config, error) {
+	var configuration Config
+	databaseTasks, err := configuration.loadDatabaseSettings()
+	if err != nil {
+		return Config{}, err
+	}
+	for _, task := range databaseTasks {
+		if taskErr := task(); taskErr != nil {
+			return Config{}, fmt.Errorf("configuration errors: %w", taskErr)
+		}
+	}
+	configuration.LogLevel = strings.TrimSpace(os.Getenv("LOG_LEVEL"))
 	return configuration, nil
 }
 
+// loadDatabaseSettings selects the storage backend. A DATABASE_URL with a postgres:// or
+// postgresql:// scheme selects PostgreSQL; without one the SQLite file at DATABASE_PATH is used.
+func (configuration *Config) loadDatabaseSettings() ([]func() error, error) {
+	configuration.DatabaseURL = strings.TrimSpace(os.Getenv("DATABASE_URL"))
+	if configuration.DatabaseURL == "" {
+		configuration.DatabaseDriver = DatabaseDriverSQLite
+		return []func() error{loadEnvString("DATABASE_PATH", &configuration.DatabasePath)}, nil
+	}
+
+	// The parse error is not wrapped because it would repeat the URL and its password.
+	parsedURL, err := url.Parse(configuration.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("configuration errors: malformed DATABASE_URL")
+	}
+	switch strings.ToLower(parsedURL.Scheme) {
+	case "postgres", "postgresql":
+		configuration.DatabaseDriver = DatabaseDriverPostgres
+	default:
+		return nil, fmt.Errorf("configuration errors: unsupported DATABASE_URL scheme %q", parsedURL.Scheme)
+	}
+	return nil, nil
+}
+
+// loadRetrySettings reads the shared retry backoff and resolves the per-channel policies. It runs
+// after RETRY_INTERVAL_SEC has been loaded.
+func (configuration *Config) loadRetrySettings() error {
+	var err error
+	if configuration.RetryMaxDelaySec, err = parseOptionalInt("RETRY_MAX_DELAY_SEC", 0); err != nil {
+		return err
+	}
+	if configuration.RetryJitter, err = parseRetryJitter("RETRY_JITTER", RetryJitterNone); err != nil {
+		return err
+	}
+	sharedPolicy := RetryPolicy{
+		IntervalSec: configuration.RetryIntervalSec,
+		MaxDelaySec: configuration.RetryMaxDelaySec,
+		Jitter:      configuration.RetryJitter,
+	}
+	channelPolicies := []struct {
+		environmentPrefix string
+		destination       *RetryPolicy
+	}{
+		{environmentPrefix: "RETRY_EMAIL_", destination: &configuration.EmailRetryPolicy},
+		{environmentPrefix: "RETRY_SMS_", destination: &configuration.SMSRetryPolicy},
+	}
+	for _, channel := range channelPolicies {
+		policy := sharedPolicy
+		if policy.IntervalSec, err = parseOptionalInt(channel.environmentPrefix+"INTERVAL_SEC", sharedPolicy.IntervalSec); err != nil {
+			return err
+		}
+		if policy.MaxDelaySec, err = parseOptionalInt(channel.environmentPrefix+"MAX_DELAY_SEC", sharedPolicy.MaxDelaySec); err != nil {
+			return err
+		}
+		if policy.Jitter, err = parseRetryJitter(channel.environmentPrefix+"JITTER", sharedPolicy.Jitter); err != nil {
+			return err
+		}
+		*channel.destination = policy
+	}
+	return nil
+}
+
+func parseRetryJitter(environmentKey string, fallback string) (string, error) {
+	jitter := strings.ToLower(strings.TrimSpace(os.Getenv(environmentKey)))
+	switch jitter {
+	case "":
+		return fallback, nil
+	case RetryJitterNone, RetryJitterFull, RetryJitterDecorrelated:
+		return jitter, nil
+	default:
+		return "", fmt.Errorf("configuration errors: unsupported %s %q", environmentKey, jitter)
+	}
+}
+
+// loadProviderSettings resolves the email and SMS provider lists and returns loaders for the
+// variables each selected provider requires.
+func (configuration *Config) loadProviderSettings() ([]func() error, error) {
+	emailRoutes, err := parseProviderRoutes("EMAIL_PROVIDERS")
+	if err != nil {
+		return nil, err
+	}
+	if len(emailRoutes) == 0 {
+		emailProvider := strings.ToLower(strings.TrimSpace(os.Getenv("EMAIL_PROVIDER")))
+		if emailProvider == "" {
+			emailProvider = EmailProviderSMTP
+		}
+		emailRoutes = []ProviderRoute{{Name: emailProvider}}
+	}
+	configuration.EmailProviders = emailRoutes
+	configuration.EmailProvider = emailRoutes[0].Name
+
+	smsRoutes, err := parseProviderRoutes("SMS_PROVIDERS")
+	if err != nil {
+		return nil, err
+	}
+	if len(smsRoutes) == 0 {
+		if smsProvider := strings.ToLower(strings.TrimSpace(os.Getenv("SMS_PROVIDER"))); smsProvider != "" {
+			smsRoutes = []ProviderRoute{{Name: smsProvider}}
+		}
+	}
+	configuration.SMSProviderExplicit = len(smsRoutes) > 0
+	if !configuration.SMSProviderExplicit {
+		smsRoutes = []ProviderRoute{{Name: SMSProviderTwilio}}
+	}
+	configuration.SMSProviders = smsRoutes
+	configuration.SMSProvider = smsRoutes[0].Name
+
+	configuration.ProviderBreakerThreshold, err = parseOptionalInt("PROVIDER_BREAKER_THRESHOLD", defaultProviderBreakerThreshold)
+	if err != nil {
+		return nil, err
+	}
+	configuration.ProviderBreakerCooldownSec, err = parseOptionalInt("PROVIDER_BREAKER_COOLDOWN_SEC", defaultProviderBreakerCooldownSec)
+	if err != nil {
+		return nil, err
+	}
+
+	var taskFunctions []func() error
+	requiresAWSCredentials := false
+	for _, route := range emailRoutes {
+		switch route.Name {
+		case EmailProviderSMTP:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("SMTP_USERNAME", &configuration.SMTPUsername),
+				loadEnvString("SMTP_PASSWORD", &configuration.SMTPPassword),
+				loadEnvString("SMTP_HOST", &configuration.SMTPHost),
+				loadEnvInt("SMTP_PORT", &configuration.SMTPPort),
+			)
+		case EmailProviderSendGrid:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("SENDGRID_API_KEY", &configuration.SendGridAPIKey),
+			)
+			configuration.SendGridBaseURL = strings.TrimSpace(os.Getenv("SENDGRID_BASE_URL"))
+		case EmailProviderMailgun:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("MAILGUN_API_KEY", &configuration.MailgunAPIKey),
+				loadEnvString("MAILGUN_DOMAIN", &configuration.MailgunDomain),
+			)
+			configuration.MailgunBaseURL = strings.TrimSpace(os.Getenv("MAILGUN_BASE_URL"))
+		case EmailProviderSES:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("SES_REGION", &configuration.SESRegion),
+			)
+			configuration.SESBaseURL = strings.TrimSpace(os.Getenv("SES_BASE_URL"))
+			requiresAWSCredentials = true
+		case EmailProviderPostmark:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("POSTMARK_SERVER_TOKEN", &configuration.PostmarkServerToken),
+			)
+			configuration.PostmarkMessageStream = strings.TrimSpace(os.Getenv("POSTMARK_MESSAGE_STREAM"))
+			configuration.PostmarkBaseURL = strings.TrimSpace(os.Getenv("POSTMARK_BASE_URL"))
+		default:
+			return nil, fmt.Errorf("configuration errors: unsupported EMAIL_PROVIDER %q", route.Name)
+		}
+	}
+
+	for _, route := range smsRoutes {
+		switch route.Name {
+		case SMSProviderTwilio:
+			if configuration.SMSProviderExplicit {
+				taskFunctions = append(taskFunctions,
+					loadEnvString("TWILIO_ACCOUNT_SID", &configuration.TwilioAccountSID),
+					loadEnvString("TWILIO_AUTH_TOKEN", &configuration.TwilioAuthToken),
+					loadEnvString("TWILIO_FROM_NUMBER", &configuration.TwilioFromNumber),
+				)
+			} else {
+				configuration.TwilioAccountSID = strings.TrimSpace(os.Getenv("TWILIO_ACCOUNT_SID"))
+				configuration.TwilioAuthToken = strings.TrimSpace(os.Getenv("TWILIO_AUTH_TOKEN"))
+				configuration.TwilioFromNumber = strings.TrimSpace(os.Getenv("TWILIO_FROM_NUMBER"))
+			}
+			configuration.TwilioBaseURL = strings.TrimSpace(os.Getenv("TWILIO_BASE_URL"))
+		case SMSProviderVonage:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("VONAGE_API_KEY", &configuration.VonageAPIKey),
+				loadEnvString("VONAGE_API_SECRET", &configuration.VonageAPISecret),
+				loadEnvString("VONAGE_FROM_NUMBER", &configuration.VonageFromNumber),
+			)
+			configuration.VonageBaseURL = strings.TrimSpace(os.Getenv("VONAGE_BASE_URL"))
+		case SMSProviderMessageBird:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("MESSAGEBIRD_ACCESS_KEY", &configuration.MessageBirdAccessKey),
+				loadEnvString("MESSAGEBIRD_ORIGINATOR", &configuration.MessageBirdOriginator),
+			)
+			configuration.MessageBirdBaseURL = strings.TrimSpace(os.Getenv("MESSAGEBIRD_BASE_URL"))
+		case SMSProviderSNS:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("SNS_REGION", &configuration.SNSRegion),
+			)
+			configuration.SNSSenderID = strings.TrimSpace(os.Getenv("SNS_SENDER_ID"))
+			configuration.SNSBaseURL = strings.TrimSpace(os.Getenv("SNS_BASE_URL"))
+			requiresAWSCredentials = true
+		case SMSProviderWebhook:
+			taskFunctions = append(taskFunctions,
+				loadEnvString("SMS_WEBHOOK_URL", &configuration.SMSWebhookURL),
+			)
+			configuration.SMSWebhookToken = strings.TrimSpace(os.Getenv("SMS_WEBHOOK_TOKEN"))
+			configuration.SMSWebhookFromNumber = strings.TrimSpace(os.Getenv("SMS_WEBHOOK_FROM_NUMBER"))
+		default:
+			return nil, fmt.Errorf("configuration errors: unsupported SMS_PROVIDER %q", route.Name)
+		}
+	}
+
+	if requiresAWSCredentials {
+		taskFunctions = append(taskFunctions,
+			loadEnvString("AWS_ACCESS_KEY_ID", &configuration.AWSAccessKeyID),
+			loadEnvString("AWS_SECRET_ACCESS_KEY", &configuration.AWSSecretAccessKey),
+		)
+		configuration.AWSSessionToken = strings.TrimSpace(os.Getenv("AWS_SESSION_TOKEN"))
+	}
+	return taskFunctions, nil
+}
+
+// parseProviderRoutes reads a comma separated provider list such as "sendgrid:90,smtp:10".
+func parseProviderRoutes(environmentKey string) ([]ProviderRoute, error) {
+	var routes []ProviderRoute
+	seen := make(map[string]struct{})
+	for _, entry := range parseCSV(os.Getenv(environmentKey)) {
+		name, rawWeight, hasWeight := strings.Cut(entry, ":")
+		route := ProviderRoute{Name: strings.ToLower(strings.TrimSpace(name))}
+		if hasWeight {
+			weight, conversionError := strconv.Atoi(strings.TrimSpace(rawWeight))
+			if conversionError != nil || weight < 0 {
+				return nil, fmt.Errorf("configuration errors: invalid weight %q for %s in %s", rawWeight, route.Name, environmentKey)
+			}
+			route.Weight = weight
+		}
+		if _, duplicate := seen[route.Name]; duplicate {
+			return nil, fmt.Errorf("configuration errors: duplicate provider %q in %s", route.Name, environmentKey)
+		}
+		seen[route.Name] = struct{}{}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func parseOptionalInt(environmentKey string, fallback int) (int, error) {
+	rawValue := strings.TrimSpace(os.Getenv(environmentKey))
+	if rawValue == "" {
+		return fallback, nil
+	}
+	parsedInteger, conversionError := strconv.Atoi(rawValue)
+	if conversionError != nil || parsedInteger <= 0 {
+		return 0, fmt.Errorf("configuration errors: invalid positive integer for %s: %q", environmentKey, rawValue)
+	}
+	return parsedInteger, nil
+}
+
 func loadEnvString(environmentKey string, destination *string) func() error {
 	const missingEnvFormat = "missing environment variable %s"
 	return func() error {
@@ -4,17 +4,41 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
-	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// InitDB opens the SQLite database at dbPath, creating its directory when needed, and applies
+// pending schema migrations.
 func InitDB(dbPath string, logger *slog.Logger) (*gorm.DB, error) {
+	database, err := OpenSQLite(dbPath, logger)
+	if err != nil {
+		return nil, err
+	}
+	return migrateOnOpen(database, logger)
+}
+
+// InitPostgres connects to the PostgreSQL database at databaseURL (a postgres:// URL or a libpq
+// keyword/value string) and applies pending schema migrations. Several Pinguin instances may share
+// it: the dispatch workers lease queued notifications with SELECT ... FOR UPDATE SKIP LOCKED.
+func InitPostgres(databaseURL string, logger *slog.Logger) (*gorm.DB, error) {
+	database, err := OpenPostgres(databaseURL, logger)
+	if err != nil {
+		return nil, err
+	}
+	return migrateOnOpen(database, logger)
+}
+
+// OpenSQLite opens the SQLite database at dbPath without migrating it.
+func OpenSQLite(dbPath string, logger *slog.Logger) (*gorm.DB, error) {
 	logger.Info("Initializing SQLite DB", "path", dbPath)
 
 	directory := filepath.Dir(dbPath)
@@ -24,21 +48,55 @@ func InitDB(dbPath string, logger *slog.Logger) (*gorm.DB, error) {
 		}
 	}
 
-	gormLogger := &slogGormLogger{logger: logger}
-	database, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{
-		Logger: gormLogger,
+	database, err := gorm.Open(sqlite.Open(sqliteDSN(dbPath)), &gorm.Config{
+		Logger: &slogGormLogger{logger: logger},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("open sqlite failed: %w", err)
 	}
+	return database, nil
+}
 
-	if err := database.AutoMigrate(&model.Notification{}, &model.NotificationAttachment{}); err != nil {
-		return nil, fmt.Errorf("migration failed: %w", err)
+// OpenPostgres connects to the PostgreSQL database at databaseURL without migrating it.
+func OpenPostgres(databaseURL string, logger *slog.Logger) (*gorm.DB, error) {
+	logger.Info("Initializing PostgreSQL DB", "host", postgresHost(databaseURL))
+
+	database, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+		Logger: &slogGormLogger{logger: logger},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open postgres failed: %w", err)
 	}
+	return database, nil
+}
 
+func migrateOnOpen(database *gorm.DB, logger *slog.Logger) (*gorm.DB, error) {
+	if _, err := MigrateUp(context.Background(), database, logger); err != nil {
+		return nil, fmt.Errorf("migration failed: %w", err)
+	}
 	return database, nil
 }
 
+// postgresHost returns the host of a postgres:// URL so it can be logged without the credentials.
+func postgresHost(databaseURL string) string {
+	parsedURL, err := url.Parse(databaseURL)
+	if err != nil || parsedURL.Host == "" {
+		return ""
+	}
+	return parsedURL.Host
+}
+
+// sqliteDSN adds the connection options that let several dispatch workers share one database file:
+// a busy timeout instead of failing fast on a locked database, write-ahead logging so readers do not
+// block the writer, and immediate transactions so a claim holds the write lock from its first read.
+func sqliteDSN(dbPath string) string {
+	separator := "?"
+	if strings.Contains(dbPath, "?") {
+		separator = "&"
+	}
+	return dbPath + separator + "_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate"
+}
+
 type slogGormLogger struct {
 	logger *slog.Logger
 }
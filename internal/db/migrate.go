@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockID keys the PostgreSQL advisory lock that serializes migrations across replicas
+// starting at the same time. SQLite serializes them through its write lock instead.
+const migrationLockID = 7_422_051_601
+
+// ErrIrreversibleMigration reports a down step requested for a migration that has none.
+var ErrIrreversibleMigration = errors.New("migration cannot be reverted")
+
+// Migration is one numbered schema change. Up and Down run inside a transaction together with the
+// bookkeeping in schema_migrations, so a failed step leaves no trace. A nil Down marks the
+// migration as irreversible.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration records an applied migration.
+type SchemaMigration struct {
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus describes a known migration and whether the database has applied it. Versions
+// recorded in schema_migrations that this build does not know are reported with Unknown set.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Unknown   bool
+}
+
+// MigrateUp applies every pending migration in version order and returns how many were applied.
+// Databases created by AutoMigrate before schema_migrations existed are adopted: the baseline only
+// creates what is missing and is then recorded as applied.
+func MigrateUp(ctx context.Context, database *gorm.DB, logger *slog.Logger) (int, error) {
+	if !database.Migrator().HasTable(&SchemaMigration{}) && database.Migrator().HasTable("notifications") {
+		logger.Info("Adopting existing schema as the migration baseline")
+	}
+
+	applied := 0
+	for _, migration := range registeredMigrations() {
+		ranStep := false
+		err := withMigrationLock(ctx, database, func(tx *gorm.DB) error {
+			var count int64
+			if err := tx.Model(&SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			ranStep = true
+			return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+		}
+		if ranStep {
+			applied++
+			logger.Info("Applied schema migration", "version", migration.Version, "name", migration.Name)
+		}
+	}
+	return applied, nil
+}
+
+// MigrateDown reverts up to steps of the most recently applied migrations and returns how many
+// were reverted.
+func MigrateDown(ctx context.Context, database *gorm.DB, steps int, logger *slog.Logger) (int, error) {
+	if steps < 1 {
+		return 0, fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	migrationsByVersion := make(map[int]Migration)
+	for _, migration := range registeredMigrations() {
+		migrationsByVersion[migration.Version] = migration
+	}
+
+	reverted := 0
+	for reverted < steps {
+		var revertedMigration *Migration
+		err := withMigrationLock(ctx, database, func(tx *gorm.DB) error {
+			var latest SchemaMigration
+			result := tx.Order("version DESC").Limit(1).Find(&latest)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+			migration, known := migrationsByVersion[latest.Version]
+			if !known {
+				return fmt.Errorf("applied migration %d (%s) is unknown to this build", latest.Version, latest.Name)
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("%w: %d (%s)", ErrIrreversibleMigration, migration.Version, migration.Name)
+			}
+			if err := migration.Down(tx); err != nil {
+				return fmt.Errorf("migration %d (%s) down failed: %w", migration.Version, migration.Name, err)
+			}
+			revertedMigration = &migration
+			return tx.Delete(&SchemaMigration{}, "version = ?", migration.Version).Error
+		})
+		if err != nil {
+			return reverted, err
+		}
+		if revertedMigration == nil {
+			break
+		}
+		reverted++
+		logger.Info("Reverted schema migration", "version", revertedMigration.Version, "name", revertedMigration.Name)
+	}
+	return reverted, nil
+}
+
+// MigrationStatuses lists every known migration in version order, followed by any applied
+// versions this build does not know.
+func MigrationStatuses(ctx context.Context, database *gorm.DB) ([]MigrationStatus, error) {
+	var applied []SchemaMigration
+	if database.Migrator().HasTable(&SchemaMigration{}) {
+		if err := database.WithContext(ctx).Order("version ASC").Find(&applied).Error; err != nil {
+			return nil, err
+		}
+	}
+	appliedByVersion := make(map[int]SchemaMigration, len(applied))
+	for _, record := range applied {
+		appliedByVersion[record.Version] = record
+	}
+
+	var statuses []MigrationStatus
+	for _, migration := range registeredMigrations() {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, found := appliedByVersion[migration.Version]; found {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+			delete(appliedByVersion, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		if _, unknown := appliedByVersion[record.Version]; unknown {
+			statuses = append(statuses, MigrationStatus{Version: record.Version, Name: record.Name, Applied: true, AppliedAt: record.AppliedAt, Unknown: true})
+		}
+	}
+	return statuses, nil
+}
+
+// withMigrationLock runs step in a transaction that holds the migration lock and can rely on
+// schema_migrations existing.
+func withMigrationLock(ctx context.Context, database *gorm.DB, step func(tx *gorm.DB) error) error {
+	return database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Migrator().AutoMigrate(&SchemaMigration{}); err != nil {
+			return err
+		}
+		return step(tx)
+	})
+}
+
+// registeredMigrations returns the migrations sorted by version and panics on a duplicate
+// version, which is a programming error.
+func registeredMigrations() []Migration {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(left, right int) bool {
+		return sorted[left].Version < sorted[right].Version
+	})
+	for index := 1; index < len(sorted); index++ {
+		if sorted[index].Version == sorted[index-1].Version {
+			panic(fmt.Sprintf("duplicate migration version %d", sorted[index].Version))
+		}
+	}
+	return sorted
+}
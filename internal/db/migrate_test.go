@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestMigrationsCreateEveryModel(t *testing.T) {
+	t.Helper()
+
+	database := openUnmigratedDatabase(t)
+	applied, err := MigrateUp(context.Background(), database, newSuiteLogger())
+	if err != nil {
+		t.Fatalf("migrate up error: %v", err)
+	}
+	if applied != len(migrations) {
+		t.Fatalf("expected %d migrations to be applied, got %d", len(migrations), applied)
+	}
+
+	migrator := database.Migrator()
+	for _, schemaModel := range schemaModels {
+		statement := &gorm.Statement{DB: database}
+		if parseErr := statement.Parse(schemaModel); parseErr != nil {
+			t.Fatalf("parse model error: %v", parseErr)
+		}
+		table := statement.Schema.Table
+		if !migrator.HasTable(table) {
+			t.Fatalf("expected the migrations to create %s", table)
+		}
+		for _, field := range statement.Schema.Fields {
+			if field.DBName != "" && !migrator.HasColumn(schemaModel, field.DBName) {
+				t.Fatalf("expected the migrations to create %s.%s", table, field.DBName)
+			}
+		}
+		for _, index := range statement.Schema.ParseIndexes() {
+			if !migrator.HasIndex(schemaModel, index.Name) {
+				t.Fatalf("expected the migrations to create index %s", index.Name)
+			}
+		}
+	}
+
+	if again, err := MigrateUp(context.Background(), database, newSuiteLogger()); err != nil || again != 0 {
+		t.Fatalf("expected a second run to apply nothing, got %d (%v)", again, err)
+	}
+}
+
+func TestMigrateUpAdoptsAutoMigratedDatabase(t *testing.T) {
+	t.Helper()
+
+	// Recreate a database written by a release that predates both schema_migrations and the
+	// lease columns.
+	database := openUnmigratedDatabase(t)
+	if err := database.AutoMigrate(baselineModels()...); err != nil {
+		t.Fatalf("auto migrate error: %v", err)
+	}
+	if err := database.Migrator().DropColumn(&baselineNotification{}, "NextAttemptAt"); err != nil {
+		t.Fatalf("drop column error: %v", err)
+	}
+	legacy := baselineNotification{
+		NotificationID: "notif-legacy", NotificationType: "email", Recipient: "user@example.com", Message: "Body", Status: "failed", RetryCount: 1,
+		Attachments: []baselineNotificationAttachment{{Filename: "legacy.txt", ContentType: "text/plain", Data: []byte("twelve bytes")}},
+	}
+	if err := database.Omit("NextAttemptAt").Create(&legacy).Error; err != nil {
+		t.Fatalf("seed legacy notification error: %v", err)
+	}
+	undeliverable := baselineRecipientDeliverability{Address: "complained@example.com", Complaints: 1, Undeliverable: true, LastFeedbackType: "complaint", LastFeedbackAt: time.Now().UTC()}
+	if err := database.Create(&undeliverable).Error; err != nil {
+		t.Fatalf("seed deliverability error: %v", err)
+	}
+
+	if _, err := MigrateUp(context.Background(), database, newSuiteLogger()); err != nil {
+		t.Fatalf("migrate up error: %v", err)
+	}
+
+	if !database.Migrator().HasColumn(&baselineNotification{}, "NextAttemptAt") {
+		t.Fatalf("expected the baseline to add the missing column")
+	}
+	var stored baselineNotification
+	if err := database.Where("notification_id = ?", "notif-legacy").First(&stored).Error; err != nil {
+		t.Fatalf("load legacy notification error: %v", err)
+	}
+	if stored.Status != "errored" || stored.RetryCount != 1 {
+		t.Fatalf("expected the legacy row to be kept and backfilled to errored, got %+v", stored)
+	}
+	var sizeBytes int64
+	if err := database.Table("notification_attachments").Select("size_bytes").Where("notification_id = ?", "notif-legacy").Scan(&sizeBytes).Error; err != nil || sizeBytes != 12 {
+		t.Fatalf("expected the attachment size to be backfilled, got %d (%v)", sizeBytes, err)
+	}
+	var suppression baselineSuppression
+	if err := database.Where("channel = ? AND recipient = ?", "email", "complained@example.com").First(&suppression).Error; err != nil || suppression.Reason != "complaint" {
+		t.Fatalf("expected the undeliverable address to be suppressed, got %+v (%v)", suppression, err)
+	}
+	statuses, err := MigrationStatuses(context.Background(), database)
+	if err != nil {
+		t.Fatalf("status error: %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Applied || status.Unknown {
+			t.Fatalf("expected every migration to be applied, got %+v", statuses)
+		}
+	}
+}
+
+func TestMigrateDownRevertsLatestMigrations(t *testing.T) {
+	t.Helper()
+
+	ctx := context.Background()
+	database := openUnmigratedDatabase(t)
+	if _, err := MigrateUp(ctx, database, newSuiteLogger()); err != nil {
+		t.Fatalf("migrate up error: %v", err)
+	}
+
+	reverted, err := MigrateDown(ctx, database, 3, newSuiteLogger())
+	if err != nil || reverted != 3 {
+		t.Fatalf("expected three migrations to be reverted, got %d (%v)", reverted, err)
+	}
+	if database.Migrator().HasColumn(&baselineFeedbackEvent{}, "report_id") {
+		t.Fatalf("expected the report_id column to be dropped")
+	}
+	if database.Migrator().HasTable("notification_attempts") {
+		t.Fatalf("expected the notification_attempts table to be dropped")
+	}
+	statuses, err := MigrationStatuses(ctx, database)
+	if err != nil {
+		t.Fatalf("status error: %v", err)
+	}
+	latest := statuses[len(statuses)-1]
+	if latest.Applied || latest.Version != migrations[len(migrations)-1].Version {
+		t.Fatalf("expected the latest migration to be pending, got %+v", latest)
+	}
+
+	reverted, err = MigrateDown(ctx, database, len(migrations)+5, newSuiteLogger())
+	if err != nil || reverted != len(migrations)-3 {
+		t.Fatalf("expected the remaining migrations to be reverted, got %d (%v)", reverted, err)
+	}
+	if database.Migrator().HasTable("notifications") {
+		t.Fatalf("expected reverting the baseline to drop the tables")
+	}
+
+	if applied, err := MigrateUp(ctx, database, newSuiteLogger()); err != nil || applied != len(migrations) {
+		t.Fatalf("expected every migration to be applied again, got %d (%v)", applied, err)
+	}
+	if _, err := MigrateDown(ctx, database, 0, newSuiteLogger()); err == nil {
+		t.Fatalf("expected non-positive steps to be rejected")
+	}
+}
+
+func openUnmigratedDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := OpenSQLite(filepath.Join(t.TempDir(), "migrations.db"), newSuiteLogger())
+	if err != nil {
+		t.Fatalf("open sqlite error: %v", err)
+	}
+	return database
+}
@@ -0,0 +1,342 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrations lists every schema change in version order. Released migrations must never change;
+// fix mistakes with a new migration instead.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "baseline",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AutoMigrate(baselineModels()...)
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(reversed(baselineModels())...)
+		},
+	},
+	{
+		// Earlier releases stored retryable failures as "failed"; the status is now "errored".
+		// Down keeps the rows as they are because both values are read as errored.
+		Version: 2,
+		Name:    "backfill_failed_status",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("UPDATE notifications SET status = ? WHERE status = ?", "errored", "failed").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	},
+	{
+		Version: 3,
+		Name:    "index_notification_status",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_notifications_status ON notifications (status)").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("DROP INDEX IF EXISTS idx_notifications_status").Error
+		},
+	},
+	{
+		// List responses report attachment sizes without loading the data.
+		Version: 4,
+		Name:    "attachment_size_bytes",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE notification_attachments ADD COLUMN size_bytes bigint NOT NULL DEFAULT 0").Error; err != nil {
+				return err
+			}
+			return tx.Exec("UPDATE notification_attachments SET size_bytes = COALESCE(length(data), 0)").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE notification_attachments DROP COLUMN size_bytes").Error
+		},
+	},
+	{
+		Version: 5,
+		Name:    "outbound_webhooks",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AutoMigrate(outboundWebhookModels()...)
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(reversed(outboundWebhookModels())...)
+		},
+	},
+	{
+		// Exhausted notifications now end as "dead" instead of staying errored. Rows already
+		// exhausted are moved by the dispatch worker when it starts, because the retry budget is
+		// configuration the migration cannot see.
+		Version: 6,
+		Name:    "notification_last_error",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE notifications ADD COLUMN last_error text NOT NULL DEFAULT ''").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE notifications DROP COLUMN last_error").Error
+		},
+	},
+	{
+		Version: 7,
+		Name:    "notification_attempts",
+		Up: func(tx *gorm.DB) error {
+			return tx.Migrator().AutoMigrate(&notificationAttempt{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&notificationAttempt{})
+		},
+	},
+	{
+		// Bounces and complaints now suppress the address. Addresses marked undeliverable by earlier
+		// releases get the same suppression, with "deliverability" as source so Down can find them.
+		Version: 8,
+		Name:    "backfill_feedback_suppressions",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec(`INSERT INTO suppressions (channel, recipient, category, reason, source, created_at, updated_at)
+SELECT 'email', address, '', CASE WHEN last_feedback_type = 'complaint' THEN 'complaint' ELSE 'bounced' END, 'deliverability', last_feedback_at, last_feedback_at
+FROM recipient_deliverabilities WHERE undeliverable = ?
+ON CONFLICT (channel, recipient, category) DO NOTHING`, true).Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("DELETE FROM suppressions WHERE source = ?", "deliverability").Error
+		},
+	},
+	{
+		// Providers redeliver webhooks and DSNs; the report ID lets a repeated report be ignored.
+		// Reports stored earlier keep a NULL report ID and never conflict.
+		Version: 9,
+		Name:    "feedback_report_id",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE feedback_events ADD COLUMN report_id text").Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_feedback_events_report ON feedback_events (provider, report_id, recipient)").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_feedback_events_report").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE feedback_events DROP COLUMN report_id").Error
+		},
+	},
+}
+
+// The baseline types freeze the schema that AutoMigrate produced before numbered migrations were
+// introduced, so later changes to the models in internal/model do not alter migration 1. Their
+// table, column, index and constraint names match the ones AutoMigrate derived from the models,
+// which is what lets the baseline adopt databases created by earlier releases.
+
+type baselineNotification struct {
+	ID                 uint   `gorm:"primaryKey"`
+	NotificationID     string `gorm:"uniqueIndex"`
+	NotificationType   string
+	Recipient          string
+	Subject            string
+	Message            string
+	HTMLMessage        string
+	Category           string `gorm:"index"`
+	ProviderMessageID  string `gorm:"index"`
+	Provider           string
+	ProviderStatus     string
+	SegmentCount       int
+	Price              string
+	PriceUnit          string
+	Status             string
+	RetryCount         int
+	LastAttemptedAt    time.Time
+	ScheduledFor       *time.Time
+	TemplateID         string
+	TemplateVersion    int
+	IdempotencyKey     *string `gorm:"uniqueIndex"`
+	RequestFingerprint string
+	BatchID            string `gorm:"index"`
+	BatchIndex         int
+	LockedBy           string `gorm:"index"`
+	LeaseUntil         *time.Time
+	NextAttemptAt      *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Attachments        []baselineNotificationAttachment `gorm:"foreignKey:NotificationID;references:NotificationID;constraint:OnDelete:CASCADE"`
+	Recipients         []baselineNotificationRecipient  `gorm:"foreignKey:NotificationID;references:NotificationID;constraint:OnDelete:CASCADE"`
+}
+
+func (baselineNotification) TableName() string { return "notifications" }
+
+type baselineNotificationAttachment struct {
+	ID             uint   `gorm:"primaryKey"`
+	NotificationID string `gorm:"index"`
+	Filename       string
+	ContentType    string
+	Data           []byte
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (baselineNotificationAttachment) TableName() string { return "notification_attachments" }
+
+type baselineNotificationRecipient struct {
+	ID             uint   `gorm:"primaryKey"`
+	NotificationID string `gorm:"index"`
+	Kind           string
+	Address        string
+	Status         string
+	Error          string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (baselineNotificationRecipient) TableName() string { return "notification_recipients" }
+
+type baselineTemplate struct {
+	ID          uint   `gorm:"primaryKey"`
+	TemplateID  string `gorm:"uniqueIndex:idx_templates_template_version;not null"`
+	Version     int    `gorm:"uniqueIndex:idx_templates_template_version;not null"`
+	Description string
+	Subject     string
+	PlainBody   string
+	HTMLBody    string
+	SMSBody     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (baselineTemplate) TableName() string { return "templates" }
+
+type baselineFeedbackEvent struct {
+	ID                uint   `gorm:"primaryKey"`
+	NotificationID    string `gorm:"index"`
+	Provider          string
+	ProviderMessageID string
+	Recipient         string `gorm:"index"`
+	Type              string
+	Diagnostic        string
+	OccurredAt        time.Time
+	CreatedAt         time.Time
+}
+
+func (baselineFeedbackEvent) TableName() string { return "feedback_events" }
+
+type baselineRecipientDeliverability struct {
+	ID               uint   `gorm:"primaryKey"`
+	Address          string `gorm:"uniqueIndex;not null"`
+	HardBounces      int
+	SoftBounces      int
+	Complaints       int
+	Undeliverable    bool `gorm:"index"`
+	LastFeedbackType string
+	LastFeedbackAt   time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (baselineRecipientDeliverability) TableName() string { return "recipient_deliverabilities" }
+
+type baselineSuppression struct {
+	ID        uint   `gorm:"primaryKey"`
+	Channel   string `gorm:"uniqueIndex:idx_suppressions_channel_recipient_category;not null"`
+	Recipient string `gorm:"uniqueIndex:idx_suppressions_channel_recipient_category;not null"`
+	Category  string `gorm:"uniqueIndex:idx_suppressions_channel_recipient_category;not null;default:''"`
+	Reason    string
+	Source    string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselineSuppression) TableName() string { return "suppressions" }
+
+type baselineInboundMessage struct {
+	ID                uint   `gorm:"primaryKey"`
+	Provider          string `gorm:"uniqueIndex:idx_inbound_messages_provider_message;not null"`
+	ProviderMessageID string `gorm:"uniqueIndex:idx_inbound_messages_provider_message;not null"`
+	FromNumber        string `gorm:"index;not null"`
+	ToNumber          string
+	Body              string
+	Keyword           string
+	ReceivedAt        time.Time `gorm:"index"`
+	CreatedAt         time.Time
+}
+
+func (baselineInboundMessage) TableName() string { return "inbound_messages" }
+
+func baselineModels() []any {
+	return []any{
+		&baselineNotification{},
+		&baselineNotificationAttachment{},
+		&baselineNotificationRecipient{},
+		&baselineTemplate{},
+		&baselineFeedbackEvent{},
+		&baselineRecipientDeliverability{},
+		&baselineSuppression{},
+		&baselineInboundMessage{},
+	}
+}
+
+// The outbound webhook types freeze the tables created by migration 5.
+
+type outboundWebhookSubscription struct {
+	ID             uint   `gorm:"primaryKey"`
+	SubscriptionID string `gorm:"uniqueIndex;not null"`
+	URL            string `gorm:"not null"`
+	Secret         string `gorm:"not null"`
+	EventTypes     string `gorm:"not null"`
+	Description    string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (outboundWebhookSubscription) TableName() string { return "webhook_subscriptions" }
+
+type outboundWebhookDelivery struct {
+	ID              uint   `gorm:"primaryKey"`
+	DeliveryID      string `gorm:"uniqueIndex;not null"`
+	SubscriptionID  string `gorm:"index;not null"`
+	EventID         string `gorm:"index;not null"`
+	EventType       string
+	NotificationID  string `gorm:"index"`
+	Payload         string
+	Status          string `gorm:"index"`
+	Attempts        int
+	ResponseStatus  int
+	LastError       string
+	LastAttemptedAt *time.Time
+	NextAttemptAt   *time.Time
+	DeliveredAt     *time.Time
+	ReplayOf        string
+	LockedBy        string `gorm:"index"`
+	LeaseUntil      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (outboundWebhookDelivery) TableName() string { return "webhook_deliveries" }
+
+func outboundWebhookModels() []any {
+	return []any{&outboundWebhookSubscription{}, &outboundWebhookDelivery{}}
+}
+
+// notificationAttempt freezes the table created by migration 7.
+type notificationAttempt struct {
+	ID             uint   `gorm:"primaryKey"`
+	NotificationID string `gorm:"index;not null"`
+	AttemptedAt    time.Time
+	Provider       string
+	DurationMs     int64
+	Outcome        string
+	ErrorClass     string
+	ErrorMessage   string
+	SMTPReplyCode  int
+	CreatedAt      time.Time
+}
+
+func (notificationAttempt) TableName() string { return "notification_attempts" }
+
+func reversed(values []any) []any {
+	result := make([]any, 0, len(values))
+	for index := len(values) - 1; index >= 0; index-- {
+		result = append(result, values[index])
+	}
+	return result
+}
@@ -0,0 +1,441 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+)
+
+// postgresTestURLEnv names the database the repository suite runs against in addition to SQLite.
+// The suite truncates every Pinguin table first, so point it at a scratch database.
+const postgresTestURLEnv = "PINGUIN_TEST_POSTGRES_URL"
+
+// TestRepositorySuite runs the same storage checks against every backend InitDB and InitPostgres
+// support, so queries in internal/model stay portable.
+func TestRepositorySuite(t *testing.T) {
+	t.Helper()
+
+	backends := []struct {
+		name string
+		open func(t *testing.T) *gorm.DB
+	}{
+		{name: "SQLite", open: openSQLiteSuiteDatabase},
+		{name: "Postgres", open: openPostgresSuiteDatabase},
+	}
+	checks := []struct {
+		name  string
+		check func(t *testing.T, database *gorm.DB)
+	}{
+		{name: "NotificationRoundTrip", check: checkNotificationRoundTrip},
+		{name: "ConcurrentClaimsAreDisjoint", check: checkConcurrentClaimsAreDisjoint},
+		{name: "SuppressionUpsert", check: checkSuppressionUpsert},
+		{name: "InboundMessageDeduplication", check: checkInboundMessageDeduplication},
+		{name: "LatestTemplates", check: checkLatestTemplates},
+		{name: "WebhookDeliveryClaims", check: checkWebhookDeliveryClaims},
+		{name: "DeadLetterRequeue", check: checkDeadLetterRequeue},
+		{name: "AttemptResultsAreLeaseFenced", check: checkAttemptResultsAreLeaseFenced},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			for _, check := range checks {
+				t.Run(check.name, func(t *testing.T) {
+					check.check(t, backend.open(t))
+				})
+			}
+		})
+	}
+}
+
+func openSQLiteSuiteDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := InitDB(filepath.Join(t.TempDir(), "suite.db"), newSuiteLogger())
+	if err != nil {
+		t.Fatalf("init sqlite error: %v", err)
+	}
+	return database
+}
+
+func openPostgresSuiteDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	databaseURL := os.Getenv(postgresTestURLEnv)
+	if databaseURL == "" {
+		t.Skipf("%s is not set", postgresTestURLEnv)
+	}
+	database, err := InitPostgres(databaseURL, newSuiteLogger())
+	if err != nil {
+		t.Fatalf("init postgres error: %v", err)
+	}
+	tableNames := make([]string, 0, len(schemaModels))
+	for _, schemaModel := range schemaModels {
+		statement := &gorm.Statement{DB: database}
+		if parseErr := statement.Parse(schemaModel); parseErr != nil {
+			t.Fatalf("parse model error: %v", parseErr)
+		}
+		tableNames = append(tableNames, statement.Schema.Table)
+	}
+	if truncateErr := database.Exec("TRUNCATE TABLE " + strings.Join(tableNames, ", ") + " RESTART IDENTITY").Error; truncateErr != nil {
+		t.Fatalf("truncate error: %v", truncateErr)
+	}
+	t.Cleanup(func() {
+		if sqlDatabase, sqlErr := database.DB(); sqlErr == nil {
+			sqlDatabase.Close()
+		}
+	})
+	return database
+}
+
+// schemaModels lists every table Pinguin stores.
+var schemaModels = []any{&model.Notification{}, &model.NotificationAttachment{}, &model.NotificationRecipient{}, &model.Template{}, &model.FeedbackEvent{}, &model.RecipientDeliverability{}, &model.Suppression{}, &model.InboundMessage{}, &model.WebhookSubscription{}, &model.WebhookDelivery{}, &model.NotificationAttempt{}}
+
+func newSuiteLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+}
+
+func checkNotificationRoundTrip(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	idempotencyKey := "order-1"
+	payload := []byte{0x00, 0xff, 0x10, 0x00}
+	notification := model.Notification{
+		NotificationID:   "notif-roundtrip",
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Receipt",
+		Message:          "Thanks",
+		Status:           model.StatusQueued,
+		IdempotencyKey:   &idempotencyKey,
+		Attachments:      []model.NotificationAttachment{{Filename: "receipt.bin", ContentType: "application/octet-stream", Data: payload, SizeBytes: int64(len(payload))}},
+		Recipients:       []model.NotificationRecipient{{Kind: model.RecipientTo, Address: "user@example.com", Status: model.RecipientPending}},
+	}
+	if err := model.CreateNotification(ctx, database, &notification); err != nil {
+		t.Fatalf("create notification error: %v", err)
+	}
+
+	fetched, err := model.GetNotificationByIdempotencyKey(ctx, database, idempotencyKey)
+	if err != nil {
+		t.Fatalf("lookup by idempotency key error: %v", err)
+	}
+	if fetched.NotificationID != notification.NotificationID || len(fetched.Recipients) != 1 {
+		t.Fatalf("unexpected notification %+v", fetched)
+	}
+	if len(fetched.Attachments) != 1 || !bytes.Equal(fetched.Attachments[0].Data, payload) {
+		t.Fatalf("expected the attachment bytes to round-trip, got %+v", fetched.Attachments)
+	}
+
+	listed, nextPageToken, err := model.ListNotifications(ctx, database, model.NotificationListFilters{Statuses: []model.NotificationStatus{model.StatusQueued}})
+	if err != nil || len(listed) != 1 || nextPageToken != "" {
+		t.Fatalf("expected one queued notification, got %d (%v)", len(listed), err)
+	}
+	if len(listed[0].Attachments) != 1 || len(listed[0].Attachments[0].Data) != 0 || listed[0].Attachments[0].SizeBytes != int64(len(payload)) {
+		t.Fatalf("expected list results to carry attachment metadata only, got %+v", listed[0].Attachments)
+	}
+
+	prefixed, _, err := model.ListNotifications(ctx, database, model.NotificationListFilters{RecipientPrefix: strings.ToUpper(notification.Recipient[:3]), Text: notification.Subject[1:4]})
+	if err != nil || len(prefixed) != 1 {
+		t.Fatalf("expected the prefix and text filters to match, got %d (%v)", len(prefixed), err)
+	}
+}
+
+func checkConcurrentClaimsAreDisjoint(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	const (
+		notificationCount = 40
+		workerCount       = 4
+	)
+	ctx := context.Background()
+	for index := 0; index < notificationCount; index++ {
+		notification := model.Notification{
+			NotificationID:   fmt.Sprintf("notif-claim-%02d", index),
+			NotificationType: model.NotificationEmail,
+			Recipient:        "user@example.com",
+			Message:          "Body",
+			Status:           model.StatusQueued,
+		}
+		if err := model.CreateNotification(ctx, database, &notification); err != nil {
+			t.Fatalf("seed notification error: %v", err)
+		}
+	}
+
+	var (
+		mutex     sync.Mutex
+		claimedBy = make(map[string][]string)
+		claimErrs []error
+		workers   sync.WaitGroup
+	)
+	for workerIndex := 0; workerIndex < workerCount; workerIndex++ {
+		workers.Add(1)
+		go func(workerID string) {
+			defer workers.Done()
+			for {
+				now := time.Now().UTC()
+				claimed, err := model.ClaimNotifications(ctx, database, model.NotificationClaim{WorkerID: workerID, MaxRetries: 3, Now: now, LeaseUntil: now.Add(time.Minute), Limit: 3})
+				mutex.Lock()
+				if err != nil {
+					claimErrs = append(claimErrs, err)
+				}
+				for _, notification := range claimed {
+					claimedBy[notification.NotificationID] = append(claimedBy[notification.NotificationID], workerID)
+				}
+				mutex.Unlock()
+				if err != nil || len(claimed) == 0 {
+					return
+				}
+			}
+		}(fmt.Sprintf("worker-%d", workerIndex))
+	}
+	workers.Wait()
+
+	if len(claimErrs) > 0 {
+		t.Fatalf("claim errors: %v", errors.Join(claimErrs...))
+	}
+	if len(claimedBy) != notificationCount {
+		t.Fatalf("expected all %d notifications to be claimed, got %d", notificationCount, len(claimedBy))
+	}
+	duplicates := make([]string, 0)
+	for notificationID, workerIDs := range claimedBy {
+		if len(workerIDs) > 1 {
+			duplicates = append(duplicates, fmt.Sprintf("%s by %v", notificationID, workerIDs))
+		}
+	}
+	sort.Strings(duplicates)
+	if len(duplicates) > 0 {
+		t.Fatalf("notifications claimed more than once: %v", duplicates)
+	}
+}
+
+func checkWebhookDeliveryClaims(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	later := now.Add(time.Hour)
+	deliveries := []model.WebhookDelivery{
+		{DeliveryID: "whdel-pending", SubscriptionID: "whsub-a", EventID: "evt-1", Status: model.WebhookDeliveryPending},
+		{DeliveryID: "whdel-retry", SubscriptionID: "whsub-a", EventID: "evt-2", Status: model.WebhookDeliveryFailed, Attempts: 1},
+		{DeliveryID: "whdel-exhausted", SubscriptionID: "whsub-a", EventID: "evt-3", Status: model.WebhookDeliveryFailed, Attempts: 3},
+		{DeliveryID: "whdel-backoff", SubscriptionID: "whsub-a", EventID: "evt-4", Status: model.WebhookDeliveryFailed, Attempts: 1, NextAttemptAt: &later},
+		{DeliveryID: "whdel-succeeded", SubscriptionID: "whsub-a", EventID: "evt-5", Status: model.WebhookDeliverySucceeded, Attempts: 1},
+		{DeliveryID: "whdel-excluded", SubscriptionID: "whsub-b", EventID: "evt-6", Status: model.WebhookDeliveryPending},
+	}
+	if err := model.CreateWebhookDeliveries(ctx, database, deliveries); err != nil {
+		t.Fatalf("seed deliveries error: %v", err)
+	}
+
+	claim := model.WebhookDeliveryClaim{WorkerID: "worker-1", MaxAttempts: 3, Now: now, LeaseUntil: now.Add(time.Minute), Limit: 10, ExcludedSubscriptions: []string{"whsub-b"}}
+	claimed, err := model.ClaimWebhookDeliveries(ctx, database, claim)
+	if err != nil {
+		t.Fatalf("claim error: %v", err)
+	}
+	claimedIDs := make([]string, 0, len(claimed))
+	for _, delivery := range claimed {
+		claimedIDs = append(claimedIDs, delivery.DeliveryID)
+	}
+	if strings.Join(claimedIDs, ",") != "whdel-pending,whdel-retry" {
+		t.Fatalf("expected the due deliveries to be claimed, got %v", claimedIDs)
+	}
+
+	claim.WorkerID = "worker-2"
+	if again, err := model.ClaimWebhookDeliveries(ctx, database, claim); err != nil || len(again) != 0 {
+		t.Fatalf("expected leased deliveries to be skipped, got %d (%v)", len(again), err)
+	}
+	if err := model.ReleaseWebhookDeliveryLease(ctx, database, "whdel-pending", "worker-1"); err != nil {
+		t.Fatalf("release error: %v", err)
+	}
+	released, err := model.ClaimWebhookDeliveries(ctx, database, claim)
+	if err != nil || len(released) != 1 || released[0].DeliveryID != "whdel-pending" {
+		t.Fatalf("expected the released delivery to be claimable again, got %v (%v)", released, err)
+	}
+
+	listed, err := model.ListWebhookDeliveries(ctx, database, model.WebhookDeliveryListFilters{SubscriptionID: "whsub-a", Statuses: []model.WebhookDeliveryStatus{model.WebhookDeliveryFailed}, Limit: 2})
+	if err != nil || len(listed) != 2 || listed[0].DeliveryID != "whdel-backoff" {
+		t.Fatalf("expected failed deliveries newest first, got %v (%v)", listed, err)
+	}
+}
+
+func checkDeadLetterRequeue(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	leaseUntil := now.Add(time.Minute)
+	seeds := []model.Notification{
+		{NotificationID: "notif-dead", Status: model.StatusDead, RetryCount: 3, LastError: "smtp timeout"},
+		{NotificationID: "notif-exhausted", Status: model.StatusErrored, RetryCount: 3, LastError: "smtp refused"},
+		{NotificationID: "notif-retrying", Status: model.StatusErrored, RetryCount: 1},
+		{NotificationID: "notif-leased", Status: model.StatusErrored, RetryCount: 3, LockedBy: "worker-1", LeaseUntil: &leaseUntil},
+		{NotificationID: "notif-sent", Status: model.StatusSent, RetryCount: 1},
+	}
+	for index := range seeds {
+		seeds[index].NotificationType = model.NotificationEmail
+		seeds[index].Recipient = "user@example.com"
+		seeds[index].Message = "Body"
+		if err := model.CreateNotification(ctx, database, &seeds[index]); err != nil {
+			t.Fatalf("seed notification error: %v", err)
+		}
+	}
+
+	marked, err := model.MarkExhaustedNotificationsDead(ctx, database, 3, now)
+	if err != nil || len(marked) != 1 || marked[0].NotificationID != "notif-exhausted" || marked[0].Status != model.StatusDead {
+		t.Fatalf("expected only the unleased exhausted notification to be marked dead, got %v (%v)", marked, err)
+	}
+
+	requeued, err := model.RequeueNotifications(ctx, database, model.NotificationListFilters{}, now)
+	if err != nil {
+		t.Fatalf("bulk requeue error: %v", err)
+	}
+	requeuedIDs := make([]string, 0, len(requeued))
+	for _, entry := range requeued {
+		if entry.PreviousStatus != model.StatusDead || entry.Notification.Status != model.StatusQueued || entry.Notification.RetryCount != 0 {
+			t.Fatalf("unexpected requeued notification %#v", entry)
+		}
+		requeuedIDs = append(requeuedIDs, entry.Notification.NotificationID)
+	}
+	if strings.Join(requeuedIDs, ",") != "notif-dead,notif-exhausted" {
+		t.Fatalf("expected the dead notifications to be requeued, got %v", requeuedIDs)
+	}
+	if requeued[0].Notification.LastError != "smtp timeout" {
+		t.Fatalf("expected the last error to survive the requeue, got %q", requeued[0].Notification.LastError)
+	}
+
+	if _, err := model.RequeueNotifications(ctx, database, model.NotificationListFilters{Statuses: []model.NotificationStatus{model.StatusSent}}, now); !errors.Is(err, model.ErrInvalidNotificationListFilter) {
+		t.Fatalf("expected sent notifications to be rejected, got %v", err)
+	}
+	single, err := model.RequeueNotification(ctx, database, "notif-retrying", now)
+	if err != nil || single.PreviousStatus != model.StatusErrored || single.Notification.RetryCount != 0 {
+		t.Fatalf("expected the errored notification to be requeued, got %#v (%v)", single, err)
+	}
+	for _, notificationID := range []string{"notif-leased", "notif-sent"} {
+		if _, err := model.RequeueNotification(ctx, database, notificationID, now); !errors.Is(err, model.ErrNotificationNotRequeueable) {
+			t.Fatalf("expected %s to be rejected, got %v", notificationID, err)
+		}
+	}
+	if _, err := model.RequeueNotification(ctx, database, "notif-missing", now); !errors.Is(err, model.ErrNotificationNotFound) {
+		t.Fatalf("expected a missing notification to be reported, got %v", err)
+	}
+}
+
+func checkAttemptResultsAreLeaseFenced(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	for _, notificationID := range []string{"notif-fenced-sent", "notif-fenced-taken"} {
+		notification := model.Notification{
+			NotificationID:   notificationID,
+			NotificationType: model.NotificationEmail,
+			Recipient:        "user@example.com",
+			Message:          "Body",
+			Status:           model.StatusQueued,
+		}
+		if err := model.CreateNotification(ctx, database, &notification); err != nil {
+			t.Fatalf("seed notification error: %v", err)
+		}
+	}
+	claimed, err := model.ClaimNotifications(ctx, database, model.NotificationClaim{WorkerID: "worker-a", MaxRetries: 3, Now: now, LeaseUntil: now.Add(time.Minute), Limit: 10})
+	if err != nil || len(claimed) != 2 {
+		t.Fatalf("expected worker-a to claim both notifications, got %d (%v)", len(claimed), err)
+	}
+	// The second lease expires and worker-b takes the notification over.
+	later := now.Add(30 * time.Second)
+	if err := database.Model(&model.Notification{}).Where("notification_id = ?", "notif-fenced-taken").Update("lease_until", now.Add(-time.Second)).Error; err != nil {
+		t.Fatalf("expire lease error: %v", err)
+	}
+	if taken, err := model.ClaimNotifications(ctx, database, model.NotificationClaim{WorkerID: "worker-b", MaxRetries: 3, Now: later, LeaseUntil: later.Add(time.Minute), Limit: 10}); err != nil || len(taken) != 1 {
+		t.Fatalf("expected worker-b to take over the expired lease, got %d (%v)", len(taken), err)
+	}
+
+	for index := range claimed {
+		claimed[index].Status = model.StatusSent
+		claimed[index].Provider = "smtp"
+		claimed[index].LastAttemptedAt = now
+	}
+	if err := model.ApplyNotificationAttemptResult(ctx, database, &claimed[0], "worker-a"); err != nil {
+		t.Fatalf("apply result error: %v", err)
+	}
+	if err := model.ApplyNotificationAttemptResult(ctx, database, &claimed[1], "worker-a"); !errors.Is(err, model.ErrNotificationLeaseLost) {
+		t.Fatalf("expected the result of a lost lease to be refused, got %v", err)
+	}
+
+	sent, err := model.GetNotificationByID(ctx, database, "notif-fenced-sent")
+	if err != nil || sent.Status != model.StatusSent || sent.Provider != "smtp" || sent.LockedBy != "" || sent.LeaseUntil != nil {
+		t.Fatalf("expected the result to be written and the lease released, got %#v (%v)", sent, err)
+	}
+	taken, err := model.GetNotificationByID(ctx, database, "notif-fenced-taken")
+	if err != nil || taken.Status != model.StatusQueued || taken.LockedBy != "worker-b" {
+		t.Fatalf("expected the notification to stay with worker-b, got %#v (%v)", taken, err)
+	}
+}
+
+func checkSuppressionUpsert(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	for _, reason := range []model.SuppressionReason{model.SuppressionManual, model.SuppressionStop} {
+		suppression := model.Suppression{Channel: model.NotificationSMS, Recipient: "+15550001111", Reason: reason}
+		if err := model.UpsertSuppression(ctx, database, &suppression); err != nil {
+			t.Fatalf("upsert suppression error: %v", err)
+		}
+	}
+	stored, err := model.GetSuppression(ctx, database, model.NotificationSMS, "+15550001111", "")
+	if err != nil || stored.Reason != model.SuppressionStop {
+		t.Fatalf("expected the second upsert to replace the reason, got %+v (%v)", stored, err)
+	}
+	listed, err := model.ListSuppressions(ctx, database, model.SuppressionListFilters{}, time.Now().UTC())
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("expected a single suppression, got %d (%v)", len(listed), err)
+	}
+}
+
+func checkInboundMessageDeduplication(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	for attempt, expectCreated := range []bool{true, false} {
+		message := model.InboundMessage{Provider: "twilio", ProviderMessageID: "SM1", FromNumber: "+15550001111", Body: "STOP", ReceivedAt: time.Now().UTC()}
+		created, err := model.CreateInboundMessage(ctx, database, &message)
+		if err != nil || created != expectCreated {
+			t.Fatalf("attempt %d: expected created=%v, got %v (%v)", attempt, expectCreated, created, err)
+		}
+	}
+}
+
+func checkLatestTemplates(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	for _, template := range []model.Template{
+		{TemplateID: "welcome", Version: 1, PlainBody: "v1"},
+		{TemplateID: "welcome", Version: 2, PlainBody: "v2"},
+		{TemplateID: "reminder", Version: 1, SMSBody: "r1"},
+	} {
+		template := template
+		if err := model.CreateTemplate(ctx, database, &template); err != nil {
+			t.Fatalf("create template error: %v", err)
+		}
+	}
+	latest, err := model.ListLatestTemplates(ctx, database)
+	if err != nil || len(latest) != 2 {
+		t.Fatalf("expected two templates, got %+v (%v)", latest, err)
+	}
+	if latest[0].TemplateID != "reminder" || latest[1].TemplateID != "welcome" || latest[1].Version != 2 {
+		t.Fatalf("unexpected latest templates %+v", latest)
+	}
+}
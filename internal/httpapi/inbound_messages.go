@@ -0,0 +1,51 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+const (
+	defaultInboundMessageLimit = 100
+	maxInboundMessageLimit     = 500
+)
+
+type inboundMessageHandler struct {
+	service service.InboundMessageService
+	logger  *slog.Logger
+}
+
+func newInboundMessageHandler(svc service.InboundMessageService, logger *slog.Logger) *inboundMessageHandler {
+	return &inboundMessageHandler{service: svc, logger: logger}
+}
+
+func (handler *inboundMessageHandler) listInboundMessages(contextGin *gin.Context) {
+	filters := model.InboundMessageListFilters{
+		FromNumber: strings.TrimSpace(contextGin.Query("from")),
+		Limit:      defaultInboundMessageLimit,
+	}
+	if rawLimit := strings.TrimSpace(contextGin.Query("limit")); rawLimit != "" {
+		limit, parseErr := strconv.Atoi(rawLimit)
+		if parseErr != nil || limit <= 0 || limit > maxInboundMessageLimit {
+			contextGin.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+		filters.Limit = limit
+	}
+	messages, err := handler.service.ListInboundMessages(contextGin.Request.Context(), filters)
+	if err != nil {
+		handler.logger.Error("list_inbound_messages_failed", "error", err)
+		contextGin.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if messages == nil {
+		messages = []model.InboundMessage{}
+	}
+	contextGin.JSON(http.StatusOK, gin.H{"inbound_messages": messages})
+}
@@ -0,0 +1,164 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+func TestTwilioInboundWebhook(t *testing.T) {
+	t.Helper()
+	form := url.Values{
+		"MessageSid": {"SM900"},
+		"From":       {"+15550001111"},
+		"To":         {"+15559990000"},
+		"Body":       {"STOP"},
+	}
+	validSignature := twilioSignature("twilio-token", "https://notify.example.com/webhooks/twilio/inbound", form)
+
+	testCases := []struct {
+		name           string
+		signature      string
+		expectedStatus int
+		expectMessage  bool
+	}{
+		{name: "ValidSignature", signature: validSignature, expectedStatus: http.StatusOK, expectMessage: true},
+		{name: "InvalidSignature", signature: "bogus", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			inboundService := &stubInboundMessageService{}
+			server := newInboundTestServer(t, inboundService, WebhookConfig{
+				PublicBaseURL:   "https://notify.example.com",
+				TwilioAuthToken: "twilio-token",
+			})
+
+			request := httptest.NewRequest(http.MethodPost, "/webhooks/twilio/inbound", strings.NewReader(form.Encode()))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			request.Header.Set("X-Twilio-Signature", testCase.signature)
+			recorder := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if !testCase.expectMessage {
+				if len(inboundService.received) != 0 {
+					t.Fatalf("expected no inbound messages, got %+v", inboundService.received)
+				}
+				return
+			}
+			if !strings.Contains(recorder.Body.String(), "<Response></Response>") {
+				t.Fatalf("expected empty TwiML response, got %s", recorder.Body.String())
+			}
+			if len(inboundService.received) != 1 {
+				t.Fatalf("expected one inbound message, got %d", len(inboundService.received))
+			}
+			received := inboundService.received[0]
+			if received.Provider != "twilio" || received.ProviderMessageID != "SM900" || received.From != "+15550001111" || received.Body != "STOP" {
+				t.Fatalf("unexpected inbound message %+v", received)
+			}
+		})
+	}
+
+	server := newInboundTestServer(t, &stubInboundMessageService{}, WebhookConfig{})
+	recorder := httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/webhooks/twilio/inbound", strings.NewReader(form.Encode())))
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected inbound webhook to be unregistered without a Twilio auth token, got %d", recorder.Code)
+	}
+}
+
+func TestInboundMessageRoutes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedLimit  int
+		expectedFrom   string
+	}{
+		{name: "DefaultLimit", path: "/api/inbound-messages", expectedStatus: http.StatusOK, expectedLimit: defaultInboundMessageLimit},
+		{name: "FilteredBySender", path: "/api/inbound-messages?from=%2B15550001111&limit=10", expectedStatus: http.StatusOK, expectedLimit: 10, expectedFrom: "+15550001111"},
+		{name: "RejectsInvalidLimit", path: "/api/inbound-messages?limit=0", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			inboundService := &stubInboundMessageService{listResponse: []model.InboundMessage{
+				{Provider: "twilio", ProviderMessageID: "SM1", FromNumber: "+15550001111", Body: "STOP", Keyword: model.InboundKeywordStop},
+			}}
+			server := newInboundTestServer(t, inboundService, WebhookConfig{})
+
+			request := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+			request.AddCookie(&http.Cookie{Name: "app_session", Value: "token"})
+			recorder := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			if inboundService.lastFilters.Limit != testCase.expectedLimit || inboundService.lastFilters.FromNumber != testCase.expectedFrom {
+				t.Fatalf("unexpected filters %+v", inboundService.lastFilters)
+			}
+			var payload struct {
+				InboundMessages []model.InboundMessage `json:"inbound_messages"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &payload); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if len(payload.InboundMessages) != 1 || payload.InboundMessages[0].Keyword != model.InboundKeywordStop {
+				t.Fatalf("unexpected inbound messages %+v", payload.InboundMessages)
+			}
+		})
+	}
+}
+
+func newInboundTestServer(t *testing.T, inboundService service.InboundMessageService, webhookConfig WebhookConfig) *Server {
+	t.Helper()
+
+	server, err := NewServer(Config{
+		ListenAddr:            ":0",
+		NotificationService:   &stubNotificationService{},
+		SessionValidator:      &stubValidator{},
+		DeliveryStatusService: &stubDeliveryStatusService{},
+		InboundMessageService: inboundService,
+		Webhooks:              webhookConfig,
+		Logger:                slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		AdminEmails:           []string{"user@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("server init error: %v", err)
+	}
+	return server
+}
+
+type stubInboundMessageService struct {
+	received     []service.InboundSMS
+	listResponse []model.InboundMessage
+	lastFilters  model.InboundMessageListFilters
+}
+
+func (stub *stubInboundMessageService) ReceiveMessage(_ context.Context, message service.InboundSMS) (model.InboundMessage, error) {
+	stub.received = append(stub.received, message)
+	return model.InboundMessage{Provider: message.Provider, ProviderMessageID: message.ProviderMessageID, FromNumber: message.From, Body: message.Body}, nil
+}
+
+func (stub *stubInboundMessageService) ListInboundMessages(_ context.Context, filters model.InboundMessageListFilters) ([]model.InboundMessage, error) {
+	stub.lastFilters = filters
+	return stub.listResponse, nil
+}
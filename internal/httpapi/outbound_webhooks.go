@@ -0,0 +1,134 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+const (
+	defaultWebhookDeliveryLimit = 100
+	maxWebhookDeliveryLimit     = 500
+)
+
+type outboundWebhookHandler struct {
+	service service.OutboundWebhookService
+	logger  *slog.Logger
+}
+
+func newOutboundWebhookHandler(svc service.OutboundWebhookService, logger *slog.Logger) *outboundWebhookHandler {
+	return &outboundWebhookHandler{service: svc, logger: logger}
+}
+
+func (handler *outboundWebhookHandler) listSubscriptions(contextGin *gin.Context) {
+	subscriptions, err := handler.service.ListSubscriptions(contextGin.Request.Context())
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	if subscriptions == nil {
+		subscriptions = []model.WebhookSubscriptionResponse{}
+	}
+	contextGin.JSON(http.StatusOK, gin.H{"webhooks": subscriptions})
+}
+
+func (handler *outboundWebhookHandler) createSubscription(contextGin *gin.Context) {
+	var payload model.WebhookSubscriptionRequest
+	if err := contextGin.ShouldBindJSON(&payload); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	subscription, err := handler.service.CreateSubscription(contextGin.Request.Context(), payload)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusCreated, subscription)
+}
+
+func (handler *outboundWebhookHandler) updateSubscription(contextGin *gin.Context) {
+	var payload model.WebhookSubscriptionRequest
+	if err := contextGin.ShouldBindJSON(&payload); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	subscription, err := handler.service.UpdateSubscription(contextGin.Request.Context(), contextGin.Param("id"), payload)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusOK, subscription)
+}
+
+func (handler *outboundWebhookHandler) deleteSubscription(contextGin *gin.Context) {
+	if err := handler.service.DeleteSubscription(contextGin.Request.Context(), contextGin.Param("id")); err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.Status(http.StatusNoContent)
+}
+
+func (handler *outboundWebhookHandler) listDeliveries(contextGin *gin.Context) {
+	filters := model.WebhookDeliveryListFilters{
+		SubscriptionID: strings.TrimSpace(contextGin.Query("subscription_id")),
+		NotificationID: strings.TrimSpace(contextGin.Query("notification_id")),
+		Limit:          defaultWebhookDeliveryLimit,
+	}
+	for _, rawStatus := range contextGin.QueryArray("status") {
+		status := model.WebhookDeliveryStatus(strings.ToLower(strings.TrimSpace(rawStatus)))
+		switch status {
+		case "":
+		case model.WebhookDeliveryPending, model.WebhookDeliverySucceeded, model.WebhookDeliveryFailed:
+			filters.Statuses = append(filters.Statuses, status)
+		default:
+			contextGin.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, succeeded or failed"})
+			return
+		}
+	}
+	if rawLimit := strings.TrimSpace(contextGin.Query("limit")); rawLimit != "" {
+		limit, parseErr := strconv.Atoi(rawLimit)
+		if parseErr != nil || limit <= 0 || limit > maxWebhookDeliveryLimit {
+			contextGin.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+		filters.Limit = limit
+	}
+	deliveries, err := handler.service.ListDeliveries(contextGin.Request.Context(), filters)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	if deliveries == nil {
+		deliveries = []model.WebhookDelivery{}
+	}
+	contextGin.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+func (handler *outboundWebhookHandler) replayDelivery(contextGin *gin.Context) {
+	delivery, err := handler.service.ReplayDelivery(contextGin.Request.Context(), contextGin.Param("id"))
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusCreated, delivery)
+}
+
+func (handler *outboundWebhookHandler) writeError(contextGin *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrWebhookSubscriptionNotFound):
+		contextGin.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+	case errors.Is(err, model.ErrWebhookDeliveryNotFound):
+		contextGin.JSON(http.StatusNotFound, gin.H{"error": "webhook delivery not found"})
+	case errors.Is(err, service.ErrInvalidWebhookSubscription):
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		handler.logger.Error("http_handler_error", "error", err)
+		contextGin.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -0,0 +1,231 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+func TestOutboundWebhookRoutes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		stub           *stubOutboundWebhookService
+		expectedStatus int
+		verify         func(t *testing.T, stub *stubOutboundWebhookService, body []byte)
+	}{
+		{
+			name:           "ListSubscriptions",
+			method:         http.MethodGet,
+			path:           "/api/webhooks",
+			stub:           &stubOutboundWebhookService{},
+			expectedStatus: http.StatusOK,
+			verify: func(t *testing.T, _ *stubOutboundWebhookService, body []byte) {
+				if string(body) != `{"webhooks":[]}` {
+					t.Fatalf("expected an empty list, got %s", body)
+				}
+			},
+		},
+		{
+			name:           "CreateSubscription",
+			method:         http.MethodPost,
+			path:           "/api/webhooks",
+			body:           `{"url":"https://hooks.example.com","event_types":["notification.sent"]}`,
+			stub:           &stubOutboundWebhookService{},
+			expectedStatus: http.StatusCreated,
+			verify: func(t *testing.T, stub *stubOutboundWebhookService, _ []byte) {
+				if stub.lastRequest.URL != "https://hooks.example.com" || len(stub.lastRequest.EventTypes) != 1 || stub.lastRequest.EventTypes[0] != model.WebhookEventNotificationSent {
+					t.Fatalf("unexpected create request %#v", stub.lastRequest)
+				}
+			},
+		},
+		{
+			name:           "CreateInvalidSubscription",
+			method:         http.MethodPost,
+			path:           "/api/webhooks",
+			body:           `{"url":"/hooks"}`,
+			stub:           &stubOutboundWebhookService{err: fmt.Errorf("%w: url must be an absolute http or https URL", service.ErrInvalidWebhookSubscription)},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "UpdateSubscription",
+			method:         http.MethodPut,
+			path:           "/api/webhooks/whsub-1",
+			body:           `{"url":"https://hooks.example.com/v2","event_types":["notification.cancelled"]}`,
+			stub:           &stubOutboundWebhookService{},
+			expectedStatus: http.StatusOK,
+			verify: func(t *testing.T, stub *stubOutboundWebhookService, _ []byte) {
+				if stub.lastSubscriptionID != "whsub-1" || stub.lastRequest.URL != "https://hooks.example.com/v2" {
+					t.Fatalf("unexpected update arguments %s %#v", stub.lastSubscriptionID, stub.lastRequest)
+				}
+			},
+		},
+		{
+			name:           "DeleteMissingSubscription",
+			method:         http.MethodDelete,
+			path:           "/api/webhooks/whsub-missing",
+			stub:           &stubOutboundWebhookService{err: model.ErrWebhookSubscriptionNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "ListDeliveries",
+			method:         http.MethodGet,
+			path:           "/api/webhook-deliveries?subscription_id=whsub-1&status=failed&limit=20",
+			stub:           &stubOutboundWebhookService{deliveries: []model.WebhookDelivery{{DeliveryID: "whdel-1", Status: model.WebhookDeliveryFailed}}},
+			expectedStatus: http.StatusOK,
+			verify: func(t *testing.T, stub *stubOutboundWebhookService, body []byte) {
+				var payload struct {
+					Deliveries []model.WebhookDelivery `json:"deliveries"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("decode error: %v", err)
+				}
+				if len(payload.Deliveries) != 1 || payload.Deliveries[0].DeliveryID != "whdel-1" {
+					t.Fatalf("unexpected deliveries %#v", payload.Deliveries)
+				}
+				filters := stub.lastFilters
+				if filters.SubscriptionID != "whsub-1" || filters.Limit != 20 || len(filters.Statuses) != 1 || filters.Statuses[0] != model.WebhookDeliveryFailed {
+					t.Fatalf("unexpected filters %#v", filters)
+				}
+			},
+		},
+		{
+			name:           "ListDeliveriesRejectsUnknownStatus",
+			method:         http.MethodGet,
+			path:           "/api/webhook-deliveries?status=lost",
+			stub:           &stubOutboundWebhookService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ListDeliveriesRejectsInvalidLimit",
+			method:         http.MethodGet,
+			path:           "/api/webhook-deliveries?limit=501",
+			stub:           &stubOutboundWebhookService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ReplayDelivery",
+			method:         http.MethodPost,
+			path:           "/api/webhook-deliveries/whdel-1/replay",
+			stub:           &stubOutboundWebhookService{},
+			expectedStatus: http.StatusCreated,
+			verify: func(t *testing.T, stub *stubOutboundWebhookService, body []byte) {
+				var replay model.WebhookDelivery
+				if err := json.Unmarshal(body, &replay); err != nil {
+					t.Fatalf("decode error: %v", err)
+				}
+				if stub.lastDeliveryID != "whdel-1" || replay.ReplayOf != "whdel-1" {
+					t.Fatalf("unexpected replay %#v", replay)
+				}
+			},
+		},
+		{
+			name:           "ReplayMissingDelivery",
+			method:         http.MethodPost,
+			path:           "/api/webhook-deliveries/whdel-missing/replay",
+			stub:           &stubOutboundWebhookService{err: model.ErrWebhookDeliveryNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := newOutboundWebhookTestHTTPServer(t, testCase.stub)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			request.Header.Set("Content-Type", "application/json")
+
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d (%s)", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if testCase.verify != nil {
+				testCase.verify(t, testCase.stub, recorder.Body.Bytes())
+			}
+		})
+	}
+}
+
+func newOutboundWebhookTestHTTPServer(t *testing.T, webhookService service.OutboundWebhookService) *Server {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server, err := NewServer(Config{
+		ListenAddr:             ":0",
+		NotificationService:    &stubNotificationService{},
+		OutboundWebhookService: webhookService,
+		SessionValidator:       &stubValidator{},
+		Logger:                 logger,
+		AdminEmails:            []string{"user@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("server init error: %v", err)
+	}
+	return server
+}
+
+type stubOutboundWebhookService struct {
+	deliveries         []model.WebhookDelivery
+	err                error
+	lastRequest        model.WebhookSubscriptionRequest
+	lastSubscriptionID string
+	lastFilters        model.WebhookDeliveryListFilters
+	lastDeliveryID     string
+}
+
+func (stub *stubOutboundWebhookService) ObserveTransition(context.Context, model.NotificationStatus, model.Notification) {
+}
+
+func (stub *stubOutboundWebhookService) ListSubscriptions(context.Context) ([]model.WebhookSubscriptionResponse, error) {
+	return nil, stub.err
+}
+
+func (stub *stubOutboundWebhookService) CreateSubscription(_ context.Context, request model.WebhookSubscriptionRequest) (model.WebhookSubscriptionResponse, error) {
+	stub.lastRequest = request
+	if stub.err != nil {
+		return model.WebhookSubscriptionResponse{}, stub.err
+	}
+	return model.WebhookSubscriptionResponse{SubscriptionID: "whsub-1", URL: request.URL, EventTypes: request.EventTypes, Secret: "whsec_generated"}, nil
+}
+
+func (stub *stubOutboundWebhookService) UpdateSubscription(_ context.Context, subscriptionID string, request model.WebhookSubscriptionRequest) (model.WebhookSubscriptionResponse, error) {
+	stub.lastSubscriptionID = subscriptionID
+	stub.lastRequest = request
+	if stub.err != nil {
+		return model.WebhookSubscriptionResponse{}, stub.err
+	}
+	return model.WebhookSubscriptionResponse{SubscriptionID: subscriptionID, URL: request.URL, EventTypes: request.EventTypes}, nil
+}
+
+func (stub *stubOutboundWebhookService) DeleteSubscription(_ context.Context, subscriptionID string) error {
+	stub.lastSubscriptionID = subscriptionID
+	return stub.err
+}
+
+func (stub *stubOutboundWebhookService) ListDeliveries(_ context.Context, filters model.WebhookDeliveryListFilters) ([]model.WebhookDelivery, error) {
+	stub.lastFilters = filters
+	return stub.deliveries, stub.err
+}
+
+func (stub *stubOutboundWebhookService) ReplayDelivery(_ context.Context, deliveryID string) (model.WebhookDelivery, error) {
+	stub.lastDeliveryID = deliveryID
+	if stub.err != nil {
+		return model.WebhookDelivery{}, stub.err
+	}
+	return model.WebhookDelivery{DeliveryID: "whdel-2", ReplayOf: deliveryID, Status: model.WebhookDeliveryPending}, nil
+}
+
+func (stub *stubOutboundWebhookService) StartDeliveryWorker(context.Context) {}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,15 +32,31 @@ type SessionValidator interface {
 
 // Config captures all inputs required to construct the HTTP server.
 type Config struct {
-	ListenAddr           string
-	StaticRoot           string
-	AllowedOrigins       []string
-	AdminEmails          []string
-	SessionValidator     SessionValidator
-	NotificationService  service.NotificationService
-	Logger               *slog.Logger
-	ReadHeaderTimeout    time.Duration
-	ShutdownGraceTimeout time.Duration
+	ListenAddr          string
+	StaticRoot          string
+	AllowedOrigins      []string
+	AdminEmails         []string
+	SessionValidator    SessionValidator
+	NotificationService service.NotificationService
+	TemplateService     service.TemplateService
+	HealthReporter      service.HealthReporter
+	// DeliveryStatusService enables the provider delivery webhooks configured in Webhooks.
+	DeliveryStatusService service.DeliveryStatusService
+	// FeedbackService records bounces and complaints reported by the webhooks and enables /webhooks/dsn.
+	FeedbackService service.FeedbackService
+	// SuppressionService enables the /api/suppressions endpoints.
+	SuppressionService service.SuppressionService
+	// InboundMessageService enables /api/inbound-messages and, with a Twilio auth token, the
+	// /webhooks/twilio/inbound endpoint.
+	InboundMessageService service.InboundMessageService
+	// UnsubscribeService enables the public one-click /unsubscribe endpoint.
+	UnsubscribeService service.UnsubscribeService
+	// OutboundWebhookService enables /api/webhooks and /api/webhook-deliveries.
+	OutboundWebhookService service.OutboundWebhookService
+	Webhooks               WebhookConfig
+	Logger                 *slog.Logger
+	ReadHeaderTimeout      time.Duration
+	ShutdownGraceTimeout   time.Duration
 }
 
 // Server hosts authenticated HTTP endpoints and static assets for the UI.
@@ -76,17 +93,62 @@ func NewServer(cfg Config) (*Server, error) {
 	engine.Use(buildCORS(cfg.AllowedOrigins))
 
 	engine.GET("/runtime-config", serveRuntimeConfig())
-	engine.GET("/healthz", func(contextGin *gin.Context) {
-		contextGin.JSON(http.StatusOK, gin.H{"status": "ok"})
-	})
+	engine.GET("/healthz", serveHealth(cfg.HealthReporter))
+
+	if cfg.DeliveryStatusService != nil {
+		webhooks, err := newWebhookHandler(cfg.DeliveryStatusService, cfg.FeedbackService, cfg.InboundMessageService, cfg.Webhooks, cfg.Logger)
+		if err != nil {
+			return nil, fmt.Errorf("httpapi: %w", err)
+		}
+		webhooks.register(engine)
+	}
+
+	if cfg.UnsubscribeService != nil {
+		newUnsubscribeHandler(cfg.UnsubscribeService, cfg.Logger).register(engine)
+	}
 
 	protected := engine.Group("/api")
 	protected.Use(sessionMiddleware(cfg.SessionValidator, adminAllowlist))
 
 	handler := newNotificationHandler(cfg.NotificationService, cfg.Logger)
 	protected.GET("/notifications", handler.listNotifications)
+	protected.GET("/notifications/:id/attempts", handler.listNotificationAttempts)
 	protected.PATCH("/notifications/:id/schedule", handler.rescheduleNotification)
 	protected.POST("/notifications/:id/cancel", handler.cancelNotification)
+	protected.POST("/notifications/:id/retry", handler.retryNotification)
+	protected.POST("/notifications/requeue", handler.requeueNotifications)
+
+	if cfg.TemplateService != nil {
+		templates := newTemplateHandler(cfg.TemplateService, cfg.Logger)
+		protected.GET("/templates", templates.listTemplates)
+		protected.POST("/templates", templates.createTemplate)
+		protected.GET("/templates/:id", templates.getTemplate)
+		protected.PUT("/templates/:id", templates.updateTemplate)
+		protected.DELETE("/templates/:id", templates.deleteTemplate)
+		protected.POST("/templates/:id/preview", templates.previewTemplate)
+	}
+
+	if cfg.SuppressionService != nil {
+		suppressions := newSuppressionHandler(cfg.SuppressionService, cfg.Logger)
+		protected.GET("/suppressions", suppressions.listSuppressions)
+		protected.POST("/suppressions", suppressions.addSuppression)
+		protected.DELETE("/suppressions/:channel/:recipient", suppressions.removeSuppression)
+	}
+
+	if cfg.InboundMessageService != nil {
+		inboundMessages := newInboundMessageHandler(cfg.InboundMessageService, cfg.Logger)
+		protected.GET("/inbound-messages", inboundMessages.listInboundMessages)
+	}
+
+	if cfg.OutboundWebhookService != nil {
+		outboundWebhooks := newOutboundWebhookHandler(cfg.OutboundWebhookService, cfg.Logger)
+		protected.GET("/webhooks", outboundWebhooks.listSubscriptions)
+		protected.POST("/webhooks", outboundWebhooks.createSubscription)
+		protected.PUT("/webhooks/:id", outboundWebhooks.updateSubscription)
+		protected.DELETE("/webhooks/:id", outboundWebhooks.deleteSubscription)
+		protected.GET("/webhook-deliveries", outboundWebhooks.listDeliveries)
+		protected.POST("/webhook-deliveries/:id/replay", outboundWebhooks.replayDelivery)
+	}
 
 	if cfg.StaticRoot != "" {
 		staticDir := filepath.Clean(cfg.StaticRoot)
@@ -176,7 +238,7 @@ func buildCORS(allowedOrigins []string) gin.HandlerFunc {
 		cfg := cors.Config{
 			AllowAllOrigins:  true,
 			AllowHeaders:     []string{"Content-Type", "X-Requested-With", "X-Client-Data", "X-Client"},
-			AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodOptions},
+			AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete, http.MethodOptions},
 			AllowCredentials: false,
 		}
 		return cors.New(cfg)
@@ -184,7 +246,7 @@ func buildCORS(allowedOrigins []string) gin.HandlerFunc {
 	cfg := cors.Config{
 		AllowOrigins:     allowedOrigins,
 		AllowHeaders:     []string{"Content-Type", "X-Requested-With", "X-Client-Data", "X-Client"},
-		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodOptions},
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete, http.MethodOptions},
 		AllowCredentials: true,
 	}
 	return cors.New(cfg)
@@ -219,16 +281,17 @@ func newNotificationHandler(svc service.NotificationService, logger *slog.Logger
 }
 
 func (handler *notificationHandler) listNotifications(contextGin *gin.Context) {
-	statusFilters := contextGin.QueryArray("status")
-	filter := model.NotificationListFilters{
-		Statuses: parseStatusFilters(statusFilters),
+	filter, err := parseListFilters(contextGin)
+	if err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	responses, err := handler.service.ListNotifications(contextGin.Request.Context(), filter)
+	page, err := handler.service.ListNotifications(contextGin.Request.Context(), filter)
 	if err != nil {
 		handler.writeError(contextGin, err)
 		return
 	}
-	contextGin.JSON(http.StatusOK, gin.H{"notifications": responses})
+	contextGin.JSON(http.StatusOK, gin.H{"notifications": page.Notifications, "next_page_token": page.NextPageToken})
 }
 
 func (handler *notificationHandler) rescheduleNotification(contextGin *gin.Context) {
@@ -275,16 +338,62 @@ func (handler *notificationHandler) cancelNotification(contextGin *gin.Context)
 	contextGin.JSON(http.StatusOK, response)
 }
 
+func (handler *notificationHandler) listNotificationAttempts(contextGin *gin.Context) {
+	attempts, err := handler.service.GetNotificationAttempts(contextGin.Request.Context(), contextGin.Param("id"))
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	if attempts == nil {
+		attempts = []model.NotificationAttempt{}
+	}
+	contextGin.JSON(http.StatusOK, gin.H{"attempts": attempts})
+}
+
+func (handler *notificationHandler) retryNotification(contextGin *gin.Context) {
+	notificationID := strings.TrimSpace(contextGin.Param("id"))
+	if notificationID == "" {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "notification_id is required"})
+		return
+	}
+	response, err := handler.service.RequeueNotification(contextGin.Request.Context(), notificationID)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusOK, response)
+}
+
+// requeueNotifications requeues the dead or errored notifications matching the list filters in the
+// query string; paging parameters are ignored.
+func (handler *notificationHandler) requeueNotifications(contextGin *gin.Context) {
+	filter, err := parseListFilters(contextGin)
+	if err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	requeued, err := handler.service.RequeueNotifications(contextGin.Request.Context(), filter)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusOK, gin.H{"requeued": requeued})
+}
+
 func (handler *notificationHandler) writeError(contextGin *gin.Context, err error) {
 	switch {
 	case isMissingNotificationID(err):
 		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "notification_id is required"})
 	case errors.Is(err, service.ErrNotificationNotEditable):
 		contextGin.JSON(http.StatusConflict, gin.H{"error": "notification can only be edited while queued"})
+	case errors.Is(err, model.ErrNotificationNotRequeueable):
+		contextGin.JSON(http.StatusConflict, gin.H{"error": "notification can only be retried while dead or errored"})
 	case errors.Is(err, service.ErrScheduleInPast):
 		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "scheduled_time must be in the future"})
 	case errors.Is(err, model.ErrNotificationNotFound), errors.Is(err, gorm.ErrRecordNotFound):
 		contextGin.JSON(http.StatusNotFound, gin.H{"error": "notification not found"})
+	case errors.Is(err, model.ErrInvalidNotificationListFilter):
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 	default:
 		handler.logger.Error("http_handler_error", "error", err)
 		contextGin.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
@@ -298,6 +407,53 @@ func isMissingNotificationID(err error) bool {
 	return strings.Contains(err.Error(), "missing notification_id")
 }
 
+// parseListFilters reads the list query parameters: repeated status and type values, recipient,
+// recipient_prefix, RFC 3339 created_after/created_before/scheduled_after/scheduled_before, q,
+// page_size and page_token.
+func parseListFilters(contextGin *gin.Context) (model.NotificationListFilters, error) {
+	filter := model.NotificationListFilters{
+		Statuses:        parseStatusFilters(contextGin.QueryArray("status")),
+		Recipient:       strings.TrimSpace(contextGin.Query("recipient")),
+		RecipientPrefix: strings.TrimSpace(contextGin.Query("recipient_prefix")),
+		Text:            strings.TrimSpace(contextGin.Query("q")),
+		PageToken:       strings.TrimSpace(contextGin.Query("page_token")),
+	}
+	for _, raw := range contextGin.QueryArray("type") {
+		if trimmed := strings.TrimSpace(raw); trimmed != "" {
+			filter.Types = append(filter.Types, model.NotificationType(strings.ToLower(trimmed)))
+		}
+	}
+	if rawPageSize := strings.TrimSpace(contextGin.Query("page_size")); rawPageSize != "" {
+		pageSize, err := strconv.Atoi(rawPageSize)
+		if err != nil {
+			return model.NotificationListFilters{}, fmt.Errorf("page_size must be an integer")
+		}
+		filter.PageSize = pageSize
+	}
+	timestamps := []struct {
+		name   string
+		target **time.Time
+	}{
+		{name: "created_after", target: &filter.CreatedAfter},
+		{name: "created_before", target: &filter.CreatedBefore},
+		{name: "scheduled_after", target: &filter.ScheduledAfter},
+		{name: "scheduled_before", target: &filter.ScheduledBefore},
+	}
+	for _, timestamp := range timestamps {
+		raw := strings.TrimSpace(contextGin.Query(timestamp.name))
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return model.NotificationListFilters{}, fmt.Errorf("%s must be an RFC 3339 timestamp", timestamp.name)
+		}
+		normalized := parsed.UTC()
+		*timestamp.target = &normalized
+	}
+	return filter, nil
+}
+
 func parseStatusFilters(values []string) []model.NotificationStatus {
 	if len(values) == 0 {
 		return nil
@@ -326,6 +482,25 @@ func pickDuration(candidate time.Duration, fallback time.Duration) time.Duration
 	return candidate
 }
 
+// serveHealth reports liveness plus, when a reporter is configured, the circuit breaker state of
+// every delivery provider. Open breakers mark the service as degraded without failing the probe.
+func serveHealth(reporter service.HealthReporter) gin.HandlerFunc {
+	return func(contextGin *gin.Context) {
+		if reporter == nil {
+			contextGin.JSON(http.StatusOK, gin.H{"status": "ok"})
+			return
+		}
+		providers := reporter.ProviderHealth()
+		overallStatus := "ok"
+		for _, provider := range providers {
+			if provider.State != service.BreakerClosed {
+				overallStatus = "degraded"
+			}
+		}
+		contextGin.JSON(http.StatusOK, gin.H{"status": overallStatus, "providers": providers})
+	}
+}
+
 type runtimeConfigPayload struct {
 	APIBaseURL string `json:"apiBaseUrl"`
 }
@@ -67,11 +67,13 @@ func TestListNotificationsReturnsData(t *testing.T) {
 			{NotificationID: "queued", Status: model.StatusQueued},
 			{NotificationID: "errored", Status: model.StatusErrored},
 		},
+		listNextPageToken: "next-token",
 	}
 	server := newTestHTTPServer(t, stubSvc, &stubValidator{})
 
 	recorder := httptest.NewRecorder()
-	request := httptest.NewRequest(http.MethodGet, "/api/notifications?status=queued&status=errored", nil)
+	query := "status=queued&status=errored&type=EMAIL&recipient_prefix=ali&q=receipt&created_after=2024-01-02T00:00:00Z&created_before=2024-01-03T00:00:00%2B02:00&page_size=20&page_token=abc"
+	request := httptest.NewRequest(http.MethodGet, "/api/notifications?"+query, nil)
 
 	server.httpServer.Handler.ServeHTTP(recorder, request)
 	if recorder.Code != http.StatusOK {
@@ -80,12 +82,55 @@ func TestListNotificationsReturnsData(t *testing.T) {
 
 	var payload struct {
 		Notifications []model.NotificationResponse `json:"notifications"`
+		NextPageToken string                       `json:"next_page_token"`
 	}
 	if err := json.Unmarshal(recorder.Body.Bytes(), &payload); err != nil {
 		t.Fatalf("response decode error: %v", err)
 	}
-	if len(payload.Notifications) != 2 {
-		t.Fatalf("expected 2 notifications, got %d", len(payload.Notifications))
+	if len(payload.Notifications) != 2 || payload.NextPageToken != "next-token" {
+		t.Fatalf("expected 2 notifications and the next page token, got %d (%q)", len(payload.Notifications), payload.NextPageToken)
+	}
+
+	filters := stubSvc.lastListFilters
+	if len(filters.Statuses) != 2 || len(filters.Types) != 1 || filters.Types[0] != model.NotificationEmail {
+		t.Fatalf("unexpected status and type filters %+v", filters)
+	}
+	if filters.RecipientPrefix != "ali" || filters.Text != "receipt" || filters.PageSize != 20 || filters.PageToken != "abc" {
+		t.Fatalf("unexpected filters %+v", filters)
+	}
+	expectedBefore := time.Date(2024, time.January, 2, 22, 0, 0, 0, time.UTC)
+	if filters.CreatedAfter == nil || !filters.CreatedAfter.Equal(time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)) || filters.CreatedBefore == nil || !filters.CreatedBefore.Equal(expectedBefore) {
+		t.Fatalf("unexpected created range %v - %v", filters.CreatedAfter, filters.CreatedBefore)
+	}
+	if filters.ScheduledAfter != nil || filters.ScheduledBefore != nil {
+		t.Fatalf("expected no scheduled range, got %v - %v", filters.ScheduledAfter, filters.ScheduledBefore)
+	}
+}
+
+func TestListNotificationsRejectsInvalidFilters(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name    string
+		query   string
+		listErr error
+	}{
+		{name: "non-numeric page size", query: "page_size=ten"},
+		{name: "malformed timestamp", query: "scheduled_before=tomorrow"},
+		{name: "filter rejected by the service", query: "page_token=bogus", listErr: fmt.Errorf("%w: malformed page token", model.ErrInvalidNotificationListFilter)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := newTestHTTPServer(t, &stubNotificationService{listErr: testCase.listErr}, &stubValidator{})
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/api/notifications?"+testCase.query, nil)
+
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d (%s)", recorder.Code, recorder.Body.String())
+			}
+		})
 	}
 }
 
@@ -210,6 +255,139 @@ func TestCancelNotificationRejectsEmptyID(t *testing.T) {
 	}
 }
 
+func TestRetryNotificationRoutes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name         string
+		path         string
+		stub         *stubNotificationService
+		expectedCode int
+		verify       func(t *testing.T, stub *stubNotificationService, body []byte)
+	}{
+		{
+			name:         "RetryDeadNotification",
+			path:         "/api/notifications/notif-1/retry",
+			stub:         &stubNotificationService{requeueResponse: model.NotificationResponse{NotificationID: "notif-1", Status: model.StatusQueued, LastError: "smtp timeout"}},
+			expectedCode: http.StatusOK,
+			verify: func(t *testing.T, stub *stubNotificationService, body []byte) {
+				var response model.NotificationResponse
+				if err := json.Unmarshal(body, &response); err != nil {
+					t.Fatalf("decode error: %v", err)
+				}
+				if stub.lastRequeueID != "notif-1" || response.Status != model.StatusQueued || response.LastError != "smtp timeout" {
+					t.Fatalf("unexpected retry response %+v", response)
+				}
+			},
+		},
+		{
+			name:         "RetryNotRequeueable",
+			path:         "/api/notifications/notif-1/retry",
+			stub:         &stubNotificationService{requeueErr: model.ErrNotificationNotRequeueable},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "RetryMissingNotification",
+			path:         "/api/notifications/notif-missing/retry",
+			stub:         &stubNotificationService{requeueErr: model.ErrNotificationNotFound},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "RequeueByFilter",
+			path:         "/api/notifications/requeue?status=dead&status=errored&type=email&created_after=2024-01-01T00:00:00Z",
+			stub:         &stubNotificationService{requeueCount: 3},
+			expectedCode: http.StatusOK,
+			verify: func(t *testing.T, stub *stubNotificationService, body []byte) {
+				if string(body) != `{"requeued":3}` {
+					t.Fatalf("unexpected requeue response %s", body)
+				}
+				filters := stub.lastRequeueFilters
+				if len(filters.Statuses) != 2 || filters.Statuses[0] != model.StatusDead || len(filters.Types) != 1 || filters.CreatedAfter == nil {
+					t.Fatalf("unexpected requeue filters %+v", filters)
+				}
+			},
+		},
+		{
+			name:         "RequeueRejectsInvalidFilter",
+			path:         "/api/notifications/requeue?status=sent",
+			stub:         &stubNotificationService{requeueErr: fmt.Errorf("%w: sent notifications cannot be requeued", model.ErrInvalidNotificationListFilter)},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := newTestHTTPServer(t, testCase.stub, &stubValidator{})
+
+			recorder := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, testCase.path, nil))
+			if recorder.Code != testCase.expectedCode {
+				t.Fatalf("expected %d, got %d (%s)", testCase.expectedCode, recorder.Code, recorder.Body.String())
+			}
+			if testCase.verify != nil {
+				testCase.verify(t, testCase.stub, recorder.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestListNotificationAttemptsRoute(t *testing.T) {
+	t.Helper()
+
+	attemptedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name         string
+		stub         *stubNotificationService
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "ListsAttempts",
+			stub: &stubNotificationService{attempts: []model.NotificationAttempt{{
+				NotificationID: "notif-1",
+				AttemptedAt:    attemptedAt,
+				Provider:       "smtp",
+				DurationMs:     120,
+				Outcome:        model.StatusErrored,
+				ErrorClass:     model.AttemptErrorRejected,
+				ErrorMessage:   "550 mailbox unavailable",
+				SMTPReplyCode:  550,
+			}}},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"attempts":[{"notification_id":"notif-1","attempted_at":"2024-03-01T12:00:00Z","provider":"smtp","duration_ms":120,"outcome":"errored","error_class":"rejected","error_message":"550 mailbox unavailable","smtp_reply_code":550}]}`,
+		},
+		{
+			name:         "NoAttempts",
+			stub:         &stubNotificationService{},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"attempts":[]}`,
+		},
+		{
+			name:         "MissingNotification",
+			stub:         &stubNotificationService{attemptsErr: model.ErrNotificationNotFound},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := newTestHTTPServer(t, testCase.stub, &stubValidator{})
+
+			recorder := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/notifications/notif-1/attempts", nil))
+			if recorder.Code != testCase.expectedCode {
+				t.Fatalf("expected %d, got %d (%s)", testCase.expectedCode, recorder.Code, recorder.Body.String())
+			}
+			if testCase.stub.lastAttemptsID != "notif-1" {
+				t.Fatalf("expected the notification ID to be forwarded, got %q", testCase.stub.lastAttemptsID)
+			}
+			if testCase.expectedBody != "" && recorder.Body.String() != testCase.expectedBody {
+				t.Fatalf("unexpected body %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
 func TestNewServerSupportsStaticRootAfterAPIRoutes(t *testing.T) {
 	t.Helper()
 
@@ -322,6 +500,74 @@ func TestRuntimeConfigEndpointReturnsValues(t *testing.T) {
 	}
 }
 
+func TestHealthEndpointReportsProviderBreakers(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name           string
+		reporter       service.HealthReporter
+		expectedStatus string
+		expectedCount  int
+	}{
+		{name: "NoReporter", expectedStatus: "ok"},
+		{
+			name: "AllClosed",
+			reporter: stubHealthReporter{
+				{Channel: "email", Provider: "smtp", State: service.BreakerClosed},
+				{Channel: "email", Provider: "sendgrid", State: service.BreakerClosed},
+			},
+			expectedStatus: "ok",
+			expectedCount:  2,
+		},
+		{
+			name: "OpenBreakerDegrades",
+			reporter: stubHealthReporter{
+				{Channel: "sms", Provider: "twilio", State: service.BreakerOpen, ConsecutiveFailures: 5},
+				{Channel: "sms", Provider: "vonage", State: service.BreakerClosed},
+			},
+			expectedStatus: "degraded",
+			expectedCount:  2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+			server, err := NewServer(Config{
+				ListenAddr:          ":0",
+				NotificationService: &stubNotificationService{},
+				SessionValidator:    &stubValidator{},
+				HealthReporter:      testCase.reporter,
+				Logger:              logger,
+				AdminEmails:         []string{"user@example.com"},
+			})
+			if err != nil {
+				t.Fatalf("server init error: %v", err)
+			}
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", recorder.Code)
+			}
+			var payload struct {
+				Status    string                   `json:"status"`
+				Providers []service.ProviderHealth `json:"providers"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &payload); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if payload.Status != testCase.expectedStatus {
+				t.Fatalf("expected status %q, got %q", testCase.expectedStatus, payload.Status)
+			}
+			if len(payload.Providers) != testCase.expectedCount {
+				t.Fatalf("expected %d providers, got %d", testCase.expectedCount, len(payload.Providers))
+			}
+		})
+	}
+}
+
 func newTestHTTPServer(t *testing.T, svc service.NotificationService, validator SessionValidator) *Server {
 	t.Helper()
 
@@ -357,7 +603,9 @@ func (validator *stubValidator) ValidateRequest(_ *http.Request) (*sessionvalida
 
 type stubNotificationService struct {
 	listResponse       []model.NotificationResponse
+	listNextPageToken  string
 	listErr            error
+	lastListFilters    model.NotificationListFilters
 	rescheduleResponse model.NotificationResponse
 	rescheduleErr      error
 	rescheduleCalls    int
@@ -366,6 +614,14 @@ type stubNotificationService struct {
 	cancelErr          error
 	cancelCalls        int
 	lastCancelID       string
+	requeueResponse    model.NotificationResponse
+	requeueErr         error
+	lastRequeueID      string
+	requeueCount       int
+	lastRequeueFilters model.NotificationListFilters
+	attempts           []model.NotificationAttempt
+	attemptsErr        error
+	lastAttemptsID     string
 }
 
 func (stub *stubNotificationService) SendNotification(context.Context, model.NotificationRequest) (model.NotificationResponse, error) {
@@ -376,8 +632,14 @@ func (stub *stubNotificationService) GetNotificationStatus(context.Context, stri
 	return model.NotificationResponse{}, errors.New("not implemented")
 }
 
-func (stub *stubNotificationService) ListNotifications(context.Context, model.NotificationListFilters) ([]model.NotificationResponse, error) {
-	return stub.listResponse, stub.listErr
+func (stub *stubNotificationService) GetNotificationAttempts(_ context.Context, notificationID string) ([]model.NotificationAttempt, error) {
+	stub.lastAttemptsID = notificationID
+	return stub.attempts, stub.attemptsErr
+}
+
+func (stub *stubNotificationService) ListNotifications(_ context.Context, filters model.NotificationListFilters) (service.NotificationPage, error) {
+	stub.lastListFilters = filters
+	return service.NotificationPage{Notifications: stub.listResponse, NextPageToken: stub.listNextPageToken}, stub.listErr
 }
 
 func (stub *stubNotificationService) RescheduleNotification(_ context.Context, notificationID string, scheduledFor time.Time) (model.NotificationResponse, error) {
@@ -399,4 +661,35 @@ func (stub *stubNotificationService) CancelNotification(_ context.Context, notif
 	return stub.cancelResponse, nil
 }
 
+func (stub *stubNotificationService) RequeueNotification(_ context.Context, notificationID string) (model.NotificationResponse, error) {
+	stub.lastRequeueID = notificationID
+	if stub.requeueErr != nil {
+		return model.NotificationResponse{}, stub.requeueErr
+	}
+	return stub.requeueResponse, nil
+}
+
+func (stub *stubNotificationService) RequeueNotifications(_ context.Context, filters model.NotificationListFilters) (int, error) {
+	stub.lastRequeueFilters = filters
+	return stub.requeueCount, stub.requeueErr
+}
+
+func (stub *stubNotificationService) SendNotificationBatch(context.Context, []model.NotificationRequest) (service.NotificationBatchResult, error) {
+	return service.NotificationBatchResult{}, errors.New("not implemented")
+}
+
+func (stub *stubNotificationService) GetNotificationBatch(context.Context, string) ([]model.NotificationResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (stub *stubNotificationService) WatchNotifications(context.Context, service.NotificationWatchFilter) (*service.NotificationSubscription, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (stub *stubNotificationService) StartRetryWorker(context.Context) {}
+
+type stubHealthReporter []service.ProviderHealth
+
+func (reporter stubHealthReporter) ProviderHealth() []service.ProviderHealth {
+	return reporter
+}
@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+// dashboardSuppressionSource labels suppressions added through the HTTP API without an explicit source.
+const dashboardSuppressionSource = "dashboard"
+
+type suppressionHandler struct {
+	service service.SuppressionService
+	logger  *slog.Logger
+}
+
+func newSuppressionHandler(svc service.SuppressionService, logger *slog.Logger) *suppressionHandler {
+	return &suppressionHandler{service: svc, logger: logger}
+}
+
+func (handler *suppressionHandler) listSuppressions(contextGin *gin.Context) {
+	var filters model.SuppressionListFilters
+	for _, rawChannel := range contextGin.QueryArray("channel") {
+		channel := model.NotificationType(strings.ToLower(strings.TrimSpace(rawChannel)))
+		switch channel {
+		case "":
+		case model.NotificationEmail, model.NotificationSMS:
+			filters.Channels = append(filters.Channels, channel)
+		default:
+			contextGin.JSON(http.StatusBadRequest, gin.H{"error": "channel must be email or sms"})
+			return
+		}
+	}
+	if rawIncludeExpired := strings.TrimSpace(contextGin.Query("include_expired")); rawIncludeExpired != "" {
+		includeExpired, parseErr := strconv.ParseBool(rawIncludeExpired)
+		if parseErr != nil {
+			contextGin.JSON(http.StatusBadRequest, gin.H{"error": "include_expired must be a boolean"})
+			return
+		}
+		filters.IncludeExpired = includeExpired
+	}
+	suppressions, err := handler.service.ListSuppressions(contextGin.Request.Context(), filters)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	if suppressions == nil {
+		suppressions = []model.Suppression{}
+	}
+	contextGin.JSON(http.StatusOK, gin.H{"suppressions": suppressions})
+}
+
+func (handler *suppressionHandler) addSuppression(contextGin *gin.Context) {
+	var payload model.SuppressionRequest
+	if err := contextGin.ShouldBindJSON(&payload); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if strings.TrimSpace(payload.Source) == "" {
+		payload.Source = dashboardSuppressionSource
+	}
+	suppression, err := handler.service.AddSuppression(contextGin.Request.Context(), payload)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusCreated, suppression)
+}
+
+func (handler *suppressionHandler) removeSuppression(contextGin *gin.Context) {
+	channel := model.NotificationType(strings.ToLower(contextGin.Param("channel")))
+	if err := handler.service.RemoveSuppression(contextGin.Request.Context(), channel, contextGin.Param("recipient"), contextGin.Query("category")); err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.Status(http.StatusNoContent)
+}
+
+func (handler *suppressionHandler) writeError(contextGin *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrSuppressionNotFound):
+		contextGin.JSON(http.StatusNotFound, gin.H{"error": "suppression not found"})
+	case errors.Is(err, service.ErrInvalidSuppression):
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		handler.logger.Error("http_handler_error", "error", err)
+		contextGin.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
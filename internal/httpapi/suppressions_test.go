@@ -0,0 +1,164 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+func TestSuppressionRoutes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		stub           *stubSuppressionService
+		expectedStatus int
+		verify         func(t *testing.T, stub *stubSuppressionService, body []byte)
+	}{
+		{
+			name:           "ListSuppressions",
+			method:         http.MethodGet,
+			path:           "/api/suppressions?channel=sms&include_expired=true",
+			stub:           &stubSuppressionService{listResponse: []model.Suppression{{Channel: model.NotificationSMS, Recipient: "+15550001111", Reason: model.SuppressionStop}}},
+			expectedStatus: http.StatusOK,
+			verify: func(t *testing.T, stub *stubSuppressionService, body []byte) {
+				var payload struct {
+					Suppressions []model.Suppression `json:"suppressions"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("decode error: %v", err)
+				}
+				if len(payload.Suppressions) != 1 || payload.Suppressions[0].Reason != model.SuppressionStop {
+					t.Fatalf("unexpected suppressions %#v", payload.Suppressions)
+				}
+				if len(stub.lastFilters.Channels) != 1 || stub.lastFilters.Channels[0] != model.NotificationSMS || !stub.lastFilters.IncludeExpired {
+					t.Fatalf("unexpected filters %#v", stub.lastFilters)
+				}
+			},
+		},
+		{
+			name:           "ListRejectsUnknownChannel",
+			method:         http.MethodGet,
+			path:           "/api/suppressions?channel=fax",
+			stub:           &stubSuppressionService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "AddSuppressionDefaultsSource",
+			method:         http.MethodPost,
+			path:           "/api/suppressions",
+			body:           `{"channel":"email","recipient":"user@example.com","reason":"unsubscribed"}`,
+			stub:           &stubSuppressionService{},
+			expectedStatus: http.StatusCreated,
+			verify: func(t *testing.T, stub *stubSuppressionService, _ []byte) {
+				if stub.lastRequest.Recipient != "user@example.com" || stub.lastRequest.Source != dashboardSuppressionSource {
+					t.Fatalf("unexpected add request %#v", stub.lastRequest)
+				}
+			},
+		},
+		{
+			name:           "AddInvalidSuppression",
+			method:         http.MethodPost,
+			path:           "/api/suppressions",
+			body:           `{"channel":"email","recipient":""}`,
+			stub:           &stubSuppressionService{err: fmt.Errorf("%w: recipient is required", service.ErrInvalidSuppression)},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "RemoveSuppression",
+			method:         http.MethodDelete,
+			path:           "/api/suppressions/sms/%2B15550001111?category=promotions",
+			stub:           &stubSuppressionService{},
+			expectedStatus: http.StatusNoContent,
+			verify: func(t *testing.T, stub *stubSuppressionService, _ []byte) {
+				if stub.lastChannel != model.NotificationSMS || stub.lastRecipient != "+15550001111" || stub.lastCategory != "promotions" {
+					t.Fatalf("unexpected remove arguments %s/%s/%s", stub.lastChannel, stub.lastRecipient, stub.lastCategory)
+				}
+			},
+		},
+		{
+			name:           "RemoveMissingSuppression",
+			method:         http.MethodDelete,
+			path:           "/api/suppressions/email/user@example.com",
+			stub:           &stubSuppressionService{err: fmt.Errorf("%w: email user@example.com", model.ErrSuppressionNotFound)},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := newSuppressionTestHTTPServer(t, testCase.stub)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			request.Header.Set("Content-Type", "application/json")
+
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d (%s)", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if testCase.verify != nil {
+				testCase.verify(t, testCase.stub, recorder.Body.Bytes())
+			}
+		})
+	}
+}
+
+func newSuppressionTestHTTPServer(t *testing.T, suppressionService service.SuppressionService) *Server {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server, err := NewServer(Config{
+		ListenAddr:          ":0",
+		NotificationService: &stubNotificationService{},
+		SuppressionService:  suppressionService,
+		SessionValidator:    &stubValidator{},
+		Logger:              logger,
+		AdminEmails:         []string{"user@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("server init error: %v", err)
+	}
+	return server
+}
+
+type stubSuppressionService struct {
+	listResponse  []model.Suppression
+	err           error
+	lastFilters   model.SuppressionListFilters
+	lastRequest   model.SuppressionRequest
+	lastChannel   model.NotificationType
+	lastRecipient string
+	lastCategory  string
+}
+
+func (stub *stubSuppressionService) ListSuppressions(_ context.Context, filters model.SuppressionListFilters) ([]model.Suppression, error) {
+	stub.lastFilters = filters
+	return stub.listResponse, stub.err
+}
+
+func (stub *stubSuppressionService) AddSuppression(_ context.Context, request model.SuppressionRequest) (model.Suppression, error) {
+	stub.lastRequest = request
+	if stub.err != nil {
+		return model.Suppression{}, stub.err
+	}
+	return model.Suppression{Channel: request.Channel, Recipient: request.Recipient, Category: request.Category, Reason: request.Reason, Source: request.Source}, nil
+}
+
+func (stub *stubSuppressionService) RemoveSuppression(_ context.Context, channel model.NotificationType, recipient string, category string) error {
+	stub.lastChannel = channel
+	stub.lastRecipient = recipient
+	stub.lastCategory = category
+	return stub.err
+}
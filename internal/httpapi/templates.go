@@ -0,0 +1,130 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+type templateHandler struct {
+	service service.TemplateService
+	logger  *slog.Logger
+}
+
+func newTemplateHandler(svc service.TemplateService, logger *slog.Logger) *templateHandler {
+	return &templateHandler{service: svc, logger: logger}
+}
+
+type templatePreviewPayload struct {
+	Version          int               `json:"version"`
+	NotificationType string            `json:"notification_type"`
+	TemplateData     map[string]string `json:"template_data"`
+}
+
+func (handler *templateHandler) listTemplates(contextGin *gin.Context) {
+	templates, err := handler.service.ListTemplates(contextGin.Request.Context())
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	if templates == nil {
+		templates = []model.Template{}
+	}
+	contextGin.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+func (handler *templateHandler) createTemplate(contextGin *gin.Context) {
+	var payload model.TemplateRequest
+	if err := contextGin.ShouldBindJSON(&payload); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	template, err := handler.service.CreateTemplate(contextGin.Request.Context(), payload)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusCreated, template)
+}
+
+func (handler *templateHandler) getTemplate(contextGin *gin.Context) {
+	version := 0
+	if rawVersion := strings.TrimSpace(contextGin.Query("version")); rawVersion != "" {
+		parsedVersion, parseErr := strconv.Atoi(rawVersion)
+		if parseErr != nil || parsedVersion < 0 {
+			contextGin.JSON(http.StatusBadRequest, gin.H{"error": "version must be a non-negative integer"})
+			return
+		}
+		version = parsedVersion
+	}
+	template, err := handler.service.GetTemplate(contextGin.Request.Context(), contextGin.Param("id"), version)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusOK, template)
+}
+
+func (handler *templateHandler) updateTemplate(contextGin *gin.Context) {
+	var payload model.TemplateRequest
+	if err := contextGin.ShouldBindJSON(&payload); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	payload.TemplateID = contextGin.Param("id")
+	template, err := handler.service.UpdateTemplate(contextGin.Request.Context(), payload)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusOK, template)
+}
+
+func (handler *templateHandler) deleteTemplate(contextGin *gin.Context) {
+	if err := handler.service.DeleteTemplate(contextGin.Request.Context(), contextGin.Param("id")); err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.Status(http.StatusNoContent)
+}
+
+func (handler *templateHandler) previewTemplate(contextGin *gin.Context) {
+	var payload templatePreviewPayload
+	if err := contextGin.ShouldBindJSON(&payload); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	notificationType := model.NotificationType(strings.ToLower(strings.TrimSpace(payload.NotificationType)))
+	switch notificationType {
+	case "", model.NotificationEmail, model.NotificationSMS:
+	default:
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "notification_type must be email or sms"})
+		return
+	}
+	rendered, err := handler.service.PreviewTemplate(contextGin.Request.Context(), contextGin.Param("id"), payload.Version, notificationType, payload.TemplateData)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	contextGin.JSON(http.StatusOK, rendered)
+}
+
+func (handler *templateHandler) writeError(contextGin *gin.Context, err error) {
+	switch {
+	case errors.Is(err, model.ErrTemplateNotFound):
+		contextGin.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+	case errors.Is(err, service.ErrTemplateExists):
+		contextGin.JSON(http.StatusConflict, gin.H{"error": "template already exists"})
+	case errors.Is(err, service.ErrInvalidTemplate), errors.Is(err, service.ErrTemplateRender):
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		handler.logger.Error("http_handler_error", "error", err)
+		contextGin.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
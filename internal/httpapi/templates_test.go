@@ -0,0 +1,244 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+func TestTemplateRoutes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		stub           *stubTemplateService
+		expectedStatus int
+		verify         func(t *testing.T, stub *stubTemplateService, body []byte)
+	}{
+		{
+			name:           "ListTemplates",
+			method:         http.MethodGet,
+			path:           "/api/templates",
+			stub:           &stubTemplateService{listResponse: []model.Template{{TemplateID: "welcome", Version: 2}}},
+			expectedStatus: http.StatusOK,
+			verify: func(t *testing.T, _ *stubTemplateService, body []byte) {
+				var payload struct {
+					Templates []model.Template `json:"templates"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("decode error: %v", err)
+				}
+				if len(payload.Templates) != 1 || payload.Templates[0].Version != 2 {
+					t.Fatalf("unexpected templates %#v", payload.Templates)
+				}
+			},
+		},
+		{
+			name:           "CreateTemplate",
+			method:         http.MethodPost,
+			path:           "/api/templates",
+			body:           `{"template_id":"welcome","plain_body":"Hi {{.name}}"}`,
+			stub:           &stubTemplateService{},
+			expectedStatus: http.StatusCreated,
+			verify: func(t *testing.T, stub *stubTemplateService, _ []byte) {
+				if stub.lastRequest.TemplateID != "welcome" || stub.lastRequest.PlainBody != "Hi {{.name}}" {
+					t.Fatalf("unexpected create request %#v", stub.lastRequest)
+				}
+			},
+		},
+		{
+			name:           "CreateDuplicateTemplate",
+			method:         http.MethodPost,
+			path:           "/api/templates",
+			body:           `{"template_id":"welcome","plain_body":"Hi"}`,
+			stub:           &stubTemplateService{err: fmt.Errorf("%w: welcome", service.ErrTemplateExists)},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "UpdateTemplateUsesPathID",
+			method:         http.MethodPut,
+			path:           "/api/templates/welcome",
+			body:           `{"template_id":"ignored","plain_body":"Hello"}`,
+			stub:           &stubTemplateService{},
+			expectedStatus: http.StatusOK,
+			verify: func(t *testing.T, stub *stubTemplateService, _ []byte) {
+				if stub.lastRequest.TemplateID != "welcome" {
+					t.Fatalf("expected path id to win, got %q", stub.lastRequest.TemplateID)
+				}
+			},
+		},
+		{
+			name:           "GetTemplateVersion",
+			method:         http.MethodGet,
+			path:           "/api/templates/welcome?version=3",
+			stub:           &stubTemplateService{},
+			expectedStatus: http.StatusOK,
+			verify: func(t *testing.T, stub *stubTemplateService, _ []byte) {
+				if stub.lastVersion != 3 {
+					t.Fatalf("expected version 3, got %d", stub.lastVersion)
+				}
+			},
+		},
+		{
+			name:           "GetTemplateRejectsInvalidVersion",
+			method:         http.MethodGet,
+			path:           "/api/templates/welcome?version=latest",
+			stub:           &stubTemplateService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "GetMissingTemplate",
+			method:         http.MethodGet,
+			path:           "/api/templates/missing",
+			stub:           &stubTemplateService{err: fmt.Errorf("%w: missing", model.ErrTemplateNotFound)},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "DeleteTemplate",
+			method:         http.MethodDelete,
+			path:           "/api/templates/welcome",
+			stub:           &stubTemplateService{},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "PreviewTemplate",
+			method:         http.MethodPost,
+			path:           "/api/templates/welcome/preview",
+			body:           `{"notification_type":"sms","template_data":{"name":"Ada"}}`,
+			stub:           &stubTemplateService{},
+			expectedStatus: http.StatusOK,
+			verify: func(t *testing.T, stub *stubTemplateService, _ []byte) {
+				if stub.lastType != model.NotificationSMS || stub.lastData["name"] != "Ada" {
+					t.Fatalf("unexpected preview arguments type=%s data=%v", stub.lastType, stub.lastData)
+				}
+			},
+		},
+		{
+			name:           "PreviewMissingVariable",
+			method:         http.MethodPost,
+			path:           "/api/templates/welcome/preview",
+			body:           `{}`,
+			stub:           &stubTemplateService{err: fmt.Errorf("%w: map has no entry for key \"name\"", service.ErrTemplateRender)},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "PreviewRejectsUnknownType",
+			method:         http.MethodPost,
+			path:           "/api/templates/welcome/preview",
+			body:           `{"notification_type":"fax"}`,
+			stub:           &stubTemplateService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := newTemplateTestHTTPServer(t, testCase.stub)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			request.Header.Set("Content-Type", "application/json")
+
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d (%s)", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if testCase.verify != nil {
+				testCase.verify(t, testCase.stub, recorder.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestTemplateRoutesDisabledWithoutService(t *testing.T) {
+	t.Helper()
+
+	server := newTestHTTPServer(t, &stubNotificationService{}, &stubValidator{})
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/api/templates", nil)
+
+	server.httpServer.Handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without template service, got %d", recorder.Code)
+	}
+}
+
+func newTemplateTestHTTPServer(t *testing.T, templateService service.TemplateService) *Server {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server, err := NewServer(Config{
+		ListenAddr:          ":0",
+		NotificationService: &stubNotificationService{},
+		TemplateService:     templateService,
+		SessionValidator:    &stubValidator{},
+		Logger:              logger,
+		AdminEmails:         []string{"user@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("server init error: %v", err)
+	}
+	return server
+}
+
+type stubTemplateService struct {
+	listResponse []model.Template
+	err          error
+	lastRequest  model.TemplateRequest
+	lastVersion  int
+	lastType     model.NotificationType
+	lastData     map[string]string
+}
+
+func (stub *stubTemplateService) CreateTemplate(_ context.Context, request model.TemplateRequest) (model.Template, error) {
+	stub.lastRequest = request
+	if stub.err != nil {
+		return model.Template{}, stub.err
+	}
+	return model.Template{TemplateID: request.TemplateID, Version: 1}, nil
+}
+
+func (stub *stubTemplateService) UpdateTemplate(_ context.Context, request model.TemplateRequest) (model.Template, error) {
+	stub.lastRequest = request
+	if stub.err != nil {
+		return model.Template{}, stub.err
+	}
+	return model.Template{TemplateID: request.TemplateID, Version: 2}, nil
+}
+
+func (stub *stubTemplateService) GetTemplate(_ context.Context, templateID string, version int) (model.Template, error) {
+	stub.lastVersion = version
+	if stub.err != nil {
+		return model.Template{}, stub.err
+	}
+	return model.Template{TemplateID: templateID, Version: version}, nil
+}
+
+func (stub *stubTemplateService) ListTemplates(context.Context) ([]model.Template, error) {
+	return stub.listResponse, stub.err
+}
+
+func (stub *stubTemplateService) DeleteTemplate(context.Context, string) error {
+	return stub.err
+}
+
+func (stub *stubTemplateService) PreviewTemplate(_ context.Context, templateID string, version int, notificationType model.NotificationType, data map[string]string) (model.RenderedTemplate, error) {
+	stub.lastVersion = version
+	stub.lastType = notificationType
+	stub.lastData = data
+	if stub.err != nil {
+		return model.RenderedTemplate{}, stub.err
+	}
+	return model.RenderedTemplate{TemplateID: templateID, Version: 1}, nil
+}
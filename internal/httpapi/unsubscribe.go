@@ -0,0 +1,98 @@
+package httpapi
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+// unsubscribePage renders both the confirmation form shown to people following the link and the
+// result of the unsubscribe. Mail clients implementing RFC 8058 skip the form and POST directly.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!doctype html>
+<html lang="en">
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Unsubscribe</title></head>
+<body>
+<main>
+{{- if .Error}}
+<h1>Unsubscribe link invalid</h1>
+<p>{{.Error}}</p>
+{{- else if .Done}}
+<h1>You are unsubscribed</h1>
+<p>{{.Recipient}} will no longer receive {{if .Category}}{{.Category}} {{end}}emails.</p>
+{{- else}}
+<h1>Unsubscribe</h1>
+<p>Stop sending {{if .Category}}{{.Category}} {{end}}emails to {{.Recipient}}?</p>
+<form method="post" action="?token={{.Token}}">
+<input type="hidden" name="List-Unsubscribe" value="One-Click">
+<button type="submit">Unsubscribe</button>
+</form>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+type unsubscribePageData struct {
+	Token     string
+	Recipient string
+	Category  string
+	Done      bool
+	Error     string
+}
+
+type unsubscribeHandler struct {
+	service service.UnsubscribeService
+	logger  *slog.Logger
+}
+
+func newUnsubscribeHandler(svc service.UnsubscribeService, logger *slog.Logger) *unsubscribeHandler {
+	return &unsubscribeHandler{service: svc, logger: logger}
+}
+
+func (handler *unsubscribeHandler) register(engine *gin.Engine) {
+	engine.GET(service.UnsubscribePath, handler.confirmUnsubscribe)
+	engine.POST(service.UnsubscribePath, handler.unsubscribe)
+}
+
+// confirmUnsubscribe shows a confirmation form instead of unsubscribing on GET, so link scanners
+// that prefetch URLs cannot unsubscribe recipients.
+func (handler *unsubscribeHandler) confirmUnsubscribe(contextGin *gin.Context) {
+	token := contextGin.Query("token")
+	claims, err := handler.service.Verify(token)
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	handler.render(contextGin, http.StatusOK, unsubscribePageData{Token: token, Recipient: claims.Recipient, Category: claims.Category})
+}
+
+// unsubscribe handles both the RFC 8058 one-click POST and the confirmation form.
+func (handler *unsubscribeHandler) unsubscribe(contextGin *gin.Context) {
+	suppression, err := handler.service.Unsubscribe(contextGin.Request.Context(), contextGin.Query("token"))
+	if err != nil {
+		handler.writeError(contextGin, err)
+		return
+	}
+	handler.render(contextGin, http.StatusOK, unsubscribePageData{Recipient: suppression.Recipient, Category: suppression.Category, Done: true})
+}
+
+func (handler *unsubscribeHandler) writeError(contextGin *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidUnsubscribeToken) {
+		handler.render(contextGin, http.StatusBadRequest, unsubscribePageData{Error: "This unsubscribe link is invalid or has been altered."})
+		return
+	}
+	handler.logger.Error("unsubscribe_failed", "error", err)
+	handler.render(contextGin, http.StatusInternalServerError, unsubscribePageData{Error: "We could not process the request. Please try again later."})
+}
+
+func (handler *unsubscribeHandler) render(contextGin *gin.Context, statusCode int, data unsubscribePageData) {
+	contextGin.Status(statusCode)
+	contextGin.Header("Content-Type", "text/html; charset=utf-8")
+	if err := unsubscribePage.Execute(contextGin.Writer, data); err != nil {
+		handler.logger.Error("unsubscribe_render_failed", "error", err)
+	}
+}
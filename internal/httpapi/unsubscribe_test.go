@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+func TestUnsubscribeEndpoint(t *testing.T) {
+	t.Helper()
+	signer := service.NewUnsubscribeSigner("https://notify.example.com", "signing-key")
+	token := url.QueryEscape(signer.Token("reader@example.com", "promotions"))
+
+	testCases := []struct {
+		name               string
+		method             string
+		path               string
+		body               string
+		expectedStatus     int
+		expectedText       string
+		expectUnsubscribed bool
+	}{
+		{name: "GetShowsConfirmation", method: http.MethodGet, path: "/unsubscribe?token=" + token, expectedStatus: http.StatusOK, expectedText: "Stop sending promotions emails to reader@example.com?"},
+		{name: "OneClickPost", method: http.MethodPost, path: "/unsubscribe?token=" + token, body: "List-Unsubscribe=One-Click", expectedStatus: http.StatusOK, expectedText: "You are unsubscribed", expectUnsubscribed: true},
+		{name: "GetRejectsForgedToken", method: http.MethodGet, path: "/unsubscribe?token=forged.token", expectedStatus: http.StatusBadRequest, expectedText: "invalid"},
+		{name: "PostRejectsMissingToken", method: http.MethodPost, path: "/unsubscribe", expectedStatus: http.StatusBadRequest, expectedText: "invalid"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			suppressions := &stubSuppressionService{}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			engine := gin.New()
+			newUnsubscribeHandler(service.NewUnsubscribeService(signer, suppressions, logger), logger).register(engine)
+
+			request := httptest.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.body))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if !strings.Contains(recorder.Body.String(), testCase.expectedText) {
+				t.Fatalf("expected %q in page, got %s", testCase.expectedText, recorder.Body.String())
+			}
+			added := suppressions.lastRequest
+			if !testCase.expectUnsubscribed {
+				if added.Recipient != "" {
+					t.Fatalf("expected no suppression, got %+v", added)
+				}
+				return
+			}
+			if added.Channel != model.NotificationEmail || added.Recipient != "reader@example.com" || added.Category != "promotions" || added.Reason != model.SuppressionUnsubscribed {
+				t.Fatalf("unexpected suppression request %+v", added)
+			}
+		})
+	}
+}
+
+func TestUnsubscribeRouteRegisteredOnlyWhenConfigured(t *testing.T) {
+	t.Helper()
+	for _, enabled := range []bool{true, false} {
+		cfg := Config{
+			ListenAddr:          ":0",
+			SessionValidator:    &stubValidator{},
+			NotificationService: &stubNotificationService{},
+			AdminEmails:         []string{"admin@example.com"},
+			Logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+		}
+		if enabled {
+			cfg.UnsubscribeService = service.NewUnsubscribeService(service.NewUnsubscribeSigner("https://notify.example.com", "key"), &stubSuppressionService{}, cfg.Logger)
+		}
+		server, err := NewServer(cfg)
+		if err != nil {
+			t.Fatalf("new server: %v", err)
+		}
+		recorder := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unsubscribe?token=forged.token", nil))
+		if enabled && recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected unsubscribe route, got %d", recorder.Code)
+		}
+		if !enabled && recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected no unsubscribe route, got %d", recorder.Code)
+		}
+	}
+}
@@ -0,0 +1,560 @@
+package httpapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+const (
+	sendGridSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	sendGridTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+	maxWebhookBodyBytes     = 5 << 20
+	defaultWebhookMaxAge    = 5 * time.Minute
+)
+
+// WebhookConfig holds the secrets used to authenticate provider delivery webhooks. Each provider's
+// endpoint is registered only when its secret is configured.
+type WebhookConfig struct {
+	// PublicBaseURL is the externally visible origin Twilio signs requests against. When empty the
+	// URL is rebuilt from the request's Host and X-Forwarded-Proto headers.
+	PublicBaseURL   string
+	TwilioAuthToken string
+	// SendGridPublicKey is the base64-encoded verification key of SendGrid's signed event webhook.
+	SendGridPublicKey string
+	MailgunSigningKey string
+	// MaxSignatureAge bounds how far the timestamp signed into a SendGrid or Mailgun request may be
+	// from the current time; zero uses five minutes.
+	MaxSignatureAge time.Duration
+	// DSNToken is the bearer token required to post raw DSN messages to /webhooks/dsn.
+	DSNToken string
+	// SESTopicARN is the only SNS topic whose SES event notifications are accepted.
+	SESTopicARN string
+}
+
+type webhookHandler struct {
+	service       service.DeliveryStatusService
+	feedback      service.FeedbackService
+	inbound       service.InboundMessageService
+	config        WebhookConfig
+	sendGridKey   *ecdsa.PublicKey
+	sns           *snsVerifier
+	mailgunTokens *webhookTokenCache
+	logger        *slog.Logger
+}
+
+func newWebhookHandler(svc service.DeliveryStatusService, feedbackSvc service.FeedbackService, inboundSvc service.InboundMessageService, webhookConfig WebhookConfig, logger *slog.Logger) (*webhookHandler, error) {
+	if webhookConfig.MaxSignatureAge <= 0 {
+		webhookConfig.MaxSignatureAge = defaultWebhookMaxAge
+	}
+	handler := &webhookHandler{service: svc, feedback: feedbackSvc, inbound: inboundSvc, config: webhookConfig, sns: newSNSVerifier(), mailgunTokens: &webhookTokenCache{}, logger: logger}
+	if webhookConfig.SendGridPublicKey != "" {
+		publicKey, err := parseSendGridPublicKey(webhookConfig.SendGridPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		handler.sendGridKey = publicKey
+	}
+	return handler, nil
+}
+
+// register mounts the webhook endpoints outside the session-protected /api group; each request is
+// authenticated by its provider signature instead.
+func (handler *webhookHandler) register(engine *gin.Engine) {
+	if handler.config.TwilioAuthToken != "" {
+		engine.POST(service.TwilioStatusCallbackPath, handler.twilioStatus)
+		if handler.inbound != nil {
+			engine.POST("/webhooks/twilio/inbound", handler.twilioInbound)
+		}
+	}
+	if handler.sendGridKey != nil {
+		engine.POST("/webhooks/sendgrid/events", handler.sendGridEvents)
+	}
+	if handler.config.MailgunSigningKey != "" {
+		engine.POST("/webhooks/mailgun/events", handler.mailgunEvents)
+	}
+	if handler.config.SESTopicARN != "" {
+		engine.POST("/webhooks/ses/events", handler.sesEvents)
+	}
+	if handler.config.DSNToken != "" && handler.feedback != nil {
+		engine.POST("/webhooks/dsn", handler.dsnMessage)
+	}
+}
+
+func (handler *webhookHandler) twilioStatus(contextGin *gin.Context) {
+	if !handler.verifyTwilioRequest(contextGin) {
+		return
+	}
+
+	providerStatus := strings.ToLower(strings.TrimSpace(contextGin.Request.PostForm.Get("MessageStatus")))
+	event := service.DeliveryEvent{
+		Provider:          config.SMSProviderTwilio,
+		ProviderMessageID: contextGin.Request.PostForm.Get("MessageSid"),
+		ProviderStatus:    providerStatus,
+		Status:            twilioDeliveryStatus(providerStatus),
+		OccurredAt:        time.Now().UTC(),
+	}
+	if !handler.apply(contextGin, event) {
+		return
+	}
+	contextGin.Status(http.StatusNoContent)
+}
+
+type sendGridEvent struct {
+	Event       string `json:"event"`
+	SGMessageID string `json:"sg_message_id"`
+	SGEventID   string `json:"sg_event_id"`
+	Timestamp   int64  `json:"timestamp"`
+	Email       string `json:"email"`
+	Reason      string `json:"reason"`
+	// Type distinguishes hard bounces ("bounce") from blocks ("blocked") on bounce events.
+	Type string `json:"type"`
+}
+
+// sendGridTrackedEvents lists the SendGrid event types that describe delivery; engagement events
+// such as opens and clicks are ignored.
+var sendGridTrackedEvents = map[string]model.NotificationStatus{
+	"processed": "",
+	"deferred":  "",
+	"delivered": model.StatusDelivered,
+	"bounce":    model.StatusBounced,
+	"dropped":   model.StatusUndelivered,
+}
+
+func (handler *webhookHandler) sendGridEvents(contextGin *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(contextGin.Writer, contextGin.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if !verifySendGridSignature(handler.sendGridKey, contextGin.GetHeader(sendGridSignatureHeader), contextGin.GetHeader(sendGridTimestampHeader), payload) {
+		handler.logger.Warn("webhook_signature_invalid", "provider", config.EmailProviderSendGrid)
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "invalid signature"})
+		return
+	}
+	if _, fresh := signedTimestampFresh(contextGin.GetHeader(sendGridTimestampHeader), time.Now(), handler.config.MaxSignatureAge); !fresh {
+		handler.logger.Warn("webhook_timestamp_stale", "provider", config.EmailProviderSendGrid)
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "stale signature"})
+		return
+	}
+	var events []sendGridEvent
+	if err := json.Unmarshal(payload, &events); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+
+	for _, sendGridEvent := range events {
+		// sg_message_id extends the X-Message-Id returned at send time with a ".filter..." suffix.
+		messageID, _, _ := strings.Cut(sendGridEvent.SGMessageID, ".")
+		occurredAt := time.Unix(sendGridEvent.Timestamp, 0).UTC()
+		if feedbackType, isFeedback := sendGridFeedbackType(sendGridEvent); isFeedback {
+			report := service.FeedbackReport{
+				Provider:          config.EmailProviderSendGrid,
+				ProviderMessageID: messageID,
+				ReportID:          sendGridEvent.SGEventID,
+				Recipient:         sendGridEvent.Email,
+				Type:              feedbackType,
+				Diagnostic:        sendGridEvent.Reason,
+				OccurredAt:        occurredAt,
+			}
+			if !handler.recordFeedback(contextGin, report) {
+				return
+			}
+		}
+		status, tracked := sendGridTrackedEvents[sendGridEvent.Event]
+		if !tracked {
+			continue
+		}
+		event := service.DeliveryEvent{
+			Provider:          config.EmailProviderSendGrid,
+			ProviderMessageID: messageID,
+			ProviderStatus:    sendGridEvent.Event,
+			Status:            status,
+			OccurredAt:        occurredAt,
+		}
+		if !handler.apply(contextGin, event) {
+			return
+		}
+	}
+	contextGin.Status(http.StatusNoContent)
+}
+
+type mailgunWebhook struct {
+	Signature struct {
+		Timestamp string `json:"timestamp"`
+		Token     string `json:"token"`
+		Signature string `json:"signature"`
+	} `json:"signature"`
+	EventData struct {
+		ID             string  `json:"id"`
+		Event          string  `json:"event"`
+		Severity       string  `json:"severity"`
+		Reason         string  `json:"reason"`
+		Recipient      string  `json:"recipient"`
+		Timestamp      float64 `json:"timestamp"`
+		DeliveryStatus struct {
+			Description string `json:"description"`
+			Message     string `json:"message"`
+		} `json:"delivery-status"`
+		Message struct {
+			Headers struct {
+				MessageID string `json:"message-id"`
+			} `json:"headers"`
+		} `json:"message"`
+	} `json:"event-data"`
+}
+
+func (handler *webhookHandler) mailgunEvents(contextGin *gin.Context) {
+	var payload mailgunWebhook
+	decoder := json.NewDecoder(http.MaxBytesReader(contextGin.Writer, contextGin.Request.Body, maxWebhookBodyBytes))
+	if err := decoder.Decode(&payload); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if !verifyMailgunSignature(handler.config.MailgunSigningKey, payload.Signature.Timestamp, payload.Signature.Token, payload.Signature.Signature) {
+		handler.logger.Warn("webhook_signature_invalid", "provider", config.EmailProviderMailgun)
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "invalid signature"})
+		return
+	}
+	now := time.Now()
+	signedAt, fresh := signedTimestampFresh(payload.Signature.Timestamp, now, handler.config.MaxSignatureAge)
+	if !fresh {
+		handler.logger.Warn("webhook_timestamp_stale", "provider", config.EmailProviderMailgun)
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "stale signature"})
+		return
+	}
+	// Each Mailgun token is accepted once while its timestamp is fresh. The claim is dropped when the
+	// request fails so Mailgun's own retry is still processed.
+	if !handler.mailgunTokens.claim(payload.Signature.Token, signedAt.Add(handler.config.MaxSignatureAge), now) {
+		handler.logger.Warn("webhook_replay_rejected", "provider", config.EmailProviderMailgun)
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "replayed signature"})
+		return
+	}
+	defer func() {
+		if contextGin.Writer.Status() >= http.StatusBadRequest {
+			handler.mailgunTokens.release(payload.Signature.Token)
+		}
+	}()
+
+	// Mailgun returns "<id@domain>" when sending but reports the bare message-id header in events.
+	messageID := "<" + strings.Trim(payload.EventData.Message.Headers.MessageID, "<>") + ">"
+	occurredAt := time.Unix(int64(payload.EventData.Timestamp), 0).UTC()
+	if feedbackType, isFeedback := mailgunFeedbackType(payload.EventData.Event, payload.EventData.Severity); isFeedback {
+		diagnostic := payload.EventData.DeliveryStatus.Description
+		if diagnostic == "" {
+			diagnostic = payload.EventData.DeliveryStatus.Message
+		}
+		report := service.FeedbackReport{
+			Provider:          config.EmailProviderMailgun,
+			ProviderMessageID: messageID,
+			ReportID:          payload.EventData.ID,
+			Recipient:         payload.EventData.Recipient,
+			Type:              feedbackType,
+			Diagnostic:        diagnostic,
+			OccurredAt:        occurredAt,
+		}
+		if !handler.recordFeedback(contextGin, report) {
+			return
+		}
+	}
+
+	providerStatus, status, tracked := mailgunDeliveryStatus(payload.EventData.Event, payload.EventData.Severity)
+	if !tracked {
+		contextGin.Status(http.StatusNoContent)
+		return
+	}
+	event := service.DeliveryEvent{
+		Provider:          config.EmailProviderMailgun,
+		ProviderMessageID: messageID,
+		ProviderStatus:    providerStatus,
+		Status:            status,
+		OccurredAt:        occurredAt,
+	}
+	if !handler.apply(contextGin, event) {
+		return
+	}
+	contextGin.Status(http.StatusNoContent)
+}
+
+// apply records a delivery event and reports whether the request may continue. Events for unknown
+// messages are acknowledged so providers do not keep redelivering them.
+func (handler *webhookHandler) apply(contextGin *gin.Context, event service.DeliveryEvent) bool {
+	_, err := handler.service.ApplyDeliveryEvent(contextGin.Request.Context(), event)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrDeliveryEventUnmatched):
+		handler.logger.Warn("delivery_event_unmatched", "provider", event.Provider, "provider_message_id", event.ProviderMessageID)
+		return true
+	case errors.Is(err, service.ErrInvalidDeliveryEvent):
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	default:
+		handler.logger.Error("delivery_event_error", "provider", event.Provider, "error", err)
+		contextGin.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return false
+	}
+}
+
+// recordFeedback stores a bounce or complaint report and reports whether the request may continue.
+// Reports are dropped when no feedback service is configured.
+func (handler *webhookHandler) recordFeedback(contextGin *gin.Context, report service.FeedbackReport) bool {
+	if handler.feedback == nil {
+		return true
+	}
+	err := handler.feedback.RecordFeedback(contextGin.Request.Context(), report)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrInvalidFeedbackReport):
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	default:
+		handler.logger.Error("email_feedback_error", "provider", report.Provider, "error", err)
+		contextGin.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return false
+	}
+}
+
+// dsnMessage ingests a raw RFC 3464 delivery status notification forwarded by the mail
+// infrastructure, for example from a bounce mailbox pipe.
+func (handler *webhookHandler) dsnMessage(contextGin *gin.Context) {
+	token, hasBearer := strings.CutPrefix(contextGin.GetHeader("Authorization"), "Bearer ")
+	if !hasBearer || !hmac.Equal([]byte(strings.TrimSpace(token)), []byte(handler.config.DSNToken)) {
+		handler.logger.Warn("webhook_signature_invalid", "provider", "dsn")
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "invalid token"})
+		return
+	}
+	reports, err := service.ParseDSN(http.MaxBytesReader(contextGin.Writer, contextGin.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, report := range reports {
+		if !handler.recordFeedback(contextGin, report) {
+			return
+		}
+	}
+	contextGin.Status(http.StatusNoContent)
+}
+
+// verifyTwilioRequest parses the form body and checks X-Twilio-Signature, writing the error
+// response and returning false when the request must be rejected.
+func (handler *webhookHandler) verifyTwilioRequest(contextGin *gin.Context) bool {
+	contextGin.Request.Body = http.MaxBytesReader(contextGin.Writer, contextGin.Request.Body, maxWebhookBodyBytes)
+	if err := contextGin.Request.ParseForm(); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return false
+	}
+	expectedSignature := twilioSignature(handler.config.TwilioAuthToken, handler.publicRequestURL(contextGin.Request), contextGin.Request.PostForm)
+	if !hmac.Equal([]byte(expectedSignature), []byte(contextGin.GetHeader("X-Twilio-Signature"))) {
+		handler.logger.Warn("webhook_signature_invalid", "provider", config.SMSProviderTwilio)
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "invalid signature"})
+		return false
+	}
+	return true
+}
+
+func (handler *webhookHandler) publicRequestURL(request *http.Request) string {
+	if handler.config.PublicBaseURL != "" {
+		return strings.TrimRight(handler.config.PublicBaseURL, "/") + request.URL.RequestURI()
+	}
+	scheme := "http"
+	if request.TLS != nil {
+		scheme = "https"
+	}
+	if forwardedProto := request.Header.Get("X-Forwarded-Proto"); forwardedProto != "" {
+		scheme, _, _ = strings.Cut(forwardedProto, ",")
+		scheme = strings.TrimSpace(scheme)
+	}
+	return scheme + "://" + request.Host + request.URL.RequestURI()
+}
+
+func twilioDeliveryStatus(providerStatus string) model.NotificationStatus {
+	switch providerStatus {
+	case "delivered":
+		return model.StatusDelivered
+	case "undelivered", "failed":
+		return model.StatusUndelivered
+	default:
+		return ""
+	}
+}
+
+func mailgunDeliveryStatus(eventName string, severity string) (string, model.NotificationStatus, bool) {
+	switch eventName {
+	case "accepted":
+		return eventName, "", true
+	case "delivered":
+		return eventName, model.StatusDelivered, true
+	case "rejected":
+		return eventName, model.StatusUndelivered, true
+	case "failed":
+		if severity == "permanent" {
+			return "failed_permanent", model.StatusBounced, true
+		}
+		return "failed_temporary", "", true
+	default:
+		return "", "", false
+	}
+}
+
+// sendGridFeedbackType maps bounce and spam report events to feedback. Blocks are reported as bounce
+// events of type "blocked" and usually clear up, so they count as soft bounces.
+func sendGridFeedbackType(event sendGridEvent) (model.FeedbackType, bool) {
+	switch event.Event {
+	case "bounce":
+		if event.Type == "blocked" {
+			return model.FeedbackSoftBounce, true
+		}
+		return model.FeedbackHardBounce, true
+	case "spamreport":
+		return model.FeedbackComplaint, true
+	default:
+		return "", false
+	}
+}
+
+func mailgunFeedbackType(eventName string, severity string) (model.FeedbackType, bool) {
+	switch eventName {
+	case "complained":
+		return model.FeedbackComplaint, true
+	case "failed":
+		if severity == "permanent" {
+			return model.FeedbackHardBounce, true
+		}
+		return model.FeedbackSoftBounce, true
+	default:
+		return "", false
+	}
+}
+
+// twilioSignature computes X-Twilio-Signature: the base64 HMAC-SHA1 of the full request URL
+// followed by every POST parameter name and value, sorted by name.
+func twilioSignature(authToken string, requestURL string, form url.Values) string {
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var builder strings.Builder
+	builder.WriteString(requestURL)
+	for _, key := range keys {
+		values := append([]string(nil), form[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			builder.WriteString(key)
+			builder.WriteString(value)
+		}
+	}
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(builder.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func parseSendGridPublicKey(encoded string) (*ecdsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decode sendgrid webhook public key: %w", err)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse sendgrid webhook public key: %w", err)
+	}
+	publicKey, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("sendgrid webhook public key must be an ECDSA key")
+	}
+	return publicKey, nil
+}
+
+// verifySendGridSignature checks SendGrid's ECDSA signature over the timestamp header followed by
+// the raw request body.
+func verifySendGridSignature(publicKey *ecdsa.PublicKey, signature string, timestamp string, payload []byte) bool {
+	if publicKey == nil || signature == "" || timestamp == "" {
+		return false
+	}
+	decodedSignature, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256(append([]byte(timestamp), payload...))
+	return ecdsa.VerifyASN1(publicKey, digest[:], decodedSignature)
+}
+
+// verifyMailgunSignature checks the hex HMAC-SHA256 Mailgun computes over timestamp+token with the
+// account's webhook signing key.
+func verifyMailgunSignature(signingKey string, timestamp string, token string, signature string) bool {
+	if signingKey == "" || timestamp == "" || token == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(timestamp + token))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
+
+// signedTimestampFresh parses a signed Unix timestamp and reports whether it lies within maxAge of
+// now in either direction, which bounds how long a captured request can be replayed.
+func signedTimestampFresh(timestamp string, now time.Time, maxAge time.Duration) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(timestamp), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	signedAt := time.Unix(seconds, 0)
+	skew := now.Sub(signedAt)
+	return signedAt, skew <= maxAge && skew >= -maxAge
+}
+
+// webhookTokenCache remembers the single-use tokens of signed webhook requests until their
+// timestamps go stale. It is kept in memory, so each replica rejects the replays it sees itself.
+type webhookTokenCache struct {
+	mutex    sync.Mutex
+	expiries map[string]time.Time
+}
+
+// claim records the token and reports false when it was already claimed and has not expired.
+func (cache *webhookTokenCache) claim(token string, expiresAt time.Time, now time.Time) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if cache.expiries == nil {
+		cache.expiries = make(map[string]time.Time)
+	}
+	for cachedToken, expiry := range cache.expiries {
+		if !expiry.After(now) {
+			delete(cache.expiries, cachedToken)
+		}
+	}
+	if _, claimed := cache.expiries[token]; claimed {
+		return false
+	}
+	cache.expiries[token] = expiresAt
+	return true
+}
+
+func (cache *webhookTokenCache) release(token string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	delete(cache.expiries, token)
+}
@@ -0,0 +1,43 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/service"
+)
+
+// emptyTwiML acknowledges an inbound message without asking Twilio to reply; HELP replies are sent
+// through the configured SmsSender instead so they are logged like any other outbound message.
+const emptyTwiML = `<?xml version="1.0" encoding="UTF-8"?><Response></Response>`
+
+// twilioInbound receives messages sent to the Twilio number, configured as the number's
+// "A message comes in" webhook.
+func (handler *webhookHandler) twilioInbound(contextGin *gin.Context) {
+	if !handler.verifyTwilioRequest(contextGin) {
+		return
+	}
+	form := contextGin.Request.PostForm
+	_, err := handler.inbound.ReceiveMessage(contextGin.Request.Context(), service.InboundSMS{
+		Provider:          config.SMSProviderTwilio,
+		ProviderMessageID: form.Get("MessageSid"),
+		From:              form.Get("From"),
+		To:                form.Get("To"),
+		Body:              form.Get("Body"),
+		ReceivedAt:        time.Now().UTC(),
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrInvalidInboundMessage):
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	default:
+		handler.logger.Error("inbound_sms_error", "provider", config.SMSProviderTwilio, "error", err)
+		contextGin.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	contextGin.Data(http.StatusOK, "text/xml; charset=utf-8", []byte(emptyTwiML))
+}
@@ -0,0 +1,342 @@
+package httpapi
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+)
+
+// snsHostPattern matches the only hosts SNS signing certificates and subscription URLs are served from.
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsMessage is the envelope SNS posts to HTTPS subscribers.
+type snsMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+}
+
+// snsVerifier checks SNS message signatures. The certificate fetch and subscription confirmation
+// go through replaceable functions so tests do not reach AWS.
+type snsVerifier struct {
+	fetchCertificate    func(ctx context.Context, certURL string) ([]byte, error)
+	confirmSubscription func(ctx context.Context, subscribeURL string) error
+
+	mutex        sync.Mutex
+	certificates map[string]*rsa.PublicKey
+}
+
+func newSNSVerifier() *snsVerifier {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return &snsVerifier{
+		fetchCertificate: func(ctx context.Context, certURL string) ([]byte, error) {
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+			if err != nil {
+				return nil, err
+			}
+			response, err := client.Do(request)
+			if err != nil {
+				return nil, err
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("fetch signing certificate: status %d", response.StatusCode)
+			}
+			return io.ReadAll(io.LimitReader(response.Body, 64<<10))
+		},
+		confirmSubscription: func(ctx context.Context, subscribeURL string) error {
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, subscribeURL, nil)
+			if err != nil {
+				return err
+			}
+			response, err := client.Do(request)
+			if err != nil {
+				return err
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				return fmt.Errorf("confirm subscription: status %d", response.StatusCode)
+			}
+			return nil
+		},
+		certificates: make(map[string]*rsa.PublicKey),
+	}
+}
+
+// verify checks the message signature against the certificate SNS names in SigningCertURL.
+func (verifier *snsVerifier) verify(ctx context.Context, message snsMessage) error {
+	if !isSNSURL(message.SigningCertURL) {
+		return fmt.Errorf("untrusted signing certificate url %q", message.SigningCertURL)
+	}
+	var hash crypto.Hash
+	var digest []byte
+	stringToSign := []byte(snsStringToSign(message))
+	switch message.SignatureVersion {
+	case "1":
+		sum := sha1.Sum(stringToSign)
+		hash, digest = crypto.SHA1, sum[:]
+	case "2":
+		sum := sha256.Sum256(stringToSign)
+		hash, digest = crypto.SHA256, sum[:]
+	default:
+		return fmt.Errorf("unsupported signature version %q", message.SignatureVersion)
+	}
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	publicKey, err := verifier.publicKey(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	return rsa.VerifyPKCS1v15(publicKey, hash, digest, signature)
+}
+
+func (verifier *snsVerifier) publicKey(ctx context.Context, certURL string) (*rsa.PublicKey, error) {
+	verifier.mutex.Lock()
+	cached, found := verifier.certificates[certURL]
+	verifier.mutex.Unlock()
+	if found {
+		return cached, nil
+	}
+
+	pemBytes, err := verifier.fetchCertificate(ctx, certURL)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("signing certificate is not PEM encoded")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signing certificate: %w", err)
+	}
+	publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("signing certificate does not hold an RSA key")
+	}
+	verifier.mutex.Lock()
+	verifier.certificates[certURL] = publicKey
+	verifier.mutex.Unlock()
+	return publicKey, nil
+}
+
+func isSNSURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == "https" && snsHostPattern.MatchString(parsed.Hostname())
+}
+
+// snsStringToSign builds the canonical "Name\nValue\n" sequence SNS signs. Notifications sign their
+// Subject only when present; subscription messages sign SubscribeURL and Token instead.
+func snsStringToSign(message snsMessage) string {
+	var fields [][2]string
+	if message.Type == "Notification" {
+		fields = append(fields, [2]string{"Message", message.Message}, [2]string{"MessageId", message.MessageID})
+		if message.Subject != "" {
+			fields = append(fields, [2]string{"Subject", message.Subject})
+		}
+		fields = append(fields, [2]string{"Timestamp", message.Timestamp}, [2]string{"TopicArn", message.TopicArn}, [2]string{"Type", message.Type})
+	} else {
+		fields = append(fields,
+			[2]string{"Message", message.Message},
+			[2]string{"MessageId", message.MessageID},
+			[2]string{"SubscribeURL", message.SubscribeURL},
+			[2]string{"Timestamp", message.Timestamp},
+			[2]string{"Token", message.Token},
+			[2]string{"TopicArn", message.TopicArn},
+			[2]string{"Type", message.Type},
+		)
+	}
+	var builder strings.Builder
+	for _, field := range fields {
+		builder.WriteString(field[0])
+		builder.WriteString("\n")
+		builder.WriteString(field[1])
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// sesEvent covers both SES notification formats: identity notifications set notificationType,
+// configuration set event destinations set eventType.
+type sesEvent struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Mail             struct {
+		MessageID string `json:"messageId"`
+	} `json:"mail"`
+	Bounce struct {
+		FeedbackID        string `json:"feedbackId"`
+		BounceType        string `json:"bounceType"`
+		BounceSubType     string `json:"bounceSubType"`
+		Timestamp         string `json:"timestamp"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		FeedbackID            string `json:"feedbackId"`
+		Timestamp             string `json:"timestamp"`
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Delivery struct {
+		Timestamp string `json:"timestamp"`
+	} `json:"delivery"`
+}
+
+// sesEvents receives SES bounce, complaint and delivery notifications relayed by SNS.
+func (handler *webhookHandler) sesEvents(contextGin *gin.Context) {
+	var message snsMessage
+	decoder := json.NewDecoder(http.MaxBytesReader(contextGin.Writer, contextGin.Request.Body, maxWebhookBodyBytes))
+	if err := decoder.Decode(&message); err != nil {
+		contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if message.TopicArn != handler.config.SESTopicARN {
+		handler.logger.Warn("webhook_topic_unexpected", "provider", config.EmailProviderSES, "topic_arn", message.TopicArn)
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "unexpected topic"})
+		return
+	}
+	if err := handler.sns.verify(contextGin.Request.Context(), message); err != nil {
+		handler.logger.Warn("webhook_signature_invalid", "provider", config.EmailProviderSES, "error", err)
+		contextGin.JSON(http.StatusForbidden, gin.H{"error": "invalid signature"})
+		return
+	}
+
+	switch message.Type {
+	case "SubscriptionConfirmation":
+		if !isSNSURL(message.SubscribeURL) {
+			contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscribe url"})
+			return
+		}
+		if err := handler.sns.confirmSubscription(contextGin.Request.Context(), message.SubscribeURL); err != nil {
+			handler.logger.Error("sns_subscription_confirm_failed", "topic_arn", message.TopicArn, "error", err)
+			contextGin.JSON(http.StatusBadGateway, gin.H{"error": "subscription confirmation failed"})
+			return
+		}
+		handler.logger.Info("sns_subscription_confirmed", "topic_arn", message.TopicArn)
+	case "Notification":
+		var event sesEvent
+		if err := json.Unmarshal([]byte(message.Message), &event); err != nil {
+			contextGin.JSON(http.StatusBadRequest, gin.H{"error": "invalid ses event"})
+			return
+		}
+		if !handler.applySESEvent(contextGin, event) {
+			return
+		}
+	}
+	contextGin.Status(http.StatusNoContent)
+}
+
+func (handler *webhookHandler) applySESEvent(contextGin *gin.Context, event sesEvent) bool {
+	eventType := event.NotificationType
+	if eventType == "" {
+		eventType = event.EventType
+	}
+	messageID := event.Mail.MessageID
+	switch eventType {
+	case "Bounce":
+		occurredAt := parseSESTimestamp(event.Bounce.Timestamp)
+		feedbackType := model.FeedbackSoftBounce
+		deliveryEvent := service.DeliveryEvent{
+			Provider:          config.EmailProviderSES,
+			ProviderMessageID: messageID,
+			ProviderStatus:    "bounce_" + strings.ToLower(event.Bounce.BounceType),
+			OccurredAt:        occurredAt,
+		}
+		if event.Bounce.BounceType == "Permanent" {
+			feedbackType = model.FeedbackHardBounce
+			deliveryEvent.Status = model.StatusBounced
+		}
+		for _, recipient := range event.Bounce.BouncedRecipients {
+			diagnostic := recipient.DiagnosticCode
+			if diagnostic == "" {
+				diagnostic = event.Bounce.BounceSubType
+			}
+			report := service.FeedbackReport{
+				Provider:          config.EmailProviderSES,
+				ProviderMessageID: messageID,
+				ReportID:          event.Bounce.FeedbackID,
+				Recipient:         recipient.EmailAddress,
+				Type:              feedbackType,
+				Diagnostic:        diagnostic,
+				OccurredAt:        occurredAt,
+			}
+			if !handler.recordFeedback(contextGin, report) {
+				return false
+			}
+		}
+		return handler.apply(contextGin, deliveryEvent)
+	case "Complaint":
+		occurredAt := parseSESTimestamp(event.Complaint.Timestamp)
+		for _, recipient := range event.Complaint.ComplainedRecipients {
+			report := service.FeedbackReport{
+				Provider:          config.EmailProviderSES,
+				ProviderMessageID: messageID,
+				ReportID:          event.Complaint.FeedbackID,
+				Recipient:         recipient.EmailAddress,
+				Type:              model.FeedbackComplaint,
+				Diagnostic:        event.Complaint.ComplaintFeedbackType,
+				OccurredAt:        occurredAt,
+			}
+			if !handler.recordFeedback(contextGin, report) {
+				return false
+			}
+		}
+		return true
+	case "Delivery":
+		return handler.apply(contextGin, service.DeliveryEvent{
+			Provider:          config.EmailProviderSES,
+			ProviderMessageID: messageID,
+			ProviderStatus:    "delivery",
+			Status:            model.StatusDelivered,
+			OccurredAt:        parseSESTimestamp(event.Delivery.Timestamp),
+		})
+	default:
+		return true
+	}
+}
+
+func parseSESTimestamp(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Now().UTC()
+	}
+	return parsed.UTC()
+}
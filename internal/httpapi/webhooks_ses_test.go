@@ -0,0 +1,188 @@
+package httpapi
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/pinguin/internal/model"
+	"log/slog"
+)
+
+const (
+	testSESTopicARN = "arn:aws:sns:us-east-1:123456789012:ses-events"
+	testSNSCertURL  = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+)
+
+func TestSESEventWebhook(t *testing.T) {
+	t.Helper()
+	privateKey, certificatePEM := newTestSNSCertificate(t)
+
+	bounce := `{"notificationType":"Bounce","mail":{"messageId":"ses-1"},"bounce":{"feedbackId":"ses-feedback-1","bounceType":"Permanent","bounceSubType":"General",` +
+		`"timestamp":"2024-01-01T10:00:00.000Z","bouncedRecipients":[{"emailAddress":"gone@example.com","diagnosticCode":"smtp; 550 5.1.1"}]}}`
+	transient := `{"eventType":"Bounce","mail":{"messageId":"ses-1"},"bounce":{"feedbackId":"ses-feedback-1","bounceType":"Transient","bounceSubType":"MailboxFull",` +
+		`"timestamp":"2024-01-01T10:00:00.000Z","bouncedRecipients":[{"emailAddress":"full@example.com"}]}}`
+	complaint := `{"notificationType":"Complaint","mail":{"messageId":"ses-1"},"complaint":{"feedbackId":"ses-feedback-1","timestamp":"2024-01-01T10:00:00.000Z",` +
+		`"complaintFeedbackType":"abuse","complainedRecipients":[{"emailAddress":"angry@example.com"}]}}`
+	delivery := `{"notificationType":"Delivery","mail":{"messageId":"ses-1"},"delivery":{"timestamp":"2024-01-01T10:00:00.000Z"}}`
+
+	testCases := []struct {
+		name             string
+		message          snsMessage
+		tamper           bool
+		expectedStatus   int
+		expectedDelivery model.NotificationStatus
+		expectedFeedback model.FeedbackType
+		expectedConfirm  bool
+	}{
+		{name: "PermanentBounce", message: snsNotification(bounce), expectedStatus: http.StatusNoContent, expectedDelivery: model.StatusBounced, expectedFeedback: model.FeedbackHardBounce},
+		{name: "TransientBounce", message: snsNotification(transient), expectedStatus: http.StatusNoContent, expectedFeedback: model.FeedbackSoftBounce},
+		{name: "Complaint", message: snsNotification(complaint), expectedStatus: http.StatusNoContent, expectedFeedback: model.FeedbackComplaint},
+		{name: "Delivery", message: snsNotification(delivery), expectedStatus: http.StatusNoContent, expectedDelivery: model.StatusDelivered},
+		{
+			name: "SubscriptionConfirmation",
+			message: snsMessage{
+				Type:         "SubscriptionConfirmation",
+				MessageID:    "sub-1",
+				Token:        "token",
+				TopicArn:     testSESTopicARN,
+				Message:      "You have chosen to subscribe",
+				Timestamp:    "2024-01-01T10:00:00.000Z",
+				SubscribeURL: "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=token",
+			},
+			expectedStatus:  http.StatusNoContent,
+			expectedConfirm: true,
+		},
+		{name: "TamperedMessage", message: snsNotification(bounce), tamper: true, expectedStatus: http.StatusForbidden},
+		{name: "UnexpectedTopic", message: snsMessage{Type: "Notification", TopicArn: "arn:aws:sns:us-east-1:123456789012:other", Message: delivery}, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := testCase.message
+			message.SignatureVersion = "2"
+			message.SigningCertURL = testSNSCertURL
+			digest := sha256.Sum256([]byte(snsStringToSign(message)))
+			signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+			if err != nil {
+				t.Fatalf("sign message: %v", err)
+			}
+			message.Signature = base64.StdEncoding.EncodeToString(signature)
+			if testCase.tamper {
+				message.Message = strings.Replace(message.Message, "Permanent", "Transient", 1)
+			}
+
+			deliveryService := &stubDeliveryStatusService{}
+			feedbackService := &stubFeedbackService{}
+			handler, err := newWebhookHandler(deliveryService, feedbackService, nil, WebhookConfig{SESTopicARN: testSESTopicARN}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatalf("webhook handler: %v", err)
+			}
+			handler.sns.fetchCertificate = func(_ context.Context, certURL string) ([]byte, error) {
+				if certURL != testSNSCertURL {
+					t.Fatalf("unexpected certificate url %s", certURL)
+				}
+				return certificatePEM, nil
+			}
+			var confirmed string
+			handler.sns.confirmSubscription = func(_ context.Context, subscribeURL string) error {
+				confirmed = subscribeURL
+				return nil
+			}
+			engine := gin.New()
+			handler.register(engine)
+
+			body, err := json.Marshal(message)
+			if err != nil {
+				t.Fatalf("encode message: %v", err)
+			}
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/webhooks/ses/events", strings.NewReader(string(body))))
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if (confirmed != "") != testCase.expectedConfirm {
+				t.Fatalf("unexpected subscription confirmation %q", confirmed)
+			}
+			if testCase.expectedFeedback == "" && len(feedbackService.reports) != 0 {
+				t.Fatalf("expected no feedback, got %+v", feedbackService.reports)
+			}
+			if testCase.expectedFeedback != "" {
+				if len(feedbackService.reports) != 1 || feedbackService.reports[0].Type != testCase.expectedFeedback || feedbackService.reports[0].ProviderMessageID != "ses-1" || feedbackService.reports[0].ReportID != "ses-feedback-1" {
+					t.Fatalf("unexpected feedback %+v", feedbackService.reports)
+				}
+			}
+			var deliveryStatus model.NotificationStatus
+			for _, event := range deliveryService.events {
+				if event.Status != "" {
+					deliveryStatus = event.Status
+				}
+			}
+			if deliveryStatus != testCase.expectedDelivery {
+				t.Fatalf("expected delivery status %q, got %+v", testCase.expectedDelivery, deliveryService.events)
+			}
+		})
+	}
+}
+
+func TestSNSVerifierRejectsForeignCertificateHost(t *testing.T) {
+	t.Helper()
+	verifier := newSNSVerifier()
+	verifier.fetchCertificate = func(context.Context, string) ([]byte, error) {
+		t.Fatalf("certificate must not be fetched from an untrusted host")
+		return nil, nil
+	}
+	for _, certURL := range []string{"https://attacker.example.com/cert.pem", "http://sns.us-east-1.amazonaws.com/cert.pem", "https://sns.us-east-1.amazonaws.com.attacker.example/cert.pem"} {
+		message := snsNotification("{}")
+		message.SignatureVersion = "1"
+		message.SigningCertURL = certURL
+		if err := verifier.verify(context.Background(), message); err == nil {
+			t.Fatalf("expected %s to be rejected", certURL)
+		}
+	}
+}
+
+func snsNotification(payload string) snsMessage {
+	return snsMessage{
+		Type:      "Notification",
+		MessageID: "msg-1",
+		TopicArn:  testSESTopicARN,
+		Message:   payload,
+		Timestamp: "2024-01-01T10:00:01.000Z",
+	}
+}
+
+func newTestSNSCertificate(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return privateKey, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER})
+}
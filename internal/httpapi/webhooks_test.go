@@ -0,0 +1,404 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+func TestTwilioSignatureMatchesDocumentedVector(t *testing.T) {
+	t.Helper()
+	form := url.Values{
+		"CallSid": {"CA1234567890ABCDE"},
+		"Caller":  {"+12349013030"},
+		"Digits":  {"1234"},
+		"From":    {"+12349013030"},
+		"To":      {"+18005551212"},
+	}
+	signature := twilioSignature("12345", "https://mycompany.com/myapp.php?foo=1&bar=2", form)
+	if signature != "0/KCTR6DLpKmkAf8muzZqo1nDgQ=" {
+		t.Fatalf("unexpected signature %s", signature)
+	}
+}
+
+func TestTwilioStatusWebhook(t *testing.T) {
+	t.Helper()
+	form := url.Values{
+		"MessageSid":    {"SM123"},
+		"MessageStatus": {"undelivered"},
+		"ErrorCode":     {"30003"},
+	}
+	validSignature := twilioSignature("twilio-token", "https://notify.example.com/webhooks/twilio/status", form)
+
+	testCases := []struct {
+		name           string
+		signature      string
+		expectedStatus int
+		expectEvent    bool
+	}{
+		{name: "ValidSignature", signature: validSignature, expectedStatus: http.StatusNoContent, expectEvent: true},
+		{name: "InvalidSignature", signature: "bogus", expectedStatus: http.StatusForbidden},
+		{name: "MissingSignature", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			deliveryService := &stubDeliveryStatusService{}
+			server := newWebhookTestServer(t, deliveryService, nil, WebhookConfig{
+				PublicBaseURL:   "https://notify.example.com",
+				TwilioAuthToken: "twilio-token",
+			})
+
+			request := httptest.NewRequest(http.MethodPost, "/webhooks/twilio/status", strings.NewReader(form.Encode()))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if testCase.signature != "" {
+				request.Header.Set("X-Twilio-Signature", testCase.signature)
+			}
+			recorder := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if !testCase.expectEvent {
+				if len(deliveryService.events) != 0 {
+					t.Fatalf("expected no events, got %+v", deliveryService.events)
+				}
+				return
+			}
+			if len(deliveryService.events) != 1 {
+				t.Fatalf("expected one event, got %d", len(deliveryService.events))
+			}
+			event := deliveryService.events[0]
+			if event.Provider != "twilio" || event.ProviderMessageID != "SM123" || event.Status != model.StatusUndelivered || event.ProviderStatus != "undelivered" {
+				t.Fatalf("unexpected event %+v", event)
+			}
+		})
+	}
+}
+
+func TestSendGridEventWebhook(t *testing.T) {
+	t.Helper()
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	payload := []byte(`[` +
+		`{"event":"processed","sg_message_id":"msg-1.filter0001.1","timestamp":1700000000},` +
+		`{"event":"open","sg_message_id":"msg-1.filter0001.1","timestamp":1700000050},` +
+		`{"event":"bounce","type":"bounce","sg_event_id":"sg-event-bounce","email":"user@example.com","reason":"550 unknown user","sg_message_id":"msg-1.filter0001.1","timestamp":1700000100},` +
+		`{"event":"spamreport","email":"other@example.com","sg_message_id":"msg-1.filter0001.1","timestamp":1700000150}` +
+		`]`)
+	sign := func(timestamp string) string {
+		digest := sha256.Sum256(append([]byte(timestamp), payload...))
+		rawSignature, err := ecdsa.SignASN1(rand.Reader, privateKey, digest[:])
+		if err != nil {
+			t.Fatalf("sign payload: %v", err)
+		}
+		return base64.StdEncoding.EncodeToString(rawSignature)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	staleTimestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	testCases := []struct {
+		name           string
+		signature      string
+		timestamp      string
+		body           []byte
+		expectedStatus int
+		expectedEvents int
+	}{
+		{name: "ValidSignature", signature: sign(timestamp), timestamp: timestamp, body: payload, expectedStatus: http.StatusNoContent, expectedEvents: 2},
+		{name: "TamperedBody", signature: sign(timestamp), timestamp: timestamp, body: bytes.Replace(payload, []byte("bounce"), []byte("delivered"), 1), expectedStatus: http.StatusForbidden},
+		{name: "StaleTimestamp", signature: sign(staleTimestamp), timestamp: staleTimestamp, body: payload, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			deliveryService := &stubDeliveryStatusService{}
+			feedbackService := &stubFeedbackService{}
+			server := newWebhookTestServer(t, deliveryService, feedbackService, WebhookConfig{
+				SendGridPublicKey: base64.StdEncoding.EncodeToString(publicKeyDER),
+			})
+
+			request := httptest.NewRequest(http.MethodPost, "/webhooks/sendgrid/events", bytes.NewReader(testCase.body))
+			request.Header.Set(sendGridSignatureHeader, testCase.signature)
+			request.Header.Set(sendGridTimestampHeader, testCase.timestamp)
+			recorder := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if len(deliveryService.events) != testCase.expectedEvents {
+				t.Fatalf("expected %d events, got %+v", testCase.expectedEvents, deliveryService.events)
+			}
+			if testCase.expectedEvents == 0 {
+				return
+			}
+			last := deliveryService.events[len(deliveryService.events)-1]
+			if last.ProviderMessageID != "msg-1" || last.Status != model.StatusBounced || last.Provider != "sendgrid" {
+				t.Fatalf("unexpected bounce event %+v", last)
+			}
+			if len(feedbackService.reports) != 2 {
+				t.Fatalf("expected two feedback reports, got %+v", feedbackService.reports)
+			}
+			bounce, complaint := feedbackService.reports[0], feedbackService.reports[1]
+			if bounce.Recipient != "user@example.com" || bounce.Type != model.FeedbackHardBounce || bounce.ProviderMessageID != "msg-1" || bounce.ReportID != "sg-event-bounce" || bounce.Diagnostic != "550 unknown user" {
+				t.Fatalf("unexpected bounce feedback %+v", bounce)
+			}
+			if complaint.Recipient != "other@example.com" || complaint.Type != model.FeedbackComplaint {
+				t.Fatalf("unexpected complaint feedback %+v", complaint)
+			}
+		})
+	}
+}
+
+func TestMailgunEventWebhook(t *testing.T) {
+	t.Helper()
+	sign := func(timestamp string, token string) string {
+		mac := hmac.New(sha256.New, []byte("mailgun-key"))
+		mac.Write([]byte(timestamp + token))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	staleTimestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	testCases := []struct {
+		name             string
+		timestamp        string
+		signature        string
+		event            string
+		severity         string
+		expectedStatus   int
+		expectEvent      bool
+		expectedState    model.NotificationStatus
+		expectedFeedback model.FeedbackType
+	}{
+		{name: "Delivered", timestamp: timestamp, signature: sign(timestamp, "tok"), event: "delivered", expectedStatus: http.StatusNoContent, expectEvent: true, expectedState: model.StatusDelivered},
+		{name: "PermanentFailureBounces", timestamp: timestamp, signature: sign(timestamp, "tok"), event: "failed", severity: "permanent", expectedStatus: http.StatusNoContent, expectEvent: true, expectedState: model.StatusBounced, expectedFeedback: model.FeedbackHardBounce},
+		{name: "TemporaryFailureSoftBounces", timestamp: timestamp, signature: sign(timestamp, "tok"), event: "failed", severity: "temporary", expectedStatus: http.StatusNoContent, expectEvent: true, expectedFeedback: model.FeedbackSoftBounce},
+		{name: "ComplaintRecordsFeedback", timestamp: timestamp, signature: sign(timestamp, "tok"), event: "complained", expectedStatus: http.StatusNoContent, expectedFeedback: model.FeedbackComplaint},
+		{name: "EngagementEventIgnored", timestamp: timestamp, signature: sign(timestamp, "tok"), event: "opened", expectedStatus: http.StatusNoContent},
+		{name: "StaleTimestamp", timestamp: staleTimestamp, signature: sign(staleTimestamp, "tok"), event: "delivered", expectedStatus: http.StatusForbidden},
+		{name: "InvalidSignature", timestamp: timestamp, signature: sign(timestamp, "other"), event: "delivered", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			deliveryService := &stubDeliveryStatusService{}
+			feedbackService := &stubFeedbackService{}
+			server := newWebhookTestServer(t, deliveryService, feedbackService, WebhookConfig{MailgunSigningKey: "mailgun-key"})
+
+			body := `{"signature":{"timestamp":"` + testCase.timestamp + `","token":"tok","signature":"` + testCase.signature + `"},` +
+				`"event-data":{"id":"mg-event-1","event":"` + testCase.event + `","severity":"` + testCase.severity + `","timestamp":1700000000.5,` +
+				`"recipient":"user@example.com","delivery-status":{"description":"mailbox full"},` +
+				`"message":{"headers":{"message-id":"20240101.abc@mg.example.com"}}}}`
+			request := httptest.NewRequest(http.MethodPost, "/webhooks/mailgun/events", strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if testCase.expectedFeedback == "" && len(feedbackService.reports) != 0 {
+				t.Fatalf("expected no feedback, got %+v", feedbackService.reports)
+			}
+			if testCase.expectedFeedback != "" {
+				if len(feedbackService.reports) != 1 {
+					t.Fatalf("expected one feedback report, got %+v", feedbackService.reports)
+				}
+				report := feedbackService.reports[0]
+				if report.Type != testCase.expectedFeedback || report.Recipient != "user@example.com" || report.Diagnostic != "mailbox full" || report.ProviderMessageID != "<20240101.abc@mg.example.com>" || report.ReportID != "mg-event-1" {
+					t.Fatalf("unexpected feedback %+v", report)
+				}
+			}
+			if !testCase.expectEvent {
+				if len(deliveryService.events) != 0 {
+					t.Fatalf("expected no events, got %+v", deliveryService.events)
+				}
+				return
+			}
+			if len(deliveryService.events) != 1 {
+				t.Fatalf("expected one event, got %d", len(deliveryService.events))
+			}
+			event := deliveryService.events[0]
+			if event.ProviderMessageID != "<20240101.abc@mg.example.com>" || event.Status != testCase.expectedState {
+				t.Fatalf("unexpected event %+v", event)
+			}
+		})
+	}
+}
+
+func TestMailgunEventWebhookRejectsReplayedToken(t *testing.T) {
+	t.Helper()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte("mailgun-key"))
+	mac.Write([]byte(timestamp + "single-use"))
+	body := `{"signature":{"timestamp":"` + timestamp + `","token":"single-use","signature":"` + hex.EncodeToString(mac.Sum(nil)) + `"},` +
+		`"event-data":{"event":"delivered","timestamp":1700000000.5,"message":{"headers":{"message-id":"replayed@mg.example.com"}}}}`
+
+	deliveryService := &stubDeliveryStatusService{failures: 1}
+	server := newWebhookTestServer(t, deliveryService, &stubFeedbackService{}, WebhookConfig{MailgunSigningKey: "mailgun-key"})
+	post := func() int {
+		request := httptest.NewRequest(http.MethodPost, "/webhooks/mailgun/events", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	// A request that failed is retried by Mailgun with the same token and must still be processed.
+	expectedStatuses := []int{http.StatusInternalServerError, http.StatusNoContent, http.StatusForbidden}
+	for attempt, expectedStatus := range expectedStatuses {
+		if status := post(); status != expectedStatus {
+			t.Fatalf("attempt %d: expected %d, got %d", attempt+1, expectedStatus, status)
+		}
+	}
+	if len(deliveryService.events) != 2 {
+		t.Fatalf("expected the replay to be rejected before it reached the service, got %d events", len(deliveryService.events))
+	}
+}
+
+func TestWebhooksNotRegisteredWithoutSecrets(t *testing.T) {
+	t.Helper()
+	server := newWebhookTestServer(t, &stubDeliveryStatusService{}, nil, WebhookConfig{})
+	for _, path := range []string{"/webhooks/twilio/status", "/webhooks/sendgrid/events", "/webhooks/mailgun/events", "/webhooks/ses/events", "/webhooks/dsn"} {
+		recorder := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}")))
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("expected %s to be unregistered, got %d", path, recorder.Code)
+		}
+	}
+}
+
+func newWebhookTestServer(t *testing.T, deliveryService service.DeliveryStatusService, feedbackService service.FeedbackService, webhookConfig WebhookConfig) *Server {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	server, err := NewServer(Config{
+		ListenAddr:            ":0",
+		NotificationService:   &stubNotificationService{},
+		SessionValidator:      &stubValidator{err: http.ErrNoCookie},
+		DeliveryStatusService: deliveryService,
+		FeedbackService:       feedbackService,
+		Webhooks:              webhookConfig,
+		Logger:                logger,
+		AdminEmails:           []string{"user@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("server init error: %v", err)
+	}
+	return server
+}
+
+type stubDeliveryStatusService struct {
+	events []service.DeliveryEvent
+	// failures is the number of leading events answered with an error.
+	failures int
+}
+
+func (stub *stubDeliveryStatusService) ApplyDeliveryEvent(_ context.Context, event service.DeliveryEvent) (model.NotificationResponse, error) {
+	stub.events = append(stub.events, event)
+	if len(stub.events) <= stub.failures {
+		return model.NotificationResponse{}, errors.New("database unavailable")
+	}
+	return model.NotificationResponse{}, nil
+}
+
+type stubFeedbackService struct {
+	reports []service.FeedbackReport
+}
+
+func (stub *stubFeedbackService) RecordFeedback(_ context.Context, report service.FeedbackReport) error {
+	stub.reports = append(stub.reports, report)
+	return nil
+}
+
+func TestDSNWebhook(t *testing.T) {
+	t.Helper()
+	dsn := strings.Join([]string{
+		`Content-Type: multipart/report; report-type=delivery-status; boundary="B"`,
+		"",
+		"--B",
+		"Content-Type: message/delivery-status",
+		"",
+		"Reporting-MTA: dns; mx.example.com",
+		"",
+		"Final-Recipient: rfc822; gone@example.org",
+		"Action: failed",
+		"Status: 5.1.1",
+		"--B",
+		"Content-Type: text/rfc822-headers",
+		"",
+		"Message-ID: <abc@example.com>",
+		"--B--",
+		"",
+	}, "\r\n")
+
+	testCases := []struct {
+		name            string
+		authorization   string
+		body            string
+		expectedStatus  int
+		expectedReports int
+	}{
+		{name: "ValidToken", authorization: "Bearer dsn-token", body: dsn, expectedStatus: http.StatusNoContent, expectedReports: 1},
+		{name: "WrongToken", authorization: "Bearer other", body: dsn, expectedStatus: http.StatusForbidden},
+		{name: "MissingToken", body: dsn, expectedStatus: http.StatusForbidden},
+		{name: "NotADSN", authorization: "Bearer dsn-token", body: "Subject: hi\r\n\r\nhello", expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			feedbackService := &stubFeedbackService{}
+			server := newWebhookTestServer(t, &stubDeliveryStatusService{}, feedbackService, WebhookConfig{DSNToken: "dsn-token"})
+
+			request := httptest.NewRequest(http.MethodPost, "/webhooks/dsn", strings.NewReader(testCase.body))
+			if testCase.authorization != "" {
+				request.Header.Set("Authorization", testCase.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			server.httpServer.Handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != testCase.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if len(feedbackService.reports) != testCase.expectedReports {
+				t.Fatalf("expected %d reports, got %+v", testCase.expectedReports, feedbackService.reports)
+			}
+			if testCase.expectedReports == 0 {
+				return
+			}
+			report := feedbackService.reports[0]
+			if report.Recipient != "gone@example.org" || report.Type != model.FeedbackHardBounce || report.ProviderMessageID != "<abc@example.com>" {
+				t.Fatalf("unexpected report %+v", report)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttemptErrorClass groups delivery errors by what went wrong, so a bad recipient can be told
+// apart from a provider outage without reading the error message.
+type AttemptErrorClass string
+
+const (
+	// AttemptErrorRecipient covers recipients that were rejected, suppressed, or marked undeliverable.
+	AttemptErrorRecipient AttemptErrorClass = "recipient"
+	// AttemptErrorRejected covers permanent provider refusals: other HTTP 4xx and SMTP 5xx replies.
+	AttemptErrorRejected AttemptErrorClass = "rejected"
+	// AttemptErrorUnavailable covers provider outages: HTTP 5xx or 429, SMTP 4xx replies, and open
+	// circuit breakers.
+	AttemptErrorUnavailable AttemptErrorClass = "unavailable"
+	AttemptErrorTimeout     AttemptErrorClass = "timeout"
+	AttemptErrorNetwork     AttemptErrorClass = "network"
+	// AttemptErrorConfiguration covers problems on this side, such as disabled SMS delivery or
+	// provider credentials refused with HTTP 401 or 403.
+	AttemptErrorConfiguration AttemptErrorClass = "configuration"
+	AttemptErrorUnknown       AttemptErrorClass = "unknown"
+)
+
+// NotificationAttempt records one delivery attempt, made inline by SendNotification or by the
+// dispatch worker. Outcome is the status the attempt left the notification in. SMTPReplyCode is
+// the reply code of a failed SMTP conversation and zero otherwise.
+type NotificationAttempt struct {
+	ID             uint               `json:"-" gorm:"primaryKey"`
+	NotificationID string             `json:"notification_id" gorm:"index;not null"`
+	AttemptedAt    time.Time          `json:"attempted_at"`
+	Provider       string             `json:"provider,omitempty"`
+	DurationMs     int64              `json:"duration_ms"`
+	Outcome        NotificationStatus `json:"outcome"`
+	ErrorClass     AttemptErrorClass  `json:"error_class,omitempty"`
+	ErrorMessage   string             `json:"error_message,omitempty"`
+	SMTPReplyCode  int                `json:"smtp_reply_code,omitempty"`
+	CreatedAt      time.Time          `json:"-"`
+}
+
+func CreateNotificationAttempt(ctx context.Context, db *gorm.DB, attempt *NotificationAttempt) error {
+	return db.WithContext(ctx).Create(attempt).Error
+}
+
+// ListNotificationAttempts returns the attempts of a notification, oldest first.
+func ListNotificationAttempts(ctx context.Context, db *gorm.DB, notificationID string) ([]NotificationAttempt, error) {
+	var attempts []NotificationAttempt
+	err := db.WithContext(ctx).
+		Where("notification_id = ?", notificationID).
+		Order("attempted_at ASC, id ASC").
+		Find(&attempts).Error
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
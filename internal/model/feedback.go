@@ -0,0 +1,159 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeedbackType classifies bounce and complaint reports received after an email was sent.
+type FeedbackType string
+
+const (
+	FeedbackHardBounce FeedbackType = "hard_bounce"
+	FeedbackSoftBounce FeedbackType = "soft_bounce"
+	FeedbackComplaint  FeedbackType = "complaint"
+)
+
+// FeedbackEvent stores one bounce or complaint report for a single recipient. NotificationID is
+// empty when the report could not be linked to a stored notification. ReportID is the provider's
+// identifier of the report; a report is stored once per provider, report ID and recipient.
+type FeedbackEvent struct {
+	ID                uint         `json:"-" gorm:"primaryKey"`
+	NotificationID    string       `json:"notification_id,omitempty" gorm:"index"`
+	Provider          string       `json:"provider" gorm:"uniqueIndex:idx_feedback_events_report"`
+	ReportID          *string      `json:"report_id,omitempty" gorm:"uniqueIndex:idx_feedback_events_report"`
+	ProviderMessageID string       `json:"provider_message_id,omitempty"`
+	Recipient         string       `json:"recipient" gorm:"index;uniqueIndex:idx_feedback_events_report"`
+	Type              FeedbackType `json:"type"`
+	Diagnostic        string       `json:"diagnostic,omitempty"`
+	OccurredAt        time.Time    `json:"occurred_at"`
+	CreatedAt         time.Time    `json:"created_at"`
+}
+
+// RecipientDeliverability aggregates the feedback received for an email address. Hard bounces and
+// complaints mark the address undeliverable; soft bounces are only counted.
+type RecipientDeliverability struct {
+	ID               uint         `json:"-" gorm:"primaryKey"`
+	Address          string       `json:"address" gorm:"uniqueIndex;not null"`
+	HardBounces      int          `json:"hard_bounces"`
+	SoftBounces      int          `json:"soft_bounces"`
+	Complaints       int          `json:"complaints"`
+	Undeliverable    bool         `json:"undeliverable" gorm:"index"`
+	LastFeedbackType FeedbackType `json:"last_feedback_type,omitempty"`
+	LastFeedbackAt   time.Time    `json:"last_feedback_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+// NormalizeEmailAddress returns the lower-cased, trimmed form used to key deliverability records.
+func NormalizeEmailAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// RecordFeedback stores a feedback event and folds it into the recipient's deliverability record
+// in one transaction. Hard bounces and complaints also suppress the address on the email channel,
+// with the provider as source, so the block shows up and can be lifted with the other suppressions.
+// A report whose ReportID was already stored for the recipient changes nothing and is reported
+// with recorded=false, so redelivered webhooks do not count twice.
+func RecordFeedback(ctx context.Context, db *gorm.DB, event *FeedbackEvent) (*RecipientDeliverability, bool, error) {
+	event.Recipient = NormalizeEmailAddress(event.Recipient)
+	var record RecipientDeliverability
+	recorded := false
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		recorded = true
+		lookupErr := tx.Where("address = ?", event.Recipient).First(&record).Error
+		if lookupErr != nil && !errors.Is(lookupErr, gorm.ErrRecordNotFound) {
+			return lookupErr
+		}
+		record.Address = event.Recipient
+		switch event.Type {
+		case FeedbackHardBounce:
+			record.HardBounces++
+			record.Undeliverable = true
+		case FeedbackSoftBounce:
+			record.SoftBounces++
+		case FeedbackComplaint:
+			record.Complaints++
+			record.Undeliverable = true
+		}
+		record.LastFeedbackType = event.Type
+		record.LastFeedbackAt = event.OccurredAt
+		if err := tx.Save(&record).Error; err != nil {
+			return err
+		}
+		reason, suppresses := feedbackSuppressionReason(event.Type)
+		if !suppresses {
+			return nil
+		}
+		return UpsertSuppression(ctx, tx, &Suppression{
+			Channel:   NotificationEmail,
+			Recipient: event.Recipient,
+			Reason:    reason,
+			Source:    event.Provider,
+		})
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &record, recorded, nil
+}
+
+func feedbackSuppressionReason(feedbackType FeedbackType) (SuppressionReason, bool) {
+	switch feedbackType {
+	case FeedbackHardBounce:
+		return SuppressionBounced, true
+	case FeedbackComplaint:
+		return SuppressionComplaint, true
+	default:
+		return "", false
+	}
+}
+
+// GetRecipientDeliverability returns the deliverability record for an address.
+func GetRecipientDeliverability(ctx context.Context, db *gorm.DB, address string) (*RecipientDeliverability, error) {
+	var record RecipientDeliverability
+	if err := db.WithContext(ctx).Where("address = ?", NormalizeEmailAddress(address)).First(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// FindUndeliverableAddresses returns the records among addresses that are marked undeliverable,
+// keyed by normalized address.
+func FindUndeliverableAddresses(ctx context.Context, db *gorm.DB, addresses []string) (map[string]RecipientDeliverability, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	normalized := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		normalized = append(normalized, NormalizeEmailAddress(address))
+	}
+	var records []RecipientDeliverability
+	if err := db.WithContext(ctx).Where("undeliverable = ? AND address IN ?", true, normalized).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[string]RecipientDeliverability, len(records))
+	for _, record := range records {
+		result[record.Address] = record
+	}
+	return result, nil
+}
+
+// ListFeedbackEvents returns the feedback recorded for a notification, oldest first.
+func ListFeedbackEvents(ctx context.Context, db *gorm.DB, notificationID string) ([]FeedbackEvent, error) {
+	var events []FeedbackEvent
+	err := db.WithContext(ctx).Where("notification_id = ?", notificationID).Order("id ASC").Find(&events).Error
+	return events, err
+}
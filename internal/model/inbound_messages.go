@@ -0,0 +1,108 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InboundKeyword classifies an inbound SMS by the carrier opt-out keyword it consists of.
+type InboundKeyword string
+
+const (
+	InboundKeywordNone  InboundKeyword = ""
+	InboundKeywordStop  InboundKeyword = "stop"
+	InboundKeywordStart InboundKeyword = "start"
+	InboundKeywordHelp  InboundKeyword = "help"
+)
+
+// inboundKeywords lists the standard carrier keywords. A message only counts as a keyword when its
+// whole body is one of them, so ordinary replies that mention "stop" are left alone.
+var inboundKeywords = map[string]InboundKeyword{
+	"STOP":        InboundKeywordStop,
+	"STOPALL":     InboundKeywordStop,
+	"UNSUBSCRIBE": InboundKeywordStop,
+	"CANCEL":      InboundKeywordStop,
+	"END":         InboundKeywordStop,
+	"QUIT":        InboundKeywordStop,
+	"START":       InboundKeywordStart,
+	"UNSTOP":      InboundKeywordStart,
+	"YES":         InboundKeywordStart,
+	"HELP":        InboundKeywordHelp,
+	"INFO":        InboundKeywordHelp,
+}
+
+// InboundMessage stores an SMS received from a recipient. Provider message IDs are unique per
+// provider so webhook retries are recorded once.
+type InboundMessage struct {
+	ID                uint           `json:"-" gorm:"primaryKey"`
+	Provider          string         `json:"provider" gorm:"uniqueIndex:idx_inbound_messages_provider_message;not null"`
+	ProviderMessageID string         `json:"provider_message_id" gorm:"uniqueIndex:idx_inbound_messages_provider_message;not null"`
+	FromNumber        string         `json:"from_number" gorm:"index;not null"`
+	ToNumber          string         `json:"to_number"`
+	Body              string         `json:"body"`
+	Keyword           InboundKeyword `json:"keyword,omitempty"`
+	ReceivedAt        time.Time      `json:"received_at" gorm:"index"`
+	CreatedAt         time.Time      `json:"created_at"`
+}
+
+// InboundMessageListFilters narrows ListInboundMessages; zero values match everything.
+type InboundMessageListFilters struct {
+	FromNumber string
+	Limit      int
+}
+
+// ClassifyInboundKeyword returns the keyword an inbound message body consists of, ignoring case,
+// surrounding whitespace and trailing punctuation.
+func ClassifyInboundKeyword(body string) InboundKeyword {
+	normalized := strings.ToUpper(strings.TrimRight(strings.TrimSpace(body), ".!"))
+	return inboundKeywords[normalized]
+}
+
+// CreateInboundMessage stores the message and reports whether it was new. A message already stored
+// under the same provider message ID is left untouched.
+func CreateInboundMessage(ctx context.Context, db *gorm.DB, message *InboundMessage) (bool, error) {
+	result := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(message)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetInboundMessage returns the message stored under a provider message ID.
+func GetInboundMessage(ctx context.Context, db *gorm.DB, provider string, providerMessageID string) (*InboundMessage, error) {
+	var message InboundMessage
+	if err := db.WithContext(ctx).Where("provider = ? AND provider_message_id = ?", provider, providerMessageID).First(&message).Error; err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// HasLaterOptOutKeyword reports whether the sender of message sent a STOP or START keyword after it.
+func HasLaterOptOutKeyword(ctx context.Context, db *gorm.DB, message InboundMessage) (bool, error) {
+	var count int64
+	err := db.WithContext(ctx).Model(&InboundMessage{}).
+		Where("from_number = ? AND keyword IN ?", message.FromNumber, []InboundKeyword{InboundKeywordStop, InboundKeywordStart}).
+		Where("(received_at > ? OR (received_at = ? AND id > ?))", message.ReceivedAt, message.ReceivedAt, message.ID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListInboundMessages returns inbound messages, newest first.
+func ListInboundMessages(ctx context.Context, db *gorm.DB, filters InboundMessageListFilters) ([]InboundMessage, error) {
+	query := db.WithContext(ctx).Model(&InboundMessage{})
+	if filters.FromNumber != "" {
+		query = query.Where("from_number = ?", NormalizeSuppressionRecipient(NotificationSMS, filters.FromNumber))
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	var messages []InboundMessage
+	if err := query.Order("received_at DESC").Order("id DESC").Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
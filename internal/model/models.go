@@ -2,11 +2,15 @@ package model
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // NotificationType enumerations: "email" or "sms".
@@ -18,11 +22,23 @@ const (
 	NotificationSMS   NotificationType = "sms"
 )
 
-// EmailAttachment carries attachment metadata used across domain layers.
+// DeliveryMode selects whether SendNotification contacts the provider before returning. The empty
+// value defers to the server's configured dispatch mode.
+type DeliveryMode string
+
+const (
+	DeliveryModeDefault DeliveryMode = ""
+	DeliveryModeEnqueue DeliveryMode = "enqueue"
+	DeliveryModeInline  DeliveryMode = "inline"
+)
+
+// EmailAttachment carries attachment metadata used across domain layers. List results leave Data
+// empty and report only SizeBytes.
 type EmailAttachment struct {
 	Filename    string `json:"filename"`
 	ContentType string `json:"content_type"`
 	Data        []byte `json:"data"`
+	SizeBytes   int64  `json:"size_bytes"`
 }
 
 // Status constants used for the Notification model.
@@ -33,13 +49,61 @@ const (
 	StatusCancelled NotificationStatus = "cancelled"
 	StatusUnknown   NotificationStatus = "unknown"
 	StatusFailed    NotificationStatus = "failed" // legacy value kept for previously persisted rows
+	// StatusDead marks a notification whose retries are exhausted. Workers no longer attempt it
+	// until an operator requeues it.
+	StatusDead NotificationStatus = "dead"
+
+	// Delivery statuses are reported asynchronously by provider webhooks after a notification is sent.
+	StatusDelivered   NotificationStatus = "delivered"
+	StatusUndelivered NotificationStatus = "undelivered"
+	StatusBounced     NotificationStatus = "bounced"
+
+	// StatusSuppressed marks a notification that was not sent because its recipients are on the suppression list.
+	StatusSuppressed NotificationStatus = "suppressed"
+)
+
+// RecipientKind identifies the header an email address was supplied in.
+type RecipientKind string
+
+// RecipientStatus captures how the mail server responded to an individual envelope recipient.
+type RecipientStatus string
+
+const (
+	RecipientTo  RecipientKind = "to"
+	RecipientCc  RecipientKind = "cc"
+	RecipientBcc RecipientKind = "bcc"
+)
+
+const (
+	RecipientPending  RecipientStatus = "pending"
+	RecipientAccepted RecipientStatus = "accepted"
+	RecipientRejected RecipientStatus = "rejected"
+	// RecipientSkipped marks an address left out of the envelope because earlier bounces or
+	// complaints made it undeliverable.
+	RecipientSkipped RecipientStatus = "skipped"
 )
 
 var ErrNotificationNotFound = errors.New("notification not found")
 
+// ErrNotificationNotRequeueable reports a requeue of a notification that is not dead or errored, or
+// that a worker is attempting right now.
+var ErrNotificationNotRequeueable = errors.New("notification must be dead or errored to be requeued")
+
+// ErrInvalidNotificationListFilter reports list filters that cannot be applied, including page
+// tokens that were not issued by ListNotifications.
+var ErrInvalidNotificationListFilter = errors.New("invalid notification list filter")
+
+const (
+	// DefaultNotificationPageSize applies when a list request leaves the page size unset.
+	DefaultNotificationPageSize = 50
+	// MaxNotificationPageSize caps the page size a list request may ask for.
+	MaxNotificationPageSize = 500
+)
+
 func CanonicalStatus(status NotificationStatus) NotificationStatus {
 	switch status {
-	case StatusQueued, StatusSent, StatusErrored, StatusCancelled, StatusUnknown:
+	case StatusQueued, StatusSent, StatusErrored, StatusCancelled, StatusUnknown, StatusDead,
+		StatusDelivered, StatusUndelivered, StatusBounced, StatusSuppressed:
 		return status
 	case StatusFailed:
 		return StatusErrored
@@ -48,9 +112,24 @@ func CanonicalStatus(status NotificationStatus) NotificationStatus {
 	}
 }
 
-// NotificationListFilters constrain List operations (e.g., by status).
+// NotificationListFilters constrain List operations. Empty fields match everything, and the time
+// ranges include their After bound and exclude their Before bound.
 type NotificationListFilters struct {
 	Statuses []NotificationStatus
+	Types    []NotificationType
+	// Recipient matches the recipient exactly; RecipientPrefix matches its start, ignoring case.
+	Recipient       string
+	RecipientPrefix string
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	ScheduledAfter  *time.Time
+	ScheduledBefore *time.Time
+	// Text matches a case-insensitive substring of the subject or the message.
+	Text string
+	// PageSize defaults to DefaultNotificationPageSize and is capped at MaxNotificationPageSize.
+	PageSize int
+	// PageToken resumes the listing after the page that returned it.
+	PageToken string
 }
 
 // NormalizedStatuses removes duplicates and legacy aliases while preserving order.
@@ -77,20 +156,41 @@ func (filters NotificationListFilters) NormalizedStatuses() []NotificationStatus
 // Notification is our main model in the DB, with GORM & JSON tags.
 // You can return this directly via JSON or create a separate struct if you like.
 type Notification struct {
-	ID                uint                     `json:"-" gorm:"primaryKey"`
-	NotificationID    string                   `json:"notification_id" gorm:"uniqueIndex"`
-	NotificationType  NotificationType         `json:"notification_type"`
-	Recipient         string                   `json:"recipient"`
-	Subject           string                   `json:"subject,omitempty"`
-	Message           string                   `json:"message"`
-	ProviderMessageID string                   `json:"provider_message_id"`
-	Status            NotificationStatus       `json:"status"`
-	RetryCount        int                      `json:"retry_count"`
-	LastAttemptedAt   time.Time                `json:"last_attempted_at"`
-	ScheduledFor      *time.Time               `json:"scheduled_for"`
-	CreatedAt         time.Time                `json:"created_at"`
-	UpdatedAt         time.Time                `json:"updated_at"`
-	Attachments       []NotificationAttachment `json:"attachments,omitempty" gorm:"foreignKey:NotificationID;references:NotificationID;constraint:OnDelete:CASCADE"`
+	ID                uint               `json:"-" gorm:"primaryKey"`
+	NotificationID    string             `json:"notification_id" gorm:"uniqueIndex"`
+	NotificationType  NotificationType   `json:"notification_type"`
+	Recipient         string             `json:"recipient"`
+	Subject           string             `json:"subject,omitempty"`
+	Message           string             `json:"message"`
+	HTMLMessage       string             `json:"html_message,omitempty"`
+	Category          string             `json:"category,omitempty" gorm:"index"`
+	ProviderMessageID string             `json:"provider_message_id" gorm:"index"`
+	Provider          string             `json:"provider,omitempty"`
+	ProviderStatus    string             `json:"provider_status,omitempty"`
+	SegmentCount      int                `json:"segment_count,omitempty"`
+	Price             string             `json:"price,omitempty"`
+	PriceUnit         string             `json:"price_unit,omitempty"`
+	Status            NotificationStatus `json:"status" gorm:"index"`
+	RetryCount        int                `json:"retry_count"`
+	LastAttemptedAt   time.Time          `json:"last_attempted_at"`
+	// LastError is the error of the most recent failed attempt; a successful attempt clears it.
+	LastError          string     `json:"last_error,omitempty"`
+	ScheduledFor       *time.Time `json:"scheduled_for"`
+	TemplateID         string     `json:"template_id,omitempty"`
+	TemplateVersion    int        `json:"template_version,omitempty"`
+	IdempotencyKey     *string    `json:"idempotency_key,omitempty" gorm:"uniqueIndex"`
+	RequestFingerprint string     `json:"-"`
+	BatchID            string     `json:"batch_id,omitempty" gorm:"index"`
+	BatchIndex         int        `json:"batch_index,omitempty"`
+	// LockedBy and LeaseUntil record the dispatch worker that has claimed the notification and when
+	// the claim lapses. NextAttemptAt is when a failed notification becomes due for another attempt.
+	LockedBy      string                   `json:"-" gorm:"index"`
+	LeaseUntil    *time.Time               `json:"-"`
+	NextAttemptAt *time.Time               `json:"-"`
+	CreatedAt     time.Time                `json:"created_at"`
+	UpdatedAt     time.Time                `json:"updated_at"`
+	Attachments   []NotificationAttachment `json:"attachments,omitempty" gorm:"foreignKey:NotificationID;references:NotificationID;constraint:OnDelete:CASCADE"`
+	Recipients    []NotificationRecipient  `json:"recipients,omitempty" gorm:"foreignKey:NotificationID;references:NotificationID;constraint:OnDelete:CASCADE"`
 }
 
 // NotificationAttachment persists attachment payloads per notification.
@@ -99,36 +199,88 @@ type NotificationAttachment struct {
 	NotificationID string    `json:"notification_id" gorm:"index"`
 	Filename       string    `json:"filename"`
 	ContentType    string    `json:"content_type"`
-	Data           []byte    `json:"data" gorm:"type:blob"`
+	Data           []byte    `json:"data"`
+	SizeBytes      int64     `json:"size_bytes"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+// NotificationRecipient persists every email address a notification targets along with the
+// per-recipient outcome reported by the mail server.
+type NotificationRecipient struct {
+	ID             uint            `json:"-" gorm:"primaryKey"`
+	NotificationID string          `json:"notification_id" gorm:"index"`
+	Kind           RecipientKind   `json:"kind"`
+	Address        string          `json:"address"`
+	Status         RecipientStatus `json:"status"`
+	Error          string          `json:"error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// RecipientDelivery reports the delivery outcome for a single email recipient.
+type RecipientDelivery struct {
+	Address string          `json:"address"`
+	Kind    RecipientKind   `json:"kind"`
+	Status  RecipientStatus `json:"status"`
+	Error   string          `json:"error,omitempty"`
+}
+
 // NotificationRequest represents the incoming request payload (REST/gRPC).
 type NotificationRequest struct {
-	NotificationType NotificationType  `json:"notification_type"`
-	Recipient        string            `json:"recipient"`
-	Subject          string            `json:"subject,omitempty"`
-	Message          string            `json:"message"`
-	ScheduledFor     *time.Time        `json:"scheduled_for,omitempty"`
-	Attachments      []EmailAttachment `json:"attachments,omitempty"`
+	NotificationType NotificationType `json:"notification_type"`
+	Recipient        string           `json:"recipient"`
+	To               []string         `json:"to,omitempty"`
+	Cc               []string         `json:"cc,omitempty"`
+	Bcc              []string         `json:"bcc,omitempty"`
+	Subject          string           `json:"subject,omitempty"`
+	Message          string           `json:"message"`
+	HTMLMessage      string           `json:"html_message,omitempty"`
+	// Category groups bulk messages (e.g. "product-updates") so recipients can unsubscribe from
+	// one category without suppressing everything. Emails with a category carry List-Unsubscribe.
+	Category        string            `json:"category,omitempty"`
+	ScheduledFor    *time.Time        `json:"scheduled_for,omitempty"`
+	Attachments     []EmailAttachment `json:"attachments,omitempty"`
+	TemplateID      string            `json:"template_id,omitempty"`
+	TemplateVersion int               `json:"template_version,omitempty"`
+	TemplateData    map[string]string `json:"template_data,omitempty"`
+	IdempotencyKey  string            `json:"idempotency_key,omitempty"`
+	// DeliveryMode only affects how this call dispatches; it is not stored or fingerprinted.
+	DeliveryMode DeliveryMode `json:"delivery_mode,omitempty"`
 }
 
 // NotificationResponse is what you'll return to the client.
 // You could also return the Notification itself, but some prefer a separate shape.
 type NotificationResponse struct {
-	NotificationID    string             `json:"notification_id"`
-	NotificationType  NotificationType   `json:"notification_type"`
-	Recipient         string             `json:"recipient"`
-	Subject           string             `json:"subject,omitempty"`
-	Message           string             `json:"message"`
-	Status            NotificationStatus `json:"status"`
-	ProviderMessageID string             `json:"provider_message_id"`
-	RetryCount        int                `json:"retry_count"`
-	ScheduledFor      *time.Time         `json:"scheduled_for,omitempty"`
-	CreatedAt         time.Time          `json:"created_at"`
-	UpdatedAt         time.Time          `json:"updated_at"`
-	Attachments       []EmailAttachment  `json:"attachments,omitempty"`
+	NotificationID      string              `json:"notification_id"`
+	NotificationType    NotificationType    `json:"notification_type"`
+	Recipient           string              `json:"recipient"`
+	To                  []string            `json:"to,omitempty"`
+	Cc                  []string            `json:"cc,omitempty"`
+	Bcc                 []string            `json:"bcc,omitempty"`
+	Subject             string              `json:"subject,omitempty"`
+	Message             string              `json:"message"`
+	HTMLMessage         string              `json:"html_message,omitempty"`
+	Category            string              `json:"category,omitempty"`
+	Status              NotificationStatus  `json:"status"`
+	ProviderMessageID   string              `json:"provider_message_id"`
+	Provider            string              `json:"provider,omitempty"`
+	ProviderStatus      string              `json:"provider_status,omitempty"`
+	SegmentCount        int                 `json:"segment_count,omitempty"`
+	Price               string              `json:"price,omitempty"`
+	PriceUnit           string              `json:"price_unit,omitempty"`
+	RetryCount          int                 `json:"retry_count"`
+	LastError           string              `json:"last_error,omitempty"`
+	ScheduledFor        *time.Time          `json:"scheduled_for,omitempty"`
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+	Attachments         []EmailAttachment   `json:"attachments,omitempty"`
+	RecipientDeliveries []RecipientDelivery `json:"recipient_deliveries,omitempty"`
+	TemplateID          string              `json:"template_id,omitempty"`
+	TemplateVersion     int                 `json:"template_version,omitempty"`
+	IdempotencyKey      string              `json:"idempotency_key,omitempty"`
+	BatchID             string              `json:"batch_id,omitempty"`
+	BatchIndex          int                 `json:"batch_index,omitempty"`
 }
 
 // NewNotification constructs a ready-to-insert DB Notification from a request, defaulting status=queued.
@@ -139,17 +291,28 @@ func NewNotification(notificationID string, req NotificationRequest) Notificatio
 		normalizedScheduled := req.ScheduledFor.UTC()
 		scheduledFor = &normalizedScheduled
 	}
+	var idempotencyKey *string
+	if req.IdempotencyKey != "" {
+		keyCopy := req.IdempotencyKey
+		idempotencyKey = &keyCopy
+	}
 	return Notification{
 		NotificationID:   notificationID,
 		NotificationType: req.NotificationType,
 		Recipient:        req.Recipient,
 		Subject:          req.Subject,
 		Message:          req.Message,
+		HTMLMessage:      req.HTMLMessage,
+		Category:         req.Category,
 		Status:           StatusQueued,
 		ScheduledFor:     scheduledFor,
+		TemplateID:       req.TemplateID,
+		TemplateVersion:  req.TemplateVersion,
+		IdempotencyKey:   idempotencyKey,
 		CreatedAt:        now,
 		UpdatedAt:        now,
 		Attachments:      convertEmailAttachments(notificationID, req.Attachments),
+		Recipients:       buildNotificationRecipients(notificationID, req, now),
 	}
 }
 
@@ -164,20 +327,52 @@ func NewNotificationResponse(n Notification) NotificationResponse {
 	if status == "" {
 		status = StatusUnknown
 	}
-	return NotificationResponse{
+	response := NotificationResponse{
 		NotificationID:    n.NotificationID,
 		NotificationType:  n.NotificationType,
 		Recipient:         n.Recipient,
 		Subject:           n.Subject,
 		Message:           n.Message,
+		HTMLMessage:       n.HTMLMessage,
+		Category:          n.Category,
 		Status:            status,
 		ProviderMessageID: n.ProviderMessageID,
+		Provider:          n.Provider,
+		ProviderStatus:    n.ProviderStatus,
+		SegmentCount:      n.SegmentCount,
+		Price:             n.Price,
+		PriceUnit:         n.PriceUnit,
 		RetryCount:        n.RetryCount,
+		LastError:         n.LastError,
 		ScheduledFor:      scheduledFor,
 		CreatedAt:         n.CreatedAt,
 		UpdatedAt:         n.UpdatedAt,
 		Attachments:       ToEmailAttachments(n.Attachments),
+		TemplateID:        n.TemplateID,
+		TemplateVersion:   n.TemplateVersion,
+		BatchID:           n.BatchID,
+		BatchIndex:        n.BatchIndex,
+	}
+	if n.IdempotencyKey != nil {
+		response.IdempotencyKey = *n.IdempotencyKey
 	}
+	for _, recipient := range n.Recipients {
+		switch recipient.Kind {
+		case RecipientTo:
+			response.To = append(response.To, recipient.Address)
+		case RecipientCc:
+			response.Cc = append(response.Cc, recipient.Address)
+		case RecipientBcc:
+			response.Bcc = append(response.Bcc, recipient.Address)
+		}
+		response.RecipientDeliveries = append(response.RecipientDeliveries, RecipientDelivery{
+			Address: recipient.Address,
+			Kind:    recipient.Kind,
+			Status:  recipient.Status,
+			Error:   recipient.Error,
+		})
+	}
+	return response
 }
 
 // ====================== DB CRUD METHODS ====================== //
@@ -186,10 +381,46 @@ func CreateNotification(ctx context.Context, db *gorm.DB, n *Notification) error
 	return db.WithContext(ctx).Create(n).Error
 }
 
+// CreateNotifications stores the notifications in a single transaction. Each insert runs in its
+// own savepoint, so a notification that cannot be stored (for example because another process
+// claimed its idempotency key) is reported at its position in the returned slice without undoing
+// the others. The error is set only when the transaction itself fails, in which case none are stored.
+func CreateNotifications(ctx context.Context, db *gorm.DB, notifications []*Notification) ([]error, error) {
+	itemErrors := make([]error, len(notifications))
+	transactionErr := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for index, notification := range notifications {
+			itemErrors[index] = tx.Transaction(func(savepoint *gorm.DB) error {
+				return savepoint.Create(notification).Error
+			})
+		}
+		return nil
+	})
+	if transactionErr != nil {
+		return nil, transactionErr
+	}
+	return itemErrors, nil
+}
+
+// ListNotificationsByBatchID returns the notifications stored for a batch in submission order.
+func ListNotificationsByBatchID(ctx context.Context, db *gorm.DB, batchID string) ([]Notification, error) {
+	var notifications []Notification
+	err := db.WithContext(ctx).
+		Preload("Attachments").
+		Preload("Recipients", orderRecipients).
+		Where("batch_id = ?", batchID).
+		Order("batch_index ASC").
+		Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
 func GetNotificationByID(ctx context.Context, db *gorm.DB, notificationID string) (*Notification, error) {
 	var notif Notification
 	err := db.WithContext(ctx).
 		Preload("Attachments").
+		Preload("Recipients", orderRecipients).
 		Where("notification_id = ?", notificationID).
 		First(&notif).Error
 	if err != nil {
@@ -198,25 +429,333 @@ func GetNotificationByID(ctx context.Context, db *gorm.DB, notificationID string
 	return &notif, nil
 }
 
+// GetNotificationByIdempotencyKey returns the notification created for a client-supplied idempotency key.
+func GetNotificationByIdempotencyKey(ctx context.Context, db *gorm.DB, idempotencyKey string) (*Notification, error) {
+	var notif Notification
+	err := db.WithContext(ctx).
+		Preload("Attachments").
+		Preload("Recipients", orderRecipients).
+		Where("idempotency_key = ?", idempotencyKey).
+		First(&notif).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notif, nil
+}
+
+// GetNotificationByProviderMessageID returns the notification a provider accepted under
+// providerMessageID. Rows stored before the delivering provider was recorded match any provider.
+func GetNotificationByProviderMessageID(ctx context.Context, db *gorm.DB, provider string, providerMessageID string) (*Notification, error) {
+	var notif Notification
+	err := db.WithContext(ctx).
+		Where("provider_message_id = ? AND (provider = ? OR provider = '')", providerMessageID, provider).
+		Order("id DESC").
+		First(&notif).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notif, nil
+}
+
 func SaveNotification(ctx context.Context, db *gorm.DB, n *Notification) error {
 	return db.WithContext(ctx).Save(n).Error
 }
 
-func GetQueuedOrFailedNotifications(ctx context.Context, db *gorm.DB, maxRetries int, currentTime time.Time) ([]Notification, error) {
+// NotificationClaim describes the due notifications a dispatch worker leases in one
+// ClaimNotifications call.
+type NotificationClaim struct {
+	WorkerID      string
+	MaxRetries    int
+	Now           time.Time
+	LeaseUntil    time.Time
+	Limit         int
+	ExcludedTypes []NotificationType
+}
+
+// ClaimNotifications leases up to claim.Limit due notifications to claim.WorkerID. Notifications
+// leased by any worker are skipped until their lease expires, so concurrent claims never return the
+// same notification twice. On PostgreSQL the candidates are selected FOR UPDATE SKIP LOCKED, so
+// workers claiming at the same moment split the due rows instead of waiting on each other; SQLite
+// serializes the claim transactions instead.
+func ClaimNotifications(ctx context.Context, db *gorm.DB, claim NotificationClaim) ([]Notification, error) {
+	var claimed []Notification
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var candidateIDs []string
+		if err := claimCandidates(tx, claim).Pluck("notification_id", &candidateIDs).Error; err != nil {
+			return err
+		}
+		if len(candidateIDs) == 0 {
+			return nil
+		}
+		// The lease conditions are checked again so a row claimed since the candidate query stays
+		// with the worker that claimed it first.
+		leaseUpdate := claimableNotifications(tx, claim).
+			Where("notification_id IN ?", candidateIDs).
+			UpdateColumns(map[string]any{"locked_by": claim.WorkerID, "lease_until": claim.LeaseUntil})
+		if leaseUpdate.Error != nil {
+			return leaseUpdate.Error
+		}
+		return tx.Preload("Attachments").
+			Preload("Recipients", orderRecipients).
+			Where("notification_id IN ? AND locked_by = ?", candidateIDs, claim.WorkerID).
+			Order("id ASC").
+			Find(&claimed).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func claimCandidates(tx *gorm.DB, claim NotificationClaim) *gorm.DB {
+	return claimableNotifications(tx, claim).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Order("id ASC").
+		Limit(claim.Limit)
+}
+
+func claimableNotifications(tx *gorm.DB, claim NotificationClaim) *gorm.DB {
+	query := tx.Model(&Notification{}).
+		Where("status IN ? AND retry_count < ?", []NotificationStatus{StatusQueued, StatusErrored, StatusFailed}, claim.MaxRetries).
+		Where("scheduled_for IS NULL OR scheduled_for <= ?", claim.Now).
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", claim.Now)
+	query = unleasedNotifications(query, claim.Now)
+	if len(claim.ExcludedTypes) > 0 {
+		query = query.Where("notification_type NOT IN ?", claim.ExcludedTypes)
+	}
+	return query
+}
+
+// ReleaseNotificationLease drops the lease workerID holds on a notification without recording an
+// attempt, making it claimable again straight away.
+func ReleaseNotificationLease(ctx context.Context, db *gorm.DB, notificationID string, workerID string) error {
+	return db.WithContext(ctx).
+		Model(&Notification{}).
+		Where("notification_id = ? AND locked_by = ?", notificationID, workerID).
+		UpdateColumns(map[string]any{"locked_by": "", "lease_until": nil}).Error
+}
+
+// ErrNotificationLeaseLost indicates an attempt result was written by a worker that no longer
+// leases the notification: another worker claimed it after the lease expired, or it was cancelled
+// or requeued while the attempt was in flight.
+var ErrNotificationLeaseLost = errors.New("notification lease lost")
+
+// ApplyNotificationAttemptResult writes the delivery columns and recipient outcomes of n after an
+// attempt by workerID and releases the lease. The write is fenced on the lease: when workerID no
+// longer holds it nothing is written and ErrNotificationLeaseLost is returned.
+func ApplyNotificationAttemptResult(ctx context.Context, db *gorm.DB, n *Notification, workerID string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		update := tx.Model(&Notification{}).
+			Where("notification_id = ? AND locked_by = ?", n.NotificationID, workerID).
+			UpdateColumns(map[string]any{
+				"status":              n.Status,
+				"provider_message_id": n.ProviderMessageID,
+				"provider":            n.Provider,
+				"provider_status":     n.ProviderStatus,
+				"segment_count":       n.SegmentCount,
+				"price":               n.Price,
+				"price_unit":          n.PriceUnit,
+				"retry_count":         n.RetryCount,
+				"last_attempted_at":   n.LastAttemptedAt,
+				"last_error":          n.LastError,
+				"next_attempt_at":     n.NextAttemptAt,
+				"updated_at":          n.UpdatedAt,
+				"locked_by":           "",
+				"lease_until":         nil,
+			})
+		if update.Error != nil {
+			return update.Error
+		}
+		if update.RowsAffected == 0 {
+			return fmt.Errorf("%w: %s", ErrNotificationLeaseLost, n.NotificationID)
+		}
+		for index := range n.Recipients {
+			if err := tx.Save(&n.Recipients[index]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RequeuedNotification is a notification moved back to queued together with its status before the
+// move.
+type RequeuedNotification struct {
+	PreviousStatus NotificationStatus
+	Notification   Notification
+}
+
+// MarkExhaustedNotificationsDead moves errored notifications that have used up maxRetries attempts
+// to dead. Workers record dead themselves when the last attempt fails; this catches rows left
+// errored by earlier releases or by a lowered retry budget. Leased rows are left to their worker.
+func MarkExhaustedNotificationsDead(ctx context.Context, db *gorm.DB, maxRetries int, now time.Time) ([]Notification, error) {
+	var marked []Notification
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var candidateIDs []string
+		candidates := unleasedNotifications(tx.Model(&Notification{}), now).
+			Where("status IN ? AND retry_count >= ?", []NotificationStatus{StatusErrored, StatusFailed}, maxRetries).
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate})
+		if err := candidates.Pluck("notification_id", &candidateIDs).Error; err != nil {
+			return err
+		}
+		if len(candidateIDs) == 0 {
+			return nil
+		}
+		update := tx.Model(&Notification{}).
+			Where("notification_id IN ?", candidateIDs).
+			UpdateColumns(map[string]any{"status": StatusDead, "next_attempt_at": nil, "locked_by": "", "lease_until": nil, "updated_at": now})
+		if update.Error != nil {
+			return update.Error
+		}
+		return tx.Preload("Attachments", omitAttachmentData).
+			Preload("Recipients", orderRecipients).
+			Where("notification_id IN ?", candidateIDs).
+			Order("id ASC").
+			Find(&marked).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return marked, nil
+}
+
+// RequeueNotification moves a dead or errored notification back to queued with a fresh retry
+// budget. Its last error is kept until the next attempt.
+func RequeueNotification(ctx context.Context, db *gorm.DB, notificationID string, now time.Time) (RequeuedNotification, error) {
+	if _, err := MustGetNotificationByID(ctx, db, notificationID); err != nil {
+		return RequeuedNotification{}, err
+	}
+	requeued, err := requeueNotifications(ctx, db, now, func(query *gorm.DB) (*gorm.DB, error) {
+		return query.Where("notification_id = ?", notificationID), nil
+	})
+	if err != nil {
+		return RequeuedNotification{}, err
+	}
+	if len(requeued) == 0 {
+		return RequeuedNotification{}, fmt.Errorf("%w: %s", ErrNotificationNotRequeueable, notificationID)
+	}
+	return requeued[0], nil
+}
+
+// RequeueNotifications moves every dead or errored notification matching filters back to queued.
+// Filters without statuses select dead notifications; statuses other than dead and errored are
+// rejected. Paging fields are ignored.
+func RequeueNotifications(ctx context.Context, db *gorm.DB, filters NotificationListFilters, now time.Time) ([]RequeuedNotification, error) {
+	statuses := filters.NormalizedStatuses()
+	if len(statuses) == 0 {
+		statuses = []NotificationStatus{StatusDead}
+	}
+	for _, status := range statuses {
+		if status != StatusDead && status != StatusErrored {
+			return nil, fmt.Errorf("%w: %s notifications cannot be requeued", ErrInvalidNotificationListFilter, status)
+		}
+	}
+	filters.Statuses = statuses
+	filters.PageToken = ""
+	return requeueNotifications(ctx, db, now, filters.apply)
+}
+
+func requeueNotifications(ctx context.Context, db *gorm.DB, now time.Time, scope func(*gorm.DB) (*gorm.DB, error)) ([]RequeuedNotification, error) {
+	var requeued []RequeuedNotification
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		candidates, err := scope(tx.Model(&Notification{}))
+		if err != nil {
+			return err
+		}
+		var previous []struct {
+			NotificationID string
+			Status         NotificationStatus
+		}
+		err = unleasedNotifications(candidates, now).
+			Where("status IN ?", []NotificationStatus{StatusDead, StatusErrored, StatusFailed}).
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Select("notification_id", "status").
+			Find(&previous).Error
+		if err != nil {
+			return err
+		}
+		if len(previous) == 0 {
+			return nil
+		}
+		candidateIDs := make([]string, 0, len(previous))
+		previousStatuses := make(map[string]NotificationStatus, len(previous))
+		for _, row := range previous {
+			candidateIDs = append(candidateIDs, row.NotificationID)
+			previousStatuses[row.NotificationID] = CanonicalStatus(row.Status)
+		}
+		// Expired leases are cleared so a late result from their worker cannot overwrite the requeue.
+		update := tx.Model(&Notification{}).
+			Where("notification_id IN ?", candidateIDs).
+			UpdateColumns(map[string]any{"status": StatusQueued, "retry_count": 0, "next_attempt_at": nil, "locked_by": "", "lease_until": nil, "updated_at": now})
+		if update.Error != nil {
+			return update.Error
+		}
+		var notifications []Notification
+		err = tx.Preload("Attachments", omitAttachmentData).
+			Preload("Recipients", orderRecipients).
+			Where("notification_id IN ?", candidateIDs).
+			Order("id ASC").
+			Find(&notifications).Error
+		if err != nil {
+			return err
+		}
+		for _, notification := range notifications {
+			requeued = append(requeued, RequeuedNotification{PreviousStatus: previousStatuses[notification.NotificationID], Notification: notification})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return requeued, nil
+}
+
+func unleasedNotifications(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("locked_by IS NULL OR locked_by = '' OR lease_until IS NULL OR lease_until < ?", now)
+}
+
+// ListNotifications returns one page of notifications, newest first, together with the token of
+// the next page; the token is empty on the last page. Attachments are loaded without their data.
+func ListNotifications(ctx context.Context, db *gorm.DB, filters NotificationListFilters) ([]Notification, string, error) {
+	pageSize, err := filters.pageSize()
+	if err != nil {
+		return nil, "", err
+	}
+	query, err := filters.apply(db.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
 	var notifications []Notification
-	err := db.WithContext(ctx).
-		Preload("Attachments").
-		Where("(status = ? OR status = ? OR status = ?) AND retry_count < ? AND (scheduled_for IS NULL OR scheduled_for <= ?)",
-			StatusQueued, StatusErrored, StatusFailed, maxRetries, currentTime).
+	err = query.
+		Preload("Attachments", omitAttachmentData).
+		Preload("Recipients", orderRecipients).
+		Order("id DESC").
+		Limit(pageSize + 1).
 		Find(&notifications).Error
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return notifications, nil
+	if len(notifications) <= pageSize {
+		return notifications, "", nil
+	}
+	notifications = notifications[:pageSize]
+	return notifications, encodeNotificationPageToken(notifications[pageSize-1].ID), nil
 }
 
-func ListNotifications(ctx context.Context, db *gorm.DB, filters NotificationListFilters) ([]Notification, error) {
-	query := db.WithContext(ctx).Preload("Attachments").Order("created_at DESC")
+func (filters NotificationListFilters) pageSize() (int, error) {
+	switch {
+	case filters.PageSize < 0:
+		return 0, fmt.Errorf("%w: page size %d is negative", ErrInvalidNotificationListFilter, filters.PageSize)
+	case filters.PageSize == 0:
+		return DefaultNotificationPageSize, nil
+	case filters.PageSize > MaxNotificationPageSize:
+		return MaxNotificationPageSize, nil
+	default:
+		return filters.PageSize, nil
+	}
+}
+
+func (filters NotificationListFilters) apply(query *gorm.DB) (*gorm.DB, error) {
 	statuses := filters.NormalizedStatuses()
 	if len(statuses) > 0 {
 		statusStrings := make([]string, 0, len(statuses))
@@ -228,11 +767,77 @@ func ListNotifications(ctx context.Context, db *gorm.DB, filters NotificationLis
 		}
 		query = query.Where("status IN ?", statusStrings)
 	}
-	var notifications []Notification
-	if err := query.Find(&notifications).Error; err != nil {
-		return nil, err
+	if len(filters.Types) > 0 {
+		for _, notificationType := range filters.Types {
+			if notificationType != NotificationEmail && notificationType != NotificationSMS {
+				return nil, fmt.Errorf("%w: unknown notification type %q", ErrInvalidNotificationListFilter, notificationType)
+			}
+		}
+		query = query.Where("notification_type IN ?", filters.Types)
 	}
-	return notifications, nil
+	if recipient := strings.TrimSpace(filters.Recipient); recipient != "" {
+		query = query.Where("recipient = ?", recipient)
+	}
+	if prefix := strings.TrimSpace(filters.RecipientPrefix); prefix != "" {
+		query = query.Where(`LOWER(recipient) LIKE ? ESCAPE '\'`, escapeLikePattern(strings.ToLower(prefix))+"%")
+	}
+	if text := strings.TrimSpace(filters.Text); text != "" {
+		pattern := "%" + escapeLikePattern(strings.ToLower(text)) + "%"
+		query = query.Where(`LOWER(subject) LIKE ? ESCAPE '\' OR LOWER(message) LIKE ? ESCAPE '\'`, pattern, pattern)
+	}
+	ranges := []struct {
+		column string
+		after  *time.Time
+		before *time.Time
+	}{
+		{column: "created_at", after: filters.CreatedAfter, before: filters.CreatedBefore},
+		{column: "scheduled_for", after: filters.ScheduledAfter, before: filters.ScheduledBefore},
+	}
+	for _, timeRange := range ranges {
+		if timeRange.after != nil && timeRange.before != nil && !timeRange.after.Before(*timeRange.before) {
+			return nil, fmt.Errorf("%w: %s range is empty", ErrInvalidNotificationListFilter, timeRange.column)
+		}
+		if timeRange.after != nil {
+			query = query.Where(timeRange.column+" >= ?", timeRange.after.UTC())
+		}
+		if timeRange.before != nil {
+			query = query.Where(timeRange.column+" < ?", timeRange.before.UTC())
+		}
+	}
+	if filters.PageToken != "" {
+		lastID, err := decodeNotificationPageToken(filters.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("id < ?", lastID)
+	}
+	return query, nil
+}
+
+// Page tokens wrap the primary key of the last notification on a page. Keys only grow, so paging
+// by key stays stable while new notifications arrive.
+func encodeNotificationPageToken(lastID uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(lastID), 10)))
+}
+
+func decodeNotificationPageToken(token string) (uint, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed page token", ErrInvalidNotificationListFilter)
+	}
+	lastID, err := strconv.ParseUint(string(decoded), 10, 64)
+	if err != nil || lastID == 0 {
+		return 0, fmt.Errorf("%w: malformed page token", ErrInvalidNotificationListFilter)
+	}
+	return uint(lastID), nil
+}
+
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func omitAttachmentData(db *gorm.DB) *gorm.DB {
+	return db.Omit("data")
 }
 
 func MustGetNotificationByID(ctx context.Context, db *gorm.DB, notificationID string) (*Notification, error) {
@@ -246,6 +851,37 @@ func MustGetNotificationByID(ctx context.Context, db *gorm.DB, notificationID st
 	return n, nil
 }
 
+func orderRecipients(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
+
+func buildNotificationRecipients(notificationID string, req NotificationRequest, createdAt time.Time) []NotificationRecipient {
+	if req.NotificationType != NotificationEmail {
+		return nil
+	}
+	total := len(req.To) + len(req.Cc) + len(req.Bcc)
+	if total == 0 {
+		return nil
+	}
+	recipients := make([]NotificationRecipient, 0, total)
+	appendKind := func(kind RecipientKind, addresses []string) {
+		for _, address := range addresses {
+			recipients = append(recipients, NotificationRecipient{
+				NotificationID: notificationID,
+				Kind:           kind,
+				Address:        address,
+				Status:         RecipientPending,
+				CreatedAt:      createdAt,
+				UpdatedAt:      createdAt,
+			})
+		}
+	}
+	appendKind(RecipientTo, req.To)
+	appendKind(RecipientCc, req.Cc)
+	appendKind(RecipientBcc, req.Bcc)
+	return recipients
+}
+
 func convertEmailAttachments(notificationID string, attachments []EmailAttachment) []NotificationAttachment {
 	if len(attachments) == 0 {
 		return nil
@@ -259,6 +895,7 @@ func convertEmailAttachments(notificationID string, attachments []EmailAttachmen
 			Filename:       att.Filename,
 			ContentType:    att.ContentType,
 			Data:           clonedData,
+			SizeBytes:      int64(len(clonedData)),
 		})
 	}
 	return converted
@@ -277,6 +914,7 @@ func ToEmailAttachments(stored []NotificationAttachment) []EmailAttachment {
 			Filename:    att.Filename,
 			ContentType: att.ContentType,
 			Data:        clonedData,
+			SizeBytes:   att.SizeBytes,
 		})
 	}
 	return result
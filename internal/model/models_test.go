@@ -2,9 +2,12 @@ package model
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -168,7 +171,14 @@ func TestDatabaseHelpersFilterAndRetrieve(t *testing.T) {
 		}
 	}
 
-	pending, pendingError := GetQueuedOrFailedNotifications(ctx, database, 5, time.Now().UTC())
+	now := time.Now().UTC()
+	pending, pendingError := ClaimNotifications(ctx, database, NotificationClaim{
+		WorkerID:   "worker-a",
+		MaxRetries: 5,
+		Now:        now,
+		LeaseUntil: now.Add(time.Minute),
+		Limit:      10,
+	})
 	if pendingError != nil {
 		t.Fatalf("pending retrieval error: %v", pendingError)
 	}
@@ -191,6 +201,250 @@ func TestDatabaseHelpersFilterAndRetrieve(t *testing.T) {
 	}
 }
 
+func TestTemplateHelpersResolveVersions(t *testing.T) {
+	t.Helper()
+
+	database := openModelTestDatabase(t)
+	ctx := context.Background()
+
+	templates := []Template{
+		{TemplateID: "welcome", Version: 1, PlainBody: "v1"},
+		{TemplateID: "welcome", Version: 2, PlainBody: "v2"},
+		{TemplateID: "digest", Version: 1, PlainBody: "digest"},
+	}
+	for index := range templates {
+		if createError := CreateTemplate(ctx, database, &templates[index]); createError != nil {
+			t.Fatalf("create template error: %v", createError)
+		}
+	}
+	if duplicateError := CreateTemplate(ctx, database, &Template{TemplateID: "welcome", Version: 2}); duplicateError == nil {
+		t.Fatalf("expected duplicate template version to be rejected")
+	}
+
+	latest, latestError := GetTemplate(ctx, database, "welcome", 0)
+	if latestError != nil || latest.Version != 2 {
+		t.Fatalf("expected latest version 2, got %#v (%v)", latest, latestError)
+	}
+	pinned, pinnedError := GetTemplate(ctx, database, "welcome", 1)
+	if pinnedError != nil || pinned.PlainBody != "v1" {
+		t.Fatalf("expected pinned version 1, got %#v (%v)", pinned, pinnedError)
+	}
+	if _, missingError := GetTemplate(ctx, database, "welcome", 5); !errors.Is(missingError, ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", missingError)
+	}
+
+	listed, listError := ListLatestTemplates(ctx, database)
+	if listError != nil {
+		t.Fatalf("list templates error: %v", listError)
+	}
+	if len(listed) != 2 || listed[0].TemplateID != "digest" || listed[1].Version != 2 {
+		t.Fatalf("unexpected latest templates %#v", listed)
+	}
+
+	if deleteError := DeleteTemplate(ctx, database, "welcome"); deleteError != nil {
+		t.Fatalf("delete template error: %v", deleteError)
+	}
+	if deleteError := DeleteTemplate(ctx, database, "welcome"); !errors.Is(deleteError, ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound on second delete, got %v", deleteError)
+	}
+}
+
+func TestSuppressionHelpersUpsertAndExpire(t *testing.T) {
+	t.Helper()
+
+	database := openModelTestDatabase(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	expired := now.Add(-time.Minute)
+
+	suppressions := []Suppression{
+		{Channel: NotificationEmail, Recipient: "user@example.com", Reason: SuppressionManual, Source: "api"},
+		{Channel: NotificationEmail, Recipient: "old@example.com", Reason: SuppressionManual, ExpiresAt: &expired},
+		{Channel: NotificationSMS, Recipient: "+15550001111", Reason: SuppressionStop},
+		{Channel: NotificationEmail, Recipient: "reader@example.com", Reason: SuppressionUnsubscribed, Category: " Promotions "},
+	}
+	for index := range suppressions {
+		if upsertError := UpsertSuppression(ctx, database, &suppressions[index]); upsertError != nil {
+			t.Fatalf("upsert suppression error: %v", upsertError)
+		}
+	}
+	replacement := Suppression{Channel: NotificationEmail, Recipient: "user@example.com", Reason: SuppressionComplaint, Source: "webhook"}
+	if upsertError := UpsertSuppression(ctx, database, &replacement); upsertError != nil {
+		t.Fatalf("replace suppression error: %v", upsertError)
+	}
+	stored, getError := GetSuppression(ctx, database, NotificationEmail, "USER@example.com", "")
+	if getError != nil || stored.Reason != SuppressionComplaint || stored.Source != "webhook" {
+		t.Fatalf("expected replaced suppression, got %#v (%v)", stored, getError)
+	}
+
+	active, findError := FindActiveSuppressions(ctx, database, NotificationEmail, "", []string{"User@Example.com", "old@example.com", "reader@example.com"}, now)
+	if findError != nil {
+		t.Fatalf("find suppressions error: %v", findError)
+	}
+	if _, found := active["user@example.com"]; !found || len(active) != 1 {
+		t.Fatalf("expected only the unexpired uncategorized suppression, got %#v", active)
+	}
+	active, findError = FindActiveSuppressions(ctx, database, NotificationEmail, "promotions", []string{"user@example.com", "reader@example.com"}, now)
+	if findError != nil || len(active) != 2 {
+		t.Fatalf("expected global and category suppressions to apply, got %#v (%v)", active, findError)
+	}
+
+	listed, listError := ListSuppressions(ctx, database, SuppressionListFilters{}, now)
+	if listError != nil || len(listed) != 3 {
+		t.Fatalf("expected three active suppressions, got %#v (%v)", listed, listError)
+	}
+	listed, listError = ListSuppressions(ctx, database, SuppressionListFilters{Channels: []NotificationType{NotificationEmail}, IncludeExpired: true}, now)
+	if listError != nil || len(listed) != 3 {
+		t.Fatalf("expected three email suppressions including expired, got %#v (%v)", listed, listError)
+	}
+	if deleteError := DeleteSuppression(ctx, database, NotificationEmail, "reader@example.com", ""); !errors.Is(deleteError, ErrSuppressionNotFound) {
+		t.Fatalf("expected category suppression to survive an uncategorized delete, got %v", deleteError)
+	}
+
+	if deleteError := DeleteSuppression(ctx, database, NotificationSMS, "+1 555 000 1111", ""); deleteError != nil {
+		t.Fatalf("delete suppression error: %v", deleteError)
+	}
+	if deleteError := DeleteSuppression(ctx, database, NotificationSMS, "+15550001111", ""); !errors.Is(deleteError, ErrSuppressionNotFound) {
+		t.Fatalf("expected ErrSuppressionNotFound on second delete, got %v", deleteError)
+	}
+}
+
+func TestInboundMessageHelpers(t *testing.T) {
+	t.Helper()
+
+	keywordCases := map[string]InboundKeyword{
+		"STOP":             InboundKeywordStop,
+		" stop ":           InboundKeywordStop,
+		"Unsubscribe.":     InboundKeywordStop,
+		"start":            InboundKeywordStart,
+		"Help!":            InboundKeywordHelp,
+		"please stop this": InboundKeywordNone,
+		"":                 InboundKeywordNone,
+	}
+	for body, expected := range keywordCases {
+		if keyword := ClassifyInboundKeyword(body); keyword != expected {
+			t.Fatalf("body %q: expected keyword %q, got %q", body, expected, keyword)
+		}
+	}
+
+	database := openModelTestDatabase(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	messages := []InboundMessage{
+		{Provider: "twilio", ProviderMessageID: "SM1", FromNumber: "+15550001111", Body: "STOP", ReceivedAt: now.Add(-time.Minute)},
+		{Provider: "twilio", ProviderMessageID: "SM2", FromNumber: "+15550002222", Body: "Hello", ReceivedAt: now},
+	}
+	for index := range messages {
+		created, createError := CreateInboundMessage(ctx, database, &messages[index])
+		if createError != nil || !created {
+			t.Fatalf("create inbound message: created=%v err=%v", created, createError)
+		}
+	}
+	duplicate := InboundMessage{Provider: "twilio", ProviderMessageID: "SM1", FromNumber: "+15550001111", Body: "STOP", ReceivedAt: now}
+	if created, createError := CreateInboundMessage(ctx, database, &duplicate); createError != nil || created {
+		t.Fatalf("expected duplicate to be ignored: created=%v err=%v", created, createError)
+	}
+
+	listed, listError := ListInboundMessages(ctx, database, InboundMessageListFilters{})
+	if listError != nil || len(listed) != 2 || listed[0].ProviderMessageID != "SM2" {
+		t.Fatalf("expected two messages newest first, got %#v (%v)", listed, listError)
+	}
+	listed, listError = ListInboundMessages(ctx, database, InboundMessageListFilters{FromNumber: "+1 555 000 1111", Limit: 5})
+	if listError != nil || len(listed) != 1 || listed[0].ProviderMessageID != "SM1" {
+		t.Fatalf("expected sender filter to match one message, got %#v (%v)", listed, listError)
+	}
+}
+
+func TestClaimNotificationsLeasesRows(t *testing.T) {
+	t.Helper()
+
+	database := openModelTestDatabase(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	expiredLease := now.Add(-time.Minute)
+	activeLease := now.Add(time.Minute)
+	seeds := []Notification{
+		{NotificationID: "due-email", NotificationType: NotificationEmail, Status: StatusQueued},
+		{NotificationID: "due-sms", NotificationType: NotificationSMS, Status: StatusErrored},
+		{NotificationID: "backing-off", NotificationType: NotificationEmail, Status: StatusErrored, NextAttemptAt: timePointer(now.Add(time.Minute))},
+		{NotificationID: "leased", NotificationType: NotificationEmail, Status: StatusQueued, LockedBy: "worker-c", LeaseUntil: &activeLease},
+		{NotificationID: "lease-expired", NotificationType: NotificationEmail, Status: StatusQueued, LockedBy: "worker-c", LeaseUntil: &expiredLease},
+		{NotificationID: "exhausted", NotificationType: NotificationEmail, Status: StatusFailed, RetryCount: 3},
+		{NotificationID: "delivered", NotificationType: NotificationEmail, Status: StatusSent},
+	}
+	for index := range seeds {
+		seeds[index].Recipient = "user@example.com"
+		seeds[index].Message = "Body"
+		if err := CreateNotification(ctx, database, &seeds[index]); err != nil {
+			t.Fatalf("seed notification: %v", err)
+		}
+	}
+	claimFor := func(workerID string, excluded ...NotificationType) NotificationClaim {
+		return NotificationClaim{WorkerID: workerID, MaxRetries: 3, Now: now, LeaseUntil: now.Add(time.Minute), Limit: 10, ExcludedTypes: excluded}
+	}
+	claimedIDs := func(notifications []Notification) []string {
+		identifiers := make([]string, 0, len(notifications))
+		for _, notification := range notifications {
+			identifiers = append(identifiers, notification.NotificationID)
+		}
+		return identifiers
+	}
+
+	first, err := ClaimNotifications(ctx, database, claimFor("worker-a", NotificationSMS))
+	if err != nil {
+		t.Fatalf("claim error: %v", err)
+	}
+	if got := claimedIDs(first); len(got) != 2 || got[0] != "due-email" || got[1] != "lease-expired" {
+		t.Fatalf("expected due-email and the expired lease, got %v", got)
+	}
+	if first[0].LockedBy != "worker-a" || first[0].LeaseUntil == nil {
+		t.Fatalf("expected claimed rows to carry the lease, got %+v", first[0])
+	}
+
+	second, err := ClaimNotifications(ctx, database, claimFor("worker-b"))
+	if err != nil {
+		t.Fatalf("claim error: %v", err)
+	}
+	if got := claimedIDs(second); len(got) != 1 || got[0] != "due-sms" {
+		t.Fatalf("expected the second worker to claim only the unleased sms, got %v", got)
+	}
+
+	if err := ReleaseNotificationLease(ctx, database, "due-email", "worker-b"); err != nil {
+		t.Fatalf("release error: %v", err)
+	}
+	if third, _ := ClaimNotifications(ctx, database, claimFor("worker-b")); len(third) != 0 {
+		t.Fatalf("expected a release by another worker to keep the lease, got %v", claimedIDs(third))
+	}
+	if err := ReleaseNotificationLease(ctx, database, "due-email", "worker-a"); err != nil {
+		t.Fatalf("release error: %v", err)
+	}
+	reclaimed, err := ClaimNotifications(ctx, database, claimFor("worker-b"))
+	if err != nil {
+		t.Fatalf("claim error: %v", err)
+	}
+	if got := claimedIDs(reclaimed); len(got) != 1 || got[0] != "due-email" {
+		t.Fatalf("expected the released notification to be claimable again, got %v", got)
+	}
+}
+
+func TestClaimCandidatesSkipLockedRowsOnPostgres(t *testing.T) {
+	t.Helper()
+
+	dialector := postgres.New(postgres.Config{DSN: "postgres://pinguin@localhost/pinguin"})
+	database, openError := gorm.Open(dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if openError != nil {
+		t.Fatalf("open dry-run postgres error: %v", openError)
+	}
+	now := time.Now().UTC()
+	statement := database.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var candidateIDs []string
+		return claimCandidates(tx, NotificationClaim{WorkerID: "worker-a", MaxRetries: 3, Now: now, LeaseUntil: now.Add(time.Minute), Limit: 5}).Pluck("notification_id", &candidateIDs)
+	})
+	if !strings.Contains(statement, "FOR UPDATE SKIP LOCKED") {
+		t.Fatalf("expected claim candidates to be locked with SKIP LOCKED, got %s", statement)
+	}
+}
+
 func openModelTestDatabase(t *testing.T) *gorm.DB {
 	t.Helper()
 
@@ -199,7 +453,7 @@ func openModelTestDatabase(t *testing.T) *gorm.DB {
 	if openError != nil {
 		t.Fatalf("open database error: %v", openError)
 	}
-	if migrateError := database.AutoMigrate(&Notification{}, &NotificationAttachment{}); migrateError != nil {
+	if migrateError := database.AutoMigrate(&Notification{}, &NotificationAttachment{}, &NotificationRecipient{}, &Template{}, &FeedbackEvent{}, &RecipientDeliverability{}, &Suppression{}, &InboundMessage{}); migrateError != nil {
 		t.Fatalf("migration error: %v", migrateError)
 	}
 	return database
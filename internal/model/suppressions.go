@@ -0,0 +1,174 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrSuppressionNotFound = errors.New("suppression not found")
+
+// SuppressionReason records why a recipient must not be contacted.
+type SuppressionReason string
+
+const (
+	SuppressionUnsubscribed SuppressionReason = "unsubscribed"
+	SuppressionBounced      SuppressionReason = "bounced"
+	SuppressionComplaint    SuppressionReason = "complaint"
+	SuppressionStop         SuppressionReason = "stop"
+	SuppressionManual       SuppressionReason = "manual"
+)
+
+// IsValid reports whether reason is one of the known suppression reasons.
+func (reason SuppressionReason) IsValid() bool {
+	switch reason {
+	case SuppressionUnsubscribed, SuppressionBounced, SuppressionComplaint, SuppressionStop, SuppressionManual:
+		return true
+	default:
+		return false
+	}
+}
+
+// Suppression blocks delivery to one recipient on one channel until ExpiresAt, or indefinitely
+// when ExpiresAt is nil. An empty Category blocks every notification; otherwise only notifications
+// sent with that category are blocked.
+type Suppression struct {
+	ID        uint              `json:"-" gorm:"primaryKey"`
+	Channel   NotificationType  `json:"channel" gorm:"uniqueIndex:idx_suppressions_channel_recipient_category;not null"`
+	Recipient string            `json:"recipient" gorm:"uniqueIndex:idx_suppressions_channel_recipient_category;not null"`
+	Category  string            `json:"category,omitempty" gorm:"uniqueIndex:idx_suppressions_channel_recipient_category;not null;default:''"`
+	Reason    SuppressionReason `json:"reason"`
+	// Source names what created the entry, e.g. "api", "dashboard" or an inbound keyword handler.
+	Source    string     `json:"source,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// SuppressionRequest carries the fields API callers supply when suppressing a recipient.
+type SuppressionRequest struct {
+	Channel   NotificationType  `json:"channel"`
+	Recipient string            `json:"recipient"`
+	Category  string            `json:"category,omitempty"`
+	Reason    SuppressionReason `json:"reason"`
+	Source    string            `json:"source,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+}
+
+// SuppressionListFilters narrows ListSuppressions; zero values match everything.
+type SuppressionListFilters struct {
+	Channels []NotificationType
+	// IncludeExpired returns entries whose expiry has passed as well.
+	IncludeExpired bool
+}
+
+// IsActive reports whether the suppression still applies at now.
+func (suppression Suppression) IsActive(now time.Time) bool {
+	return suppression.ExpiresAt == nil || suppression.ExpiresAt.After(now)
+}
+
+// NormalizeSuppressionRecipient returns the form recipients are stored and matched in: email
+// addresses are lower-cased, phone numbers lose their formatting spaces.
+func NormalizeSuppressionRecipient(channel NotificationType, recipient string) string {
+	if channel == NotificationEmail {
+		return NormalizeEmailAddress(recipient)
+	}
+	return strings.Join(strings.Fields(recipient), "")
+}
+
+// NormalizeCategory returns the form notification categories are stored and matched in.
+func NormalizeCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+// UpsertSuppression creates the suppression or replaces the reason, source and expiry of the
+// existing entry for the same channel, recipient and category.
+func UpsertSuppression(ctx context.Context, db *gorm.DB, suppression *Suppression) error {
+	suppression.Category = NormalizeCategory(suppression.Category)
+	return db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel"}, {Name: "recipient"}, {Name: "category"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reason", "source", "expires_at", "updated_at"}),
+	}).Create(suppression).Error
+}
+
+// GetSuppression returns the suppression stored for a channel, recipient and category.
+func GetSuppression(ctx context.Context, db *gorm.DB, channel NotificationType, recipient string, category string) (*Suppression, error) {
+	var suppression Suppression
+	err := db.WithContext(ctx).
+		Where("channel = ? AND recipient = ? AND category = ?", channel, NormalizeSuppressionRecipient(channel, recipient), NormalizeCategory(category)).
+		First(&suppression).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSuppressionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &suppression, nil
+}
+
+// DeleteSuppression removes the suppression for a channel, recipient and category. Removing the
+// blanket email suppression also clears the undeliverable mark left by bounces and complaints, so
+// the address can be sent to again.
+func DeleteSuppression(ctx context.Context, db *gorm.DB, channel NotificationType, recipient string, category string) error {
+	recipient = NormalizeSuppressionRecipient(channel, recipient)
+	category = NormalizeCategory(category)
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("channel = ? AND recipient = ? AND category = ?", channel, recipient, category).Delete(&Suppression{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSuppressionNotFound
+		}
+		if channel != NotificationEmail || category != "" {
+			return nil
+		}
+		return tx.Model(&RecipientDeliverability{}).
+			Where("address = ? AND undeliverable = ?", recipient, true).
+			Updates(map[string]any{"undeliverable": false, "updated_at": time.Now().UTC()}).Error
+	})
+}
+
+// ListSuppressions returns suppressions ordered by channel and recipient.
+func ListSuppressions(ctx context.Context, db *gorm.DB, filters SuppressionListFilters, now time.Time) ([]Suppression, error) {
+	query := db.WithContext(ctx).Model(&Suppression{})
+	if len(filters.Channels) > 0 {
+		query = query.Where("channel IN ?", filters.Channels)
+	}
+	if !filters.IncludeExpired {
+		query = query.Where("(expires_at IS NULL OR expires_at > ?)", now)
+	}
+	var suppressions []Suppression
+	err := query.Order("channel ASC").Order("recipient ASC").Order("category ASC").Find(&suppressions).Error
+	return suppressions, err
+}
+
+// FindActiveSuppressions returns the active suppressions that block recipients on a channel for a
+// notification category, keyed by normalized recipient. Suppressions without a category always apply.
+func FindActiveSuppressions(ctx context.Context, db *gorm.DB, channel NotificationType, category string, recipients []string, now time.Time) (map[string]Suppression, error) {
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+	normalized := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		normalized = append(normalized, NormalizeSuppressionRecipient(channel, recipient))
+	}
+	var suppressions []Suppression
+	err := db.WithContext(ctx).
+		Where("channel = ? AND recipient IN ?", channel, normalized).
+		Where("category IN ?", []string{"", NormalizeCategory(category)}).
+		Where("(expires_at IS NULL OR expires_at > ?)", now).
+		Find(&suppressions).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]Suppression, len(suppressions))
+	for _, suppression := range suppressions {
+		result[suppression.Recipient] = suppression
+	}
+	return result, nil
+}
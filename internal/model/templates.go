@@ -0,0 +1,100 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrTemplateNotFound = errors.New("template not found")
+
+// Template stores one immutable version of a named message template. Editing a template creates a
+// new version so notifications keep referencing the exact content they were rendered from.
+type Template struct {
+	ID          uint      `json:"-" gorm:"primaryKey"`
+	TemplateID  string    `json:"template_id" gorm:"uniqueIndex:idx_templates_template_version;not null"`
+	Version     int       `json:"version" gorm:"uniqueIndex:idx_templates_template_version;not null"`
+	Description string    `json:"description,omitempty"`
+	Subject     string    `json:"subject,omitempty"`
+	PlainBody   string    `json:"plain_body,omitempty"`
+	HTMLBody    string    `json:"html_body,omitempty"`
+	SMSBody     string    `json:"sms_body,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TemplateRequest carries the editable template fields supplied by API callers.
+type TemplateRequest struct {
+	TemplateID  string `json:"template_id"`
+	Description string `json:"description,omitempty"`
+	Subject     string `json:"subject,omitempty"`
+	PlainBody   string `json:"plain_body,omitempty"`
+	HTMLBody    string `json:"html_body,omitempty"`
+	SMSBody     string `json:"sms_body,omitempty"`
+}
+
+// RenderedTemplate is the output of rendering a template against caller data.
+type RenderedTemplate struct {
+	TemplateID string `json:"template_id"`
+	Version    int    `json:"version"`
+	Subject    string `json:"subject,omitempty"`
+	PlainBody  string `json:"plain_body,omitempty"`
+	HTMLBody   string `json:"html_body,omitempty"`
+	SMSBody    string `json:"sms_body,omitempty"`
+}
+
+func CreateTemplate(ctx context.Context, db *gorm.DB, template *Template) error {
+	return db.WithContext(ctx).Create(template).Error
+}
+
+// GetTemplate returns the requested version of a template, or the latest version when version is zero.
+func GetTemplate(ctx context.Context, db *gorm.DB, templateID string, version int) (*Template, error) {
+	query := db.WithContext(ctx).Where("template_id = ?", templateID)
+	if version > 0 {
+		query = query.Where("version = ?", version)
+	} else {
+		query = query.Order("version DESC")
+	}
+	var template Template
+	if err := query.First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if version > 0 {
+				return nil, fmt.Errorf("%w: %s (version %d)", ErrTemplateNotFound, templateID, version)
+			}
+			return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, templateID)
+		}
+		return nil, fmt.Errorf("get_template: %w", err)
+	}
+	return &template, nil
+}
+
+// ListLatestTemplates returns the newest version of every template ordered by template ID.
+func ListLatestTemplates(ctx context.Context, db *gorm.DB) ([]Template, error) {
+	latestVersions := db.Model(&Template{}).
+		Select("template_id, MAX(version) AS version").
+		Group("template_id")
+	var templates []Template
+	err := db.WithContext(ctx).
+		Joins("JOIN (?) AS latest ON latest.template_id = templates.template_id AND latest.version = templates.version", latestVersions).
+		Order("templates.template_id ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// DeleteTemplate removes every version of a template and reports ErrTemplateNotFound when none existed.
+func DeleteTemplate(ctx context.Context, db *gorm.DB, templateID string) error {
+	result := db.WithContext(ctx).Where("template_id = ?", templateID).Delete(&Template{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", ErrTemplateNotFound, templateID)
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+)
+
+// WebhookEventType names an event that webhook subscriptions can receive.
+type WebhookEventType string
+
+const (
+	WebhookEventNotificationSent      WebhookEventType = "notification.sent"
+	WebhookEventNotificationErrored   WebhookEventType = "notification.errored"
+	WebhookEventNotificationCancelled WebhookEventType = "notification.cancelled"
+	// WebhookEventNotificationRetriesExhausted follows the errored attempt after which no further
+	// attempts are made.
+	WebhookEventNotificationRetriesExhausted WebhookEventType = "notification.retries_exhausted"
+)
+
+// IsValid reports whether eventType is one of the known webhook event types.
+func (eventType WebhookEventType) IsValid() bool {
+	switch eventType {
+	case WebhookEventNotificationSent, WebhookEventNotificationErrored, WebhookEventNotificationCancelled, WebhookEventNotificationRetriesExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebhookSubscription registers a URL that receives the events named in EventTypes, stored as a
+// comma separated list. Deliveries are signed with Secret.
+type WebhookSubscription struct {
+	ID             uint   `gorm:"primaryKey"`
+	SubscriptionID string `gorm:"uniqueIndex;not null"`
+	URL            string `gorm:"not null"`
+	Secret         string `json:"-" gorm:"not null"`
+	EventTypes     string `gorm:"not null"`
+	Description    string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Events returns the event types the subscription receives.
+func (subscription WebhookSubscription) Events() []WebhookEventType {
+	var events []WebhookEventType
+	for _, eventType := range strings.Split(subscription.EventTypes, ",") {
+		if eventType != "" {
+			events = append(events, WebhookEventType(eventType))
+		}
+	}
+	return events
+}
+
+// Receives reports whether the subscription is registered for eventType.
+func (subscription WebhookSubscription) Receives(eventType WebhookEventType) bool {
+	return slices.Contains(subscription.Events(), eventType)
+}
+
+// JoinWebhookEventTypes renders event types in the form WebhookSubscription.EventTypes stores them.
+func JoinWebhookEventTypes(eventTypes []WebhookEventType) string {
+	values := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		values = append(values, string(eventType))
+	}
+	return strings.Join(values, ",")
+}
+
+// WebhookSubscriptionRequest carries the fields API callers supply when creating or updating a
+// webhook subscription. An empty Secret asks for a generated one on create and keeps the current
+// one on update.
+type WebhookSubscriptionRequest struct {
+	URL         string             `json:"url"`
+	Secret      string             `json:"secret,omitempty"`
+	EventTypes  []WebhookEventType `json:"event_types"`
+	Description string             `json:"description,omitempty"`
+}
+
+// WebhookSubscriptionResponse is the API form of a subscription. Secret is only filled in when the
+// subscription is created or its secret replaced.
+type WebhookSubscriptionResponse struct {
+	SubscriptionID string             `json:"subscription_id"`
+	URL            string             `json:"url"`
+	EventTypes     []WebhookEventType `json:"event_types"`
+	Description    string             `json:"description,omitempty"`
+	Secret         string             `json:"secret,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// NewWebhookSubscriptionResponse renders subscription for API callers without its secret.
+func NewWebhookSubscriptionResponse(subscription WebhookSubscription) WebhookSubscriptionResponse {
+	return WebhookSubscriptionResponse{
+		SubscriptionID: subscription.SubscriptionID,
+		URL:            subscription.URL,
+		EventTypes:     subscription.Events(),
+		Description:    subscription.Description,
+		CreatedAt:      subscription.CreatedAt,
+		UpdatedAt:      subscription.UpdatedAt,
+	}
+}
+
+// WebhookEvent is the JSON body POSTed to subscribers. Every subscription receiving the same event
+// gets the same ID, so receivers can use it to discard duplicates.
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      WebhookEventData `json:"data"`
+}
+
+// WebhookEventData carries the notification an event describes, without attachment data.
+type WebhookEventData struct {
+	Notification NotificationResponse `json:"notification"`
+}
+
+// WebhookDeliveryStatus tracks one delivery through the webhook worker.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed deliveries are retried until their attempts reach the configured maximum.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery records one event sent, or to be sent, to one subscription, and doubles as the
+// delivery log. Payload holds the JSON body, so replays send exactly what was sent before.
+type WebhookDelivery struct {
+	ID              uint                  `json:"-" gorm:"primaryKey"`
+	DeliveryID      string                `json:"delivery_id" gorm:"uniqueIndex;not null"`
+	SubscriptionID  string                `json:"subscription_id" gorm:"index;not null"`
+	EventID         string                `json:"event_id" gorm:"index;not null"`
+	EventType       WebhookEventType      `json:"event_type"`
+	NotificationID  string                `json:"notification_id" gorm:"index"`
+	Payload         string                `json:"payload"`
+	Status          WebhookDeliveryStatus `json:"status" gorm:"index"`
+	Attempts        int                   `json:"attempts"`
+	ResponseStatus  int                   `json:"response_status,omitempty"`
+	LastError       string                `json:"last_error,omitempty"`
+	LastAttemptedAt *time.Time            `json:"last_attempted_at,omitempty"`
+	NextAttemptAt   *time.Time            `json:"next_attempt_at,omitempty"`
+	DeliveredAt     *time.Time            `json:"delivered_at,omitempty"`
+	// ReplayOf names the delivery this one re-sends.
+	ReplayOf   string     `json:"replay_of,omitempty"`
+	LockedBy   string     `json:"-" gorm:"index"`
+	LeaseUntil *time.Time `json:"-"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// WebhookDeliveryListFilters narrows ListWebhookDeliveries; zero values match everything.
+type WebhookDeliveryListFilters struct {
+	SubscriptionID string
+	NotificationID string
+	Statuses       []WebhookDeliveryStatus
+	Limit          int
+}
+
+// WebhookDeliveryClaim describes one ClaimWebhookDeliveries call. Deliveries to the subscriptions
+// in ExcludedSubscriptions are left for a later claim.
+type WebhookDeliveryClaim struct {
+	WorkerID              string
+	MaxAttempts           int
+	Now                   time.Time
+	LeaseUntil            time.Time
+	Limit                 int
+	ExcludedSubscriptions []string
+}
+
+// CreateWebhookSubscription stores a new subscription.
+func CreateWebhookSubscription(ctx context.Context, db *gorm.DB, subscription *WebhookSubscription) error {
+	return db.WithContext(ctx).Create(subscription).Error
+}
+
+// GetWebhookSubscription returns the subscription stored under subscriptionID.
+func GetWebhookSubscription(ctx context.Context, db *gorm.DB, subscriptionID string) (*WebhookSubscription, error) {
+	var subscription WebhookSubscription
+	err := db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).First(&subscription).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// SaveWebhookSubscription persists changes to an existing subscription.
+func SaveWebhookSubscription(ctx context.Context, db *gorm.DB, subscription *WebhookSubscription) error {
+	return db.WithContext(ctx).Save(subscription).Error
+}
+
+// DeleteWebhookSubscription removes a subscription. Its deliveries stay in the log.
+func DeleteWebhookSubscription(ctx context.Context, db *gorm.DB, subscriptionID string) error {
+	result := db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).Delete(&WebhookSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookSubscriptionNotFound
+	}
+	return nil
+}
+
+// ListWebhookSubscriptions returns every subscription, oldest first.
+func ListWebhookSubscriptions(ctx context.Context, db *gorm.DB) ([]WebhookSubscription, error) {
+	var subscriptions []WebhookSubscription
+	err := db.WithContext(ctx).Order("id ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// CreateWebhookDeliveries stores deliveries in one statement.
+func CreateWebhookDeliveries(ctx context.Context, db *gorm.DB, deliveries []WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Create(&deliveries).Error
+}
+
+// GetWebhookDelivery returns the delivery stored under deliveryID.
+func GetWebhookDelivery(ctx context.Context, db *gorm.DB, deliveryID string) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := db.WithContext(ctx).Where("delivery_id = ?", deliveryID).First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// SaveWebhookDelivery persists changes to an existing delivery.
+func SaveWebhookDelivery(ctx context.Context, db *gorm.DB, delivery *WebhookDelivery) error {
+	return db.WithContext(ctx).Save(delivery).Error
+}
+
+// ListWebhookDeliveries returns deliveries, newest first.
+func ListWebhookDeliveries(ctx context.Context, db *gorm.DB, filters WebhookDeliveryListFilters) ([]WebhookDelivery, error) {
+	query := db.WithContext(ctx).Model(&WebhookDelivery{})
+	if filters.SubscriptionID != "" {
+		query = query.Where("subscription_id = ?", filters.SubscriptionID)
+	}
+	if filters.NotificationID != "" {
+		query = query.Where("notification_id = ?", filters.NotificationID)
+	}
+	if len(filters.Statuses) > 0 {
+		query = query.Where("status IN ?", filters.Statuses)
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	var deliveries []WebhookDelivery
+	if err := query.Order("id DESC").Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimWebhookDeliveries leases up to claim.Limit due deliveries to claim.WorkerID, skipping
+// deliveries leased by another worker, the same way ClaimNotifications does for notifications.
+func ClaimWebhookDeliveries(ctx context.Context, db *gorm.DB, claim WebhookDeliveryClaim) ([]WebhookDelivery, error) {
+	var claimed []WebhookDelivery
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var candidateIDs []string
+		err := claimableWebhookDeliveries(tx, claim).
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+			Order("id ASC").
+			Limit(claim.Limit).
+			Pluck("delivery_id", &candidateIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(candidateIDs) == 0 {
+			return nil
+		}
+		leaseUpdate := claimableWebhookDeliveries(tx, claim).
+			Where("delivery_id IN ?", candidateIDs).
+			UpdateColumns(map[string]any{"locked_by": claim.WorkerID, "lease_until": claim.LeaseUntil})
+		if leaseUpdate.Error != nil {
+			return leaseUpdate.Error
+		}
+		return tx.Where("delivery_id IN ? AND locked_by = ?", candidateIDs, claim.WorkerID).
+			Order("id ASC").
+			Find(&claimed).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func claimableWebhookDeliveries(tx *gorm.DB, claim WebhookDeliveryClaim) *gorm.DB {
+	query := tx.Model(&WebhookDelivery{}).
+		Where("status IN ? AND attempts < ?", []WebhookDeliveryStatus{WebhookDeliveryPending, WebhookDeliveryFailed}, claim.MaxAttempts).
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", claim.Now).
+		Where("locked_by IS NULL OR locked_by = '' OR lease_until IS NULL OR lease_until < ?", claim.Now)
+	if len(claim.ExcludedSubscriptions) > 0 {
+		query = query.Where("subscription_id NOT IN ?", claim.ExcludedSubscriptions)
+	}
+	return query
+}
+
+// ReleaseWebhookDeliveryLease drops the lease workerID holds on a delivery without recording an
+// attempt.
+func ReleaseWebhookDeliveryLease(ctx context.Context, db *gorm.DB, deliveryID string, workerID string) error {
+	return db.WithContext(ctx).
+		Model(&WebhookDelivery{}).
+		Where("delivery_id = ? AND locked_by = ?", deliveryID, workerID).
+		UpdateColumns(map[string]any{"locked_by": "", "lease_until": nil}).Error
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	awsSigningAlgorithm = "AWS4-HMAC-SHA256"
+	awsAmzDateFormat    = "20060102T150405Z"
+	awsDateStampFormat  = "20060102"
+)
+
+// awsCredentials are the static credentials used to sign AWS API requests.
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// signAWSRequestV4 adds AWS Signature Version 4 headers to request. Every header already present on
+// the request is signed together with host and x-amz-date, so callers set Content-Type first.
+func signAWSRequestV4(request *http.Request, body []byte, credentials awsCredentials, region string, serviceName string, signingTime time.Time) {
+	signingTime = signingTime.UTC()
+	amzDate := signingTime.Format(awsAmzDateFormat)
+	dateStamp := signingTime.Format(awsDateStampFormat)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	canonicalHeaders, signedHeaders := awsCanonicalHeaders(request)
+	payloadDigest := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		awsCanonicalURI(request.URL),
+		awsCanonicalQuery(request.URL),
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(payloadDigest[:]),
+	}, "\n")
+
+	credentialScope := strings.Join([]string{dateStamp, region, serviceName, "aws4_request"}, "/")
+	canonicalDigest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		awsSigningAlgorithm,
+		amzDate,
+		credentialScope,
+		hex.EncodeToString(canonicalDigest[:]),
+	}, "\n")
+
+	signingKey := awsHMAC([]byte("AWS4"+credentials.SecretAccessKey), dateStamp)
+	signingKey = awsHMAC(signingKey, region)
+	signingKey = awsHMAC(signingKey, serviceName)
+	signingKey = awsHMAC(signingKey, "aws4_request")
+	signature := hex.EncodeToString(awsHMAC(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSigningAlgorithm, credentials.AccessKeyID, credentialScope, signedHeaders, signature,
+	))
+}
+
+func awsCanonicalHeaders(request *http.Request) (string, string) {
+	values := map[string]string{"host": request.URL.Host}
+	if request.Host != "" {
+		values["host"] = request.Host
+	}
+	for name, headerValues := range request.Header {
+		lowerName := strings.ToLower(name)
+		if lowerName == "authorization" || lowerName == "user-agent" {
+			continue
+		}
+		trimmed := make([]string, 0, len(headerValues))
+		for _, value := range headerValues {
+			trimmed = append(trimmed, strings.Join(strings.Fields(value), " "))
+		}
+		values[lowerName] = strings.Join(trimmed, ",")
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name)
+		canonical.WriteString(":")
+		canonical.WriteString(values[name])
+		canonical.WriteString("\n")
+	}
+	return canonical.String(), strings.Join(names, ";")
+}
+
+func awsCanonicalURI(requestURL *url.URL) string {
+	path := requestURL.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func awsCanonicalQuery(requestURL *url.URL) string {
+	query := requestURL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, awsURIEncode(key)+"="+awsURIEncode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// awsURIEncode percent-encodes everything except the RFC 3986 unreserved characters, as SigV4 requires.
+func awsURIEncode(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func awsHMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
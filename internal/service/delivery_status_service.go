@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// DeliveryEvent is a provider's asynchronous report about a message it accepted earlier.
+type DeliveryEvent struct {
+	Provider          string
+	ProviderMessageID string
+	// ProviderStatus is the provider's own status name, e.g. Twilio "delivered" or SendGrid "bounce".
+	ProviderStatus string
+	// Status is the notification status the event maps to; empty for events that only refresh ProviderStatus.
+	Status     model.NotificationStatus
+	OccurredAt time.Time
+}
+
+// DeliveryStatusService applies provider delivery events to the notifications they describe.
+type DeliveryStatusService interface {
+	ApplyDeliveryEvent(ctx context.Context, event DeliveryEvent) (model.NotificationResponse, error)
+}
+
+var (
+	ErrInvalidDeliveryEvent   = errors.New("invalid delivery event")
+	ErrDeliveryEventUnmatched = errors.New("delivery event does not match a notification")
+)
+
+type deliveryStatusServiceImpl struct {
+	database *gorm.DB
+	logger   *slog.Logger
+	events   *NotificationEventBus
+}
+
+// NewDeliveryStatusService creates a DeliveryStatusService backed by the notifications table. Status
+// changes are published to events, which may be nil.
+func NewDeliveryStatusService(database *gorm.DB, logger *slog.Logger, events *NotificationEventBus) DeliveryStatusService {
+	return &deliveryStatusServiceImpl{database: database, logger: logger, events: events}
+}
+
+func (serviceInstance *deliveryStatusServiceImpl) ApplyDeliveryEvent(ctx context.Context, event DeliveryEvent) (model.NotificationResponse, error) {
+	providerMessageID := strings.TrimSpace(event.ProviderMessageID)
+	if providerMessageID == "" {
+		return model.NotificationResponse{}, fmt.Errorf("%w: missing provider message id", ErrInvalidDeliveryEvent)
+	}
+
+	record, err := model.GetNotificationByProviderMessageID(ctx, serviceInstance.database, event.Provider, providerMessageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.NotificationResponse{}, fmt.Errorf("%w: %s %s", ErrDeliveryEventUnmatched, event.Provider, providerMessageID)
+		}
+		return model.NotificationResponse{}, err
+	}
+
+	previousStatus := record.Status
+	if canApplyDeliveryStatus(record.Status, event.Status) {
+		record.Status = event.Status
+	}
+	if event.ProviderStatus != "" {
+		record.ProviderStatus = event.ProviderStatus
+	}
+	record.UpdatedAt = time.Now().UTC()
+	if err := model.SaveNotification(ctx, serviceInstance.database, record); err != nil {
+		return model.NotificationResponse{}, err
+	}
+	if record.Status != previousStatus {
+		serviceInstance.events.PublishTransition(ctx, previousStatus, *record)
+	}
+
+	serviceInstance.logger.Info(
+		"delivery_event_applied",
+		"notification_id", record.NotificationID,
+		"provider", event.Provider,
+		"provider_status", event.ProviderStatus,
+		"previous_status", previousStatus,
+		"status", record.Status,
+		"occurred_at", event.OccurredAt,
+	)
+	return model.NewNotificationResponse(*record), nil
+}
+
+// deliveryStatusRank orders statuses a webhook may move a notification through. Providers do not
+// guarantee callback order, so a late "delivered" never overrides an earlier bounce, and the first
+// failure (undelivered or bounced) is final.
+func deliveryStatusRank(status model.NotificationStatus) int {
+	switch status {
+	case model.StatusSent:
+		return 1
+	case model.StatusDelivered:
+		return 2
+	case model.StatusUndelivered, model.StatusBounced:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// canApplyDeliveryStatus reports whether a notification in current may move to next. Only sent
+// notifications receive delivery statuses; queued, errored and cancelled rows are left alone.
+// Statuses only move up in rank, so a redelivered or reordered callback cannot change a failure
+// into another failure and publish the transition again.
+func canApplyDeliveryStatus(current model.NotificationStatus, next model.NotificationStatus) bool {
+	nextRank := deliveryStatusRank(next)
+	currentRank := deliveryStatusRank(current)
+	if nextRank < 2 || currentRank == 0 {
+		return false
+	}
+	return nextRank > currentRank
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+)
+
+func TestApplyDeliveryEventTransitions(t *testing.T) {
+	t.Helper()
+	testCases := []struct {
+		name           string
+		initialStatus  model.NotificationStatus
+		events         []DeliveryEvent
+		expectedStatus model.NotificationStatus
+		expectedRaw    string
+		// expectedTransitions lists the status changes published, in order.
+		expectedTransitions []model.NotificationStatus
+	}{
+		{
+			name:                "SentBecomesDelivered",
+			initialStatus:       model.StatusSent,
+			events:              []DeliveryEvent{{ProviderStatus: "delivered", Status: model.StatusDelivered}},
+			expectedStatus:      model.StatusDelivered,
+			expectedRaw:         "delivered",
+			expectedTransitions: []model.NotificationStatus{model.StatusDelivered},
+		},
+		{
+			name:          "IntermediateEventOnlyRefreshesProviderStatus",
+			initialStatus: model.StatusSent,
+			events: []DeliveryEvent{
+				{ProviderStatus: "sending"},
+			},
+			expectedStatus: model.StatusSent,
+			expectedRaw:    "sending",
+		},
+		{
+			name:          "BounceOverridesDelivered",
+			initialStatus: model.StatusSent,
+			events: []DeliveryEvent{
+				{ProviderStatus: "delivered", Status: model.StatusDelivered},
+				{ProviderStatus: "bounce", Status: model.StatusBounced},
+			},
+			expectedStatus:      model.StatusBounced,
+			expectedRaw:         "bounce",
+			expectedTransitions: []model.NotificationStatus{model.StatusDelivered, model.StatusBounced},
+		},
+		{
+			name:          "LateDeliveredDoesNotOverrideUndelivered",
+			initialStatus: model.StatusSent,
+			events: []DeliveryEvent{
+				{ProviderStatus: "undelivered", Status: model.StatusUndelivered},
+				{ProviderStatus: "delivered", Status: model.StatusDelivered},
+			},
+			expectedStatus:      model.StatusUndelivered,
+			expectedRaw:         "delivered",
+			expectedTransitions: []model.NotificationStatus{model.StatusUndelivered},
+		},
+		{
+			name:          "LateBounceDoesNotOverrideUndelivered",
+			initialStatus: model.StatusSent,
+			events: []DeliveryEvent{
+				{ProviderStatus: "undelivered", Status: model.StatusUndelivered},
+				{ProviderStatus: "bounce", Status: model.StatusBounced},
+			},
+			expectedStatus:      model.StatusUndelivered,
+			expectedRaw:         "bounce",
+			expectedTransitions: []model.NotificationStatus{model.StatusUndelivered},
+		},
+		{
+			name:          "ReorderedFailuresDoNotFlipFlop",
+			initialStatus: model.StatusSent,
+			events: []DeliveryEvent{
+				{ProviderStatus: "bounce", Status: model.StatusBounced},
+				{ProviderStatus: "undelivered", Status: model.StatusUndelivered},
+				{ProviderStatus: "bounce", Status: model.StatusBounced},
+			},
+			expectedStatus:      model.StatusBounced,
+			expectedRaw:         "bounce",
+			expectedTransitions: []model.NotificationStatus{model.StatusBounced},
+		},
+		{
+			name:           "UnsentNotificationKeepsStatus",
+			initialStatus:  model.StatusErrored,
+			events:         []DeliveryEvent{{ProviderStatus: "delivered", Status: model.StatusDelivered}},
+			expectedStatus: model.StatusErrored,
+			expectedRaw:    "delivered",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database := openIsolatedDatabase(t)
+			record := model.Notification{
+				NotificationID:    "notif-delivery",
+				NotificationType:  model.NotificationSMS,
+				Recipient:         "+15550001111",
+				Message:           "Body",
+				Status:            testCase.initialStatus,
+				ProviderMessageID: "SM123",
+				Provider:          "twilio",
+				CreatedAt:         time.Now().UTC(),
+				UpdatedAt:         time.Now().UTC(),
+			}
+			if err := model.CreateNotification(context.Background(), database, &record); err != nil {
+				t.Fatalf("create notification: %v", err)
+			}
+			events := NewNotificationEventBus()
+			transitions := &transitionRecorder{}
+			events.Observe(transitions)
+			serviceInstance := NewDeliveryStatusService(database, newDiscardLogger(), events)
+
+			var response model.NotificationResponse
+			for _, event := range testCase.events {
+				event.Provider = "twilio"
+				event.ProviderMessageID = "SM123"
+				var err error
+				response, err = serviceInstance.ApplyDeliveryEvent(context.Background(), event)
+				if err != nil {
+					t.Fatalf("apply delivery event: %v", err)
+				}
+			}
+			if response.Status != testCase.expectedStatus || response.ProviderStatus != testCase.expectedRaw {
+				t.Fatalf("expected %s/%s, got %s/%s", testCase.expectedStatus, testCase.expectedRaw, response.Status, response.ProviderStatus)
+			}
+			stored, err := model.GetNotificationByID(context.Background(), database, "notif-delivery")
+			if err != nil {
+				t.Fatalf("load notification: %v", err)
+			}
+			if stored.Status != testCase.expectedStatus {
+				t.Fatalf("expected stored status %s, got %s", testCase.expectedStatus, stored.Status)
+			}
+			if !slices.Equal(transitions.statuses, testCase.expectedTransitions) {
+				t.Fatalf("expected transitions %v, got %v", testCase.expectedTransitions, transitions.statuses)
+			}
+		})
+	}
+}
+
+// transitionRecorder collects the statuses of the status changes published to a bus.
+type transitionRecorder struct {
+	statuses []model.NotificationStatus
+}
+
+func (recorder *transitionRecorder) ObserveTransition(_ context.Context, previousStatus model.NotificationStatus, record model.Notification) {
+	if record.Status != previousStatus {
+		recorder.statuses = append(recorder.statuses, record.Status)
+	}
+}
+
+func TestApplyDeliveryEventErrors(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	serviceInstance := NewDeliveryStatusService(database, newDiscardLogger(), nil)
+
+	testCases := []struct {
+		name        string
+		event       DeliveryEvent
+		expectedErr error
+	}{
+		{name: "MissingMessageID", event: DeliveryEvent{Provider: "twilio", Status: model.StatusDelivered}, expectedErr: ErrInvalidDeliveryEvent},
+		{name: "UnknownMessage", event: DeliveryEvent{Provider: "twilio", ProviderMessageID: "SM-missing", Status: model.StatusDelivered}, expectedErr: ErrDeliveryEventUnmatched},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := serviceInstance.ApplyDeliveryEvent(context.Background(), testCase.event)
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Fatalf("expected %v, got %v", testCase.expectedErr, err)
+			}
+		})
+	}
+}
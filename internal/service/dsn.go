@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
+)
+
+// ErrInvalidDSN reports a message that is not an RFC 3464 delivery status notification.
+var ErrInvalidDSN = errors.New("invalid delivery status notification")
+
+// ParseDSN reads a multipart/report delivery status notification (RFC 3464) and returns one
+// feedback report per failed or delayed recipient. Relayed and delivered recipients are ignored.
+// The original Message-ID, quoted back in the returned headers, becomes ProviderMessageID so the
+// reports link to notifications sent over SMTP, and the DSN's own Message-ID becomes ReportID.
+func ParseDSN(reader io.Reader) ([]FeedbackReport, error) {
+	message, err := mail.ReadMessage(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDSN, err)
+	}
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" || !strings.EqualFold(params["report-type"], "delivery-status") {
+		return nil, fmt.Errorf("%w: expected multipart/report with report-type=delivery-status", ErrInvalidDSN)
+	}
+
+	var (
+		recipientFields []textproto.MIMEHeader
+		messageFields   textproto.MIMEHeader
+		originalID      string
+		foundStatus     bool
+	)
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, partErr := parts.NextPart()
+		if errors.Is(partErr, io.EOF) {
+			break
+		}
+		if partErr != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDSN, partErr)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch partType {
+		case "message/delivery-status", "message/global-delivery-status":
+			messageFields, recipientFields, err = readDeliveryStatusFields(part)
+			if err != nil {
+				return nil, err
+			}
+			foundStatus = true
+		case "message/rfc822", "text/rfc822-headers", "message/global", "message/global-headers":
+			headers, headerErr := textproto.NewReader(bufio.NewReader(part)).ReadMIMEHeader()
+			if headerErr != nil && !errors.Is(headerErr, io.EOF) {
+				return nil, fmt.Errorf("%w: original headers: %v", ErrInvalidDSN, headerErr)
+			}
+			originalID = strings.TrimSpace(headers.Get("Message-Id"))
+		}
+	}
+	if !foundStatus {
+		return nil, fmt.Errorf("%w: missing message/delivery-status part", ErrInvalidDSN)
+	}
+
+	reportID := strings.TrimSpace(message.Header.Get("Message-Id"))
+	arrivalDate := parseDSNDate(messageFields.Get("Arrival-Date"))
+	var reports []FeedbackReport
+	for _, fields := range recipientFields {
+		feedbackType, tracked := dsnFeedbackType(fields.Get("Action"), fields.Get("Status"))
+		if !tracked {
+			continue
+		}
+		recipient := dsnAddress(fields.Get("Final-Recipient"))
+		if recipient == "" {
+			recipient = dsnAddress(fields.Get("Original-Recipient"))
+		}
+		if recipient == "" {
+			continue
+		}
+		occurredAt := parseDSNDate(fields.Get("Last-Attempt-Date"))
+		if occurredAt.IsZero() {
+			occurredAt = arrivalDate
+		}
+		diagnostic := strings.TrimSpace(fields.Get("Diagnostic-Code"))
+		if diagnostic == "" {
+			diagnostic = strings.TrimSpace(fields.Get("Status"))
+		}
+		reports = append(reports, FeedbackReport{
+			Provider:          config.EmailProviderSMTP,
+			ProviderMessageID: originalID,
+			ReportID:          reportID,
+			Recipient:         recipient,
+			Type:              feedbackType,
+			Diagnostic:        diagnostic,
+			OccurredAt:        occurredAt,
+		})
+	}
+	return reports, nil
+}
+
+// readDeliveryStatusFields splits a message/delivery-status body into its per-message field group
+// and the per-recipient groups that follow it.
+func readDeliveryStatusFields(body io.Reader) (textproto.MIMEHeader, []textproto.MIMEHeader, error) {
+	reader := textproto.NewReader(bufio.NewReader(body))
+	var groups []textproto.MIMEHeader
+	for {
+		fields, err := reader.ReadMIMEHeader()
+		if len(fields) > 0 {
+			groups = append(groups, fields)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: delivery-status fields: %v", ErrInvalidDSN, err)
+		}
+	}
+	if len(groups) < 2 {
+		return nil, nil, fmt.Errorf("%w: no per-recipient fields", ErrInvalidDSN)
+	}
+	return groups[0], groups[1:], nil
+}
+
+// dsnFeedbackType maps the Action and Status fields to a feedback type. Permanent (5.x.x) failures
+// are hard bounces; transient failures and delays are soft bounces.
+func dsnFeedbackType(action string, status string) (model.FeedbackType, bool) {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "failed":
+		if strings.HasPrefix(strings.TrimSpace(status), "4.") {
+			return model.FeedbackSoftBounce, true
+		}
+		return model.FeedbackHardBounce, true
+	case "delayed":
+		return model.FeedbackSoftBounce, true
+	default:
+		return "", false
+	}
+}
+
+// dsnAddress strips the address-type prefix from a Final-Recipient value such as "rfc822; a@b.c".
+func dsnAddress(value string) string {
+	if _, address, found := strings.Cut(value, ";"); found {
+		value = address
+	}
+	return strings.Trim(strings.TrimSpace(value), "<>")
+}
+
+func parseDSNDate(value string) time.Time {
+	parsed, err := mail.ParseDate(strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed.UTC()
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+const defaultMailgunBaseURL = "https://api.mailgun.net"
+
+// MailgunConfig configures delivery through the Mailgun messages.mime endpoint. Set BaseURL to
+// https://api.eu.mailgun.net for domains hosted in the EU region.
+type MailgunConfig struct {
+	APIKey      string
+	Domain      string
+	BaseURL     string
+	FromAddress string
+}
+
+// MailgunEmailSender uploads the same MIME document the SMTP sender produces, so headers, HTML
+// alternatives and attachments render identically across providers.
+type MailgunEmailSender struct {
+	Config     MailgunConfig
+	HTTPClient *http.Client
+	Logger     *slog.Logger
+}
+
+func NewMailgunEmailSender(configuration MailgunConfig, httpClient *http.Client, logger *slog.Logger) *MailgunEmailSender {
+	configuration.BaseURL = resolveBaseURL(configuration.BaseURL, defaultMailgunBaseURL)
+	return &MailgunEmailSender{Config: configuration, HTTPClient: httpClient, Logger: logger}
+}
+
+type mailgunSendResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+func (senderInstance *MailgunEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	envelopeRecipients := message.EnvelopeRecipients()
+	if len(envelopeRecipients) == 0 {
+		return EmailDeliveryResult{}, fmt.Errorf("email message has no recipients")
+	}
+
+	var body bytes.Buffer
+	formWriter := multipart.NewWriter(&body)
+	for _, recipient := range envelopeRecipients {
+		if err := formWriter.WriteField("to", recipient); err != nil {
+			return EmailDeliveryResult{}, fmt.Errorf("encode mailgun request: %w", err)
+		}
+	}
+	mimePart, partErr := formWriter.CreateFormFile("message", "message.mime")
+	if partErr != nil {
+		return EmailDeliveryResult{}, fmt.Errorf("encode mailgun request: %w", partErr)
+	}
+	if _, err := mimePart.Write([]byte(buildEmailMessage(senderInstance.Config.FromAddress, message))); err != nil {
+		return EmailDeliveryResult{}, fmt.Errorf("encode mailgun request: %w", err)
+	}
+	if err := formWriter.Close(); err != nil {
+		return EmailDeliveryResult{}, fmt.Errorf("encode mailgun request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v3/%s/messages.mime", senderInstance.Config.BaseURL, url.PathEscape(senderInstance.Config.Domain))
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if requestErr != nil {
+		return EmailDeliveryResult{}, requestErr
+	}
+	request.SetBasicAuth("api", senderInstance.Config.APIKey)
+	request.Header.Set("Content-Type", formWriter.FormDataContentType())
+
+	response, responseErr := senderInstance.HTTPClient.Do(request)
+	if responseErr != nil {
+		senderInstance.Logger.Error("Mailgun request error", "error", responseErr)
+		return EmailDeliveryResult{}, responseErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return EmailDeliveryResult{}, providerResponseError(config.EmailProviderMailgun, response)
+	}
+	var decoded mailgunSendResponse
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		logUnreadableAcceptance(senderInstance.Logger, config.EmailProviderMailgun, err)
+	}
+	return acceptedByProvider(config.EmailProviderMailgun, message, decoded.ID), nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+const defaultPostmarkBaseURL = "https://api.postmarkapp.com"
+
+// PostmarkConfig configures delivery through the Postmark email API.
+type PostmarkConfig struct {
+	ServerToken   string
+	MessageStream string
+	BaseURL       string
+	FromAddress   string
+}
+
+// PostmarkEmailSender delivers email through the Postmark /email endpoint.
+type PostmarkEmailSender struct {
+	Config     PostmarkConfig
+	HTTPClient *http.Client
+	Logger     *slog.Logger
+}
+
+func NewPostmarkEmailSender(configuration PostmarkConfig, httpClient *http.Client, logger *slog.Logger) *PostmarkEmailSender {
+	configuration.BaseURL = resolveBaseURL(configuration.BaseURL, defaultPostmarkBaseURL)
+	return &PostmarkEmailSender{Config: configuration, HTTPClient: httpClient, Logger: logger}
+}
+
+type postmarkAttachment struct {
+	Name        string `json:"Name"`
+	Content     string `json:"Content"`
+	ContentType string `json:"ContentType"`
+}
+
+type postmarkHeader struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type postmarkEmailRequest struct {
+	From          string               `json:"From"`
+	To            string               `json:"To"`
+	Cc            string               `json:"Cc,omitempty"`
+	Bcc           string               `json:"Bcc,omitempty"`
+	Subject       string               `json:"Subject"`
+	TextBody      string               `json:"TextBody"`
+	HTMLBody      string               `json:"HtmlBody,omitempty"`
+	MessageStream string               `json:"MessageStream,omitempty"`
+	Attachments   []postmarkAttachment `json:"Attachments,omitempty"`
+	Headers       []postmarkHeader     `json:"Headers,omitempty"`
+}
+
+type postmarkEmailResponse struct {
+	MessageID string `json:"MessageID"`
+	ErrorCode int    `json:"ErrorCode"`
+	Message   string `json:"Message"`
+}
+
+func (senderInstance *PostmarkEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	if len(message.To) == 0 {
+		return EmailDeliveryResult{}, fmt.Errorf("email message has no recipients")
+	}
+	payload := postmarkEmailRequest{
+		From:          senderInstance.Config.FromAddress,
+		To:            strings.Join(message.To, ", "),
+		Cc:            strings.Join(message.Cc, ", "),
+		Bcc:           strings.Join(message.Bcc, ", "),
+		Subject:       message.Subject,
+		TextBody:      message.Body,
+		HTMLBody:      message.HTMLBody,
+		MessageStream: senderInstance.Config.MessageStream,
+	}
+	if message.ListUnsubscribeURL != "" {
+		payload.Headers = []postmarkHeader{
+			{Name: "List-Unsubscribe", Value: "<" + message.ListUnsubscribeURL + ">"},
+			{Name: "List-Unsubscribe-Post", Value: listUnsubscribePostValue},
+		}
+	}
+	for _, attachment := range message.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = defaultAttachmentContentType
+		}
+		payload.Attachments = append(payload.Attachments, postmarkAttachment{
+			Name:        sanitizeFilename(attachment.Filename),
+			Content:     base64.StdEncoding.EncodeToString(attachment.Data),
+			ContentType: contentType,
+		})
+	}
+	body, marshalErr := json.Marshal(payload)
+	if marshalErr != nil {
+		return EmailDeliveryResult{}, fmt.Errorf("encode postmark request: %w", marshalErr)
+	}
+
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, senderInstance.Config.BaseURL+"/email", bytes.NewReader(body))
+	if requestErr != nil {
+		return EmailDeliveryResult{}, requestErr
+	}
+	request.Header.Set("X-Postmark-Server-Token", senderInstance.Config.ServerToken)
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, responseErr := senderInstance.HTTPClient.Do(request)
+	if responseErr != nil {
+		senderInstance.Logger.Error("Postmark request error", "error", responseErr)
+		return EmailDeliveryResult{}, responseErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return EmailDeliveryResult{}, providerResponseError(config.EmailProviderPostmark, response)
+	}
+	var decoded postmarkEmailResponse
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		logUnreadableAcceptance(senderInstance.Logger, config.EmailProviderPostmark, err)
+	}
+	if decoded.ErrorCode != 0 {
+		return EmailDeliveryResult{}, fmt.Errorf("postmark API error %d: %s", decoded.ErrorCode, decoded.Message)
+	}
+	return acceptedByProvider(config.EmailProviderPostmark, message, decoded.MessageID), nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+const maxProviderErrorBodyBytes = 4096
+
+// NewEmailSender builds the EmailSender selected by configuration. A single provider is returned
+// as-is; several providers are wrapped in a FailoverEmailSender that tries them in order behind
+// per-provider circuit breakers.
+func NewEmailSender(cfg config.Config, logger *slog.Logger) EmailSender {
+	httpClient := &http.Client{Timeout: time.Duration(cfg.ConnectionTimeoutSec) * time.Second}
+	if len(cfg.EmailProviders) <= 1 {
+		return newEmailSenderByName(cfg.EmailProvider, cfg, httpClient, logger)
+	}
+	cooldown := time.Duration(cfg.ProviderBreakerCooldownSec) * time.Second
+	routes := make([]providerRoute, 0, len(cfg.EmailProviders))
+	senders := make([]EmailSender, 0, len(cfg.EmailProviders))
+	for _, configured := range cfg.EmailProviders {
+		routes = append(routes, providerRoute{
+			name:    configured.Name,
+			weight:  configured.Weight,
+			breaker: newCircuitBreaker(cfg.ProviderBreakerThreshold, cooldown),
+		})
+		senders = append(senders, newEmailSenderByName(configured.Name, cfg, httpClient, logger))
+	}
+	return &FailoverEmailSender{router: newProviderRouter("email", routes, logger), senders: senders}
+}
+
+// newEmailSenderByName builds a single email provider. Configuration loading rejects unknown
+// providers, so anything other than an HTTP API provider falls back to SMTP.
+func newEmailSenderByName(providerName string, cfg config.Config, httpClient *http.Client, logger *slog.Logger) EmailSender {
+	switch providerName {
+	case config.EmailProviderSendGrid:
+		return NewSendGridEmailSender(SendGridConfig{
+			APIKey:      cfg.SendGridAPIKey,
+			BaseURL:     cfg.SendGridBaseURL,
+			FromAddress: cfg.FromEmail,
+		}, httpClient, logger)
+	case config.EmailProviderMailgun:
+		return NewMailgunEmailSender(MailgunConfig{
+			APIKey:      cfg.MailgunAPIKey,
+			Domain:      cfg.MailgunDomain,
+			BaseURL:     cfg.MailgunBaseURL,
+			FromAddress: cfg.FromEmail,
+		}, httpClient, logger)
+	case config.EmailProviderSES:
+		return NewSESEmailSender(SESConfig{
+			Region:          cfg.SESRegion,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			SessionToken:    cfg.AWSSessionToken,
+			BaseURL:         cfg.SESBaseURL,
+			FromAddress:     cfg.FromEmail,
+		}, httpClient, logger)
+	case config.EmailProviderPostmark:
+		return NewPostmarkEmailSender(PostmarkConfig{
+			ServerToken:   cfg.PostmarkServerToken,
+			MessageStream: cfg.PostmarkMessageStream,
+			BaseURL:       cfg.PostmarkBaseURL,
+			FromAddress:   cfg.FromEmail,
+		}, httpClient, logger)
+	default:
+		return NewSMTPEmailSender(SMTPConfig{
+			Host:        cfg.SMTPHost,
+			Port:        fmt.Sprintf("%d", cfg.SMTPPort),
+			Username:    cfg.SMTPUsername,
+			Password:    cfg.SMTPPassword,
+			FromAddress: cfg.FromEmail,
+			Timeouts:    cfg,
+		}, logger)
+	}
+}
+
+// acceptedByProvider reports every envelope recipient as accepted. HTTP API providers accept or
+// reject a message as a whole, so per-recipient outcomes arrive later through provider events.
+func acceptedByProvider(providerName string, message EmailMessage, providerMessageID string) EmailDeliveryResult {
+	return EmailDeliveryResult{
+		AcceptedRecipients: message.EnvelopeRecipients(),
+		ProviderMessageID:  providerMessageID,
+		Provider:           providerName,
+	}
+}
+
+// logUnreadableAcceptance records a 2xx provider response whose body could not be read. The provider
+// has accepted the message, so callers report it as sent without a provider message ID: failing it
+// would make the router or the retry worker send it again.
+func logUnreadableAcceptance(logger *slog.Logger, providerName string, err error) {
+	logger.Warn("provider_response_unreadable", "provider", providerName, "error", err)
+}
+
+// providerResponseError converts a non-2xx provider response into a ProviderHTTPError carrying a
+// bounded excerpt of the response body.
+func providerResponseError(providerName string, response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxProviderErrorBodyBytes))
+	return &ProviderHTTPError{Provider: providerName, StatusCode: response.StatusCode, Body: strings.TrimSpace(string(body))}
+}
+
+func resolveBaseURL(configured string, fallback string) string {
+	trimmed := strings.TrimRight(strings.TrimSpace(configured), "/")
+	if trimmed == "" {
+		return fallback
+	}
+	return trimmed
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
+)
+
+func TestHTTPEmailProvidersReturnProviderMessageID(t *testing.T) {
+	t.Helper()
+
+	message := EmailMessage{
+		To:       []string{"to@example.com"},
+		Cc:       []string{"cc@example.com"},
+		Bcc:      []string{"bcc@example.com"},
+		Subject:  "Greetings",
+		Body:     "Hello body",
+		HTMLBody: "<p>Hello body</p>",
+		Attachments: []model.EmailAttachment{
+			{Filename: "report.txt", ContentType: "text/plain", Data: []byte("report")},
+		},
+		ListUnsubscribeURL: "https://notify.example.com/unsubscribe?token=abc",
+	}
+
+	testCases := []struct {
+		name              string
+		handler           func(t *testing.T, writer http.ResponseWriter, request *http.Request)
+		newSender         func(baseURL string) EmailSender
+		expectedMessageID string
+	}{
+		{
+			name: "SendGrid",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				if request.URL.Path != "/v3/mail/send" {
+					t.Fatalf("unexpected path %s", request.URL.Path)
+				}
+				if request.Header.Get("Authorization") != "Bearer sg-key" {
+					t.Fatalf("unexpected authorization %q", request.Header.Get("Authorization"))
+				}
+				var payload sendGridMailRequest
+				if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+					t.Fatalf("decode payload: %v", err)
+				}
+				if payload.From.Email != "from@example.com" || payload.Subject != "Greetings" {
+					t.Fatalf("unexpected payload header fields: %+v", payload)
+				}
+				personalization := payload.Personalizations[0]
+				if personalization.To[0].Email != "to@example.com" || personalization.Cc[0].Email != "cc@example.com" || personalization.Bcc[0].Email != "bcc@example.com" {
+					t.Fatalf("unexpected personalization: %+v", personalization)
+				}
+				if len(payload.Content) != 2 || payload.Content[0].Type != "text/plain" || payload.Content[1].Type != "text/html" {
+					t.Fatalf("unexpected content: %+v", payload.Content)
+				}
+				if len(payload.Attachments) != 1 || payload.Attachments[0].Content != base64.StdEncoding.EncodeToString([]byte("report")) {
+					t.Fatalf("unexpected attachments: %+v", payload.Attachments)
+				}
+				if payload.Headers["List-Unsubscribe"] != "<https://notify.example.com/unsubscribe?token=abc>" || payload.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+					t.Fatalf("unexpected headers: %+v", payload.Headers)
+				}
+				writer.Header().Set("X-Message-Id", "sg-message-1")
+				writer.WriteHeader(http.StatusAccepted)
+			},
+			newSender: func(baseURL string) EmailSender {
+				return NewSendGridEmailSender(SendGridConfig{APIKey: "sg-key", BaseURL: baseURL, FromAddress: "from@example.com"}, http.DefaultClient, newDiscardLogger())
+			},
+			expectedMessageID: "sg-message-1",
+		},
+		{
+			name: "Mailgun",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				if request.URL.Path != "/v3/mg.example.com/messages.mime" {
+					t.Fatalf("unexpected path %s", request.URL.Path)
+				}
+				user, pass, ok := request.BasicAuth()
+				if !ok || user != "api" || pass != "mg-key" {
+					t.Fatalf("unexpected basic auth %q:%q", user, pass)
+				}
+				if err := request.ParseMultipartForm(1 << 20); err != nil {
+					t.Fatalf("parse multipart form: %v", err)
+				}
+				recipients := request.MultipartForm.Value["to"]
+				if strings.Join(recipients, ",") != "to@example.com,cc@example.com,bcc@example.com" {
+					t.Fatalf("unexpected recipients %v", recipients)
+				}
+				mimeFile, _, err := request.FormFile("message")
+				if err != nil {
+					t.Fatalf("missing message file: %v", err)
+				}
+				mimeDocument, _ := io.ReadAll(mimeFile)
+				if !strings.Contains(string(mimeDocument), "Subject: Greetings") || strings.Contains(string(mimeDocument), "bcc@example.com") ||
+					!strings.Contains(string(mimeDocument), "List-Unsubscribe: <https://notify.example.com/unsubscribe?token=abc>\r\n") {
+					t.Fatalf("unexpected MIME document:\n%s", mimeDocument)
+				}
+				writer.Header().Set("Content-Type", "application/json")
+				_, _ = writer.Write([]byte(`{"id":"<mg-message-1@mg.example.com>","message":"Queued. Thank you."}`))
+			},
+			newSender: func(baseURL string) EmailSender {
+				return NewMailgunEmailSender(MailgunConfig{APIKey: "mg-key", Domain: "mg.example.com", BaseURL: baseURL, FromAddress: "from@example.com"}, http.DefaultClient, newDiscardLogger())
+			},
+			expectedMessageID: "<mg-message-1@mg.example.com>",
+		},
+		{
+			name: "SES",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				if request.URL.Path != "/v2/email/outbound-emails" {
+					t.Fatalf("unexpected path %s", request.URL.Path)
+				}
+				authorization := request.Header.Get("Authorization")
+				if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDTEST/") || !strings.Contains(authorization, "/eu-west-1/ses/aws4_request") {
+					t.Fatalf("unexpected authorization %q", authorization)
+				}
+				if request.Header.Get("X-Amz-Security-Token") != "session" {
+					t.Fatalf("expected session token header")
+				}
+				var payload sesSendEmailRequest
+				if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+					t.Fatalf("decode payload: %v", err)
+				}
+				if payload.FromEmailAddress != "from@example.com" || payload.Destination.BccAddresses[0] != "bcc@example.com" {
+					t.Fatalf("unexpected payload: %+v", payload)
+				}
+				if !strings.Contains(string(payload.Content.Raw.Data), "Subject: Greetings") {
+					t.Fatalf("unexpected raw message:\n%s", payload.Content.Raw.Data)
+				}
+				writer.Header().Set("Content-Type", "application/json")
+				_, _ = writer.Write([]byte(`{"MessageId":"ses-message-1"}`))
+			},
+			newSender: func(baseURL string) EmailSender {
+				return NewSESEmailSender(SESConfig{
+					Region:          "eu-west-1",
+					AccessKeyID:     "AKIDTEST",
+					SecretAccessKey: "secret",
+					SessionToken:    "session",
+					BaseURL:         baseURL,
+					FromAddress:     "from@example.com",
+				}, http.DefaultClient, newDiscardLogger())
+			},
+			expectedMessageID: "ses-message-1",
+		},
+		{
+			name: "Postmark",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				if request.URL.Path != "/email" {
+					t.Fatalf("unexpected path %s", request.URL.Path)
+				}
+				if request.Header.Get("X-Postmark-Server-Token") != "pm-token" {
+					t.Fatalf("unexpected server token %q", request.Header.Get("X-Postmark-Server-Token"))
+				}
+				var payload postmarkEmailRequest
+				if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+					t.Fatalf("decode payload: %v", err)
+				}
+				if payload.To != "to@example.com" || payload.Cc != "cc@example.com" || payload.Bcc != "bcc@example.com" {
+					t.Fatalf("unexpected recipients: %+v", payload)
+				}
+				if payload.HTMLBody != "<p>Hello body</p>" || payload.MessageStream != "outbound" || len(payload.Attachments) != 1 {
+					t.Fatalf("unexpected payload: %+v", payload)
+				}
+				if len(payload.Headers) != 2 || payload.Headers[0].Name != "List-Unsubscribe" || payload.Headers[1].Value != "List-Unsubscribe=One-Click" {
+					t.Fatalf("unexpected headers: %+v", payload.Headers)
+				}
+				writer.Header().Set("Content-Type", "application/json")
+				_, _ = writer.Write([]byte(`{"ErrorCode":0,"Message":"OK","MessageID":"pm-message-1"}`))
+			},
+			newSender: func(baseURL string) EmailSender {
+				return NewPostmarkEmailSender(PostmarkConfig{ServerToken: "pm-token", MessageStream: "outbound", BaseURL: baseURL, FromAddress: "from@example.com"}, http.DefaultClient, newDiscardLogger())
+			},
+			expectedMessageID: "pm-message-1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Helper()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if request.Method != http.MethodPost {
+					t.Fatalf("expected POST, got %s", request.Method)
+				}
+				testCase.handler(t, writer, request)
+			}))
+			defer server.Close()
+
+			result, err := testCase.newSender(server.URL).SendEmail(context.Background(), message)
+			if err != nil {
+				t.Fatalf("SendEmail returned error: %v", err)
+			}
+			if result.ProviderMessageID != testCase.expectedMessageID {
+				t.Fatalf("expected provider message ID %q, got %q", testCase.expectedMessageID, result.ProviderMessageID)
+			}
+			if len(result.AcceptedRecipients) != 3 || len(result.RejectedRecipients) != 0 {
+				t.Fatalf("unexpected recipient outcome: %+v", result)
+			}
+		})
+	}
+}
+
+func TestHTTPEmailProvidersReportErrorResponses(t *testing.T) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		_, _ = writer.Write([]byte(`{"message":"bad credentials"}`))
+	}))
+	defer server.Close()
+
+	senders := map[string]EmailSender{
+		"sendgrid": NewSendGridEmailSender(SendGridConfig{APIKey: "key", BaseURL: server.URL}, http.DefaultClient, newDiscardLogger()),
+		"mailgun":  NewMailgunEmailSender(MailgunConfig{APIKey: "key", Domain: "mg.example.com", BaseURL: server.URL}, http.DefaultClient, newDiscardLogger()),
+		"ses":      NewSESEmailSender(SESConfig{Region: "us-east-1", AccessKeyID: "id", SecretAccessKey: "secret", BaseURL: server.URL}, http.DefaultClient, newDiscardLogger()),
+		"postmark": NewPostmarkEmailSender(PostmarkConfig{ServerToken: "token", BaseURL: server.URL}, http.DefaultClient, newDiscardLogger()),
+	}
+	for providerName, sender := range senders {
+		t.Run(providerName, func(t *testing.T) {
+			t.Helper()
+			_, err := sender.SendEmail(context.Background(), EmailMessage{To: []string{"to@example.com"}, Subject: "s", Body: "b"})
+			if err == nil {
+				t.Fatalf("expected error for non-2xx response")
+			}
+			if !strings.Contains(err.Error(), providerName) || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "bad credentials") {
+				t.Fatalf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPEmailProvidersAcceptUnreadableSuccessResponses(t *testing.T) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write([]byte(`<html>accepted</html>`))
+	}))
+	defer server.Close()
+
+	senders := map[string]EmailSender{
+		"mailgun":  NewMailgunEmailSender(MailgunConfig{APIKey: "key", Domain: "mg.example.com", BaseURL: server.URL}, http.DefaultClient, newDiscardLogger()),
+		"ses":      NewSESEmailSender(SESConfig{Region: "us-east-1", AccessKeyID: "id", SecretAccessKey: "secret", BaseURL: server.URL}, http.DefaultClient, newDiscardLogger()),
+		"postmark": NewPostmarkEmailSender(PostmarkConfig{ServerToken: "token", BaseURL: server.URL}, http.DefaultClient, newDiscardLogger()),
+	}
+	for providerName, sender := range senders {
+		t.Run(providerName, func(t *testing.T) {
+			t.Helper()
+			result, err := sender.SendEmail(context.Background(), EmailMessage{To: []string{"to@example.com"}, Subject: "s", Body: "b"})
+			if err != nil {
+				t.Fatalf("expected an accepted message to be reported as sent, got %v", err)
+			}
+			if result.Provider != providerName || result.ProviderMessageID != "" || len(result.AcceptedRecipients) != 1 {
+				t.Fatalf("unexpected result %+v", result)
+			}
+		})
+	}
+}
+
+func TestNewEmailSenderSelectsConfiguredProvider(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		provider string
+		check    func(sender EmailSender) bool
+	}{
+		{provider: config.EmailProviderSMTP, check: func(sender EmailSender) bool { _, ok := sender.(*SMTPEmailSender); return ok }},
+		{provider: config.EmailProviderSendGrid, check: func(sender EmailSender) bool { _, ok := sender.(*SendGridEmailSender); return ok }},
+		{provider: config.EmailProviderMailgun, check: func(sender EmailSender) bool { _, ok := sender.(*MailgunEmailSender); return ok }},
+		{provider: config.EmailProviderSES, check: func(sender EmailSender) bool { _, ok := sender.(*SESEmailSender); return ok }},
+		{provider: config.EmailProviderPostmark, check: func(sender EmailSender) bool { _, ok := sender.(*PostmarkEmailSender); return ok }},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.provider, func(t *testing.T) {
+			t.Helper()
+			sender := NewEmailSender(config.Config{EmailProvider: testCase.provider, SMTPPort: 465, SESRegion: "us-east-1"}, newDiscardLogger())
+			if !testCase.check(sender) {
+				t.Fatalf("unexpected sender type %T", sender)
+			}
+		})
+	}
+}
+
+func TestSignAWSRequestV4MatchesReferenceVector(t *testing.T) {
+	t.Helper()
+
+	request, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	signingTime := time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+	signAWSRequestV4(request, nil, awsCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "service", signingTime)
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if request.Header.Get("Authorization") != expected {
+		t.Fatalf("unexpected authorization header:\n%s", request.Header.Get("Authorization"))
+	}
+}
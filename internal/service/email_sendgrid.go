@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+const defaultSendGridBaseURL = "https://api.sendgrid.com"
+
+// SendGridConfig configures delivery through the SendGrid v3 Mail Send API.
+type SendGridConfig struct {
+	APIKey      string
+	BaseURL     string
+	FromAddress string
+}
+
+// SendGridEmailSender delivers email through the SendGrid v3 Mail Send API.
+type SendGridEmailSender struct {
+	Config     SendGridConfig
+	HTTPClient *http.Client
+	Logger     *slog.Logger
+}
+
+func NewSendGridEmailSender(configuration SendGridConfig, httpClient *http.Client, logger *slog.Logger) *SendGridEmailSender {
+	configuration.BaseURL = resolveBaseURL(configuration.BaseURL, defaultSendGridBaseURL)
+	return &SendGridEmailSender{Config: configuration, HTTPClient: httpClient, Logger: logger}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+type sendGridPersonalization struct {
+	To  []sendGridAddress `json:"to"`
+	Cc  []sendGridAddress `json:"cc,omitempty"`
+	Bcc []sendGridAddress `json:"bcc,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type,omitempty"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+}
+
+type sendGridMailRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+	Headers          map[string]string         `json:"headers,omitempty"`
+}
+
+func (senderInstance *SendGridEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	if len(message.To) == 0 {
+		return EmailDeliveryResult{}, fmt.Errorf("email message has no recipients")
+	}
+	payload := sendGridMailRequest{
+		Personalizations: []sendGridPersonalization{{
+			To:  toSendGridAddresses(message.To),
+			Cc:  toSendGridAddresses(message.Cc),
+			Bcc: toSendGridAddresses(message.Bcc),
+		}},
+		From:    sendGridAddress{Email: senderInstance.Config.FromAddress},
+		Subject: message.Subject,
+		Content: []sendGridContent{{Type: "text/plain", Value: message.Body}},
+	}
+	if strings.TrimSpace(message.HTMLBody) != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/html", Value: message.HTMLBody})
+	}
+	if message.ListUnsubscribeURL != "" {
+		payload.Headers = map[string]string{
+			"List-Unsubscribe":      "<" + message.ListUnsubscribeURL + ">",
+			"List-Unsubscribe-Post": listUnsubscribePostValue,
+		}
+	}
+	for _, attachment := range message.Attachments {
+		payload.Attachments = append(payload.Attachments, sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(attachment.Data),
+			Type:        attachment.ContentType,
+			Filename:    sanitizeFilename(attachment.Filename),
+			Disposition: "attachment",
+		})
+	}
+	body, marshalErr := json.Marshal(payload)
+	if marshalErr != nil {
+		return EmailDeliveryResult{}, fmt.Errorf("encode sendgrid request: %w", marshalErr)
+	}
+
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, senderInstance.Config.BaseURL+"/v3/mail/send", bytes.NewReader(body))
+	if requestErr != nil {
+		return EmailDeliveryResult{}, requestErr
+	}
+	request.Header.Set("Authorization", "Bearer "+senderInstance.Config.APIKey)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, responseErr := senderInstance.HTTPClient.Do(request)
+	if responseErr != nil {
+		senderInstance.Logger.Error("SendGrid request error", "error", responseErr)
+		return EmailDeliveryResult{}, responseErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return EmailDeliveryResult{}, providerResponseError(config.EmailProviderSendGrid, response)
+	}
+	return acceptedByProvider(config.EmailProviderSendGrid, message, response.Header.Get("X-Message-Id")), nil
+}
+
+func toSendGridAddresses(addresses []string) []sendGridAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+	result := make([]sendGridAddress, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, sendGridAddress{Email: address})
+	}
+	return result
+}
@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"mime/quotedprintable"
 	"net"
 	"net/smtp"
+	"net/textproto"
 	"strings"
 	"time"
 
@@ -25,14 +31,77 @@ type SMTPConfig struct {
 	Timeouts    config.Config
 }
 
+// EmailMessage is a fully addressed outbound email. Bcc recipients receive the message through the
+// SMTP envelope only and never appear in the rendered headers.
+type EmailMessage struct {
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Subject     string
+	Body        string
+	HTMLBody    string
+	Attachments []model.EmailAttachment
+	// MessageID is rendered as the Message-ID header when set. Bounce reports quote it back, which
+	// is how they are linked to the notification.
+	MessageID string
+	// ListUnsubscribeURL is the signed one-click unsubscribe link rendered as the List-Unsubscribe
+	// and List-Unsubscribe-Post headers (RFC 8058) when set.
+	ListUnsubscribeURL string
+}
+
+// EnvelopeRecipients returns every address the message must be delivered to, without duplicates.
+func (message EmailMessage) EnvelopeRecipients() []string {
+	seen := make(map[string]struct{}, len(message.To)+len(message.Cc)+len(message.Bcc))
+	var recipients []string
+	for _, group := range [][]string{message.To, message.Cc, message.Bcc} {
+		for _, address := range group {
+			key := strings.ToLower(address)
+			if _, exists := seen[key]; exists {
+				continue
+			}
+			seen[key] = struct{}{}
+			recipients = append(recipients, address)
+		}
+	}
+	return recipients
+}
+
+// RecipientRejection describes an envelope recipient refused by the mail server.
+type RecipientRejection struct {
+	Address string
+	Reason  string
+}
+
+// EmailDeliveryResult reports which envelope recipients the mail server accepted and rejected,
+// and the identifier the provider assigned to the message when it returns one.
+type EmailDeliveryResult struct {
+	AcceptedRecipients []string
+	RejectedRecipients []RecipientRejection
+	ProviderMessageID  string
+	// Provider names the backend that handled the message, e.g. "smtp" or "sendgrid".
+	Provider string
+}
+
+var (
+	// ErrAllRecipientsRejected indicates the mail server refused every envelope recipient.
+	ErrAllRecipientsRejected = errors.New("smtp server rejected all recipients")
+	// ErrSMTPAuthentication indicates the mail server refused the configured credentials.
+	ErrSMTPAuthentication = errors.New("smtp authentication failed")
+	// ErrSMTPSenderRejected indicates the mail server refused the configured sender address.
+	ErrSMTPSenderRejected = errors.New("smtp server rejected sender")
+)
+
 type EmailSender interface {
-	SendEmail(ctx context.Context, recipient string, subject string, message string, attachments []model.EmailAttachment) error
+	SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error)
 }
 
 var (
 	dialTLSFunc = func(dialer *net.Dialer, network string, addr string, config *tls.Config) (net.Conn, error) {
 		return tls.DialWithDialer(dialer, network, addr, config)
 	}
+	dialSMTPFunc = func(dialer *net.Dialer, network string, addr string) (net.Conn, error) {
+		return dialer.Dial(network, addr)
+	}
 	newSMTPClient = func(conn net.Conn, host string) (smtpClient, error) {
 		client, err := smtp.NewClient(conn, host)
 		if err != nil {
@@ -40,10 +109,11 @@ var (
 		}
 		return smtpClientWrapper{client: client}, nil
 	}
-	sendMailFunc = smtp.SendMail
 )
 
 type smtpClient interface {
+	Extension(string) (bool, string)
+	StartTLS(*tls.Config) error
 	Auth(smtp.Auth) error
 	Mail(string) error
 	Rcpt(string) error
@@ -55,6 +125,14 @@ type smtpClientWrapper struct {
 	client *smtp.Client
 }
 
+func (wrapper smtpClientWrapper) Extension(name string) (bool, string) {
+	return wrapper.client.Extension(name)
+}
+
+func (wrapper smtpClientWrapper) StartTLS(config *tls.Config) error {
+	return wrapper.client.StartTLS(config)
+}
+
 func (wrapper smtpClientWrapper) Auth(auth smtp.Auth) error {
 	return wrapper.client.Auth(auth)
 }
@@ -87,83 +165,141 @@ func NewSMTPEmailSender(configuration SMTPConfig, logger *slog.Logger) *SMTPEmai
 	}
 }
 
-func (senderInstance *SMTPEmailSender) SendEmail(ctx context.Context, recipient string, subject string, message string, attachments []model.EmailAttachment) error {
-	emailMessage := buildEmailMessage(senderInstance.Config.FromAddress, recipient, subject, message, attachments)
+func (senderInstance *SMTPEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	envelopeRecipients := message.EnvelopeRecipients()
+	if len(envelopeRecipients) == 0 {
+		return EmailDeliveryResult{}, errors.New("email message has no recipients")
+	}
+	if message.MessageID == "" {
+		message.MessageID = newMessageID(senderInstance.Config.FromAddress)
+	}
+	emailMessage := buildEmailMessage(senderInstance.Config.FromAddress, message)
 
-	if senderInstance.Config.Port == "465" {
-		serverAddr := net.JoinHostPort(senderInstance.Config.Host, senderInstance.Config.Port)
+	serverAddr := net.JoinHostPort(senderInstance.Config.Host, senderInstance.Config.Port)
+	dialer := &net.Dialer{
+		Timeout: time.Duration(senderInstance.Config.Timeouts.ConnectionTimeoutSec) * time.Second,
+	}
+	implicitTLS := senderInstance.Config.Port == "465"
+
+	var connection net.Conn
+	var dialError error
+	if implicitTLS {
 		tlsConfig := &tls.Config{
 			InsecureSkipVerify: true, // In production, perform proper certificate validation.
 			ServerName:         senderInstance.Config.Host,
 		}
-
-		dialer := &net.Dialer{
-			Timeout: time.Duration(senderInstance.Config.Timeouts.ConnectionTimeoutSec) * time.Second,
+		connection, dialError = dialTLSFunc(dialer, "tcp", serverAddr, tlsConfig)
+		if dialError != nil {
+			return EmailDeliveryResult{}, fmt.Errorf("failed to dial TLS: %w", dialError)
 		}
-
-		tlsConnection, dialError := dialTLSFunc(dialer, "tcp", serverAddr, tlsConfig)
+	} else {
+		connection, dialError = dialSMTPFunc(dialer, "tcp", serverAddr)
 		if dialError != nil {
-			return fmt.Errorf("failed to dial TLS: %w", dialError)
+			return EmailDeliveryResult{}, fmt.Errorf("failed to dial SMTP server: %w", dialError)
 		}
-		defer tlsConnection.Close()
+	}
+	defer connection.Close()
 
-		if ctx.Err() != nil {
-			return ctx.Err()
-		}
+	if ctx.Err() != nil {
+		return EmailDeliveryResult{}, ctx.Err()
+	}
 
-		smtpClient, clientError := newSMTPClient(tlsConnection, senderInstance.Config.Host)
-		if clientError != nil {
-			return fmt.Errorf("failed to create SMTP client: %w", clientError)
-		}
-		defer smtpClient.Quit()
+	smtpClient, clientError := newSMTPClient(connection, senderInstance.Config.Host)
+	if clientError != nil {
+		return EmailDeliveryResult{}, fmt.Errorf("failed to create SMTP client: %w", clientError)
+	}
+	defer smtpClient.Quit()
 
-		smtpAuth := smtp.PlainAuth("", senderInstance.Config.Username, senderInstance.Config.Password, senderInstance.Config.Host)
+	smtpAuth := smtp.PlainAuth("", senderInstance.Config.Username, senderInstance.Config.Password, senderInstance.Config.Host)
+	if implicitTLS {
 		if authError := smtpClient.Auth(smtpAuth); authError != nil {
-			return fmt.Errorf("failed to authenticate: %w", authError)
+			return EmailDeliveryResult{}, fmt.Errorf("%w: %w", ErrSMTPAuthentication, authError)
 		}
-
-		if mailError := smtpClient.Mail(senderInstance.Config.FromAddress); mailError != nil {
-			return fmt.Errorf("failed to set sender: %w", mailError)
+	} else {
+		if supportsTLS, _ := smtpClient.Extension("STARTTLS"); supportsTLS {
+			if tlsError := smtpClient.StartTLS(&tls.Config{ServerName: senderInstance.Config.Host}); tlsError != nil {
+				return EmailDeliveryResult{}, fmt.Errorf("failed to start TLS: %w", tlsError)
+			}
 		}
-		if rcptError := smtpClient.Rcpt(recipient); rcptError != nil {
-			return fmt.Errorf("failed to set recipient: %w", rcptError)
+		supportsAuth, _ := smtpClient.Extension("AUTH")
+		if !supportsAuth {
+			return EmailDeliveryResult{}, fmt.Errorf("%w: server does not support AUTH", ErrSMTPAuthentication)
 		}
-
-		dataWriter, dataError := smtpClient.Data()
-		if dataError != nil {
-			return fmt.Errorf("failed to get data writer: %w", dataError)
+		if authError := smtpClient.Auth(smtpAuth); authError != nil {
+			return EmailDeliveryResult{}, fmt.Errorf("%w: %w", ErrSMTPAuthentication, authError)
 		}
-		_, writeError := dataWriter.Write([]byte(emailMessage))
-		if writeError != nil {
-			dataWriter.Close()
-			return fmt.Errorf("failed to write email message: %w", writeError)
+	}
+
+	if mailError := smtpClient.Mail(senderInstance.Config.FromAddress); mailError != nil {
+		return EmailDeliveryResult{}, fmt.Errorf("%w: %w", ErrSMTPSenderRejected, mailError)
+	}
+
+	deliveryResult := EmailDeliveryResult{Provider: config.EmailProviderSMTP, ProviderMessageID: message.MessageID}
+	var permanentRcptError, transientRcptError error
+	for _, recipient := range envelopeRecipients {
+		if rcptError := smtpClient.Rcpt(recipient); rcptError != nil {
+			var rcptReply *textproto.Error
+			if errors.As(rcptError, &rcptReply) && rcptReply.Code >= 500 {
+				permanentRcptError = rcptError
+			} else if transientRcptError == nil {
+				transientRcptError = rcptError
+			}
+			deliveryResult.RejectedRecipients = append(deliveryResult.RejectedRecipients, RecipientRejection{
+				Address: recipient,
+				Reason:  rcptError.Error(),
+			})
+			if senderInstance.Logger != nil {
+				senderInstance.Logger.Warn("smtp_recipient_rejected", "recipient", recipient, "error", rcptError)
+			}
+			continue
 		}
-		if closeDataError := dataWriter.Close(); closeDataError != nil {
-			return fmt.Errorf("failed to close data writer: %w", closeDataError)
+		deliveryResult.AcceptedRecipients = append(deliveryResult.AcceptedRecipients, recipient)
+	}
+	if len(deliveryResult.AcceptedRecipients) == 0 {
+		// A transient refusal is reported ahead of permanent ones, so the message is only given up
+		// on when every recipient was refused for good. The reply keeps its SMTP code for the
+		// attempt history.
+		rcptError := transientRcptError
+		if rcptError == nil {
+			rcptError = permanentRcptError
 		}
-
-		return nil
+		return deliveryResult, fmt.Errorf("%w: %w", ErrAllRecipientsRejected, rcptError)
 	}
 
-	smtpAddress := net.JoinHostPort(senderInstance.Config.Host, senderInstance.Config.Port)
-	smtpAuth := smtp.PlainAuth("", senderInstance.Config.Username, senderInstance.Config.Password, senderInstance.Config.Host)
-	sendError := sendMailFunc(smtpAddress, smtpAuth, senderInstance.Config.FromAddress, []string{recipient}, []byte(emailMessage))
-	if sendError != nil {
-		return fmt.Errorf("smtp send failed: %w", sendError)
+	dataWriter, dataError := smtpClient.Data()
+	if dataError != nil {
+		return deliveryResult, fmt.Errorf("failed to get data writer: %w", dataError)
 	}
-	return nil
+	_, writeError := dataWriter.Write([]byte(emailMessage))
+	if writeError != nil {
+		dataWriter.Close()
+		return deliveryResult, fmt.Errorf("failed to write email message: %w", writeError)
+	}
+	if closeDataError := dataWriter.Close(); closeDataError != nil {
+		return deliveryResult, fmt.Errorf("failed to close data writer: %w", closeDataError)
+	}
+
+	return deliveryResult, nil
 }
 
-func buildEmailMessage(fromAddress string, toAddress string, subject string, body string, attachments []model.EmailAttachment) string {
+func buildEmailMessage(fromAddress string, message EmailMessage) string {
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("From: %s\r\n", fromAddress))
-	builder.WriteString(fmt.Sprintf("To: %s\r\n", toAddress))
-	builder.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	builder.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(message.To, ", ")))
+	if len(message.Cc) > 0 {
+		builder.WriteString(fmt.Sprintf("Cc: %s\r\n", strings.Join(message.Cc, ", ")))
+	}
+	builder.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject)))
+	if message.MessageID != "" {
+		builder.WriteString(fmt.Sprintf("Message-ID: %s\r\n", message.MessageID))
+	}
+	if message.ListUnsubscribeURL != "" {
+		builder.WriteString(fmt.Sprintf("List-Unsubscribe: <%s>\r\n", message.ListUnsubscribeURL))
+		builder.WriteString(fmt.Sprintf("List-Unsubscribe-Post: %s\r\n", listUnsubscribePostValue))
+	}
 	builder.WriteString("MIME-Version: 1.0\r\n")
-	if len(attachments) == 0 {
-		builder.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
-		builder.WriteString("\r\n")
-		builder.WriteString(body)
+	if len(message.Attachments) == 0 {
+		writeBodyEntity(&builder, message)
 		return builder.String()
 	}
 
@@ -172,12 +308,10 @@ func buildEmailMessage(fromAddress string, toAddress string, subject string, bod
 	builder.WriteString("\r\n")
 
 	builder.WriteString(fmt.Sprintf("--%s\r\n", boundary))
-	builder.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
-	builder.WriteString("Content-Transfer-Encoding: 7bit\r\n\r\n")
-	builder.WriteString(body)
+	writeBodyEntity(&builder, message)
 	builder.WriteString("\r\n")
 
-	for _, attachment := range attachments {
+	for _, attachment := range message.Attachments {
 		builder.WriteString(fmt.Sprintf("--%s\r\n", boundary))
 		contentType := attachment.ContentType
 		if contentType == "" {
@@ -195,6 +329,75 @@ func buildEmailMessage(fromAddress string, toAddress string, subject string, bod
 	return builder.String()
 }
 
+// newMessageID returns a unique RFC 5322 Message-ID in the sender's domain.
+func newMessageID(fromAddress string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(fromAddress, "@"); at >= 0 && at < len(fromAddress)-1 {
+		domain = strings.Trim(fromAddress[at+1:], "<> ")
+	}
+	randomBytes := make([]byte, 16)
+	_, _ = rand.Read(randomBytes)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(randomBytes), domain)
+}
+
+// writeBodyEntity renders the readable part of the message: a single text/plain entity, or a
+// multipart/alternative entity carrying both the plain-text and HTML bodies.
+func writeBodyEntity(builder *strings.Builder, message EmailMessage) {
+	if strings.TrimSpace(message.HTMLBody) == "" {
+		writeTextEntity(builder, "text/plain", message.Body)
+		return
+	}
+
+	boundary := fmt.Sprintf("PinguinAlternative-%d", time.Now().UnixNano())
+	builder.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=\"%s\"\r\n", boundary))
+	builder.WriteString("\r\n")
+
+	builder.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	writeTextEntity(builder, "text/plain", message.Body)
+	builder.WriteString("\r\n")
+
+	builder.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	writeTextEntity(builder, "text/html", message.HTMLBody)
+	builder.WriteString("\r\n")
+
+	builder.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
+}
+
+func writeTextEntity(builder *strings.Builder, contentType string, content string) {
+	builder.WriteString(fmt.Sprintf("Content-Type: %s; charset=\"utf-8\"\r\n", contentType))
+	if !requiresQuotedPrintable(content) {
+		builder.WriteString("Content-Transfer-Encoding: 7bit\r\n\r\n")
+		builder.WriteString(content)
+		return
+	}
+	builder.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	builder.WriteString(encodeQuotedPrintable(content))
+}
+
+// requiresQuotedPrintable reports whether content cannot travel as 7bit: it carries non-ASCII
+// bytes or lines longer than the 998 octets allowed by RFC 5322.
+func requiresQuotedPrintable(content string) bool {
+	for index := 0; index < len(content); index++ {
+		if content[index] >= 0x80 {
+			return true
+		}
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if len(line) > 998 {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeQuotedPrintable(content string) string {
+	var encoded strings.Builder
+	writer := quotedprintable.NewWriter(&encoded)
+	_, _ = writer.Write([]byte(content))
+	_ = writer.Close()
+	return encoded.String()
+}
+
 func encodeBase64Chunked(data []byte) string {
 	if len(data) == 0 {
 		return ""
@@ -4,9 +4,17 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/base64"
+	"errors"
 	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net"
+	"net/mail"
 	"net/smtp"
+	"net/textproto"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,24 +27,22 @@ func newDiscardLogger() *slog.Logger {
 }
 
 func TestSendEmailPlain(t *testing.T) {
-	originalSendMail := sendMailFunc
+	originalDial := dialSMTPFunc
+	originalClient := newSMTPClient
 	defer func() {
-		sendMailFunc = originalSendMail
+		dialSMTPFunc = originalDial
+		newSMTPClient = originalClient
 	}()
 
-	var captured struct {
-		addr string
-		from string
-		to   []string
-		body string
+	var dialedAddress string
+	dialSMTPFunc = func(_ *net.Dialer, _ string, addr string) (net.Conn, error) {
+		dialedAddress = addr
+		return stubConn{}, nil
 	}
 
-	sendMailFunc = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
-		captured.addr = addr
-		captured.from = from
-		captured.to = append([]string(nil), to...)
-		captured.body = string(msg)
-		return nil
+	client := &stubSMTPClient{extensions: map[string]bool{"STARTTLS": true, "AUTH": true}}
+	newSMTPClient = func(net.Conn, string) (smtpClient, error) {
+		return client, nil
 	}
 
 	sender := NewSMTPEmailSender(SMTPConfig{
@@ -47,21 +53,238 @@ func TestSendEmailPlain(t *testing.T) {
 		FromAddress: "from@example.com",
 	}, newDiscardLogger())
 
-	if err := sender.SendEmail(context.Background(), "to@example.com", "Greetings", "Hello body", nil); err != nil {
+	result, err := sender.SendEmail(context.Background(), EmailMessage{
+		To:      []string{"to@example.com"},
+		Subject: "Greetings",
+		Body:    "Hello body",
+	})
+	if err != nil {
 		t.Fatalf("SendEmail returned error: %v", err)
 	}
-	if captured.addr != "smtp.example.com:587" {
-		t.Fatalf("unexpected smtp address %q", captured.addr)
+	if dialedAddress != "smtp.example.com:587" {
+		t.Fatalf("unexpected smtp address %q", dialedAddress)
+	}
+	if !client.startTLSCalled {
+		t.Fatalf("expected STARTTLS to be negotiated")
+	}
+	if !client.authCalled {
+		t.Fatalf("expected Auth to be called")
+	}
+	if client.mailAddr != "from@example.com" {
+		t.Fatalf("unexpected from %q", client.mailAddr)
 	}
-	if captured.from != "from@example.com" {
-		t.Fatalf("unexpected from %q", captured.from)
+	if len(client.rcptAddrs) != 1 || client.rcptAddrs[0] != "to@example.com" {
+		t.Fatalf("unexpected recipients %#v", client.rcptAddrs)
 	}
-	if len(captured.to) != 1 || captured.to[0] != "to@example.com" {
-		t.Fatalf("unexpected recipients %#v", captured.to)
+	if len(result.AcceptedRecipients) != 1 || len(result.RejectedRecipients) != 0 {
+		t.Fatalf("unexpected delivery result %#v", result)
 	}
-	if captured.body == "" {
+	if client.payload == nil || client.payload.Len() == 0 {
 		t.Fatalf("expected body content to be sent")
 	}
+	if !strings.HasPrefix(result.ProviderMessageID, "<") || !strings.HasSuffix(result.ProviderMessageID, "@example.com>") {
+		t.Fatalf("expected generated message id, got %q", result.ProviderMessageID)
+	}
+	if !strings.Contains(client.payload.String(), "Message-ID: "+result.ProviderMessageID+"\r\n") {
+		t.Fatalf("expected Message-ID header in payload")
+	}
+}
+
+func TestSendEmailRecordsRecipientOutcomes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name             string
+		rejected         map[string]int
+		expectErr        error
+		expectReplyCode  int
+		expectAccepted   []string
+		expectRejected   []string
+		expectDataCalled bool
+	}{
+		{
+			name:             "all accepted",
+			expectAccepted:   []string{"to@example.com", "cc@example.com", "bcc@example.com"},
+			expectDataCalled: true,
+		},
+		{
+			name:             "partial rejection",
+			rejected:         map[string]int{"cc@example.com": 550},
+			expectAccepted:   []string{"to@example.com", "bcc@example.com"},
+			expectRejected:   []string{"cc@example.com"},
+			expectDataCalled: true,
+		},
+		{
+			name:            "all rejected",
+			rejected:        map[string]int{"to@example.com": 550, "cc@example.com": 550, "bcc@example.com": 551},
+			expectErr:       ErrAllRecipientsRejected,
+			expectReplyCode: 551,
+			expectRejected:  []string{"to@example.com", "cc@example.com", "bcc@example.com"},
+		},
+		{
+			name:            "all rejected, one transiently",
+			rejected:        map[string]int{"to@example.com": 550, "cc@example.com": 450, "bcc@example.com": 550},
+			expectErr:       ErrAllRecipientsRejected,
+			expectReplyCode: 450,
+			expectRejected:  []string{"to@example.com", "cc@example.com", "bcc@example.com"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			originalDial := dialTLSFunc
+			originalClient := newSMTPClient
+			defer func() {
+				dialTLSFunc = originalDial
+				newSMTPClient = originalClient
+			}()
+
+			dialTLSFunc = func(*net.Dialer, string, string, *tls.Config) (net.Conn, error) {
+				return stubConn{}, nil
+			}
+			client := &stubSMTPClient{rejected: testCase.rejected}
+			newSMTPClient = func(net.Conn, string) (smtpClient, error) {
+				return client, nil
+			}
+
+			sender := NewSMTPEmailSender(SMTPConfig{
+				Host:        "smtp.example.com",
+				Port:        "465",
+				FromAddress: "from@example.com",
+			}, newDiscardLogger())
+
+			result, err := sender.SendEmail(context.Background(), EmailMessage{
+				To:      []string{"to@example.com"},
+				Cc:      []string{"cc@example.com", "TO@example.com"},
+				Bcc:     []string{"bcc@example.com"},
+				Subject: "Digest",
+				Body:    "Body",
+			})
+			if !errors.Is(err, testCase.expectErr) {
+				t.Fatalf("expected error %v, got %v", testCase.expectErr, err)
+			}
+			var reply *textproto.Error
+			if testCase.expectReplyCode != 0 && (!errors.As(err, &reply) || reply.Code != testCase.expectReplyCode) {
+				t.Fatalf("expected the error to carry reply %d, got %v", testCase.expectReplyCode, err)
+			}
+			if len(client.rcptAddrs) != 3 {
+				t.Fatalf("expected one RCPT per unique recipient, got %#v", client.rcptAddrs)
+			}
+			if strings.Join(result.AcceptedRecipients, ",") != strings.Join(testCase.expectAccepted, ",") {
+				t.Fatalf("unexpected accepted recipients %#v", result.AcceptedRecipients)
+			}
+			rejectedAddresses := make([]string, 0, len(result.RejectedRecipients))
+			for _, rejection := range result.RejectedRecipients {
+				if rejection.Reason == "" {
+					t.Fatalf("expected rejection reason for %s", rejection.Address)
+				}
+				rejectedAddresses = append(rejectedAddresses, rejection.Address)
+			}
+			if strings.Join(rejectedAddresses, ",") != strings.Join(testCase.expectRejected, ",") {
+				t.Fatalf("unexpected rejected recipients %#v", rejectedAddresses)
+			}
+			if (client.payload != nil) != testCase.expectDataCalled {
+				t.Fatalf("unexpected DATA invocation state")
+			}
+		})
+	}
+}
+
+func TestSendEmailReportsRefusedConfiguration(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name      string
+		client    *stubSMTPClient
+		expectErr error
+	}{
+		{
+			name:      "credentials refused",
+			client:    &stubSMTPClient{authErr: &textproto.Error{Code: 535, Msg: "5.7.8 authentication credentials invalid"}},
+			expectErr: ErrSMTPAuthentication,
+		},
+		{
+			name:      "sender refused",
+			client:    &stubSMTPClient{mailErr: &textproto.Error{Code: 553, Msg: "5.7.1 sender not owned by user"}},
+			expectErr: ErrSMTPSenderRejected,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			originalDial := dialTLSFunc
+			originalClient := newSMTPClient
+			defer func() {
+				dialTLSFunc = originalDial
+				newSMTPClient = originalClient
+			}()
+
+			dialTLSFunc = func(*net.Dialer, string, string, *tls.Config) (net.Conn, error) {
+				return stubConn{}, nil
+			}
+			newSMTPClient = func(net.Conn, string) (smtpClient, error) {
+				return testCase.client, nil
+			}
+
+			sender := NewSMTPEmailSender(SMTPConfig{Host: "smtp.example.com", Port: "465", FromAddress: "from@example.com"}, newDiscardLogger())
+			_, err := sender.SendEmail(context.Background(), EmailMessage{To: []string{"to@example.com"}, Subject: "Digest", Body: "Body"})
+			if !errors.Is(err, testCase.expectErr) || !retryableDispatchError(err) {
+				t.Fatalf("expected a retryable %v, got %v", testCase.expectErr, err)
+			}
+			if len(testCase.client.rcptAddrs) != 0 {
+				t.Fatalf("expected no RCPT after the refusal, got %#v", testCase.client.rcptAddrs)
+			}
+		})
+	}
+}
+
+func TestBuildEmailMessageOmitsBccHeader(t *testing.T) {
+	t.Helper()
+
+	rendered := buildEmailMessage("from@example.com", EmailMessage{
+		To:      []string{"a@example.com", "b@example.com"},
+		Cc:      []string{"c@example.com"},
+		Bcc:     []string{"hidden@example.com"},
+		Subject: "Digest",
+		Body:    "Body",
+	})
+	if !strings.Contains(rendered, "To: a@example.com, b@example.com\r\n") {
+		t.Fatalf("expected To header with all recipients, got %q", rendered)
+	}
+	if !strings.Contains(rendered, "Cc: c@example.com\r\n") {
+		t.Fatalf("expected Cc header, got %q", rendered)
+	}
+	if strings.Contains(rendered, "hidden@example.com") || strings.Contains(rendered, "Bcc:") {
+		t.Fatalf("bcc recipients must not appear in headers: %q", rendered)
+	}
+}
+
+func TestBuildEmailMessageListUnsubscribeHeaders(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name            string
+		unsubscribeURL  string
+		expectedHeaders bool
+	}{
+		{name: "WithURL", unsubscribeURL: "https://notify.example.com/unsubscribe?token=abc", expectedHeaders: true},
+		{name: "WithoutURL"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rendered := buildEmailMessage("from@example.com", EmailMessage{
+				To:                 []string{"a@example.com"},
+				Subject:            "Digest",
+				Body:               "Body",
+				ListUnsubscribeURL: testCase.unsubscribeURL,
+			})
+			hasURL := strings.Contains(rendered, "List-Unsubscribe: <https://notify.example.com/unsubscribe?token=abc>\r\n")
+			hasPost := strings.Contains(rendered, "List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+			if hasURL != testCase.expectedHeaders || hasPost != testCase.expectedHeaders {
+				t.Fatalf("expected list-unsubscribe headers=%v, got %q", testCase.expectedHeaders, rendered)
+			}
+		})
+	}
 }
 
 type stubConn struct{}
@@ -82,24 +305,42 @@ type stubWriteCloser struct {
 func (stub *stubWriteCloser) Close() error { return nil }
 
 type stubSMTPClient struct {
-	authCalled bool
-	mailAddr   string
-	rcptAddr   string
-	payload    *stubWriteCloser
+	extensions map[string]bool
+	// rejected maps refused recipients to the SMTP reply code of the refusal.
+	rejected       map[string]int
+	authErr        error
+	mailErr        error
+	startTLSCalled bool
+	authCalled     bool
+	mailAddr       string
+	rcptAddrs      []string
+	payload        *stubWriteCloser
+}
+
+func (client *stubSMTPClient) Extension(name string) (bool, string) {
+	return client.extensions[name], ""
+}
+
+func (client *stubSMTPClient) StartTLS(*tls.Config) error {
+	client.startTLSCalled = true
+	return nil
 }
 
 func (client *stubSMTPClient) Auth(smtp.Auth) error {
 	client.authCalled = true
-	return nil
+	return client.authErr
 }
 
 func (client *stubSMTPClient) Mail(addr string) error {
 	client.mailAddr = addr
-	return nil
+	return client.mailErr
 }
 
 func (client *stubSMTPClient) Rcpt(addr string) error {
-	client.rcptAddr = addr
+	client.rcptAddrs = append(client.rcptAddrs, addr)
+	if code, isRejected := client.rejected[addr]; isRejected {
+		return &textproto.Error{Code: code, Msg: "mailbox unavailable"}
+	}
 	return nil
 }
 
@@ -145,7 +386,12 @@ func TestSendEmailTLS(t *testing.T) {
 		},
 	}
 
-	if err := sender.SendEmail(context.Background(), "to@example.com", "Greetings", "Hello body", attachments); err != nil {
+	if _, err := sender.SendEmail(context.Background(), EmailMessage{
+		To:          []string{"to@example.com"},
+		Subject:     "Greetings",
+		Body:        "Hello body",
+		Attachments: attachments,
+	}); err != nil {
 		t.Fatalf("SendEmail returned error: %v", err)
 	}
 	if !client.authCalled {
@@ -154,10 +400,132 @@ func TestSendEmailTLS(t *testing.T) {
 	if client.mailAddr != "from@example.com" {
 		t.Fatalf("unexpected MAIL address %q", client.mailAddr)
 	}
-	if client.rcptAddr != "to@example.com" {
-		t.Fatalf("unexpected RCPT address %q", client.rcptAddr)
+	if len(client.rcptAddrs) != 1 || client.rcptAddrs[0] != "to@example.com" {
+		t.Fatalf("unexpected RCPT addresses %#v", client.rcptAddrs)
 	}
 	if client.payload == nil || client.payload.Len() == 0 {
 		t.Fatalf("expected payload to be written")
 	}
 }
+
+func TestBuildEmailMessageBodyStructure(t *testing.T) {
+	t.Helper()
+
+	attachment := model.EmailAttachment{Filename: "report.txt", ContentType: "text/plain", Data: []byte("report")}
+	testCases := []struct {
+		name              string
+		message           EmailMessage
+		expectedTopLevel  string
+		expectedLeafTypes []string
+		expectedEncoding  string
+	}{
+		{
+			name:              "PlainASCII",
+			message:           EmailMessage{To: []string{"to@example.com"}, Subject: "Hi", Body: "Hello"},
+			expectedTopLevel:  "text/plain",
+			expectedLeafTypes: []string{"text/plain"},
+			expectedEncoding:  "7bit",
+		},
+		{
+			name:              "PlainNonASCII",
+			message:           EmailMessage{To: []string{"to@example.com"}, Subject: "Grüße", Body: "Grüße aus München"},
+			expectedTopLevel:  "text/plain",
+			expectedLeafTypes: []string{"text/plain"},
+			expectedEncoding:  "quoted-printable",
+		},
+		{
+			name:              "HTMLAlternative",
+			message:           EmailMessage{To: []string{"to@example.com"}, Subject: "Hi", Body: "Hello", HTMLBody: "<p>Héllo</p>"},
+			expectedTopLevel:  "multipart/alternative",
+			expectedLeafTypes: []string{"text/plain", "text/html"},
+		},
+		{
+			name:              "HTMLWithAttachments",
+			message:           EmailMessage{To: []string{"to@example.com"}, Subject: "Hi", Body: "Hello", HTMLBody: "<p>Hello</p>", Attachments: []model.EmailAttachment{attachment}},
+			expectedTopLevel:  "multipart/mixed",
+			expectedLeafTypes: []string{"text/plain", "text/html", "text/plain"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rendered := buildEmailMessage("from@example.com", testCase.message)
+			parsed, parseErr := mail.ReadMessage(strings.NewReader(rendered))
+			if parseErr != nil {
+				t.Fatalf("parse message: %v", parseErr)
+			}
+			decodedSubject, subjectErr := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+			if subjectErr != nil || decodedSubject != testCase.message.Subject {
+				t.Fatalf("unexpected subject %q (%v)", decodedSubject, subjectErr)
+			}
+			mediaType, _, mediaErr := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+			if mediaErr != nil {
+				t.Fatalf("parse content type: %v", mediaErr)
+			}
+			if mediaType != testCase.expectedTopLevel {
+				t.Fatalf("expected top-level %s, got %s", testCase.expectedTopLevel, mediaType)
+			}
+			if testCase.expectedEncoding != "" && parsed.Header.Get("Content-Transfer-Encoding") != testCase.expectedEncoding {
+				t.Fatalf("expected encoding %s, got %q", testCase.expectedEncoding, parsed.Header.Get("Content-Transfer-Encoding"))
+			}
+
+			leaves := collectMimeLeaves(t, textproto.MIMEHeader(parsed.Header), parsed.Body)
+			if len(leaves) != len(testCase.expectedLeafTypes) {
+				t.Fatalf("expected %d leaf parts, got %d", len(testCase.expectedLeafTypes), len(leaves))
+			}
+			for index, leaf := range leaves {
+				if leaf.contentType != testCase.expectedLeafTypes[index] {
+					t.Fatalf("leaf %d: expected %s, got %s", index, testCase.expectedLeafTypes[index], leaf.contentType)
+				}
+			}
+			if leaves[0].body != testCase.message.Body {
+				t.Fatalf("plain body did not round-trip: %q", leaves[0].body)
+			}
+			if testCase.message.HTMLBody != "" && leaves[1].body != testCase.message.HTMLBody {
+				t.Fatalf("html body did not round-trip: %q", leaves[1].body)
+			}
+		})
+	}
+}
+
+type mimeLeaf struct {
+	contentType string
+	body        string
+}
+
+func collectMimeLeaves(t *testing.T, header textproto.MIMEHeader, body io.Reader) []mimeLeaf {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("parse content type: %v", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		var reader io.Reader = body
+		switch header.Get("Content-Transfer-Encoding") {
+		case "quoted-printable":
+			reader = quotedprintable.NewReader(body)
+		case "base64":
+			reader = base64.NewDecoder(base64.StdEncoding, body)
+		}
+		content, readErr := io.ReadAll(reader)
+		if readErr != nil {
+			t.Fatalf("read part: %v", readErr)
+		}
+		return []mimeLeaf{{contentType: mediaType, body: strings.TrimRight(string(content), "\r\n")}}
+	}
+
+	var leaves []mimeLeaf
+	partReader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, partErr := partReader.NextRawPart()
+		if partErr == io.EOF {
+			break
+		}
+		if partErr != nil {
+			t.Fatalf("read multipart: %v", partErr)
+		}
+		leaves = append(leaves, collectMimeLeaves(t, part.Header, part)...)
+	}
+	return leaves
+}
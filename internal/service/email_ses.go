@@ -0,0 +1,106 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+// SESConfig configures delivery through the Amazon SES v2 SendEmail API. BaseURL defaults to the
+// regional endpoint and exists mainly so tests and VPC endpoints can override it.
+type SESConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	BaseURL         string
+	FromAddress     string
+}
+
+// SESEmailSender sends raw MIME messages through SES v2 so the rendered output matches SMTP delivery.
+type SESEmailSender struct {
+	Config     SESConfig
+	HTTPClient *http.Client
+	Logger     *slog.Logger
+	now        func() time.Time
+}
+
+func NewSESEmailSender(configuration SESConfig, httpClient *http.Client, logger *slog.Logger) *SESEmailSender {
+	configuration.BaseURL = resolveBaseURL(configuration.BaseURL, fmt.Sprintf("https://email.%s.amazonaws.com", configuration.Region))
+	return &SESEmailSender{Config: configuration, HTTPClient: httpClient, Logger: logger, now: time.Now}
+}
+
+type sesDestination struct {
+	ToAddresses  []string `json:"ToAddresses,omitempty"`
+	CcAddresses  []string `json:"CcAddresses,omitempty"`
+	BccAddresses []string `json:"BccAddresses,omitempty"`
+}
+
+type sesRawMessage struct {
+	Data []byte `json:"Data"`
+}
+
+type sesContent struct {
+	Raw sesRawMessage `json:"Raw"`
+}
+
+type sesSendEmailRequest struct {
+	FromEmailAddress string         `json:"FromEmailAddress"`
+	Destination      sesDestination `json:"Destination"`
+	Content          sesContent     `json:"Content"`
+}
+
+type sesSendEmailResponse struct {
+	MessageID string `json:"MessageId"`
+}
+
+func (senderInstance *SESEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	if len(message.EnvelopeRecipients()) == 0 {
+		return EmailDeliveryResult{}, fmt.Errorf("email message has no recipients")
+	}
+	payload := sesSendEmailRequest{
+		FromEmailAddress: senderInstance.Config.FromAddress,
+		Destination: sesDestination{
+			ToAddresses:  message.To,
+			CcAddresses:  message.Cc,
+			BccAddresses: message.Bcc,
+		},
+		Content: sesContent{Raw: sesRawMessage{Data: []byte(buildEmailMessage(senderInstance.Config.FromAddress, message))}},
+	}
+	body, marshalErr := json.Marshal(payload)
+	if marshalErr != nil {
+		return EmailDeliveryResult{}, fmt.Errorf("encode ses request: %w", marshalErr)
+	}
+
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, senderInstance.Config.BaseURL+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if requestErr != nil {
+		return EmailDeliveryResult{}, requestErr
+	}
+	request.Header.Set("Content-Type", "application/json")
+	signAWSRequestV4(request, body, awsCredentials{
+		AccessKeyID:     senderInstance.Config.AccessKeyID,
+		SecretAccessKey: senderInstance.Config.SecretAccessKey,
+		SessionToken:    senderInstance.Config.SessionToken,
+	}, senderInstance.Config.Region, "ses", senderInstance.now())
+
+	response, responseErr := senderInstance.HTTPClient.Do(request)
+	if responseErr != nil {
+		senderInstance.Logger.Error("SES request error", "error", responseErr)
+		return EmailDeliveryResult{}, responseErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return EmailDeliveryResult{}, providerResponseError(config.EmailProviderSES, response)
+	}
+	var decoded sesSendEmailResponse
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		logUnreadableAcceptance(senderInstance.Logger, config.EmailProviderSES, err)
+	}
+	return acceptedByProvider(config.EmailProviderSES, message, decoded.MessageID), nil
+}
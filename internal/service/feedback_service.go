@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// FeedbackReport is a bounce or complaint for one recipient, as parsed from a DSN message or a
+// provider webhook.
+type FeedbackReport struct {
+	Provider string
+	// ProviderMessageID identifies the original message: the provider's message id, or the
+	// Message-ID header quoted back by a DSN.
+	ProviderMessageID string
+	// ReportID identifies the report itself: the provider's event id, or the Message-ID of a DSN.
+	// A report already recorded for the recipient under the same ID is ignored.
+	ReportID   string
+	Recipient  string
+	Type       model.FeedbackType
+	Diagnostic string
+	OccurredAt time.Time
+}
+
+// FeedbackService records bounces and complaints and maintains per-recipient deliverability.
+type FeedbackService interface {
+	RecordFeedback(ctx context.Context, report FeedbackReport) error
+}
+
+var (
+	ErrInvalidFeedbackReport = errors.New("invalid feedback report")
+	// ErrRecipientUndeliverable reports that every recipient of an email is marked undeliverable.
+	ErrRecipientUndeliverable = errors.New("email recipients marked undeliverable")
+)
+
+type feedbackServiceImpl struct {
+	database *gorm.DB
+	logger   *slog.Logger
+}
+
+// NewFeedbackService creates a FeedbackService backed by the feedback and deliverability tables.
+func NewFeedbackService(database *gorm.DB, logger *slog.Logger) FeedbackService {
+	return &feedbackServiceImpl{database: database, logger: logger}
+}
+
+func (serviceInstance *feedbackServiceImpl) RecordFeedback(ctx context.Context, report FeedbackReport) error {
+	recipient := model.NormalizeEmailAddress(report.Recipient)
+	if recipient == "" {
+		return fmt.Errorf("%w: missing recipient", ErrInvalidFeedbackReport)
+	}
+	switch report.Type {
+	case model.FeedbackHardBounce, model.FeedbackSoftBounce, model.FeedbackComplaint:
+	default:
+		return fmt.Errorf("%w: unsupported type %q", ErrInvalidFeedbackReport, report.Type)
+	}
+	occurredAt := report.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now().UTC()
+	}
+
+	event := model.FeedbackEvent{
+		Provider:          report.Provider,
+		ProviderMessageID: strings.TrimSpace(report.ProviderMessageID),
+		Recipient:         recipient,
+		Type:              report.Type,
+		Diagnostic:        report.Diagnostic,
+		OccurredAt:        occurredAt,
+	}
+	if reportID := strings.TrimSpace(report.ReportID); reportID != "" {
+		event.ReportID = &reportID
+	}
+	if event.ProviderMessageID != "" {
+		notification, err := model.GetNotificationByProviderMessageID(ctx, serviceInstance.database, report.Provider, event.ProviderMessageID)
+		switch {
+		case err == nil:
+			event.NotificationID = notification.NotificationID
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			// Reports for messages sent before this service tracked them still count against the address.
+		default:
+			return err
+		}
+	}
+
+	deliverability, recorded, err := model.RecordFeedback(ctx, serviceInstance.database, &event)
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		serviceInstance.logger.Info("email_feedback_duplicate", "provider", event.Provider, "report_id", report.ReportID, "recipient", event.Recipient)
+		return nil
+	}
+	serviceInstance.logger.Info(
+		"email_feedback_recorded",
+		"notification_id", event.NotificationID,
+		"provider", event.Provider,
+		"recipient", event.Recipient,
+		"type", event.Type,
+		"undeliverable", deliverability.Undeliverable,
+	)
+	return nil
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+)
+
+func TestRecordFeedbackUpdatesDeliverability(t *testing.T) {
+	t.Helper()
+	testCases := []struct {
+		name                  string
+		reports               []model.FeedbackType
+		expectedUndeliverable bool
+		expectedHard          int
+		expectedSoft          int
+		expectedComplaints    int
+		expectedReason        model.SuppressionReason
+	}{
+		{name: "SoftBouncesOnlyCounted", reports: []model.FeedbackType{model.FeedbackSoftBounce, model.FeedbackSoftBounce}, expectedSoft: 2},
+		{name: "HardBounceMarksUndeliverable", reports: []model.FeedbackType{model.FeedbackSoftBounce, model.FeedbackHardBounce}, expectedUndeliverable: true, expectedHard: 1, expectedSoft: 1, expectedReason: model.SuppressionBounced},
+		{name: "ComplaintMarksUndeliverable", reports: []model.FeedbackType{model.FeedbackComplaint}, expectedUndeliverable: true, expectedComplaints: 1, expectedReason: model.SuppressionComplaint},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database := openIsolatedDatabase(t)
+			record := model.Notification{
+				NotificationID:    "notif-feedback",
+				NotificationType:  model.NotificationEmail,
+				Recipient:         "user@example.com",
+				Message:           "Body",
+				Status:            model.StatusSent,
+				ProviderMessageID: "<abc@example.com>",
+				Provider:          "smtp",
+				CreatedAt:         time.Now().UTC(),
+				UpdatedAt:         time.Now().UTC(),
+			}
+			if err := model.CreateNotification(context.Background(), database, &record); err != nil {
+				t.Fatalf("create notification: %v", err)
+			}
+			serviceInstance := NewFeedbackService(database, newDiscardLogger())
+
+			for _, feedbackType := range testCase.reports {
+				err := serviceInstance.RecordFeedback(context.Background(), FeedbackReport{
+					Provider:          "smtp",
+					ProviderMessageID: "<abc@example.com>",
+					Recipient:         " User@Example.com ",
+					Type:              feedbackType,
+				})
+				if err != nil {
+					t.Fatalf("record feedback: %v", err)
+				}
+			}
+
+			deliverability, err := model.GetRecipientDeliverability(context.Background(), database, "user@example.com")
+			if err != nil {
+				t.Fatalf("load deliverability: %v", err)
+			}
+			if deliverability.Undeliverable != testCase.expectedUndeliverable ||
+				deliverability.HardBounces != testCase.expectedHard ||
+				deliverability.SoftBounces != testCase.expectedSoft ||
+				deliverability.Complaints != testCase.expectedComplaints {
+				t.Fatalf("unexpected deliverability %+v", deliverability)
+			}
+			suppression, suppressionErr := model.GetSuppression(context.Background(), database, model.NotificationEmail, "user@example.com", "")
+			if testCase.expectedReason == "" {
+				if !errors.Is(suppressionErr, model.ErrSuppressionNotFound) {
+					t.Fatalf("expected no suppression, got %+v (%v)", suppression, suppressionErr)
+				}
+			} else if suppressionErr != nil || suppression.Reason != testCase.expectedReason || suppression.Source != "smtp" || suppression.ExpiresAt != nil {
+				t.Fatalf("expected a permanent %s suppression from smtp, got %+v (%v)", testCase.expectedReason, suppression, suppressionErr)
+			}
+			events, err := model.ListFeedbackEvents(context.Background(), database, "notif-feedback")
+			if err != nil {
+				t.Fatalf("list feedback events: %v", err)
+			}
+			if len(events) != len(testCase.reports) {
+				t.Fatalf("expected %d linked events, got %d", len(testCase.reports), len(events))
+			}
+		})
+	}
+}
+
+func TestRemovingFeedbackSuppressionRestoresDelivery(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	ctx := context.Background()
+	if err := NewFeedbackService(database, newDiscardLogger()).RecordFeedback(ctx, FeedbackReport{Provider: "ses", Recipient: "user@example.com", Type: model.FeedbackHardBounce}); err != nil {
+		t.Fatalf("record feedback: %v", err)
+	}
+
+	suppressionService := NewSuppressionService(database, newDiscardLogger())
+	listed, err := suppressionService.ListSuppressions(ctx, model.SuppressionListFilters{})
+	if err != nil || len(listed) != 1 || listed[0].Reason != model.SuppressionBounced || listed[0].Source != "ses" {
+		t.Fatalf("expected the bounce to be listed as a suppression, got %+v (%v)", listed, err)
+	}
+	if err := suppressionService.RemoveSuppression(ctx, model.NotificationEmail, "User@Example.com", ""); err != nil {
+		t.Fatalf("remove suppression: %v", err)
+	}
+
+	deliverability, err := model.GetRecipientDeliverability(ctx, database, "user@example.com")
+	if err != nil || deliverability.Undeliverable || deliverability.HardBounces != 1 {
+		t.Fatalf("expected the address to be deliverable with its history kept, got %+v (%v)", deliverability, err)
+	}
+	emailSender := &stubEmailSender{}
+	serviceInstance := &notificationServiceImpl{database: database, logger: newDiscardLogger(), emailSender: emailSender, maxRetries: 3, retryIntervalSec: 1}
+	response, err := serviceInstance.SendNotification(ctx, model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Welcome back",
+		Message:          "Body",
+	})
+	if err != nil || response.Status != model.StatusSent || len(emailSender.receivedMessages) != 1 {
+		t.Fatalf("expected the address to be sent to again, got %+v (%v)", response, err)
+	}
+}
+
+func TestRecordFeedbackIgnoresRedeliveredReports(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	serviceInstance := NewFeedbackService(database, newDiscardLogger())
+	ctx := context.Background()
+
+	reports := []FeedbackReport{
+		{Provider: "smtp", ReportID: "<dsn-1@mx.example.com>", Recipient: "user@example.com", Type: model.FeedbackSoftBounce},
+		{Provider: "smtp", ReportID: "<dsn-1@mx.example.com>", Recipient: "other@example.com", Type: model.FeedbackSoftBounce},
+		{Provider: "smtp", ReportID: "<dsn-1@mx.example.com>", Recipient: "User@Example.com", Type: model.FeedbackSoftBounce},
+		{Provider: "sendgrid", ReportID: "<dsn-1@mx.example.com>", Recipient: "user@example.com", Type: model.FeedbackSoftBounce},
+		{Provider: "smtp", ReportID: "<dsn-2@mx.example.com>", Recipient: "user@example.com", Type: model.FeedbackSoftBounce},
+		{Provider: "smtp", Recipient: "user@example.com", Type: model.FeedbackSoftBounce},
+		{Provider: "smtp", Recipient: "user@example.com", Type: model.FeedbackSoftBounce},
+	}
+	for _, report := range reports {
+		if err := serviceInstance.RecordFeedback(ctx, report); err != nil {
+			t.Fatalf("record feedback %+v: %v", report, err)
+		}
+	}
+
+	// The third report repeats the first; reports without an ID cannot be told apart and all count.
+	deliverability, err := model.GetRecipientDeliverability(ctx, database, "user@example.com")
+	if err != nil || deliverability.SoftBounces != 5 {
+		t.Fatalf("expected the redelivered report to be counted once, got %+v (%v)", deliverability, err)
+	}
+	var storedEvents int64
+	if err := database.Model(&model.FeedbackEvent{}).Count(&storedEvents).Error; err != nil || storedEvents != int64(len(reports)-1) {
+		t.Fatalf("expected %d stored events, got %d (%v)", len(reports)-1, storedEvents, err)
+	}
+}
+
+func TestRecordFeedbackErrors(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	serviceInstance := NewFeedbackService(database, newDiscardLogger())
+
+	testCases := []struct {
+		name   string
+		report FeedbackReport
+	}{
+		{name: "MissingRecipient", report: FeedbackReport{Provider: "smtp", Type: model.FeedbackHardBounce}},
+		{name: "UnknownType", report: FeedbackReport{Provider: "smtp", Recipient: "user@example.com", Type: "unsubscribe"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := serviceInstance.RecordFeedback(context.Background(), testCase.report)
+			if !errors.Is(err, ErrInvalidFeedbackReport) {
+				t.Fatalf("expected ErrInvalidFeedbackReport, got %v", err)
+			}
+		})
+	}
+
+	err := serviceInstance.RecordFeedback(context.Background(), FeedbackReport{
+		Provider:          "smtp",
+		ProviderMessageID: "<unknown@example.com>",
+		Recipient:         "user@example.com",
+		Type:              model.FeedbackHardBounce,
+	})
+	if err != nil {
+		t.Fatalf("expected unmatched report to be recorded, got %v", err)
+	}
+}
+
+func TestParseDSN(t *testing.T) {
+	t.Helper()
+	message := strings.Join([]string{
+		"From: MAILER-DAEMON@mx.example.com",
+		"To: from@example.com",
+		"Subject: Delivery Status Notification (Failure)",
+		"Message-ID: <dsn-1@mx.example.com>",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/report; report-type=delivery-status; boundary="REPORT"`,
+		"",
+		"--REPORT",
+		"Content-Type: text/plain",
+		"",
+		"Delivery to the following recipients failed.",
+		"--REPORT",
+		"Content-Type: message/delivery-status",
+		"",
+		"Reporting-MTA: dns; mx.example.com",
+		"Arrival-Date: Mon, 01 Jan 2024 10:00:00 +0000",
+		"",
+		"Final-Recipient: rfc822; gone@example.org",
+		"Action: failed",
+		"Status: 5.1.1",
+		"Diagnostic-Code: smtp; 550 5.1.1 user unknown",
+		"",
+		"Final-Recipient: rfc822; busy@example.org",
+		"Action: delayed",
+		"Status: 4.2.2",
+		"Last-Attempt-Date: Mon, 01 Jan 2024 11:00:00 +0000",
+		"",
+		"Final-Recipient: rfc822; fine@example.org",
+		"Action: relayed",
+		"Status: 2.0.0",
+		"--REPORT",
+		"Content-Type: text/rfc822-headers",
+		"",
+		"From: from@example.com",
+		"Message-ID: <1700000000.abc@example.com>",
+		"Subject: Digest",
+		"--REPORT--",
+		"",
+	}, "\r\n")
+
+	reports, err := ParseDSN(strings.NewReader(message))
+	if err != nil {
+		t.Fatalf("parse dsn: %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("expected two reports, got %+v", reports)
+	}
+	hard, soft := reports[0], reports[1]
+	if hard.Recipient != "gone@example.org" || hard.Type != model.FeedbackHardBounce || hard.Diagnostic != "smtp; 550 5.1.1 user unknown" {
+		t.Fatalf("unexpected hard bounce %+v", hard)
+	}
+	if hard.ProviderMessageID != "<1700000000.abc@example.com>" || hard.Provider != "smtp" || hard.ReportID != "<dsn-1@mx.example.com>" {
+		t.Fatalf("unexpected original message reference %+v", hard)
+	}
+	if !hard.OccurredAt.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected arrival date, got %s", hard.OccurredAt)
+	}
+	if soft.Recipient != "busy@example.org" || soft.Type != model.FeedbackSoftBounce || !soft.OccurredAt.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected soft bounce %+v", soft)
+	}
+
+	if _, err := ParseDSN(strings.NewReader("Content-Type: text/plain\r\n\r\nhello")); !errors.Is(err, ErrInvalidDSN) {
+		t.Fatalf("expected ErrInvalidDSN for plain message, got %v", err)
+	}
+}
+
+func TestSendNotificationSkipsUndeliverableRecipients(t *testing.T) {
+	t.Helper()
+	testCases := []struct {
+		name             string
+		undeliverable    []string
+		expectSkipped    bool
+		expectedTo       string
+		expectedStatuses map[string]model.RecipientStatus
+	}{
+		{
+			name:          "SkipsBouncedCc",
+			undeliverable: []string{"CC@example.com"},
+			expectedTo:    "to@example.com",
+			expectedStatuses: map[string]model.RecipientStatus{
+				"to@example.com": model.RecipientAccepted,
+				"cc@example.com": model.RecipientSkipped,
+			},
+		},
+		{
+			name:          "AllSuppressed",
+			undeliverable: []string{"to@example.com", "cc@example.com"},
+			expectSkipped: true,
+			expectedStatuses: map[string]model.RecipientStatus{
+				"to@example.com": model.RecipientSkipped,
+				"cc@example.com": model.RecipientSkipped,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database := openIsolatedDatabase(t)
+			feedbackService := NewFeedbackService(database, newDiscardLogger())
+			for _, address := range testCase.undeliverable {
+				if err := feedbackService.RecordFeedback(context.Background(), FeedbackReport{Provider: "smtp", Recipient: address, Type: model.FeedbackHardBounce}); err != nil {
+					t.Fatalf("seed feedback: %v", err)
+				}
+			}
+			emailSender := &stubEmailSender{}
+			serviceInstance := &notificationServiceImpl{
+				database:         database,
+				logger:           newDiscardLogger(),
+				emailSender:      emailSender,
+				maxRetries:       3,
+				retryIntervalSec: 1,
+			}
+
+			response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				To:               []string{"to@example.com"},
+				Cc:               []string{"cc@example.com"},
+				Subject:          "Digest",
+				Message:          "Body",
+			})
+			if testCase.expectSkipped != errors.Is(err, ErrRecipientSuppressed) {
+				t.Fatalf("unexpected send error: %v", err)
+			}
+
+			stored, fetchErr := model.GetNotificationByID(context.Background(), database, response.NotificationID)
+			if fetchErr != nil {
+				t.Fatalf("fetch error: %v", fetchErr)
+			}
+			for _, recipient := range stored.Recipients {
+				if recipient.Status != testCase.expectedStatuses[recipient.Address] {
+					t.Fatalf("recipient %s: expected %s, got %s", recipient.Address, testCase.expectedStatuses[recipient.Address], recipient.Status)
+				}
+			}
+			if testCase.expectSkipped {
+				if len(emailSender.receivedMessages) != 0 {
+					t.Fatalf("expected no dispatch, got %d", len(emailSender.receivedMessages))
+				}
+				if stored.Status != model.StatusSuppressed {
+					t.Fatalf("expected suppressed notification, got %s", stored.Status)
+				}
+				return
+			}
+			if len(emailSender.receivedMessages) != 1 {
+				t.Fatalf("expected one dispatch, got %d", len(emailSender.receivedMessages))
+			}
+			dispatched := emailSender.receivedMessages[0]
+			if strings.Join(dispatched.To, ",") != testCase.expectedTo || len(dispatched.Cc) != 0 {
+				t.Fatalf("unexpected envelope %#v", dispatched)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+)
+
+// ErrIdempotencyKeyConflict reports that an idempotency key was reused with a different payload.
+var ErrIdempotencyKeyConflict = errors.New("idempotency key already used with a different request")
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyGate serialises requests that share an idempotency key within this process so a
+// concurrent retry waits for the first attempt and replays it. Across processes the unique index on
+// the key decides, because notifications are stored before any provider is contacted.
+// The zero value is ready to use.
+type idempotencyGate struct {
+	mutex sync.Mutex
+	locks map[string]*idempotencyLock
+}
+
+type idempotencyLock struct {
+	mutex    sync.Mutex
+	refCount int
+}
+
+func (gate *idempotencyGate) acquire(key string) func() {
+	gate.mutex.Lock()
+	if gate.locks == nil {
+		gate.locks = make(map[string]*idempotencyLock)
+	}
+	lock, exists := gate.locks[key]
+	if !exists {
+		lock = &idempotencyLock{}
+		gate.locks[key] = lock
+	}
+	lock.refCount++
+	gate.mutex.Unlock()
+
+	lock.mutex.Lock()
+	return func() {
+		lock.mutex.Unlock()
+		gate.mutex.Lock()
+		lock.refCount--
+		if lock.refCount == 0 {
+			delete(gate.locks, key)
+		}
+		gate.mutex.Unlock()
+	}
+}
+
+// findIdempotentReplay looks up a notification previously stored under the key. It reports
+// found=false when the key is new and ErrIdempotencyKeyConflict when the stored payload differs.
+func (serviceInstance *notificationServiceImpl) findIdempotentReplay(ctx context.Context, idempotencyKey string, fingerprint string) (model.NotificationResponse, bool, error) {
+	existing, lookupErr := model.GetNotificationByIdempotencyKey(ctx, serviceInstance.database, idempotencyKey)
+	if lookupErr != nil {
+		if errors.Is(lookupErr, gorm.ErrRecordNotFound) {
+			return model.NotificationResponse{}, false, nil
+		}
+		return model.NotificationResponse{}, false, lookupErr
+	}
+	if existing.RequestFingerprint != fingerprint {
+		serviceInstance.logger.Warn("idempotency_key_conflict", "notification_id", existing.NotificationID)
+		return model.NotificationResponse{}, true, fmt.Errorf("%w: %s", ErrIdempotencyKeyConflict, idempotencyKey)
+	}
+	serviceInstance.logger.Info("idempotent_replay", "notification_id", existing.NotificationID)
+	return model.NewNotificationResponse(*existing), true, nil
+}
+
+type fingerprintAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	DataDigest  string `json:"data_digest"`
+}
+
+// requestFingerprint hashes the caller-supplied payload so a replayed key can be matched against
+// the request that first used it. Map keys are sorted by encoding/json, keeping the digest stable.
+func requestFingerprint(request model.NotificationRequest) string {
+	attachments := make([]fingerprintAttachment, 0, len(request.Attachments))
+	for _, attachment := range request.Attachments {
+		dataDigest := sha256.Sum256(attachment.Data)
+		attachments = append(attachments, fingerprintAttachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			DataDigest:  hex.EncodeToString(dataDigest[:]),
+		})
+	}
+	var scheduledFor string
+	if request.ScheduledFor != nil {
+		scheduledFor = request.ScheduledFor.UTC().Format(time.RFC3339Nano)
+	}
+	payload, _ := json.Marshal(struct {
+		NotificationType model.NotificationType  `json:"notification_type"`
+		Recipient        string                  `json:"recipient"`
+		To               []string                `json:"to"`
+		Cc               []string                `json:"cc"`
+		Bcc              []string                `json:"bcc"`
+		Subject          string                  `json:"subject"`
+		Message          string                  `json:"message"`
+		HTMLMessage      string                  `json:"html_message"`
+		Category         string                  `json:"category"`
+		ScheduledFor     string                  `json:"scheduled_for"`
+		Attachments      []fingerprintAttachment `json:"attachments"`
+		TemplateID       string                  `json:"template_id"`
+		TemplateVersion  int                     `json:"template_version"`
+		TemplateData     map[string]string       `json:"template_data"`
+	}{
+		NotificationType: request.NotificationType,
+		Recipient:        request.Recipient,
+		To:               request.To,
+		Cc:               request.Cc,
+		Bcc:              request.Bcc,
+		Subject:          request.Subject,
+		Message:          request.Message,
+		HTMLMessage:      request.HTMLMessage,
+		Category:         request.Category,
+		ScheduledFor:     scheduledFor,
+		Attachments:      attachments,
+		TemplateID:       request.TemplateID,
+		TemplateVersion:  request.TemplateVersion,
+		TemplateData:     request.TemplateData,
+	})
+	digest := sha256.Sum256(payload)
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/model"
+	"log/slog"
+)
+
+func TestSendNotificationHonorsIdempotencyKey(t *testing.T) {
+	t.Helper()
+
+	baseRequest := model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Receipt",
+		Message:          "Thanks for your order",
+		IdempotencyKey:   "order-42",
+	}
+
+	testCases := []struct {
+		name               string
+		replay             model.NotificationRequest
+		expectedErr        error
+		expectedDispatches int
+	}{
+		{
+			name:               "SamePayloadReturnsOriginal",
+			replay:             baseRequest,
+			expectedDispatches: 1,
+		},
+		{
+			name: "WhitespaceAroundKeyIsIgnored",
+			replay: func() model.NotificationRequest {
+				request := baseRequest
+				request.IdempotencyKey = " order-42 "
+				return request
+			}(),
+			expectedDispatches: 1,
+		},
+		{
+			name: "DifferentPayloadConflicts",
+			replay: func() model.NotificationRequest {
+				request := baseRequest
+				request.Message = "A different body"
+				return request
+			}(),
+			expectedErr:        ErrIdempotencyKeyConflict,
+			expectedDispatches: 1,
+		},
+		{
+			name: "DifferentKeySendsAgain",
+			replay: func() model.NotificationRequest {
+				request := baseRequest
+				request.IdempotencyKey = "order-43"
+				return request
+			}(),
+			expectedDispatches: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			emailSender := &stubEmailSender{}
+			serviceInstance := &notificationServiceImpl{
+				database:    openIsolatedDatabase(t),
+				logger:      slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+				emailSender: emailSender,
+				maxRetries:  3,
+			}
+
+			original, err := serviceInstance.SendNotification(context.Background(), baseRequest)
+			if err != nil {
+				t.Fatalf("send error: %v", err)
+			}
+			if original.IdempotencyKey != "order-42" {
+				t.Fatalf("expected idempotency key on response, got %q", original.IdempotencyKey)
+			}
+
+			replayed, replayErr := serviceInstance.SendNotification(context.Background(), testCase.replay)
+			if testCase.expectedErr != nil {
+				if !errors.Is(replayErr, testCase.expectedErr) {
+					t.Fatalf("expected %v, got %v", testCase.expectedErr, replayErr)
+				}
+			} else if replayErr != nil {
+				t.Fatalf("replay error: %v", replayErr)
+			}
+			if emailSender.callCount != testCase.expectedDispatches {
+				t.Fatalf("expected %d dispatches, got %d", testCase.expectedDispatches, emailSender.callCount)
+			}
+			if testCase.expectedErr == nil && testCase.expectedDispatches == 1 && replayed.NotificationID != original.NotificationID {
+				t.Fatalf("expected original notification %s, got %s", original.NotificationID, replayed.NotificationID)
+			}
+		})
+	}
+}
+
+func TestSendNotificationSerializesConcurrentIdempotentRequests(t *testing.T) {
+	t.Helper()
+
+	emailSender := &stubEmailSender{}
+	serviceInstance := &notificationServiceImpl{
+		database:    openIsolatedDatabase(t),
+		logger:      slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender: emailSender,
+		maxRetries:  3,
+	}
+	request := model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Receipt",
+		Message:          "Thanks for your order",
+		IdempotencyKey:   "order-concurrent",
+	}
+
+	const attempts = 5
+	var waitGroup sync.WaitGroup
+	notificationIDs := make([]string, attempts)
+	errs := make([]error, attempts)
+	for attempt := 0; attempt < attempts; attempt++ {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+			response, err := serviceInstance.SendNotification(context.Background(), request)
+			notificationIDs[index] = response.NotificationID
+			errs[index] = err
+		}(attempt)
+	}
+	waitGroup.Wait()
+
+	for index := range errs {
+		if errs[index] != nil {
+			t.Fatalf("attempt %d error: %v", index, errs[index])
+		}
+		if notificationIDs[index] != notificationIDs[0] {
+			t.Fatalf("expected every attempt to return %s, got %s", notificationIDs[0], notificationIDs[index])
+		}
+	}
+	if emailSender.callCount != 1 {
+		t.Fatalf("expected a single dispatch, got %d", emailSender.callCount)
+	}
+}
+
+// replayingEmailSender submits request to another replica while it delivers, as a client retrying
+// against a second server would.
+type replayingEmailSender struct {
+	stubEmailSender
+	replica  *notificationServiceImpl
+	request  model.NotificationRequest
+	replayed model.NotificationResponse
+	err      error
+}
+
+func (sender *replayingEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	sender.replayed, sender.err = sender.replica.SendNotification(ctx, sender.request)
+	return sender.stubEmailSender.SendEmail(ctx, message)
+}
+
+func TestSendNotificationClaimsIdempotencyKeyBeforeInlineDispatch(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	request := model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Receipt",
+		Message:          "Thanks for your order",
+		IdempotencyKey:   "order-replicas",
+	}
+	replicaSender := &stubEmailSender{}
+	replica := &notificationServiceImpl{
+		database:    database,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender: replicaSender,
+		maxRetries:  3,
+	}
+	sender := &replayingEmailSender{replica: replica, request: request}
+	serviceInstance := &notificationServiceImpl{
+		database:    database,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender: sender,
+		maxRetries:  3,
+	}
+
+	original, err := serviceInstance.SendNotification(context.Background(), request)
+	if err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+	if sender.err != nil {
+		t.Fatalf("replica send error: %v", sender.err)
+	}
+	if replicaSender.callCount != 0 || sender.callCount != 1 {
+		t.Fatalf("expected a single dispatch, got %d and %d", sender.callCount, replicaSender.callCount)
+	}
+	if sender.replayed.NotificationID != original.NotificationID || sender.replayed.Status != model.StatusQueued {
+		t.Fatalf("expected the replica to replay the in-flight notification, got %#v", sender.replayed)
+	}
+	if original.Status != model.StatusSent {
+		t.Fatalf("expected the original send to succeed, got %s", original.Status)
+	}
+}
+
+func TestRequestFingerprintIgnoresIdempotencyKey(t *testing.T) {
+	t.Helper()
+
+	request := model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Message:          "Body",
+		TemplateData:     map[string]string{"b": "2", "a": "1"},
+		Attachments:      []model.EmailAttachment{{Filename: "a.txt", ContentType: "text/plain", Data: []byte("a")}},
+	}
+	keyed := request
+	keyed.IdempotencyKey = "key"
+	if requestFingerprint(request) != requestFingerprint(keyed) {
+		t.Fatalf("expected fingerprint to ignore the idempotency key")
+	}
+	changed := request
+	changed.Attachments = []model.EmailAttachment{{Filename: "a.txt", ContentType: "text/plain", Data: []byte("b")}}
+	if requestFingerprint(request) == requestFingerprint(changed) {
+		t.Fatalf("expected attachment data to change the fingerprint")
+	}
+}
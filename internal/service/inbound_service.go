@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// inboundKeywordSource labels suppressions written by STOP replies.
+const inboundKeywordSource = "inbound-sms"
+
+// InboundSMS is an SMS received from a recipient, as reported by a provider webhook.
+type InboundSMS struct {
+	Provider          string
+	ProviderMessageID string
+	From              string
+	To                string
+	Body              string
+	ReceivedAt        time.Time
+}
+
+// InboundMessageService records inbound SMS and applies carrier STOP/START/HELP keywords.
+type InboundMessageService interface {
+	// ReceiveMessage stores the message and applies its keyword. Redelivered messages are returned
+	// without a second HELP reply; their STOP or START is applied again, because the provider
+	// redelivers when applying it failed, unless a later STOP or START from the sender superseded it.
+	ReceiveMessage(ctx context.Context, message InboundSMS) (model.InboundMessage, error)
+	ListInboundMessages(ctx context.Context, filters model.InboundMessageListFilters) ([]model.InboundMessage, error)
+}
+
+var ErrInvalidInboundMessage = errors.New("invalid inbound message")
+
+type inboundMessageServiceImpl struct {
+	database           *gorm.DB
+	suppressionService SuppressionService
+	smsSender          SmsSender
+	helpReply          string
+	logger             *slog.Logger
+}
+
+// NewInboundMessageService creates an InboundMessageService. HELP messages are answered with
+// helpReply through smsSender; either being empty disables the auto-reply.
+func NewInboundMessageService(database *gorm.DB, suppressionService SuppressionService, smsSender SmsSender, helpReply string, logger *slog.Logger) InboundMessageService {
+	return &inboundMessageServiceImpl{
+		database:           database,
+		suppressionService: suppressionService,
+		smsSender:          smsSender,
+		helpReply:          strings.TrimSpace(helpReply),
+		logger:             logger,
+	}
+}
+
+func (serviceInstance *inboundMessageServiceImpl) ReceiveMessage(ctx context.Context, message InboundSMS) (model.InboundMessage, error) {
+	fromNumber := model.NormalizeSuppressionRecipient(model.NotificationSMS, message.From)
+	if fromNumber == "" || strings.TrimSpace(message.ProviderMessageID) == "" {
+		return model.InboundMessage{}, fmt.Errorf("%w: from and provider message id are required", ErrInvalidInboundMessage)
+	}
+	receivedAt := message.ReceivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now().UTC()
+	}
+	record := model.InboundMessage{
+		Provider:          message.Provider,
+		ProviderMessageID: strings.TrimSpace(message.ProviderMessageID),
+		FromNumber:        fromNumber,
+		ToNumber:          model.NormalizeSuppressionRecipient(model.NotificationSMS, message.To),
+		Body:              message.Body,
+		Keyword:           model.ClassifyInboundKeyword(message.Body),
+		ReceivedAt:        receivedAt,
+	}
+	created, err := model.CreateInboundMessage(ctx, serviceInstance.database, &record)
+	if err != nil {
+		return model.InboundMessage{}, err
+	}
+	if !created {
+		serviceInstance.logger.Info("inbound_sms_duplicate", "provider", record.Provider, "provider_message_id", record.ProviderMessageID)
+		return serviceInstance.reapplyOptOutKeyword(ctx, record)
+	}
+	serviceInstance.logger.Info("inbound_sms_received", "provider", record.Provider, "provider_message_id", record.ProviderMessageID, "keyword", record.Keyword)
+
+	if record.Keyword == model.InboundKeywordHelp {
+		serviceInstance.sendHelpReply(ctx, record.FromNumber)
+		return record, nil
+	}
+	if err := serviceInstance.applyOptOutKeyword(ctx, record); err != nil {
+		return model.InboundMessage{}, err
+	}
+	return record, nil
+}
+
+// reapplyOptOutKeyword applies the STOP or START of a redelivered message again. The stored message
+// decides, so a redelivery that arrives after a later keyword from the same sender cannot undo it.
+func (serviceInstance *inboundMessageServiceImpl) reapplyOptOutKeyword(ctx context.Context, redelivered model.InboundMessage) (model.InboundMessage, error) {
+	stored, err := model.GetInboundMessage(ctx, serviceInstance.database, redelivered.Provider, redelivered.ProviderMessageID)
+	if err != nil {
+		return model.InboundMessage{}, err
+	}
+	if stored.Keyword != model.InboundKeywordStop && stored.Keyword != model.InboundKeywordStart {
+		return *stored, nil
+	}
+	superseded, err := model.HasLaterOptOutKeyword(ctx, serviceInstance.database, *stored)
+	if err != nil {
+		return model.InboundMessage{}, err
+	}
+	if superseded {
+		return *stored, nil
+	}
+	if err := serviceInstance.applyOptOutKeyword(ctx, *stored); err != nil {
+		return model.InboundMessage{}, err
+	}
+	return *stored, nil
+}
+
+// applyOptOutKeyword suppresses the sender of a STOP and lifts that suppression for a START. Both
+// are idempotent, so redelivered messages can apply them again.
+func (serviceInstance *inboundMessageServiceImpl) applyOptOutKeyword(ctx context.Context, record model.InboundMessage) error {
+	var err error
+	switch record.Keyword {
+	case model.InboundKeywordStop:
+		_, err = serviceInstance.suppressionService.AddSuppression(ctx, model.SuppressionRequest{
+			Channel:   model.NotificationSMS,
+			Recipient: record.FromNumber,
+			Reason:    model.SuppressionStop,
+			Source:    inboundKeywordSource,
+		})
+	case model.InboundKeywordStart:
+		err = serviceInstance.optIn(ctx, record.FromNumber)
+	}
+	if err != nil {
+		return fmt.Errorf("apply %s keyword: %w", record.Keyword, err)
+	}
+	return nil
+}
+
+func (serviceInstance *inboundMessageServiceImpl) ListInboundMessages(ctx context.Context, filters model.InboundMessageListFilters) ([]model.InboundMessage, error) {
+	return model.ListInboundMessages(ctx, serviceInstance.database, filters)
+}
+
+// optIn lifts the suppression written by an earlier STOP. Suppressions added for other reasons,
+// such as a manual block, are kept: a START reply only undoes the recipient's own opt-out.
+func (serviceInstance *inboundMessageServiceImpl) optIn(ctx context.Context, fromNumber string) error {
+	suppression, err := model.GetSuppression(ctx, serviceInstance.database, model.NotificationSMS, fromNumber, "")
+	if errors.Is(err, model.ErrSuppressionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if suppression.Reason != model.SuppressionStop {
+		serviceInstance.logger.Info("inbound_sms_start_ignored", "reason", suppression.Reason)
+		return nil
+	}
+	err = serviceInstance.suppressionService.RemoveSuppression(ctx, model.NotificationSMS, fromNumber, "")
+	if errors.Is(err, model.ErrSuppressionNotFound) {
+		return nil
+	}
+	return err
+}
+
+// sendHelpReply answers a HELP message. A failed reply is logged rather than returned so the
+// provider does not redeliver the inbound message.
+func (serviceInstance *inboundMessageServiceImpl) sendHelpReply(ctx context.Context, fromNumber string) {
+	if serviceInstance.helpReply == "" || serviceInstance.smsSender == nil {
+		return
+	}
+	result, err := serviceInstance.smsSender.SendSms(ctx, fromNumber, serviceInstance.helpReply)
+	if err != nil {
+		serviceInstance.logger.Error("inbound_sms_help_reply_failed", "error", err)
+		return
+	}
+	serviceInstance.logger.Info("inbound_sms_help_replied", "provider", result.Provider, "provider_message_id", result.ProviderMessageID)
+}
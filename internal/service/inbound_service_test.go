@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+)
+
+type replyRecordingSmsSender struct {
+	recipients []string
+	messages   []string
+	err        error
+}
+
+func (sender *replyRecordingSmsSender) SendSms(_ context.Context, recipient string, message string) (SmsDeliveryResult, error) {
+	sender.recipients = append(sender.recipients, recipient)
+	sender.messages = append(sender.messages, message)
+	return SmsDeliveryResult{ProviderMessageID: "SM-reply", Provider: "test"}, sender.err
+}
+
+func TestReceiveMessageAppliesKeywords(t *testing.T) {
+	t.Helper()
+	const fromNumber = "+15550001111"
+
+	testCases := []struct {
+		name                string
+		existing            *model.SuppressionRequest
+		body                string
+		expectedKeyword     model.InboundKeyword
+		expectSuppressed    bool
+		expectedReason      model.SuppressionReason
+		expectedHelpReplies int
+	}{
+		{name: "StopSuppresses", body: "Stop", expectedKeyword: model.InboundKeywordStop, expectSuppressed: true, expectedReason: model.SuppressionStop},
+		{
+			name:            "StartLiftsStop",
+			existing:        &model.SuppressionRequest{Channel: model.NotificationSMS, Recipient: fromNumber, Reason: model.SuppressionStop},
+			body:            "START",
+			expectedKeyword: model.InboundKeywordStart,
+		},
+		{
+			name:             "StartKeepsManualSuppression",
+			existing:         &model.SuppressionRequest{Channel: model.NotificationSMS, Recipient: fromNumber, Reason: model.SuppressionManual},
+			body:             "start",
+			expectedKeyword:  model.InboundKeywordStart,
+			expectSuppressed: true,
+			expectedReason:   model.SuppressionManual,
+		},
+		{name: "StartWithoutSuppression", body: "UNSTOP", expectedKeyword: model.InboundKeywordStart},
+		{name: "HelpReplies", body: "help", expectedKeyword: model.InboundKeywordHelp, expectedHelpReplies: 1},
+		{name: "PlainMessage", body: "Running late, stop by later", expectedKeyword: model.InboundKeywordNone},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database := openIsolatedDatabase(t)
+			suppressionService := NewSuppressionService(database, newDiscardLogger())
+			if testCase.existing != nil {
+				if _, err := suppressionService.AddSuppression(context.Background(), *testCase.existing); err != nil {
+					t.Fatalf("seed suppression: %v", err)
+				}
+			}
+			replySender := &replyRecordingSmsSender{}
+			serviceInstance := NewInboundMessageService(database, suppressionService, replySender, "Acme alerts: reply STOP to opt out.", newDiscardLogger())
+
+			message, err := serviceInstance.ReceiveMessage(context.Background(), InboundSMS{
+				Provider:          "twilio",
+				ProviderMessageID: "SM100",
+				From:              "+1 555 000 1111",
+				To:                "+15559990000",
+				Body:              testCase.body,
+			})
+			if err != nil {
+				t.Fatalf("receive message: %v", err)
+			}
+			if message.Keyword != testCase.expectedKeyword || message.FromNumber != fromNumber {
+				t.Fatalf("unexpected inbound message %+v", message)
+			}
+
+			suppression, err := model.GetSuppression(context.Background(), database, model.NotificationSMS, fromNumber, "")
+			if testCase.expectSuppressed {
+				if err != nil || suppression.Reason != testCase.expectedReason {
+					t.Fatalf("expected %s suppression, got %+v (%v)", testCase.expectedReason, suppression, err)
+				}
+			} else if !errors.Is(err, model.ErrSuppressionNotFound) {
+				t.Fatalf("expected no suppression, got %+v (%v)", suppression, err)
+			}
+			if len(replySender.messages) != testCase.expectedHelpReplies {
+				t.Fatalf("expected %d help replies, got %v", testCase.expectedHelpReplies, replySender.messages)
+			}
+			if testCase.expectedHelpReplies > 0 && (replySender.recipients[0] != fromNumber || replySender.messages[0] != "Acme alerts: reply STOP to opt out.") {
+				t.Fatalf("unexpected help reply to %v: %v", replySender.recipients, replySender.messages)
+			}
+		})
+	}
+}
+
+func TestReceiveMessageIgnoresRedelivery(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	suppressionService := NewSuppressionService(database, newDiscardLogger())
+	replySender := &replyRecordingSmsSender{err: errors.New("provider down")}
+	serviceInstance := NewInboundMessageService(database, suppressionService, replySender, "Reply STOP to opt out.", newDiscardLogger())
+	inbound := InboundSMS{Provider: "twilio", ProviderMessageID: "SM200", From: "+15550001111", Body: "HELP"}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if _, err := serviceInstance.ReceiveMessage(context.Background(), inbound); err != nil {
+			t.Fatalf("attempt %d: expected failed help reply to be tolerated, got %v", attempt, err)
+		}
+	}
+	if len(replySender.messages) != 1 {
+		t.Fatalf("expected a single help reply attempt, got %d", len(replySender.messages))
+	}
+	listed, err := serviceInstance.ListInboundMessages(context.Background(), model.InboundMessageListFilters{})
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("expected one stored message, got %+v (%v)", listed, err)
+	}
+
+	if _, err := serviceInstance.ReceiveMessage(context.Background(), InboundSMS{Provider: "twilio", Body: "STOP"}); !errors.Is(err, ErrInvalidInboundMessage) {
+		t.Fatalf("expected ErrInvalidInboundMessage, got %v", err)
+	}
+}
+
+// flakySuppressionService fails the first AddSuppression call, as a database hiccup would.
+type flakySuppressionService struct {
+	SuppressionService
+	failed bool
+}
+
+func (service *flakySuppressionService) AddSuppression(ctx context.Context, request model.SuppressionRequest) (model.Suppression, error) {
+	if !service.failed {
+		service.failed = true
+		return model.Suppression{}, errors.New("database is locked")
+	}
+	return service.SuppressionService.AddSuppression(ctx, request)
+}
+
+func TestReceiveMessageReappliesKeywordOnRedelivery(t *testing.T) {
+	t.Helper()
+	const fromNumber = "+15550001111"
+	database := openIsolatedDatabase(t)
+	suppressionService := NewSuppressionService(database, newDiscardLogger())
+	serviceInstance := NewInboundMessageService(database, &flakySuppressionService{SuppressionService: suppressionService}, nil, "", newDiscardLogger())
+	receivedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	stop := InboundSMS{Provider: "twilio", ProviderMessageID: "SM300", From: fromNumber, Body: "STOP", ReceivedAt: receivedAt}
+
+	if _, err := serviceInstance.ReceiveMessage(context.Background(), stop); err == nil {
+		t.Fatalf("expected the failed suppression to be reported so the provider redelivers")
+	}
+	if _, err := serviceInstance.ReceiveMessage(context.Background(), stop); err != nil {
+		t.Fatalf("redelivery: %v", err)
+	}
+	if suppression, err := model.GetSuppression(context.Background(), database, model.NotificationSMS, fromNumber, ""); err != nil || suppression.Reason != model.SuppressionStop {
+		t.Fatalf("expected the redelivered STOP to suppress the sender, got %+v (%v)", suppression, err)
+	}
+
+	start := InboundSMS{Provider: "twilio", ProviderMessageID: "SM301", From: fromNumber, Body: "START", ReceivedAt: receivedAt.Add(time.Minute)}
+	laterStop := InboundSMS{Provider: "twilio", ProviderMessageID: "SM302", From: fromNumber, Body: "STOP", ReceivedAt: receivedAt.Add(2 * time.Minute)}
+	for _, inbound := range []InboundSMS{start, laterStop, start} {
+		if _, err := serviceInstance.ReceiveMessage(context.Background(), inbound); err != nil {
+			t.Fatalf("receive %s: %v", inbound.ProviderMessageID, err)
+		}
+	}
+	if _, err := model.GetSuppression(context.Background(), database, model.NotificationSMS, fromNumber, ""); err != nil {
+		t.Fatalf("expected a redelivered START not to undo the later STOP, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
+)
+
+// newNotificationAttempt describes an attempt that left the notification in outcome. dispatchErr
+// is the error the attempt failed with, nil when it succeeded.
+func newNotificationAttempt(notificationID string, attemptedAt time.Time, duration time.Duration, provider string, outcome model.NotificationStatus, dispatchErr error) model.NotificationAttempt {
+	attempt := model.NotificationAttempt{
+		NotificationID: notificationID,
+		AttemptedAt:    attemptedAt,
+		Provider:       provider,
+		DurationMs:     duration.Milliseconds(),
+		Outcome:        outcome,
+	}
+	if dispatchErr == nil {
+		return attempt
+	}
+	attempt.ErrorClass = classifyAttemptError(dispatchErr)
+	attempt.ErrorMessage = dispatchErr.Error()
+	// Failed attempts do not report a provider, so it is recovered from the error where possible.
+	var smtpReply *textproto.Error
+	if errors.As(dispatchErr, &smtpReply) {
+		attempt.SMTPReplyCode = smtpReply.Code
+		if attempt.Provider == "" {
+			attempt.Provider = config.EmailProviderSMTP
+		}
+	}
+	var providerError *ProviderHTTPError
+	if attempt.Provider == "" && errors.As(dispatchErr, &providerError) {
+		attempt.Provider = providerError.Provider
+	}
+	return attempt
+}
+
+// classifyAttemptError reports which model.AttemptErrorClass err belongs to. Recipient errors are
+// checked first because providers report them through the same HTTP and SMTP errors as outages.
+func classifyAttemptError(err error) model.AttemptErrorClass {
+	switch {
+	case errors.Is(err, ErrRecipientSuppressed), errors.Is(err, ErrRecipientUndeliverable), errors.Is(err, ErrAllRecipientsRejected),
+		errors.Is(err, ErrInvalidRecipient), errors.Is(err, ErrRecipientUnsubscribed):
+		return model.AttemptErrorRecipient
+	case errors.Is(err, ErrSMSDisabled), errors.Is(err, ErrSMSProviderNotConfigured),
+		errors.Is(err, ErrSMTPAuthentication), errors.Is(err, ErrSMTPSenderRejected):
+		return model.AttemptErrorConfiguration
+	case errors.Is(err, ErrNoProviderAvailable):
+		return model.AttemptErrorUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return model.AttemptErrorTimeout
+	}
+	var providerError *ProviderHTTPError
+	if errors.As(err, &providerError) {
+		switch {
+		case providerError.Retryable():
+			return model.AttemptErrorUnavailable
+		case providerError.StatusCode == http.StatusUnauthorized, providerError.StatusCode == http.StatusForbidden:
+			return model.AttemptErrorConfiguration
+		}
+		return model.AttemptErrorRejected
+	}
+	var smtpReply *textproto.Error
+	if errors.As(err, &smtpReply) {
+		switch {
+		case smtpReply.Code < 500:
+			return model.AttemptErrorUnavailable
+		case recipientScopedReply(smtpReply):
+			return model.AttemptErrorRecipient
+		}
+		return model.AttemptErrorRejected
+	}
+	var networkError net.Error
+	if errors.As(err, &networkError) {
+		if networkError.Timeout() {
+			return model.AttemptErrorTimeout
+		}
+		return model.AttemptErrorNetwork
+	}
+	return model.AttemptErrorUnknown
+}
+
+// retryableDispatchError reports whether another attempt could succeed after err. Refusals of the
+// recipient or the message are final. SMTP replies are final only when they refuse the recipients
+// for good; 4xx replies such as greylisting and 5xx replies about the sender, the credentials or the
+// message may be answered differently on a later attempt.
+func retryableDispatchError(err error) bool {
+	var smtpReply *textproto.Error
+	if errors.As(err, &smtpReply) {
+		return smtpReply.Code < 500 || !(errors.Is(err, ErrAllRecipientsRejected) || recipientScopedReply(smtpReply))
+	}
+	if errors.Is(err, ErrAllRecipientsRejected) {
+		// No recipient was refused with a reply, e.g. the connection dropped during RCPT.
+		return true
+	}
+	switch classifyAttemptError(err) {
+	case model.AttemptErrorRecipient, model.AttemptErrorRejected:
+		return false
+	}
+	return true
+}
+
+// recipientScopedReply reports whether an SMTP reply carries an enhanced status code about the
+// addressing (5.1.x) or the mailbox (5.2.x), as mail servers report unknown or disabled recipients
+// after DATA.
+func recipientScopedReply(reply *textproto.Error) bool {
+	return strings.HasPrefix(reply.Msg, "5.1.") || strings.HasPrefix(reply.Msg, "5.2.")
+}
+
+// recordNotificationAttempt stores attempt. The history is informational, so a failed insert is
+// logged instead of failing the delivery it describes.
+func (serviceInstance *notificationServiceImpl) recordNotificationAttempt(ctx context.Context, attempt model.NotificationAttempt) {
+	if err := model.CreateNotificationAttempt(ctx, serviceInstance.database, &attempt); err != nil {
+		serviceInstance.logger.Error("Failed to record notification attempt", "notification_id", attempt.NotificationID, "error", err)
+	}
+}
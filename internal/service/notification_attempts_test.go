@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
+	"log/slog"
+)
+
+func TestNewNotificationAttemptClassifiesErrors(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name             string
+		provider         string
+		dispatchErr      error
+		expectedClass    model.AttemptErrorClass
+		expectedProvider string
+		expectedSMTPCode int
+	}{
+		{
+			name:             "Success",
+			provider:         "sendgrid",
+			expectedProvider: "sendgrid",
+		},
+		{
+			name:             "AllRecipientsRejectedBySMTP",
+			dispatchErr:      fmt.Errorf("%w: %w", ErrAllRecipientsRejected, &textproto.Error{Code: 550, Msg: "mailbox unavailable"}),
+			expectedClass:    model.AttemptErrorRecipient,
+			expectedProvider: config.EmailProviderSMTP,
+			expectedSMTPCode: 550,
+		},
+		{
+			name:             "TemporarySMTPReply",
+			dispatchErr:      fmt.Errorf("send: %w", &textproto.Error{Code: 451, Msg: "try again later"}),
+			expectedClass:    model.AttemptErrorUnavailable,
+			expectedProvider: config.EmailProviderSMTP,
+			expectedSMTPCode: 451,
+		},
+		{
+			name:             "PermanentSMTPReply",
+			dispatchErr:      &textproto.Error{Code: 554, Msg: "transaction failed"},
+			expectedClass:    model.AttemptErrorRejected,
+			expectedProvider: config.EmailProviderSMTP,
+			expectedSMTPCode: 554,
+		},
+		{
+			name:             "RecipientScopedDataReply",
+			dispatchErr:      fmt.Errorf("failed to close data writer: %w", &textproto.Error{Code: 550, Msg: "5.1.1 user unknown"}),
+			expectedClass:    model.AttemptErrorRecipient,
+			expectedProvider: config.EmailProviderSMTP,
+			expectedSMTPCode: 550,
+		},
+		{
+			name:             "SMTPCredentialsRefused",
+			dispatchErr:      fmt.Errorf("%w: %w", ErrSMTPAuthentication, &textproto.Error{Code: 535, Msg: "5.7.8 authentication credentials invalid"}),
+			expectedClass:    model.AttemptErrorConfiguration,
+			expectedProvider: config.EmailProviderSMTP,
+			expectedSMTPCode: 535,
+		},
+		{
+			name:             "SMTPSenderRejected",
+			dispatchErr:      fmt.Errorf("%w: %w", ErrSMTPSenderRejected, &textproto.Error{Code: 553, Msg: "5.7.1 sender not owned by user"}),
+			expectedClass:    model.AttemptErrorConfiguration,
+			expectedProvider: config.EmailProviderSMTP,
+			expectedSMTPCode: 553,
+		},
+		{
+			name:             "ProviderOutage",
+			dispatchErr:      &ProviderHTTPError{Provider: "twilio", StatusCode: 503},
+			expectedClass:    model.AttemptErrorUnavailable,
+			expectedProvider: "twilio",
+		},
+		{
+			name:             "ProviderRejection",
+			dispatchErr:      &ProviderHTTPError{Provider: "sendgrid", StatusCode: 400},
+			expectedClass:    model.AttemptErrorRejected,
+			expectedProvider: "sendgrid",
+		},
+		{
+			name:             "ProviderCredentialsRefused",
+			dispatchErr:      &ProviderHTTPError{Provider: "postmark", StatusCode: 403},
+			expectedClass:    model.AttemptErrorConfiguration,
+			expectedProvider: "postmark",
+		},
+		{
+			name:          "NoProviderAvailable",
+			dispatchErr:   ErrNoProviderAvailable,
+			expectedClass: model.AttemptErrorUnavailable,
+		},
+		{
+			name:          "SmsDisabled",
+			dispatchErr:   ErrSMSDisabled,
+			expectedClass: model.AttemptErrorConfiguration,
+		},
+		{
+			name:          "DeadlineExceeded",
+			dispatchErr:   fmt.Errorf("dial: %w", context.DeadlineExceeded),
+			expectedClass: model.AttemptErrorTimeout,
+		},
+		{
+			name:          "ConnectionRefused",
+			dispatchErr:   &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			expectedClass: model.AttemptErrorNetwork,
+		},
+		{
+			name:          "Unknown",
+			dispatchErr:   errors.New("boom"),
+			expectedClass: model.AttemptErrorUnknown,
+		},
+	}
+
+	attemptedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			outcome := model.StatusErrored
+			if testCase.dispatchErr == nil {
+				outcome = model.StatusSent
+			}
+			attempt := newNotificationAttempt("notif-1", attemptedAt, 1500*time.Millisecond, testCase.provider, outcome, testCase.dispatchErr)
+			if attempt.ErrorClass != testCase.expectedClass {
+				t.Fatalf("expected error class %q, got %q", testCase.expectedClass, attempt.ErrorClass)
+			}
+			if attempt.Provider != testCase.expectedProvider {
+				t.Fatalf("expected provider %q, got %q", testCase.expectedProvider, attempt.Provider)
+			}
+			if attempt.SMTPReplyCode != testCase.expectedSMTPCode {
+				t.Fatalf("expected SMTP reply code %d, got %d", testCase.expectedSMTPCode, attempt.SMTPReplyCode)
+			}
+			if attempt.DurationMs != 1500 || attempt.Outcome != outcome || !attempt.AttemptedAt.Equal(attemptedAt) {
+				t.Fatalf("unexpected attempt %#v", attempt)
+			}
+			if testCase.dispatchErr != nil && attempt.ErrorMessage != testCase.dispatchErr.Error() {
+				t.Fatalf("expected error message %q, got %q", testCase.dispatchErr.Error(), attempt.ErrorMessage)
+			}
+		})
+	}
+}
+
+func TestRetryableDispatchError(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name              string
+		dispatchErr       error
+		expectedRetryable bool
+	}{
+		{
+			name:              "MailboxUnavailable",
+			dispatchErr:       fmt.Errorf("%w: %w", ErrAllRecipientsRejected, &textproto.Error{Code: 550, Msg: "mailbox unavailable"}),
+			expectedRetryable: false,
+		},
+		{
+			name:              "Greylisted",
+			dispatchErr:       fmt.Errorf("%w: %w", ErrAllRecipientsRejected, &textproto.Error{Code: 450, Msg: "greylisted"}),
+			expectedRetryable: true,
+		},
+		{
+			name:              "RecipientsRefusedWithoutReply",
+			dispatchErr:       fmt.Errorf("%w: %w", ErrAllRecipientsRejected, io.ErrUnexpectedEOF),
+			expectedRetryable: true,
+		},
+		{
+			name:              "RecipientScopedDataReply",
+			dispatchErr:       fmt.Errorf("failed to close data writer: %w", &textproto.Error{Code: 550, Msg: "5.1.1 user unknown"}),
+			expectedRetryable: false,
+		},
+		{
+			name:              "MessageRejectedAfterData",
+			dispatchErr:       fmt.Errorf("failed to close data writer: %w", &textproto.Error{Code: 554, Msg: "5.7.1 message rejected as spam"}),
+			expectedRetryable: true,
+		},
+		{
+			name:              "SMTPCredentialsRefused",
+			dispatchErr:       fmt.Errorf("%w: %w", ErrSMTPAuthentication, &textproto.Error{Code: 535, Msg: "5.7.8 authentication credentials invalid"}),
+			expectedRetryable: true,
+		},
+		{
+			name:              "SMTPSenderRejected",
+			dispatchErr:       fmt.Errorf("%w: %w", ErrSMTPSenderRejected, &textproto.Error{Code: 550, Msg: "5.7.1 sender rejected"}),
+			expectedRetryable: true,
+		},
+		{
+			name:              "InvalidPhoneNumber",
+			dispatchErr:       fmt.Errorf("%w: 21211", ErrInvalidRecipient),
+			expectedRetryable: false,
+		},
+		{
+			name:              "ProviderRejection",
+			dispatchErr:       &ProviderHTTPError{Provider: "sendgrid", StatusCode: 400},
+			expectedRetryable: false,
+		},
+		{
+			name:              "ProviderCredentialsRefused",
+			dispatchErr:       &ProviderHTTPError{Provider: "sendgrid", StatusCode: 401},
+			expectedRetryable: true,
+		},
+		{
+			name:              "ProviderOutage",
+			dispatchErr:       &ProviderHTTPError{Provider: "twilio", StatusCode: 503},
+			expectedRetryable: true,
+		},
+		{
+			name:              "SmsDisabled",
+			dispatchErr:       ErrSMSDisabled,
+			expectedRetryable: true,
+		},
+		{
+			name:              "Unknown",
+			dispatchErr:       errors.New("boom"),
+			expectedRetryable: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if retryable := retryableDispatchError(testCase.dispatchErr); retryable != testCase.expectedRetryable {
+				t.Fatalf("expected retryable=%t, got %t", testCase.expectedRetryable, retryable)
+			}
+		})
+	}
+}
+
+// callerCancellingEmailSender accepts the message and then cancels the caller's context, as a
+// client deadline expiring right after the provider answered would.
+type callerCancellingEmailSender struct {
+	stubEmailSender
+	cancel context.CancelFunc
+}
+
+func (sender *callerCancellingEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	result, err := sender.stubEmailSender.SendEmail(ctx, message)
+	sender.cancel()
+	return result, err
+}
+
+func TestSendNotificationStoresAcceptedInlineAttemptAfterCallerGivesUp(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender:      &callerCancellingEmailSender{stubEmailSender: stubEmailSender{provider: "sendgrid"}, cancel: cancel},
+		maxRetries:       3,
+		retryIntervalSec: 1,
+	}
+
+	response, sendErr := serviceInstance.SendNotification(ctx, model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Subject",
+		Message:          "Body",
+	})
+	if sendErr != nil {
+		t.Fatalf("SendNotification error: %v", sendErr)
+	}
+
+	stored, fetchErr := model.GetNotificationByID(context.Background(), database, response.NotificationID)
+	if fetchErr != nil {
+		t.Fatalf("fetch notification error: %v", fetchErr)
+	}
+	if stored.Status != model.StatusSent || stored.LockedBy != "" || stored.LeaseUntil != nil {
+		t.Fatalf("expected the accepted message to be stored as sent and released, got %s locked by %q", stored.Status, stored.LockedBy)
+	}
+	attempts, attemptsErr := model.ListNotificationAttempts(context.Background(), database, response.NotificationID)
+	if attemptsErr != nil || len(attempts) != 1 || attempts[0].Outcome != model.StatusSent {
+		t.Fatalf("expected the sent attempt to be recorded, got %#v (%v)", attempts, attemptsErr)
+	}
+}
+
+func TestSendNotificationRecordsInlineAttempt(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender:      &stubEmailSender{provider: "sendgrid"},
+		maxRetries:       3,
+		retryIntervalSec: 1,
+	}
+
+	response, sendErr := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Subject",
+		Message:          "Body",
+	})
+	if sendErr != nil {
+		t.Fatalf("SendNotification error: %v", sendErr)
+	}
+
+	attempts, attemptsErr := serviceInstance.GetNotificationAttempts(context.Background(), response.NotificationID)
+	if attemptsErr != nil {
+		t.Fatalf("GetNotificationAttempts error: %v", attemptsErr)
+	}
+	if len(attempts) != 1 {
+		t.Fatalf("expected one attempt, got %d", len(attempts))
+	}
+	if attempts[0].Outcome != model.StatusSent || attempts[0].Provider != "sendgrid" || attempts[0].ErrorClass != "" {
+		t.Fatalf("unexpected attempt %#v", attempts[0])
+	}
+}
+
+func TestRetryWorkerRecordsAttempts(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender:      &stubEmailSender{},
+		maxRetries:       3,
+		retryIntervalSec: 1,
+		smsEnabled:       false,
+	}
+
+	now := time.Now().UTC()
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID:   "notif-sms-disabled",
+		NotificationType: model.NotificationSMS,
+		Recipient:        "+15555555555",
+		Message:          "Body",
+		Status:           model.StatusQueued,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	})
+
+	clock := &adjustableClock{now: now}
+	worker := newRetryWorkerForTest(t, serviceInstance, clock)
+	worker.RunOnce(context.Background())
+
+	attempts, attemptsErr := serviceInstance.GetNotificationAttempts(context.Background(), "notif-sms-disabled")
+	if attemptsErr != nil {
+		t.Fatalf("GetNotificationAttempts error: %v", attemptsErr)
+	}
+	if len(attempts) != 1 {
+		t.Fatalf("expected one attempt, got %d", len(attempts))
+	}
+	attempt := attempts[0]
+	if attempt.Outcome != model.StatusErrored || attempt.ErrorClass != model.AttemptErrorConfiguration || attempt.ErrorMessage != ErrSMSDisabled.Error() {
+		t.Fatalf("unexpected attempt %#v", attempt)
+	}
+	if !attempt.AttemptedAt.Equal(now) {
+		t.Fatalf("expected the attempt to be stamped with the worker clock, got %s", attempt.AttemptedAt)
+	}
+}
+
+func TestGetNotificationAttemptsRejectsUnknownNotification(t *testing.T) {
+	t.Helper()
+
+	serviceInstance := newNotificationServiceForDomainTests(openIsolatedDatabase(t))
+
+	if _, err := serviceInstance.GetNotificationAttempts(context.Background(), ""); err == nil {
+		t.Fatalf("expected an error for a missing notification id")
+	}
+	if _, err := serviceInstance.GetNotificationAttempts(context.Background(), "notif-missing"); !errors.Is(err, model.ErrNotificationNotFound) {
+		t.Fatalf("expected ErrNotificationNotFound, got %v", err)
+	}
+}
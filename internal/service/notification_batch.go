@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+)
+
+// MaxNotificationBatchSize caps the number of requests accepted by one SendNotificationBatch call.
+const MaxNotificationBatchSize = 1000
+
+var (
+	ErrInvalidNotificationBatch  = errors.New("invalid notification batch")
+	ErrNotificationBatchNotFound = errors.New("notification batch not found")
+)
+
+// NotificationBatchItem reports the outcome of one request in a batch. Index is the request's
+// position in the submitted list. Notification is set when the request was stored (or replayed
+// through its idempotency key) and Err when it was rejected; a notification stored with the
+// suppressed status carries both.
+type NotificationBatchItem struct {
+	Index        int
+	Notification *model.NotificationResponse
+	Err          error
+}
+
+// NotificationBatchResult is the outcome of SendNotificationBatch, with one item per request.
+type NotificationBatchResult struct {
+	BatchID string
+	Items   []NotificationBatchItem
+}
+
+func (serviceInstance *notificationServiceImpl) SendNotificationBatch(ctx context.Context, requests []model.NotificationRequest) (NotificationBatchResult, error) {
+	if len(requests) == 0 {
+		return NotificationBatchResult{}, fmt.Errorf("%w: at least one request is required", ErrInvalidNotificationBatch)
+	}
+	if len(requests) > MaxNotificationBatchSize {
+		return NotificationBatchResult{}, fmt.Errorf("%w: %d requests exceed the limit of %d", ErrInvalidNotificationBatch, len(requests), MaxNotificationBatchSize)
+	}
+
+	submittedAt := time.Now().UTC()
+	result := NotificationBatchResult{
+		BatchID: fmt.Sprintf("batch-%d", submittedAt.UnixNano()),
+		Items:   make([]NotificationBatchItem, len(requests)),
+	}
+	normalizedRequests := make([]model.NotificationRequest, len(requests))
+	keyIndexes := make(map[string]int)
+	for index, request := range requests {
+		item := &result.Items[index]
+		item.Index = index
+
+		request, requestErr := serviceInstance.normalizeNotificationRequest(request)
+		if requestErr != nil {
+			item.Err = requestErr
+			continue
+		}
+		if request.DeliveryMode == model.DeliveryModeInline {
+			item.Err = fmt.Errorf("%w: batch requests are always enqueued", ErrInvalidDeliveryMode)
+			continue
+		}
+		if request.IdempotencyKey != "" {
+			if firstIndex, repeated := keyIndexes[request.IdempotencyKey]; repeated {
+				item.Err = fmt.Errorf("%w: %s is already used by request %d of this batch", ErrIdempotencyKeyConflict, request.IdempotencyKey, firstIndex)
+				continue
+			}
+			keyIndexes[request.IdempotencyKey] = index
+		}
+		normalizedRequests[index] = request
+	}
+
+	// The keys are held until the batch is stored, like SendNotification holds its key, and are
+	// taken in sorted order so two batches sharing keys cannot wait on each other.
+	batchKeys := make([]string, 0, len(keyIndexes))
+	for key := range keyIndexes {
+		batchKeys = append(batchKeys, key)
+	}
+	sort.Strings(batchKeys)
+	for _, key := range batchKeys {
+		release := serviceInstance.idempotencyGate.acquire(key)
+		defer release()
+	}
+
+	pending := make([]*model.Notification, 0, len(requests))
+	pendingItems := make([]*NotificationBatchItem, 0, len(requests))
+	for index, request := range normalizedRequests {
+		item := &result.Items[index]
+		if item.Err != nil {
+			continue
+		}
+		var fingerprint string
+		if request.IdempotencyKey != "" {
+			fingerprint = requestFingerprint(request)
+			replayResponse, found, replayErr := serviceInstance.findIdempotentReplay(ctx, request.IdempotencyKey, fingerprint)
+			if replayErr != nil {
+				item.Err = replayErr
+				continue
+			}
+			if found {
+				item.Notification = &replayResponse
+				continue
+			}
+		}
+
+		notification, _, buildErr := serviceInstance.buildNotification(ctx, request, fmt.Sprintf("notif-%d-%d", submittedAt.UnixNano(), index))
+		if buildErr != nil {
+			item.Err = buildErr
+			continue
+		}
+		notification.RequestFingerprint = fingerprint
+		notification.BatchID = result.BatchID
+		notification.BatchIndex = index
+		if suppressionErr := serviceInstance.skipSuppressedRecipients(ctx, &notification, submittedAt); suppressionErr != nil {
+			if !errors.Is(suppressionErr, ErrRecipientSuppressed) {
+				item.Err = suppressionErr
+				continue
+			}
+			notification.Status = model.StatusSuppressed
+			notification.RetryCount = serviceInstance.maxRetries
+			item.Err = suppressionErr
+		}
+		pending = append(pending, &notification)
+		pendingItems = append(pendingItems, item)
+	}
+
+	storeErrors, err := model.CreateNotifications(ctx, serviceInstance.database, pending)
+	if err != nil {
+		serviceInstance.logger.Error("Failed to store notification batch", "batch_id", result.BatchID, "error", err)
+		return NotificationBatchResult{}, err
+	}
+	stored := 0
+	for index, notification := range pending {
+		item := pendingItems[index]
+		if storeErr := storeErrors[index]; storeErr != nil {
+			if idempotencyKey := normalizedRequests[item.Index].IdempotencyKey; idempotencyKey != "" {
+				// Another process may have stored the same key between the lookup and this insert.
+				replayResponse, found, replayErr := serviceInstance.findIdempotentReplay(ctx, idempotencyKey, notification.RequestFingerprint)
+				if found {
+					item.Err = replayErr
+					if replayErr == nil {
+						item.Notification = &replayResponse
+					}
+					continue
+				}
+			}
+			serviceInstance.logger.Error("Failed to store batch notification", "batch_id", result.BatchID, "batch_index", item.Index, "error", storeErr)
+			item.Err = storeErr
+			continue
+		}
+		stored++
+		response := model.NewNotificationResponse(*notification)
+		item.Notification = &response
+		serviceInstance.events.PublishTransition(ctx, "", *notification)
+	}
+	if stored > 0 {
+		serviceInstance.wakeDispatcher()
+	}
+
+	rejected := 0
+	for _, item := range result.Items {
+		if item.Err != nil {
+			rejected++
+		}
+	}
+	serviceInstance.logger.Info(
+		"notification_batch_persisted",
+		"batch_id", result.BatchID,
+		"request_count", len(requests),
+		"stored_count", stored,
+		"rejected_count", rejected,
+	)
+	return result, nil
+}
+
+func (serviceInstance *notificationServiceImpl) GetNotificationBatch(ctx context.Context, batchID string) ([]model.NotificationResponse, error) {
+	trimmedID := strings.TrimSpace(batchID)
+	if trimmedID == "" {
+		return nil, fmt.Errorf("missing batch_id")
+	}
+	records, err := model.ListNotificationsByBatchID(ctx, serviceInstance.database, trimmedID)
+	if err != nil {
+		serviceInstance.logger.Error("Failed to load notification batch", "batch_id", trimmedID, "error", err)
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotificationBatchNotFound, trimmedID)
+	}
+	responses := make([]model.NotificationResponse, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, model.NewNotificationResponse(record))
+	}
+	return responses, nil
+}
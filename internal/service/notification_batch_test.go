@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+)
+
+func TestSendNotificationBatchQueuesValidItems(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	if _, err := NewSuppressionService(database, newDiscardLogger()).AddSuppression(context.Background(), model.SuppressionRequest{
+		Channel:   model.NotificationEmail,
+		Recipient: "blocked@example.com",
+		Reason:    model.SuppressionManual,
+	}); err != nil {
+		t.Fatalf("seed suppression: %v", err)
+	}
+	emailSender := &stubEmailSender{}
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           newDiscardLogger(),
+		emailSender:      emailSender,
+		maxRetries:       3,
+		retryIntervalSec: 1,
+	}
+
+	previous, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "replayed@example.com",
+		Subject:          "Receipt",
+		Message:          "Thanks",
+		IdempotencyKey:   "order-1",
+	})
+	if err != nil {
+		t.Fatalf("seed send: %v", err)
+	}
+
+	requests := []model.NotificationRequest{
+		{NotificationType: model.NotificationEmail, Recipient: "first@example.com", Subject: "Hi", Message: "One"},
+		{NotificationType: model.NotificationEmail, Recipient: "", Message: "Missing recipient"},
+		{NotificationType: model.NotificationEmail, Recipient: "blocked@example.com", Subject: "Hi", Message: "Suppressed"},
+		{NotificationType: model.NotificationSMS, Recipient: "+15550001111", Message: "SMS disabled"},
+		{NotificationType: model.NotificationEmail, Recipient: "replayed@example.com", Subject: "Receipt", Message: "Thanks", IdempotencyKey: "order-1"},
+		{NotificationType: model.NotificationEmail, Recipient: "second@example.com", Subject: "Hi", Message: "Two", IdempotencyKey: "order-2"},
+		{NotificationType: model.NotificationEmail, Recipient: "second@example.com", Subject: "Hi", Message: "Two", IdempotencyKey: "order-2"},
+		{NotificationType: model.NotificationEmail, Recipient: "third@example.com", Subject: "Hi", Message: "Three", DeliveryMode: model.DeliveryModeInline},
+	}
+	result, err := serviceInstance.SendNotificationBatch(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("batch error: %v", err)
+	}
+	if emailSender.callCount != 1 {
+		t.Fatalf("expected batch items to be queued rather than sent inline, got %d sends", emailSender.callCount)
+	}
+	if len(result.Items) != len(requests) || result.BatchID == "" {
+		t.Fatalf("unexpected batch result %+v", result)
+	}
+
+	expectations := []struct {
+		stored      bool
+		status      model.NotificationStatus
+		expectedErr error
+	}{
+		{stored: true, status: model.StatusQueued},
+		{expectedErr: ErrInvalidNotificationRequest},
+		{stored: true, status: model.StatusSuppressed, expectedErr: ErrRecipientSuppressed},
+		{expectedErr: ErrSMSDisabled},
+		{stored: true, status: model.StatusSent},
+		{stored: true, status: model.StatusQueued},
+		{expectedErr: ErrIdempotencyKeyConflict},
+		{expectedErr: ErrInvalidDeliveryMode},
+	}
+	for index, expectation := range expectations {
+		item := result.Items[index]
+		if item.Index != index {
+			t.Fatalf("item %d: unexpected index %d", index, item.Index)
+		}
+		if (item.Notification != nil) != expectation.stored {
+			t.Fatalf("item %d: expected stored=%v, got %+v", index, expectation.stored, item)
+		}
+		if expectation.stored && item.Notification.Status != expectation.status {
+			t.Fatalf("item %d: expected status %s, got %s", index, expectation.status, item.Notification.Status)
+		}
+		switch {
+		case expectation.expectedErr != nil && !errors.Is(item.Err, expectation.expectedErr):
+			t.Fatalf("item %d: expected %v, got %v", index, expectation.expectedErr, item.Err)
+		case expectation.expectedErr == nil && expectation.stored && item.Err != nil:
+			t.Fatalf("item %d: unexpected error %v", index, item.Err)
+		case !expectation.stored && item.Err == nil:
+			t.Fatalf("item %d: expected an error", index)
+		}
+	}
+	if result.Items[4].Notification.NotificationID != previous.NotificationID {
+		t.Fatalf("expected idempotent replay of %s, got %s", previous.NotificationID, result.Items[4].Notification.NotificationID)
+	}
+
+	stored, err := serviceInstance.GetNotificationBatch(context.Background(), result.BatchID)
+	if err != nil {
+		t.Fatalf("get batch: %v", err)
+	}
+	if len(stored) != 3 {
+		t.Fatalf("expected three stored notifications, got %d", len(stored))
+	}
+	for position, expectedIndex := range []int{0, 2, 5} {
+		if stored[position].BatchID != result.BatchID || stored[position].BatchIndex != expectedIndex {
+			t.Fatalf("unexpected batch membership %+v", stored[position])
+		}
+	}
+
+	worker := newRetryWorkerForTest(t, serviceInstance, &adjustableClock{now: time.Now().UTC()})
+	worker.RunOnce(context.Background())
+	if emailSender.callCount != 3 {
+		t.Fatalf("expected the worker to send both queued items, got %d sends", emailSender.callCount)
+	}
+	first, err := serviceInstance.GetNotificationStatus(context.Background(), result.Items[0].Notification.NotificationID)
+	if err != nil || first.Status != model.StatusSent {
+		t.Fatalf("expected queued item to be sent, got %+v (%v)", first, err)
+	}
+}
+
+func TestSendNotificationBatchHandlesKeysClaimedByAnotherProcess(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := &notificationServiceImpl{
+		database:    database,
+		logger:      newDiscardLogger(),
+		emailSender: &stubEmailSender{},
+		maxRetries:  3,
+	}
+	requests := []model.NotificationRequest{
+		{NotificationType: model.NotificationEmail, Recipient: "first@example.com", Subject: "Hi", Message: "One", IdempotencyKey: "race-same"},
+		{NotificationType: model.NotificationEmail, Recipient: "second@example.com", Subject: "Hi", Message: "Two", IdempotencyKey: "race-different"},
+		{NotificationType: model.NotificationEmail, Recipient: "third@example.com", Subject: "Hi", Message: "Three", IdempotencyKey: "race-free"},
+	}
+
+	// Another replica stores the "race-" keys right after this process looked them up and found
+	// nothing, so the conflict only surfaces on insert.
+	competitors := map[string]string{
+		"race-same":      requestFingerprint(requests[0]),
+		"race-different": "fingerprint-of-another-payload",
+	}
+	claimRegistration := database.Callback().Query().After("gorm:query").Register("test:claim_key", func(tx *gorm.DB) {
+		if !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return
+		}
+		for _, variable := range tx.Statement.Vars {
+			key, isString := variable.(string)
+			fingerprint, competing := competitors[key]
+			if !isString || !competing {
+				continue
+			}
+			delete(competitors, key)
+			competitor := model.NewNotification("notif-other-"+key, model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				Recipient:        "other@example.com",
+				Message:          "Stored elsewhere",
+				IdempotencyKey:   key,
+			})
+			competitor.RequestFingerprint = fingerprint
+			if err := database.Session(&gorm.Session{NewDB: true}).Create(&competitor).Error; err != nil {
+				t.Errorf("store competing notification: %v", err)
+			}
+		}
+	})
+	if claimRegistration != nil {
+		t.Fatalf("register callback: %v", claimRegistration)
+	}
+
+	result, err := serviceInstance.SendNotificationBatch(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("expected the batch to survive the conflicts, got %v", err)
+	}
+	if len(competitors) != 0 {
+		t.Fatalf("expected both competing notifications to be stored, %v remain", competitors)
+	}
+
+	replayed := result.Items[0]
+	if replayed.Err != nil || replayed.Notification == nil || replayed.Notification.NotificationID != "notif-other-race-same" {
+		t.Fatalf("expected the matching key to replay the competing notification, got %+v", replayed)
+	}
+	conflicted := result.Items[1]
+	if !errors.Is(conflicted.Err, ErrIdempotencyKeyConflict) || conflicted.Notification != nil {
+		t.Fatalf("expected only the differing key to be rejected, got %+v", conflicted)
+	}
+	free := result.Items[2]
+	if free.Err != nil || free.Notification == nil || free.Notification.BatchID != result.BatchID {
+		t.Fatalf("expected the unclaimed key to be stored with the batch, got %+v", free)
+	}
+
+	stored, err := serviceInstance.GetNotificationBatch(context.Background(), result.BatchID)
+	if err != nil {
+		t.Fatalf("get batch: %v", err)
+	}
+	if len(stored) != 1 || stored[0].NotificationID != free.Notification.NotificationID {
+		t.Fatalf("expected only the unclaimed request in the batch, got %+v", stored)
+	}
+}
+
+func TestSendNotificationBatchRejectsInvalidBatches(t *testing.T) {
+	t.Helper()
+
+	serviceInstance := &notificationServiceImpl{
+		database:    openIsolatedDatabase(t),
+		logger:      newDiscardLogger(),
+		emailSender: &stubEmailSender{},
+		maxRetries:  3,
+	}
+	oversized := make([]model.NotificationRequest, MaxNotificationBatchSize+1)
+
+	testCases := []struct {
+		name     string
+		requests []model.NotificationRequest
+	}{
+		{name: "Empty"},
+		{name: "Oversized", requests: oversized},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := serviceInstance.SendNotificationBatch(context.Background(), testCase.requests); !errors.Is(err, ErrInvalidNotificationBatch) {
+				t.Fatalf("expected ErrInvalidNotificationBatch, got %v", err)
+			}
+		})
+	}
+
+	if _, err := serviceInstance.GetNotificationBatch(context.Background(), "batch-missing"); !errors.Is(err, ErrNotificationBatchNotFound) {
+		t.Fatalf("expected ErrNotificationBatchNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/pkg/scheduler"
+)
+
+func TestSendNotificationDeliveryModes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name           string
+		serverMode     model.DeliveryMode
+		requestMode    model.DeliveryMode
+		expectedStatus model.NotificationStatus
+		expectedSends  int
+		expectWake     bool
+		expectedErr    error
+	}{
+		{name: "ServerEnqueue", serverMode: model.DeliveryModeEnqueue, expectedStatus: model.StatusQueued, expectWake: true},
+		{name: "RequestInlineOverridesEnqueue", serverMode: model.DeliveryModeEnqueue, requestMode: "Inline", expectedStatus: model.StatusSent, expectedSends: 1},
+		{name: "ServerInline", serverMode: model.DeliveryModeInline, expectedStatus: model.StatusSent, expectedSends: 1},
+		{name: "RequestEnqueueOverridesInline", serverMode: model.DeliveryModeInline, requestMode: model.DeliveryModeEnqueue, expectedStatus: model.StatusQueued, expectWake: true},
+		{name: "UnsetServerModeSendsInline", expectedStatus: model.StatusSent, expectedSends: 1},
+		{name: "RejectsUnknownMode", serverMode: model.DeliveryModeEnqueue, requestMode: "later", expectedErr: ErrInvalidDeliveryMode},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			emailSender := &stubEmailSender{}
+			serviceInstance := &notificationServiceImpl{
+				database:            openIsolatedDatabase(t),
+				logger:              newDiscardLogger(),
+				emailSender:         emailSender,
+				maxRetries:          3,
+				retryIntervalSec:    1,
+				defaultDeliveryMode: testCase.serverMode,
+				dispatchWake:        make(chan struct{}, 1),
+			}
+
+			response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				Recipient:        "user@example.com",
+				Subject:          "Subject",
+				Message:          "Body",
+				DeliveryMode:     testCase.requestMode,
+			})
+			if testCase.expectedErr != nil {
+				if !errors.Is(err, testCase.expectedErr) {
+					t.Fatalf("expected %v, got %v", testCase.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendNotification error: %v", err)
+			}
+			if response.Status != testCase.expectedStatus || emailSender.callCount != testCase.expectedSends {
+				t.Fatalf("expected status %s after %d sends, got %s after %d", testCase.expectedStatus, testCase.expectedSends, response.Status, emailSender.callCount)
+			}
+			woken := len(serviceInstance.dispatchWake) == 1
+			if woken != testCase.expectWake {
+				t.Fatalf("expected wake=%v, got %v", testCase.expectWake, woken)
+			}
+			if response.Status != model.StatusQueued {
+				return
+			}
+
+			worker := newRetryWorkerForTest(t, serviceInstance, &adjustableClock{now: time.Now().UTC()})
+			worker.RunOnce(context.Background())
+			delivered, err := serviceInstance.GetNotificationStatus(context.Background(), response.NotificationID)
+			if err != nil || delivered.Status != model.StatusSent || emailSender.callCount != 1 {
+				t.Fatalf("expected the dispatch worker to send the queued notification, got %+v (%v)", delivered, err)
+			}
+		})
+	}
+}
+
+func TestStartRetryWorkerDispatchesOnWake(t *testing.T) {
+	t.Helper()
+
+	serviceInstance := &notificationServiceImpl{
+		database:            openIsolatedDatabase(t),
+		logger:              newDiscardLogger(),
+		emailSender:         &stubEmailSender{},
+		maxRetries:          3,
+		retryIntervalSec:    3600,
+		defaultDeliveryMode: model.DeliveryModeEnqueue,
+		dispatchWake:        make(chan struct{}, 1),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go serviceInstance.StartRetryWorker(ctx)
+
+	response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Subject",
+		Message:          "Body",
+	})
+	if err != nil {
+		t.Fatalf("SendNotification error: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		current, statusErr := serviceInstance.GetNotificationStatus(context.Background(), response.NotificationID)
+		if statusErr == nil && current.Status == model.StatusSent {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected the wake-up to dispatch the notification well before the hourly poll")
+}
+
+func TestNotificationRetryStoreClassifiesJobsByChannel(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	for _, notification := range []model.Notification{
+		{NotificationID: "notif-email", NotificationType: model.NotificationEmail, Recipient: "user@example.com", Message: "Body", Status: model.StatusQueued},
+		{NotificationID: "notif-sms", NotificationType: model.NotificationSMS, Recipient: "+15550001111", Message: "Body", Status: model.StatusQueued},
+	} {
+		notification := notification
+		if err := model.CreateNotification(context.Background(), database, &notification); err != nil {
+			t.Fatalf("seed notification: %v", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	jobs, err := newNotificationRetryStore(database, nil).ClaimJobs(context.Background(), scheduler.Claim{WorkerID: "worker-a", MaxRetries: 3, Now: now, LeaseUntil: now.Add(time.Minute), Limit: 10})
+	if err != nil {
+		t.Fatalf("pending jobs: %v", err)
+	}
+	classes := make(map[string]string, len(jobs))
+	for _, job := range jobs {
+		classes[job.ID] = job.Class
+	}
+	if classes["notif-email"] != string(model.NotificationEmail) || classes["notif-sms"] != string(model.NotificationSMS) {
+		t.Fatalf("expected jobs to be classified by notification type, got %v", classes)
+	}
+}
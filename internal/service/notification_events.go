@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/temirov/pinguin/internal/model"
+)
+
+// notificationSubscriptionBuffer bounds the events queued for one watcher. Publishers never wait
+// for watchers; a watcher that falls this far behind is closed with ErrNotificationWatchOverflow.
+const notificationSubscriptionBuffer = 64
+
+var (
+	ErrNotificationWatchOverflow = errors.New("notification watch fell behind")
+	ErrNotificationEventsClosed  = errors.New("notification events closed")
+)
+
+// NotificationWatchFilter selects the notifications a watcher receives events for. Empty fields
+// match every notification.
+type NotificationWatchFilter struct {
+	NotificationID string
+	BatchID        string
+	Statuses       []model.NotificationStatus
+	Types          []model.NotificationType
+}
+
+func (filter NotificationWatchFilter) matches(notification model.NotificationResponse) bool {
+	if filter.NotificationID != "" && notification.NotificationID != filter.NotificationID {
+		return false
+	}
+	if filter.BatchID != "" && notification.BatchID != filter.BatchID {
+		return false
+	}
+	if len(filter.Types) > 0 && !slices.Contains(filter.Types, notification.NotificationType) {
+		return false
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := model.NotificationListFilters{Statuses: filter.Statuses}.NormalizedStatuses()
+		if !slices.Contains(statuses, model.CanonicalStatus(notification.Status)) {
+			return false
+		}
+	}
+	return true
+}
+
+// NotificationObserver is told about every status write published to a NotificationEventBus,
+// including dispatch attempts that leave the status unchanged. It runs on the writer's goroutine, so
+// it must not block for long.
+type NotificationObserver interface {
+	ObserveTransition(ctx context.Context, previousStatus model.NotificationStatus, record model.Notification)
+}
+
+// NotificationEventBus fans notification status transitions out to in-process watchers and
+// observers. It only sees writes made by this process; a nil bus discards everything published to it.
+type NotificationEventBus struct {
+	mutex         sync.Mutex
+	subscriptions map[*NotificationSubscription]struct{}
+	observers     []NotificationObserver
+	closed        bool
+}
+
+// NewNotificationEventBus returns an empty bus.
+func NewNotificationEventBus() *NotificationEventBus {
+	return &NotificationEventBus{subscriptions: make(map[*NotificationSubscription]struct{})}
+}
+
+// Observe registers observer for every later PublishTransition call. Observers keep running after
+// Close so that writes made while the server drains are still seen.
+func (bus *NotificationEventBus) Observe(observer NotificationObserver) {
+	if bus == nil || observer == nil {
+		return
+	}
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.observers = append(bus.observers, observer)
+}
+
+// PublishTransition hands record to the observers and announces it to every matching watcher when
+// its status differs from previousStatus. Pass an empty previousStatus for a newly stored
+// notification. Attachment data is left out of the watch event.
+func (bus *NotificationEventBus) PublishTransition(ctx context.Context, previousStatus model.NotificationStatus, record model.Notification) {
+	if bus == nil {
+		return
+	}
+	bus.mutex.Lock()
+	observers := bus.observers
+	bus.mutex.Unlock()
+	for _, observer := range observers {
+		observer.ObserveTransition(ctx, previousStatus, record)
+	}
+	if model.CanonicalStatus(previousStatus) == model.CanonicalStatus(record.Status) {
+		return
+	}
+	event := notificationEvent(record)
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	for subscription := range bus.subscriptions {
+		if !subscription.filter.matches(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			bus.closeSubscriptionLocked(subscription, ErrNotificationWatchOverflow)
+		}
+	}
+}
+
+// notificationEvent renders record for watchers without its attachment data.
+func notificationEvent(record model.Notification) model.NotificationResponse {
+	event := model.NewNotificationResponse(record)
+	for index := range event.Attachments {
+		event.Attachments[index].Data = nil
+	}
+	return event
+}
+
+// Subscribe registers a watcher for the notifications matching filter.
+func (bus *NotificationEventBus) Subscribe(filter NotificationWatchFilter) (*NotificationSubscription, error) {
+	if bus == nil {
+		return nil, ErrNotificationEventsClosed
+	}
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	if bus.closed {
+		return nil, ErrNotificationEventsClosed
+	}
+	subscription := &NotificationSubscription{
+		bus:    bus,
+		filter: filter,
+		events: make(chan model.NotificationResponse, notificationSubscriptionBuffer),
+	}
+	bus.subscriptions[subscription] = struct{}{}
+	return subscription, nil
+}
+
+// Close ends every subscription with ErrNotificationEventsClosed and rejects new ones. The server
+// calls it on shutdown so that open watch streams finish and graceful stops do not wait on them.
+func (bus *NotificationEventBus) Close() {
+	if bus == nil {
+		return
+	}
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.closed = true
+	for subscription := range bus.subscriptions {
+		bus.closeSubscriptionLocked(subscription, ErrNotificationEventsClosed)
+	}
+}
+
+func (bus *NotificationEventBus) closeSubscriptionLocked(subscription *NotificationSubscription, err error) {
+	if _, open := bus.subscriptions[subscription]; !open {
+		return
+	}
+	delete(bus.subscriptions, subscription)
+	subscription.err = err
+	close(subscription.events)
+}
+
+// NotificationSubscription receives the events of one watcher until it is closed.
+type NotificationSubscription struct {
+	// Current holds the watched notification's state when the subscription was opened by
+	// WatchNotifications for a single notification ID, so that transitions published before the
+	// watch started are not missed.
+	Current *model.NotificationResponse
+
+	bus    *NotificationEventBus
+	filter NotificationWatchFilter
+	events chan model.NotificationResponse
+	err    error
+}
+
+// Events delivers the notification state after each matching transition. The channel is closed
+// when the subscription ends; Err then reports why.
+func (subscription *NotificationSubscription) Events() <-chan model.NotificationResponse {
+	return subscription.events
+}
+
+// Err returns ErrNotificationWatchOverflow or ErrNotificationEventsClosed when the bus ended the
+// subscription, and nil while it is open or after the watcher closed it.
+func (subscription *NotificationSubscription) Err() error {
+	subscription.bus.mutex.Lock()
+	defer subscription.bus.mutex.Unlock()
+	return subscription.err
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (subscription *NotificationSubscription) Close() {
+	subscription.bus.mutex.Lock()
+	defer subscription.bus.mutex.Unlock()
+	subscription.bus.closeSubscriptionLocked(subscription, nil)
+}
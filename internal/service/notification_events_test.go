@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+)
+
+func TestNotificationEventBusFiltersTransitions(t *testing.T) {
+	t.Helper()
+
+	record := model.Notification{
+		NotificationID:   "notif-watch",
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Message:          "Body",
+		Status:           model.StatusSent,
+		BatchID:          "batch-1",
+		Attachments:      []model.NotificationAttachment{{Filename: "a.txt", ContentType: "text/plain", Data: []byte("data"), SizeBytes: 4}},
+	}
+
+	testCases := []struct {
+		name           string
+		filter         NotificationWatchFilter
+		previousStatus model.NotificationStatus
+		expectEvent    bool
+	}{
+		{name: "EmptyFilterMatches", previousStatus: model.StatusQueued, expectEvent: true},
+		{name: "MatchingID", filter: NotificationWatchFilter{NotificationID: "notif-watch"}, previousStatus: model.StatusQueued, expectEvent: true},
+		{name: "OtherID", filter: NotificationWatchFilter{NotificationID: "notif-other"}, previousStatus: model.StatusQueued},
+		{name: "MatchingBatch", filter: NotificationWatchFilter{BatchID: "batch-1"}, previousStatus: model.StatusQueued, expectEvent: true},
+		{name: "OtherBatch", filter: NotificationWatchFilter{BatchID: "batch-2"}, previousStatus: model.StatusQueued},
+		{name: "MatchingStatus", filter: NotificationWatchFilter{Statuses: []model.NotificationStatus{model.StatusSent}}, previousStatus: model.StatusQueued, expectEvent: true},
+		{name: "OtherStatus", filter: NotificationWatchFilter{Statuses: []model.NotificationStatus{model.StatusErrored}}, previousStatus: model.StatusQueued},
+		{name: "MatchingType", filter: NotificationWatchFilter{Types: []model.NotificationType{model.NotificationEmail}}, previousStatus: model.StatusQueued, expectEvent: true},
+		{name: "OtherType", filter: NotificationWatchFilter{Types: []model.NotificationType{model.NotificationSMS}}, previousStatus: model.StatusQueued},
+		{name: "UnchangedStatus", previousStatus: model.StatusSent},
+		{name: "NewNotification", expectEvent: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bus := NewNotificationEventBus()
+			subscription, err := bus.Subscribe(testCase.filter)
+			if err != nil {
+				t.Fatalf("subscribe error: %v", err)
+			}
+			defer subscription.Close()
+
+			bus.PublishTransition(context.Background(), testCase.previousStatus, record)
+
+			select {
+			case event := <-subscription.Events():
+				if !testCase.expectEvent {
+					t.Fatalf("unexpected event %+v", event)
+				}
+				if event.NotificationID != "notif-watch" || event.Status != model.StatusSent {
+					t.Fatalf("unexpected event %+v", event)
+				}
+				if len(event.Attachments) != 1 || event.Attachments[0].Data != nil || event.Attachments[0].SizeBytes != 4 {
+					t.Fatalf("expected attachment metadata without data, got %+v", event.Attachments)
+				}
+			default:
+				if testCase.expectEvent {
+					t.Fatalf("expected an event")
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationEventBusEndsSubscriptions(t *testing.T) {
+	t.Helper()
+
+	record := model.Notification{NotificationID: "notif-busy", NotificationType: model.NotificationSMS, Status: model.StatusSent}
+
+	bus := NewNotificationEventBus()
+	slow, err := bus.Subscribe(NotificationWatchFilter{})
+	if err != nil {
+		t.Fatalf("subscribe error: %v", err)
+	}
+	for index := 0; index <= notificationSubscriptionBuffer; index++ {
+		bus.PublishTransition(context.Background(), model.StatusQueued, record)
+	}
+	drained := 0
+	for range slow.Events() {
+		drained++
+	}
+	if drained != notificationSubscriptionBuffer || !errors.Is(slow.Err(), ErrNotificationWatchOverflow) {
+		t.Fatalf("expected overflow after %d events, got %d (%v)", notificationSubscriptionBuffer, drained, slow.Err())
+	}
+
+	closedByWatcher, err := bus.Subscribe(NotificationWatchFilter{})
+	if err != nil {
+		t.Fatalf("subscribe error: %v", err)
+	}
+	closedByWatcher.Close()
+	closedByWatcher.Close()
+	if _, open := <-closedByWatcher.Events(); open || closedByWatcher.Err() != nil {
+		t.Fatalf("expected a watcher close to end the subscription without an error, got %v", closedByWatcher.Err())
+	}
+
+	open, err := bus.Subscribe(NotificationWatchFilter{})
+	if err != nil {
+		t.Fatalf("subscribe error: %v", err)
+	}
+	bus.Close()
+	if _, stillOpen := <-open.Events(); stillOpen || !errors.Is(open.Err(), ErrNotificationEventsClosed) {
+		t.Fatalf("expected bus close to end the subscription, got %v", open.Err())
+	}
+	if _, err := bus.Subscribe(NotificationWatchFilter{}); !errors.Is(err, ErrNotificationEventsClosed) {
+		t.Fatalf("expected a closed bus to reject subscriptions, got %v", err)
+	}
+
+	var missing *NotificationEventBus
+	missing.PublishTransition(context.Background(), model.StatusQueued, record)
+	if _, err := missing.Subscribe(NotificationWatchFilter{}); !errors.Is(err, ErrNotificationEventsClosed) {
+		t.Fatalf("expected a nil bus to reject subscriptions, got %v", err)
+	}
+}
+
+func TestStatusWritesPublishTransitions(t *testing.T) {
+	t.Helper()
+
+	ctx := context.Background()
+	database := openIsolatedDatabase(t)
+	bus := NewNotificationEventBus()
+	serviceInstance := newNotificationServiceForDomainTests(database)
+	serviceInstance.events = bus
+
+	now := time.Now().UTC()
+	future := now.Add(time.Hour)
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID: "notif-cancel", NotificationType: model.NotificationEmail, Recipient: "user@example.com",
+		Message: "Body", Status: model.StatusQueued, ScheduledFor: &future, CreatedAt: now, UpdatedAt: now,
+	})
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID: "notif-dispatch", NotificationType: model.NotificationEmail, Recipient: "user@example.com",
+		Message: "Body", Status: model.StatusQueued, CreatedAt: now, UpdatedAt: now,
+	})
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID: "notif-delivery", NotificationType: model.NotificationSMS, Recipient: "+15550001111",
+		Message: "Body", Status: model.StatusSent, Provider: "twilio", ProviderMessageID: "SM123", CreatedAt: now, UpdatedAt: now,
+	})
+
+	if _, err := serviceInstance.WatchNotifications(ctx, NotificationWatchFilter{NotificationID: "notif-missing"}); !errors.Is(err, model.ErrNotificationNotFound) {
+		t.Fatalf("expected a missing notification to be reported, got %v", err)
+	}
+
+	testCases := []struct {
+		name           string
+		notificationID string
+		write          func(t *testing.T)
+		currentStatus  model.NotificationStatus
+		expectedStatus model.NotificationStatus
+	}{
+		{
+			name:           "Cancel",
+			notificationID: "notif-cancel",
+			write: func(t *testing.T) {
+				if _, err := serviceInstance.CancelNotification(ctx, "notif-cancel"); err != nil {
+					t.Fatalf("cancel error: %v", err)
+				}
+			},
+			currentStatus:  model.StatusQueued,
+			expectedStatus: model.StatusCancelled,
+		},
+		{
+			name:           "DispatchAttempt",
+			notificationID: "notif-dispatch",
+			write: func(t *testing.T) {
+				newRetryWorkerForTest(t, serviceInstance, &adjustableClock{now: now.Add(time.Minute)}).RunOnce(ctx)
+			},
+			currentStatus:  model.StatusQueued,
+			expectedStatus: model.StatusSent,
+		},
+		{
+			name:           "DeliveryEvent",
+			notificationID: "notif-delivery",
+			write: func(t *testing.T) {
+				deliveryService := NewDeliveryStatusService(database, newDiscardLogger(), bus)
+				event := DeliveryEvent{Provider: "twilio", ProviderMessageID: "SM123", ProviderStatus: "delivered", Status: model.StatusDelivered}
+				if _, err := deliveryService.ApplyDeliveryEvent(ctx, event); err != nil {
+					t.Fatalf("apply delivery event error: %v", err)
+				}
+			},
+			currentStatus:  model.StatusSent,
+			expectedStatus: model.StatusDelivered,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			subscription, err := serviceInstance.WatchNotifications(ctx, NotificationWatchFilter{NotificationID: testCase.notificationID})
+			if err != nil {
+				t.Fatalf("watch error: %v", err)
+			}
+			defer subscription.Close()
+			if subscription.Current == nil || subscription.Current.Status != testCase.currentStatus {
+				t.Fatalf("expected the current %s state, got %+v", testCase.currentStatus, subscription.Current)
+			}
+
+			testCase.write(t)
+
+			select {
+			case event := <-subscription.Events():
+				if event.NotificationID != testCase.notificationID || event.Status != testCase.expectedStatus {
+					t.Fatalf("expected %s to become %s, got %+v", testCase.notificationID, testCase.expectedStatus, event)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("expected a %s event", testCase.expectedStatus)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/temirov/pinguin/internal/config"
 	"github.com/temirov/pinguin/internal/model"
 	"github.com/temirov/pinguin/pkg/scheduler"
 	"gorm.io/gorm"
@@ -13,31 +14,56 @@ import (
 
 type notificationRetryStore struct {
 	database *gorm.DB
+	events   *NotificationEventBus
 }
 
-func newNotificationRetryStore(database *gorm.DB) *notificationRetryStore {
-	return &notificationRetryStore{database: database}
+func newNotificationRetryStore(database *gorm.DB, events *NotificationEventBus) *notificationRetryStore {
+	return &notificationRetryStore{database: database, events: events}
 }
 
-func (store *notificationRetryStore) PendingJobs(ctx context.Context, maxRetries int, now time.Time) ([]scheduler.Job, error) {
-	records, err := model.GetQueuedOrFailedNotifications(ctx, store.database, maxRetries, now)
+func (store *notificationRetryStore) ClaimJobs(ctx context.Context, claim scheduler.Claim) ([]scheduler.Job, error) {
+	excludedTypes := make([]model.NotificationType, 0, len(claim.ExcludedClasses))
+	for _, class := range claim.ExcludedClasses {
+		excludedTypes = append(excludedTypes, model.NotificationType(class))
+	}
+	records, err := model.ClaimNotifications(ctx, store.database, model.NotificationClaim{
+		WorkerID:      claim.WorkerID,
+		MaxRetries:    claim.MaxRetries,
+		Now:           claim.Now,
+		LeaseUntil:    claim.LeaseUntil,
+		Limit:         claim.Limit,
+		ExcludedTypes: excludedTypes,
+	})
 	if err != nil {
 		return nil, err
 	}
 	jobs := make([]scheduler.Job, 0, len(records))
 	for index := range records {
 		record := records[index]
-		jobs = append(jobs, scheduler.Job{
+		job := scheduler.Job{
 			ID:              record.NotificationID,
+			Class:           string(record.NotificationType),
 			ScheduledFor:    record.ScheduledFor,
 			RetryCount:      record.RetryCount,
 			LastAttemptedAt: record.LastAttemptedAt,
+			LockedBy:        record.LockedBy,
 			Payload:         &records[index],
-		})
+		}
+		if record.NextAttemptAt != nil {
+			job.NextAttemptAt = *record.NextAttemptAt
+		}
+		if record.LeaseUntil != nil {
+			job.LeaseUntil = *record.LeaseUntil
+		}
+		jobs = append(jobs, job)
 	}
 	return jobs, nil
 }
 
+func (store *notificationRetryStore) ReleaseJob(ctx context.Context, job scheduler.Job) error {
+	return model.ReleaseNotificationLease(ctx, store.database, job.ID, job.LockedBy)
+}
+
 func (store *notificationRetryStore) ApplyAttemptResult(ctx context.Context, job scheduler.Job, update scheduler.AttemptUpdate) error {
 	record, err := store.notificationFromJob(job)
 	if err != nil {
@@ -47,12 +73,36 @@ func (store *notificationRetryStore) ApplyAttemptResult(ctx context.Context, job
 	if canonicalStatus == "" {
 		canonicalStatus = model.StatusErrored
 	}
+	previousStatus := record.Status
 	record.Status = canonicalStatus
 	record.ProviderMessageID = update.ProviderMessageID
+	record.Provider = update.Provider
 	record.RetryCount = update.RetryCount
 	record.LastAttemptedAt = update.LastAttemptedAt
+	record.LastError = ""
+	if update.Err != nil {
+		record.LastError = update.Err.Error()
+	}
 	record.UpdatedAt = update.LastAttemptedAt
-	return model.SaveNotification(ctx, store.database, record)
+	record.LockedBy = ""
+	record.LeaseUntil = nil
+	record.NextAttemptAt = nil
+	if !update.NextAttemptAt.IsZero() {
+		nextAttemptAt := update.NextAttemptAt
+		record.NextAttemptAt = &nextAttemptAt
+	}
+	if err := model.ApplyNotificationAttemptResult(ctx, store.database, record, job.LockedBy); err != nil {
+		if errors.Is(err, model.ErrNotificationLeaseLost) {
+			// The notification was cancelled, requeued, or claimed by another worker while this
+			// attempt ran; its current state wins and the result is dropped.
+			return nil
+		}
+		return err
+	}
+	attempt := newNotificationAttempt(record.NotificationID, update.LastAttemptedAt, update.Duration, update.Provider, canonicalStatus, update.Err)
+	attemptErr := model.CreateNotificationAttempt(ctx, store.database, &attempt)
+	store.events.PublishTransition(ctx, previousStatus, *record)
+	return attemptErr
 }
 
 func (store *notificationRetryStore) notificationFromJob(job scheduler.Job) (*model.Notification, error) {
@@ -66,6 +116,19 @@ func (store *notificationRetryStore) notificationFromJob(job scheduler.Job) (*mo
 	return notificationRecord, nil
 }
 
+// newRetryPolicy converts a configured channel backoff into the scheduler's policy. A zero interval
+// is left to the worker's default policy.
+func newRetryPolicy(policy config.RetryPolicy) scheduler.RetryPolicy {
+	if policy.IntervalSec <= 0 {
+		return nil
+	}
+	return scheduler.ExponentialBackoff{
+		BaseDelay: time.Duration(policy.IntervalSec) * time.Second,
+		MaxDelay:  time.Duration(policy.MaxDelaySec) * time.Second,
+		Jitter:    scheduler.Jitter(policy.Jitter),
+	}
+}
+
 type notificationDispatcher struct {
 	serviceInstance *notificationServiceImpl
 }
@@ -80,26 +143,49 @@ func (dispatcher *notificationDispatcher) Attempt(ctx context.Context, job sched
 		return scheduler.DispatchResult{}, err
 	}
 
+	if suppressionErr := dispatcher.serviceInstance.skipSuppressedRecipients(ctx, notificationRecord, time.Now().UTC()); suppressionErr != nil {
+		if errors.Is(suppressionErr, ErrRecipientSuppressed) {
+			return scheduler.DispatchResult{Status: string(model.StatusSuppressed)}, scheduler.Permanent(suppressionErr)
+		}
+		return scheduler.DispatchResult{}, suppressionErr
+	}
+
 	switch notificationRecord.NotificationType {
 	case model.NotificationEmail:
+		if skipErr := dispatcher.serviceInstance.skipUndeliverableRecipients(ctx, notificationRecord, time.Now().UTC()); skipErr != nil {
+			if errors.Is(skipErr, ErrRecipientUndeliverable) {
+				return scheduler.DispatchResult{}, scheduler.Permanent(skipErr)
+			}
+			return scheduler.DispatchResult{}, skipErr
+		}
 		emailAttachments := model.ToEmailAttachments(notificationRecord.Attachments)
-		sendErr := dispatcher.serviceInstance.emailSender.SendEmail(ctx, notificationRecord.Recipient, notificationRecord.Subject, notificationRecord.Message, emailAttachments)
+		deliveryResult, sendErr := dispatcher.serviceInstance.emailSender.SendEmail(ctx, dispatcher.serviceInstance.emailMessageFromNotification(*notificationRecord, emailAttachments))
+		applyRecipientResults(notificationRecord, deliveryResult, sendErr, time.Now().UTC())
 		if sendErr != nil {
 			return scheduler.DispatchResult{}, sendErr
 		}
-		return scheduler.DispatchResult{Status: string(model.StatusSent)}, nil
+		return scheduler.DispatchResult{
+			Status:            string(model.StatusSent),
+			ProviderMessageID: deliveryResult.ProviderMessageID,
+			Provider:          deliveryResult.Provider,
+		}, nil
 	case model.NotificationSMS:
 		if dispatcher.serviceInstance.smsSender == nil || !dispatcher.serviceInstance.smsEnabled {
 			dispatcher.serviceInstance.logger.Warn("Skipping SMS retry because delivery is disabled", "notification_id", notificationRecord.NotificationID)
 			return scheduler.DispatchResult{Status: string(model.StatusErrored)}, ErrSMSDisabled
 		}
-		providerMessageID, sendErr := dispatcher.serviceInstance.smsSender.SendSms(ctx, notificationRecord.Recipient, notificationRecord.Message)
+		smsResult, sendErr := dispatcher.serviceInstance.smsSender.SendSms(ctx, notificationRecord.Recipient, notificationRecord.Message)
 		if sendErr != nil {
+			if isPermanentSmsError(sendErr) {
+				return scheduler.DispatchResult{}, scheduler.Permanent(sendErr)
+			}
 			return scheduler.DispatchResult{}, sendErr
 		}
+		applySmsResult(notificationRecord, smsResult)
 		return scheduler.DispatchResult{
 			Status:            string(model.StatusSent),
-			ProviderMessageID: providerMessageID,
+			ProviderMessageID: smsResult.ProviderMessageID,
+			Provider:          smsResult.Provider,
 		}, nil
 	default:
 		dispatcher.serviceInstance.logger.Error("Unsupported notification type during retry", "notification_id", notificationRecord.NotificationID)
@@ -107,6 +193,12 @@ func (dispatcher *notificationDispatcher) Attempt(ctx context.Context, job sched
 	}
 }
 
+// Retryable lets the worker skip the remaining retries of notifications that cannot be delivered,
+// such as an SMTP 550 for every recipient or a provider rejecting the request.
+func (dispatcher *notificationDispatcher) Retryable(_ scheduler.Job, err error) bool {
+	return retryableDispatchError(err)
+}
+
 func (dispatcher *notificationDispatcher) recordFromJob(job scheduler.Job) (*model.Notification, error) {
 	notificationRecord, ok := job.Payload.(*model.Notification)
 	if !ok || notificationRecord == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/temirov/pinguin/internal/model"
 	"github.com/temirov/pinguin/pkg/scheduler"
@@ -14,9 +15,9 @@ type testEmailSender struct {
 	called bool
 }
 
-func (sender *testEmailSender) SendEmail(context.Context, string, string, string, []model.EmailAttachment) error {
+func (sender *testEmailSender) SendEmail(_ context.Context, message EmailMessage) (EmailDeliveryResult, error) {
 	sender.called = true
-	return nil
+	return EmailDeliveryResult{AcceptedRecipients: message.EnvelopeRecipients()}, nil
 }
 
 type testSmsSender struct {
@@ -25,14 +26,15 @@ type testSmsSender struct {
 	called   bool
 }
 
-func (sender *testSmsSender) SendSms(context.Context, string, string) (string, error) {
+func (sender *testSmsSender) SendSms(context.Context, string, string) (SmsDeliveryResult, error) {
 	sender.called = true
-	return sender.response, sender.err
+	return SmsDeliveryResult{ProviderMessageID: sender.response, Provider: "test"}, sender.err
 }
 
 func TestNotificationDispatcherEmail(t *testing.T) {
 	emailSender := &testEmailSender{}
 	serviceInstance := &notificationServiceImpl{
+		database:    openIsolatedDatabase(t),
 		emailSender: emailSender,
 		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
 	}
@@ -59,6 +61,7 @@ func TestNotificationDispatcherEmail(t *testing.T) {
 
 func TestNotificationDispatcherSMSDisabled(t *testing.T) {
 	serviceInstance := &notificationServiceImpl{
+		database:   openIsolatedDatabase(t),
 		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
 		smsEnabled: false,
 	}
@@ -82,6 +85,7 @@ func TestNotificationDispatcherSMSDisabled(t *testing.T) {
 func TestNotificationDispatcherSMSSuccess(t *testing.T) {
 	sender := &testSmsSender{response: "sid-123"}
 	serviceInstance := &notificationServiceImpl{
+		database:   openIsolatedDatabase(t),
 		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
 		smsSender:  sender,
 		smsEnabled: true,
@@ -108,3 +112,151 @@ func TestNotificationDispatcherSMSSuccess(t *testing.T) {
 		t.Fatalf("expected provider message ID %q, got %q", sender.response, result.ProviderMessageID)
 	}
 }
+
+func TestNotificationDispatcherForwardsHTMLBody(t *testing.T) {
+	emailSender := &stubEmailSender{}
+	serviceInstance := &notificationServiceImpl{
+		database:    openIsolatedDatabase(t),
+		emailSender: emailSender,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	dispatcher := newNotificationDispatcher(serviceInstance)
+	job := scheduler.Job{
+		Payload: &model.Notification{
+			NotificationType: model.NotificationEmail,
+			Recipient:        "user@example.com",
+			Subject:          "Hello",
+			Message:          "Body",
+			HTMLMessage:      "<p>Body</p>",
+		},
+	}
+	if _, err := dispatcher.Attempt(context.Background(), job); err != nil {
+		t.Fatalf("Attempt returned error: %v", err)
+	}
+	if len(emailSender.receivedMessages) != 1 {
+		t.Fatalf("expected one dispatched message")
+	}
+	dispatched := emailSender.receivedMessages[0]
+	if dispatched.HTMLBody != "<p>Body</p>" || dispatched.Body != "Body" {
+		t.Fatalf("expected plain and html bodies to be forwarded, got %#v", dispatched)
+	}
+}
+
+func TestNotificationDispatcherMarksRecipientErrorsPermanent(t *testing.T) {
+	t.Helper()
+	testCases := []struct {
+		name            string
+		sendErr         error
+		expectPermanent bool
+	}{
+		{name: "InvalidRecipient", sendErr: &TwilioError{Code: 21211, HTTPError: &ProviderHTTPError{Provider: "twilio", StatusCode: 400}}, expectPermanent: true},
+		{name: "Unsubscribed", sendErr: &TwilioError{Code: 21610, HTTPError: &ProviderHTTPError{Provider: "twilio", StatusCode: 400}}, expectPermanent: true},
+		{name: "ServerError", sendErr: &ProviderHTTPError{Provider: "twilio", StatusCode: 500}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			serviceInstance := &notificationServiceImpl{
+				database:   openIsolatedDatabase(t),
+				logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+				smsSender:  &testSmsSender{err: testCase.sendErr},
+				smsEnabled: true,
+			}
+			dispatcher := newNotificationDispatcher(serviceInstance)
+			job := scheduler.Job{Payload: &model.Notification{NotificationType: model.NotificationSMS, Recipient: "+1333", Message: "Body"}}
+
+			_, err := dispatcher.Attempt(context.Background(), job)
+			if err == nil {
+				t.Fatalf("expected dispatch error")
+			}
+			if scheduler.IsPermanent(err) != testCase.expectPermanent {
+				t.Fatalf("expected permanent=%v, got %v", testCase.expectPermanent, err)
+			}
+		})
+	}
+}
+
+func TestSendNotificationExhaustsRetriesOnPermanentSmsError(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+		smsSender:        &testSmsSender{err: &TwilioError{Code: 21610, HTTPError: &ProviderHTTPError{Provider: "twilio", StatusCode: 400}}},
+		maxRetries:       4,
+		retryIntervalSec: 1,
+		smsEnabled:       true,
+	}
+
+	response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationSMS,
+		Recipient:        "+15550001111",
+		Message:          "Body",
+	})
+	if err != nil {
+		t.Fatalf("SendNotification error: %v", err)
+	}
+	if response.Status != model.StatusDead || response.RetryCount != 4 {
+		t.Fatalf("expected dead notification with exhausted retries, got %s/%d", response.Status, response.RetryCount)
+	}
+	if response.LastError == "" {
+		t.Fatalf("expected the permanent error to be recorded")
+	}
+	if pending := claimDueNotifications(t, database, serviceInstance.maxRetries); len(pending) != 0 {
+		t.Fatalf("expected permanent failure to be excluded from retries, got %d pending", len(pending))
+	}
+}
+
+// cancellingEmailSender cancels the notification it is asked to deliver before reporting success,
+// as an operator would while the attempt is in flight.
+type cancellingEmailSender struct {
+	serviceInstance *notificationServiceImpl
+	notificationID  string
+	cancelErr       error
+}
+
+func (sender *cancellingEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	_, sender.cancelErr = sender.serviceInstance.CancelNotification(ctx, sender.notificationID)
+	return EmailDeliveryResult{AcceptedRecipients: message.EnvelopeRecipients(), Provider: "smtp"}, nil
+}
+
+func TestRetryWorkerDropsResultOfCancelledAttempt(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+		maxRetries:       3,
+		retryIntervalSec: 1,
+	}
+	sender := &cancellingEmailSender{serviceInstance: serviceInstance, notificationID: "notif-cancelled-in-flight"}
+	serviceInstance.emailSender = sender
+	now := time.Now().UTC()
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID:   "notif-cancelled-in-flight",
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Message:          "Body",
+		Status:           model.StatusQueued,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	})
+
+	newRetryWorkerForTest(t, serviceInstance, &adjustableClock{now: now}).RunOnce(context.Background())
+
+	if sender.cancelErr != nil {
+		t.Fatalf("CancelNotification error: %v", sender.cancelErr)
+	}
+	stored, err := model.GetNotificationByID(context.Background(), database, "notif-cancelled-in-flight")
+	if err != nil {
+		t.Fatalf("fetch error: %v", err)
+	}
+	if stored.Status != model.StatusCancelled {
+		t.Fatalf("expected the cancellation to survive the attempt, got %s", stored.Status)
+	}
+	attempts, err := serviceInstance.GetNotificationAttempts(context.Background(), "notif-cancelled-in-flight")
+	if err != nil || len(attempts) != 0 {
+		t.Fatalf("expected the dropped result to record no attempt, got %d (%v)", len(attempts), err)
+	}
+}
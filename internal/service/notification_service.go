@@ -16,24 +16,53 @@ import (
 
 // NotificationService defines the external interface for processing notifications.
 type NotificationService interface {
-	// SendNotification immediately dispatches the notification and stores it.
+	// SendNotification stores the notification. In enqueue mode it returns once the row is queued for
+	// the dispatch worker; in inline mode it contacts the provider before returning.
 	SendNotification(ctx context.Context, request model.NotificationRequest) (model.NotificationResponse, error)
 	// GetNotificationStatus retrieves the stored notification status.
 	GetNotificationStatus(ctx context.Context, notificationID string) (model.NotificationResponse, error)
-	// ListNotifications returns stored notifications honoring the provided filters.
-	ListNotifications(ctx context.Context, filters model.NotificationListFilters) ([]model.NotificationResponse, error)
+	// GetNotificationAttempts returns the delivery attempts recorded for a notification, oldest first.
+	GetNotificationAttempts(ctx context.Context, notificationID string) ([]model.NotificationAttempt, error)
+	// ListNotifications returns one page of stored notifications honoring the provided filters.
+	// Attachments carry their metadata only.
+	ListNotifications(ctx context.Context, filters model.NotificationListFilters) (NotificationPage, error)
 	// RescheduleNotification updates the scheduled send time for a queued notification.
 	RescheduleNotification(ctx context.Context, notificationID string, scheduledFor time.Time) (model.NotificationResponse, error)
 	// CancelNotification transitions a queued notification to cancelled so workers skip it.
 	CancelNotification(ctx context.Context, notificationID string) (model.NotificationResponse, error)
-	// StartRetryWorker begins a background worker that processes retries with exponential backoff.
+	// RequeueNotification moves a dead or errored notification back to queued with a fresh retry
+	// budget.
+	RequeueNotification(ctx context.Context, notificationID string) (model.NotificationResponse, error)
+	// RequeueNotifications requeues every dead or errored notification matching filters and returns
+	// how many were requeued. Filters without statuses select dead notifications.
+	RequeueNotifications(ctx context.Context, filters model.NotificationListFilters) (int, error)
+	// SendNotificationBatch validates each request on its own and stores the accepted ones in a single
+	// transaction, queued for the dispatch worker whatever the configured dispatch mode.
+	SendNotificationBatch(ctx context.Context, requests []model.NotificationRequest) (NotificationBatchResult, error)
+	// GetNotificationBatch returns the notifications stored for a batch in submission order.
+	GetNotificationBatch(ctx context.Context, batchID string) ([]model.NotificationResponse, error)
+	// WatchNotifications subscribes to the status transitions of the notifications matching filter.
+	// When the filter names one notification its current state is loaded into the subscription's
+	// Current after subscribing. Callers must Close the subscription.
+	WatchNotifications(ctx context.Context, filter NotificationWatchFilter) (*NotificationSubscription, error)
+	// StartRetryWorker begins the background worker that delivers queued notifications and processes
+	// retries with exponential backoff.
 	StartRetryWorker(ctx context.Context)
 }
 
+// NotificationPage is one page of a notification listing. NextPageToken is empty on the last page.
+type NotificationPage struct {
+	Notifications []model.NotificationResponse
+	NextPageToken string
+}
+
 var (
-	ErrSMSDisabled             = errors.New("sms delivery disabled: missing Twilio credentials")
+	ErrSMSDisabled             = errors.New("sms delivery disabled: no SMS provider configured")
 	ErrScheduleInPast          = errors.New("notification schedule must be in the future")
 	ErrNotificationNotEditable = errors.New("notification must be queued before editing")
+	ErrInvalidDeliveryMode     = errors.New("invalid delivery mode")
+	// ErrInvalidNotificationRequest wraps the validation failures of a send request.
+	ErrInvalidNotificationRequest = errors.New("invalid notification request")
 )
 
 const (
@@ -41,6 +70,9 @@ const (
 	maxAttachmentSizeBytes       = 5 * 1024 * 1024  // 5 MiB per file
 	maxTotalAttachmentSizeBytes  = 25 * 1024 * 1024 // 25 MiB aggregate cap
 	defaultAttachmentContentType = "application/octet-stream"
+	defaultInlineLeaseDuration   = 5 * time.Minute
+	// inlineResultStoreTimeout bounds storing an inline attempt's outcome after the caller gave up.
+	inlineResultStoreTimeout = 10 * time.Second
 )
 
 type notificationServiceImpl struct {
@@ -51,11 +83,34 @@ type notificationServiceImpl struct {
 	maxRetries       int
 	retryIntervalSec int
 	smsEnabled       bool
+	idempotencyGate  idempotencyGate
+	// unsubscribeSigner issues List-Unsubscribe links for categorized emails; nil disables them.
+	unsubscribeSigner *UnsubscribeSigner
+	// defaultDeliveryMode applies to requests without a delivery mode; the zero value dispatches inline.
+	defaultDeliveryMode model.DeliveryMode
+	// dispatchWake nudges the dispatch worker when a notification is enqueued; dispatchPollInterval
+	// bounds the wait when the nudge is missed, for example for rows written by another process.
+	dispatchWake         chan struct{}
+	dispatchPollInterval time.Duration
+	// dispatchConcurrency limits concurrent worker attempts per notification type; types without an
+	// entry are attempted one at a time. dispatchMaxInFlight caps attempts overall when positive.
+	dispatchConcurrency map[model.NotificationType]int
+	dispatchMaxInFlight int
+	// retryPolicies sets the backoff of each notification type; types without an entry double
+	// retryIntervalSec after every failed attempt.
+	retryPolicies map[model.NotificationType]scheduler.RetryPolicy
+	// dispatchWorkerID and dispatchLeaseDuration configure the worker's leases on queued
+	// notifications; zero values let the scheduler pick its defaults.
+	dispatchWorkerID      string
+	dispatchLeaseDuration time.Duration
+	// events receives every status transition this service writes.
+	events *NotificationEventBus
 }
 
-// NewNotificationService creates a NotificationService backed by SMTP/Twilio senders.
-func NewNotificationService(db *gorm.DB, logger *slog.Logger, cfg config.Config) NotificationService {
-	return NewNotificationServiceWithSenders(db, logger, cfg, nil, nil)
+// NewNotificationService creates a NotificationService backed by the configured email and SMS
+// providers. Status transitions are published to events; a nil bus gives the service its own.
+func NewNotificationService(db *gorm.DB, logger *slog.Logger, cfg config.Config, events *NotificationEventBus) NotificationService {
+	return NewNotificationServiceWithSenders(db, logger, cfg, nil, nil, events)
 }
 
 // NewNotificationServiceWithSenders allows callers (primarily tests) to provide custom senders.
@@ -65,16 +120,10 @@ func NewNotificationServiceWithSenders(
 	cfg config.Config,
 	emailSender EmailSender,
 	smsSender SmsSender,
+	events *NotificationEventBus,
 ) NotificationService {
 	if emailSender == nil {
-		emailSender = NewSMTPEmailSender(SMTPConfig{
-			Host:        cfg.SMTPHost,
-			Port:        fmt.Sprintf("%d", cfg.SMTPPort),
-			Username:    cfg.SMTPUsername,
-			Password:    cfg.SMTPPassword,
-			FromAddress: cfg.FromEmail,
-			Timeouts:    cfg,
-		}, logger)
+		emailSender = NewEmailSender(cfg, logger)
 	}
 
 	var resolvedSmsSender SmsSender
@@ -83,90 +132,123 @@ func NewNotificationServiceWithSenders(
 	case smsSender != nil:
 		resolvedSmsSender = smsSender
 		smsEnabled = true
-	case cfg.TwilioConfigured():
-		resolvedSmsSender = NewTwilioSmsSender(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioFromNumber, logger, cfg)
-		smsEnabled = true
 	default:
-		logger.Warn("SMS notifications disabled: missing Twilio credentials")
+		configuredSender, senderErr := NewSmsSender(cfg, logger)
+		if senderErr != nil {
+			logger.Warn("SMS notifications disabled", "sms_provider", cfg.SMSProvider, "error", senderErr)
+			break
+		}
+		resolvedSmsSender = configuredSender
+		smsEnabled = true
 	}
 
-	return &notificationServiceImpl{
-		database:         db,
-		logger:           logger,
-		emailSender:      emailSender,
-		smsSender:        resolvedSmsSender,
-		maxRetries:       cfg.MaxRetries,
-		retryIntervalSec: cfg.RetryIntervalSec,
-		smsEnabled:       smsEnabled,
+	dispatchConcurrency := make(map[model.NotificationType]int)
+	if cfg.DispatchEmailConcurrency > 0 {
+		dispatchConcurrency[model.NotificationEmail] = cfg.DispatchEmailConcurrency
+	}
+	if cfg.DispatchSMSConcurrency > 0 {
+		dispatchConcurrency[model.NotificationSMS] = cfg.DispatchSMSConcurrency
 	}
-}
 
-func (serviceInstance *notificationServiceImpl) SendNotification(ctx context.Context, request model.NotificationRequest) (model.NotificationResponse, error) {
-	if request.Recipient == "" || request.Message == "" {
-		serviceInstance.logger.Error("Missing required fields", "recipient", request.Recipient, "message", request.Message)
-		return model.NotificationResponse{}, fmt.Errorf("missing required fields: recipient or message")
+	retryPolicies := make(map[model.NotificationType]scheduler.RetryPolicy)
+	if policy := newRetryPolicy(cfg.EmailRetryPolicy); policy != nil {
+		retryPolicies[model.NotificationEmail] = policy
+	}
+	if policy := newRetryPolicy(cfg.SMSRetryPolicy); policy != nil {
+		retryPolicies[model.NotificationSMS] = policy
 	}
 
-	switch request.NotificationType {
-	case model.NotificationEmail, model.NotificationSMS:
-	default:
-		serviceInstance.logger.Error("Unsupported notification type", "type", request.NotificationType)
-		return model.NotificationResponse{}, fmt.Errorf("unsupported notification type: %s", request.NotificationType)
+	defaultDeliveryMode := model.DeliveryModeEnqueue
+	if cfg.DispatchMode == config.DispatchModeInline {
+		defaultDeliveryMode = model.DeliveryModeInline
 	}
 
-	if request.NotificationType == model.NotificationSMS && !serviceInstance.smsEnabled {
-		serviceInstance.logger.Warn("SMS notification rejected because delivery is disabled", "recipient", request.Recipient)
-		return model.NotificationResponse{}, ErrSMSDisabled
+	if events == nil {
+		events = NewNotificationEventBus()
 	}
 
-	normalizedAttachments, attachmentsErr := normalizeAttachments(request.NotificationType, request.Attachments)
-	if attachmentsErr != nil {
-		serviceInstance.logger.Error("Attachment validation failed", "error", attachmentsErr)
-		return model.NotificationResponse{}, attachmentsErr
+	return &notificationServiceImpl{
+		database:             db,
+		logger:               logger,
+		emailSender:          emailSender,
+		smsSender:            resolvedSmsSender,
+		maxRetries:           cfg.MaxRetries,
+		retryIntervalSec:     cfg.RetryIntervalSec,
+		smsEnabled:           smsEnabled,
+		unsubscribeSigner:    NewUnsubscribeSigner(cfg.PublicBaseURL, cfg.UnsubscribeSigningKey),
+		defaultDeliveryMode:  defaultDeliveryMode,
+		dispatchWake:         make(chan struct{}, 1),
+		dispatchPollInterval: time.Duration(cfg.DispatchPollIntervalMs) * time.Millisecond,
+		dispatchConcurrency:  dispatchConcurrency,
+		dispatchMaxInFlight:  cfg.DispatchMaxInFlight,
+		retryPolicies:        retryPolicies,
+
+		dispatchWorkerID:      cfg.DispatchWorkerID,
+		dispatchLeaseDuration: time.Duration(cfg.DispatchLeaseSec) * time.Second,
+		events:                events,
 	}
-	request.Attachments = normalizedAttachments
+}
 
-	notificationID := fmt.Sprintf("notif-%d", time.Now().UnixNano())
-	newNotification := model.NewNotification(notificationID, request)
+func (serviceInstance *notificationServiceImpl) SendNotification(ctx context.Context, request model.NotificationRequest) (model.NotificationResponse, error) {
+	request, requestErr := serviceInstance.normalizeNotificationRequest(request)
+	if requestErr != nil {
+		return model.NotificationResponse{}, requestErr
+	}
+	var fingerprint string
+	if request.IdempotencyKey != "" {
+		fingerprint = requestFingerprint(request)
+		release := serviceInstance.idempotencyGate.acquire(request.IdempotencyKey)
+		defer release()
+		replayResponse, found, replayErr := serviceInstance.findIdempotentReplay(ctx, request.IdempotencyKey, fingerprint)
+		if replayErr != nil || found {
+			return replayResponse, replayErr
+		}
+	}
+
+	newNotification, request, buildErr := serviceInstance.buildNotification(ctx, request, fmt.Sprintf("notif-%d", time.Now().UnixNano()))
+	if buildErr != nil {
+		return model.NotificationResponse{}, buildErr
+	}
+	newNotification.RequestFingerprint = fingerprint
 
 	currentTime := time.Now().UTC()
 
-	shouldAttemptImmediateSend := true
+	shouldAttemptImmediateSend := serviceInstance.resolveDeliveryMode(request.DeliveryMode) == model.DeliveryModeInline
 	if request.ScheduledFor != nil && request.ScheduledFor.After(currentTime) {
 		shouldAttemptImmediateSend = false
 	}
 
-	var dispatchError error
-	if shouldAttemptImmediateSend {
-		switch newNotification.NotificationType {
-		case model.NotificationEmail:
-			dispatchError = serviceInstance.emailSender.SendEmail(ctx, newNotification.Recipient, newNotification.Subject, newNotification.Message, request.Attachments)
-			if dispatchError == nil {
-				newNotification.Status = model.StatusSent
-				newNotification.LastAttemptedAt = currentTime
-				// When using SMTP no provider message ID is returned.
-			}
-		case model.NotificationSMS:
-			if serviceInstance.smsSender == nil {
-				dispatchError = ErrSMSDisabled
-				break
-			}
-			var providerMessageID string
-			providerMessageID, dispatchError = serviceInstance.smsSender.SendSms(ctx, newNotification.Recipient, newNotification.Message)
-			if dispatchError == nil {
-				newNotification.Status = model.StatusSent
-				newNotification.ProviderMessageID = providerMessageID
-				newNotification.LastAttemptedAt = currentTime
-			}
-		}
-		if dispatchError != nil {
-			serviceInstance.logger.Error("Immediate dispatch failed", "error", dispatchError)
-			newNotification.Status = model.StatusErrored
-			newNotification.LastAttemptedAt = currentTime
+	suppressionErr := serviceInstance.skipSuppressedRecipients(ctx, &newNotification, currentTime)
+	if suppressionErr != nil {
+		if !errors.Is(suppressionErr, ErrRecipientSuppressed) {
+			serviceInstance.logger.Error("Suppression lookup failed", "error", suppressionErr)
+			return model.NotificationResponse{}, suppressionErr
 		}
+		// The rejected notification is still stored so the dashboard shows why nothing was sent.
+		newNotification.Status = model.StatusSuppressed
+		newNotification.RetryCount = serviceInstance.maxRetries
+		shouldAttemptImmediateSend = false
+	}
+
+	var inlineLeaseOwner string
+	if shouldAttemptImmediateSend {
+		// The row is stored, and its idempotency key claimed, before any provider is contacted, so
+		// replicas handling the same key cannot both send. The lease keeps the dispatch worker away
+		// while the inline attempt runs and hands the notification to it if this process dies.
+		inlineLeaseOwner = "inline:" + newNotification.NotificationID
+		leaseUntil := currentTime.Add(serviceInstance.inlineLeaseDuration())
+		newNotification.LockedBy = inlineLeaseOwner
+		newNotification.LeaseUntil = &leaseUntil
 	}
 
 	if err := model.CreateNotification(ctx, serviceInstance.database, &newNotification); err != nil {
+		if request.IdempotencyKey != "" {
+			// Another process may have stored the same key between the lookup and this insert.
+			replayResponse, found, replayErr := serviceInstance.findIdempotentReplay(ctx, request.IdempotencyKey, fingerprint)
+			if found {
+				return replayResponse, replayErr
+			}
+		}
 		serviceInstance.logger.Error("Failed to store notification", "error", err)
 		return model.NotificationResponse{}, err
 	}
@@ -176,9 +258,194 @@ func (serviceInstance *notificationServiceImpl) SendNotification(ctx context.Con
 		"notification_type", newNotification.NotificationType,
 		"status", newNotification.Status,
 	)
+	if shouldAttemptImmediateSend {
+		return serviceInstance.dispatchInline(ctx, &newNotification, request.Attachments, inlineLeaseOwner, currentTime)
+	}
+	serviceInstance.events.PublishTransition(ctx, "", newNotification)
+	if newNotification.Status == model.StatusQueued {
+		serviceInstance.wakeDispatcher()
+	}
+	if suppressionErr != nil {
+		return model.NewNotificationResponse(newNotification), suppressionErr
+	}
 	return model.NewNotificationResponse(newNotification), nil
 }
 
+// inlineLeaseDuration is how long an inline attempt holds its notification, matching the dispatch
+// worker's lease.
+func (serviceInstance *notificationServiceImpl) inlineLeaseDuration() time.Duration {
+	if serviceInstance.dispatchLeaseDuration > 0 {
+		return serviceInstance.dispatchLeaseDuration
+	}
+	return defaultInlineLeaseDuration
+}
+
+// dispatchInline attempts a notification stored under leaseOwner's lease and records the outcome.
+// When the notification was cancelled during the attempt the outcome is dropped and the stored
+// notification is returned instead.
+func (serviceInstance *notificationServiceImpl) dispatchInline(ctx context.Context, notification *model.Notification, attachments []model.EmailAttachment, leaseOwner string, attemptedAt time.Time) (model.NotificationResponse, error) {
+	var dispatchError error
+	attemptStartedAt := time.Now()
+	switch notification.NotificationType {
+	case model.NotificationEmail:
+		if dispatchError = serviceInstance.skipUndeliverableRecipients(ctx, notification, attemptedAt); dispatchError != nil {
+			break
+		}
+		var deliveryResult EmailDeliveryResult
+		deliveryResult, dispatchError = serviceInstance.emailSender.SendEmail(ctx, serviceInstance.emailMessageFromNotification(*notification, attachments))
+		applyRecipientResults(notification, deliveryResult, dispatchError, attemptedAt)
+		if dispatchError == nil {
+			notification.Status = model.StatusSent
+			notification.ProviderMessageID = deliveryResult.ProviderMessageID
+			notification.Provider = deliveryResult.Provider
+		}
+	case model.NotificationSMS:
+		if serviceInstance.smsSender == nil {
+			dispatchError = ErrSMSDisabled
+			break
+		}
+		var smsResult SmsDeliveryResult
+		smsResult, dispatchError = serviceInstance.smsSender.SendSms(ctx, notification.Recipient, notification.Message)
+		if dispatchError == nil {
+			notification.Status = model.StatusSent
+			applySmsResult(notification, smsResult)
+		}
+	}
+	attemptDuration := time.Since(attemptStartedAt)
+	notification.LastAttemptedAt = attemptedAt
+	notification.UpdatedAt = time.Now().UTC()
+	if dispatchError != nil {
+		serviceInstance.logger.Error("Immediate dispatch failed", "error", dispatchError)
+		notification.Status = model.StatusErrored
+		notification.LastError = dispatchError.Error()
+		if !retryableDispatchError(dispatchError) {
+			// Retrying cannot fix the failure, so the retry budget is spent at once.
+			notification.Status = model.StatusDead
+			notification.RetryCount = serviceInstance.maxRetries
+		}
+	}
+
+	// The outcome is stored even when the caller gives up once the provider has answered: a message
+	// the provider accepted but left queued under the inline lease would be sent again by the
+	// dispatch worker once the lease expires.
+	storeCtx, cancelStore := context.WithTimeout(context.WithoutCancel(ctx), inlineResultStoreTimeout)
+	defer cancelStore()
+	if applyErr := model.ApplyNotificationAttemptResult(storeCtx, serviceInstance.database, notification, leaseOwner); applyErr != nil {
+		if !errors.Is(applyErr, model.ErrNotificationLeaseLost) {
+			serviceInstance.logger.Error("Failed to store inline attempt", "notification_id", notification.NotificationID, "error", applyErr)
+			return model.NotificationResponse{}, applyErr
+		}
+		stored, fetchErr := model.MustGetNotificationByID(storeCtx, serviceInstance.database, notification.NotificationID)
+		if fetchErr != nil {
+			return model.NotificationResponse{}, fetchErr
+		}
+		return model.NewNotificationResponse(*stored), nil
+	}
+	notification.LockedBy = ""
+	notification.LeaseUntil = nil
+	serviceInstance.recordNotificationAttempt(storeCtx, newNotificationAttempt(notification.NotificationID, attemptedAt, attemptDuration, notification.Provider, notification.Status, dispatchError))
+	serviceInstance.events.PublishTransition(storeCtx, "", *notification)
+	return model.NewNotificationResponse(*notification), nil
+}
+
+// normalizeNotificationRequest checks the notification type and normalizes the fields that the
+// idempotency fingerprint is computed from.
+func (serviceInstance *notificationServiceImpl) normalizeNotificationRequest(request model.NotificationRequest) (model.NotificationRequest, error) {
+	switch request.NotificationType {
+	case model.NotificationEmail, model.NotificationSMS:
+	default:
+		serviceInstance.logger.Error("Unsupported notification type", "type", request.NotificationType)
+		return request, fmt.Errorf("%w: unsupported notification type: %s", ErrInvalidNotificationRequest, request.NotificationType)
+	}
+
+	request.IdempotencyKey = strings.TrimSpace(request.IdempotencyKey)
+	request.Category = model.NormalizeCategory(request.Category)
+	request.DeliveryMode = model.DeliveryMode(strings.ToLower(strings.TrimSpace(string(request.DeliveryMode))))
+	switch request.DeliveryMode {
+	case model.DeliveryModeDefault, model.DeliveryModeEnqueue, model.DeliveryModeInline:
+	default:
+		return request, fmt.Errorf("%w: %s", ErrInvalidDeliveryMode, request.DeliveryMode)
+	}
+	if len(request.IdempotencyKey) > maxIdempotencyKeyLength {
+		return request, fmt.Errorf("%w: idempotency_key exceeds %d characters", ErrInvalidNotificationRequest, maxIdempotencyKeyLength)
+	}
+	return request, nil
+}
+
+// resolveDeliveryMode returns the mode a request is dispatched with.
+func (serviceInstance *notificationServiceImpl) resolveDeliveryMode(requested model.DeliveryMode) model.DeliveryMode {
+	if requested != model.DeliveryModeDefault {
+		return requested
+	}
+	if serviceInstance.defaultDeliveryMode != model.DeliveryModeDefault {
+		return serviceInstance.defaultDeliveryMode
+	}
+	return model.DeliveryModeInline
+}
+
+// wakeDispatcher starts a dispatch worker cycle without waiting for the next poll. It never blocks:
+// a pending wake-up already covers the notification just stored.
+func (serviceInstance *notificationServiceImpl) wakeDispatcher() {
+	select {
+	case serviceInstance.dispatchWake <- struct{}{}:
+	default:
+	}
+}
+
+// buildNotification validates a normalized request, expands its template, and returns the queued
+// notification to store together with the request it was built from.
+func (serviceInstance *notificationServiceImpl) buildNotification(ctx context.Context, request model.NotificationRequest, notificationID string) (model.Notification, model.NotificationRequest, error) {
+	if request.NotificationType == model.NotificationEmail {
+		request = normalizeEmailRecipients(request)
+	}
+	if strings.TrimSpace(request.TemplateID) != "" {
+		expandedRequest, templateErr := serviceInstance.expandTemplate(ctx, request)
+		if templateErr != nil {
+			serviceInstance.logger.Error("Template expansion failed", "template_id", request.TemplateID, "error", templateErr)
+			return model.Notification{}, request, templateErr
+		}
+		request = expandedRequest
+	}
+	if request.Recipient == "" || request.Message == "" {
+		serviceInstance.logger.Error("Missing required fields", "recipient", request.Recipient, "message", request.Message)
+		return model.Notification{}, request, fmt.Errorf("%w: missing required fields: recipient or message", ErrInvalidNotificationRequest)
+	}
+
+	if request.NotificationType == model.NotificationSMS && len(request.To)+len(request.Cc)+len(request.Bcc) > 0 {
+		return model.Notification{}, request, fmt.Errorf("%w: to, cc and bcc recipients supported only for email notifications", ErrInvalidNotificationRequest)
+	}
+
+	if request.NotificationType == model.NotificationSMS && strings.TrimSpace(request.HTMLMessage) != "" {
+		return model.Notification{}, request, fmt.Errorf("%w: html_message supported only for email notifications", ErrInvalidNotificationRequest)
+	}
+
+	if request.NotificationType == model.NotificationSMS && !serviceInstance.smsEnabled {
+		serviceInstance.logger.Warn("SMS notification rejected because delivery is disabled", "recipient", request.Recipient)
+		return model.Notification{}, request, ErrSMSDisabled
+	}
+
+	normalizedAttachments, attachmentsErr := normalizeAttachments(request.NotificationType, request.Attachments)
+	if attachmentsErr != nil {
+		serviceInstance.logger.Error("Attachment validation failed", "error", attachmentsErr)
+		return model.Notification{}, request, attachmentsErr
+	}
+	request.Attachments = normalizedAttachments
+
+	return model.NewNotification(notificationID, request), request, nil
+}
+
+// ProviderHealth reports circuit breaker state for senders that route across several providers.
+func (serviceInstance *notificationServiceImpl) ProviderHealth() []ProviderHealth {
+	var health []ProviderHealth
+	if reporter, ok := serviceInstance.emailSender.(HealthReporter); ok {
+		health = append(health, reporter.ProviderHealth()...)
+	}
+	if reporter, ok := serviceInstance.smsSender.(HealthReporter); ok {
+		health = append(health, reporter.ProviderHealth()...)
+	}
+	return health
+}
+
 func (serviceInstance *notificationServiceImpl) GetNotificationStatus(ctx context.Context, notificationID string) (model.NotificationResponse, error) {
 	if notificationID == "" {
 		serviceInstance.logger.Error("Missing notification_id")
@@ -192,17 +459,59 @@ func (serviceInstance *notificationServiceImpl) GetNotificationStatus(ctx contex
 	return model.NewNotificationResponse(*notificationRecord), nil
 }
 
-func (serviceInstance *notificationServiceImpl) ListNotifications(ctx context.Context, filters model.NotificationListFilters) ([]model.NotificationResponse, error) {
-	records, err := model.ListNotifications(ctx, serviceInstance.database, filters)
+func (serviceInstance *notificationServiceImpl) GetNotificationAttempts(ctx context.Context, notificationID string) ([]model.NotificationAttempt, error) {
+	trimmedID := strings.TrimSpace(notificationID)
+	if trimmedID == "" {
+		return nil, fmt.Errorf("missing notification_id")
+	}
+	if _, err := model.MustGetNotificationByID(ctx, serviceInstance.database, trimmedID); err != nil {
+		return nil, err
+	}
+	attempts, err := model.ListNotificationAttempts(ctx, serviceInstance.database, trimmedID)
 	if err != nil {
-		serviceInstance.logger.Error("Failed to list notifications", "error", err)
+		serviceInstance.logger.Error("Failed to list notification attempts", "notification_id", trimmedID, "error", err)
 		return nil, err
 	}
+	return attempts, nil
+}
+
+func (serviceInstance *notificationServiceImpl) ListNotifications(ctx context.Context, filters model.NotificationListFilters) (NotificationPage, error) {
+	records, nextPageToken, err := model.ListNotifications(ctx, serviceInstance.database, filters)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidNotificationListFilter) {
+			serviceInstance.logger.Warn("Rejecting notification list filters", "error", err)
+		} else {
+			serviceInstance.logger.Error("Failed to list notifications", "error", err)
+		}
+		return NotificationPage{}, err
+	}
 	responses := make([]model.NotificationResponse, 0, len(records))
 	for _, record := range records {
 		responses = append(responses, model.NewNotificationResponse(record))
 	}
-	return responses, nil
+	return NotificationPage{Notifications: responses, NextPageToken: nextPageToken}, nil
+}
+
+func (serviceInstance *notificationServiceImpl) WatchNotifications(ctx context.Context, filter NotificationWatchFilter) (*NotificationSubscription, error) {
+	filter.NotificationID = strings.TrimSpace(filter.NotificationID)
+	filter.BatchID = strings.TrimSpace(filter.BatchID)
+	subscription, err := serviceInstance.events.Subscribe(filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.NotificationID == "" {
+		return subscription, nil
+	}
+	// Loading the row after subscribing means a transition is either reflected here or delivered
+	// as an event, possibly both.
+	record, err := model.MustGetNotificationByID(ctx, serviceInstance.database, filter.NotificationID)
+	if err != nil {
+		subscription.Close()
+		return nil, err
+	}
+	current := notificationEvent(*record)
+	subscription.Current = &current
+	return subscription, nil
 }
 
 func (serviceInstance *notificationServiceImpl) RescheduleNotification(ctx context.Context, notificationID string, scheduledFor time.Time) (model.NotificationResponse, error) {
@@ -250,23 +559,91 @@ func (serviceInstance *notificationServiceImpl) CancelNotification(ctx context.C
 	}
 	existingNotification.Status = model.StatusCancelled
 	existingNotification.ScheduledFor = nil
+	// Dropping the lease makes a worker attempting the notification discard its result.
+	existingNotification.LockedBy = ""
+	existingNotification.LeaseUntil = nil
 	existingNotification.UpdatedAt = time.Now().UTC()
 	if saveErr := model.SaveNotification(ctx, serviceInstance.database, existingNotification); saveErr != nil {
 		serviceInstance.logger.Error("Failed to cancel notification", "notification_id", trimmedID, "error", saveErr)
 		return model.NotificationResponse{}, saveErr
 	}
+	serviceInstance.events.PublishTransition(ctx, model.StatusQueued, *existingNotification)
 	return model.NewNotificationResponse(*existingNotification), nil
 }
 
+func (serviceInstance *notificationServiceImpl) RequeueNotification(ctx context.Context, notificationID string) (model.NotificationResponse, error) {
+	trimmedID := strings.TrimSpace(notificationID)
+	if trimmedID == "" {
+		return model.NotificationResponse{}, fmt.Errorf("missing notification_id")
+	}
+	requeued, requeueErr := model.RequeueNotification(ctx, serviceInstance.database, trimmedID, time.Now().UTC())
+	if requeueErr != nil {
+		serviceInstance.logger.Warn("Failed to requeue notification", "notification_id", trimmedID, "error", requeueErr)
+		return model.NotificationResponse{}, requeueErr
+	}
+	serviceInstance.logger.Info("notification_requeued", "notification_id", trimmedID, "previous_status", requeued.PreviousStatus)
+	serviceInstance.events.PublishTransition(ctx, requeued.PreviousStatus, requeued.Notification)
+	serviceInstance.wakeDispatcher()
+	return model.NewNotificationResponse(requeued.Notification), nil
+}
+
+func (serviceInstance *notificationServiceImpl) RequeueNotifications(ctx context.Context, filters model.NotificationListFilters) (int, error) {
+	requeued, requeueErr := model.RequeueNotifications(ctx, serviceInstance.database, filters, time.Now().UTC())
+	if requeueErr != nil {
+		serviceInstance.logger.Error("Failed to requeue notifications", "error", requeueErr)
+		return 0, requeueErr
+	}
+	for _, notification := range requeued {
+		serviceInstance.events.PublishTransition(ctx, notification.PreviousStatus, notification.Notification)
+	}
+	serviceInstance.logger.Info("notifications_requeued", "count", len(requeued))
+	if len(requeued) > 0 {
+		serviceInstance.wakeDispatcher()
+	}
+	return len(requeued), nil
+}
+
+// markExhaustedNotificationsDead moves errored notifications without attempts left to dead.
+func (serviceInstance *notificationServiceImpl) markExhaustedNotificationsDead(ctx context.Context) {
+	marked, err := model.MarkExhaustedNotificationsDead(ctx, serviceInstance.database, serviceInstance.maxRetries, time.Now().UTC())
+	if err != nil {
+		serviceInstance.logger.Error("Failed to mark exhausted notifications dead", "error", err)
+		return
+	}
+	for _, notification := range marked {
+		serviceInstance.events.PublishTransition(ctx, model.StatusErrored, notification)
+	}
+	if len(marked) > 0 {
+		serviceInstance.logger.Info("notifications_marked_dead", "count", len(marked))
+	}
+}
+
 func (serviceInstance *notificationServiceImpl) StartRetryWorker(ctx context.Context) {
+	serviceInstance.markExhaustedNotificationsDead(ctx)
+	classConcurrency := make(map[string]int, len(serviceInstance.dispatchConcurrency))
+	for notificationType, limit := range serviceInstance.dispatchConcurrency {
+		classConcurrency[string(notificationType)] = limit
+	}
+	classRetryPolicies := make(map[string]scheduler.RetryPolicy, len(serviceInstance.retryPolicies))
+	for notificationType, policy := range serviceInstance.retryPolicies {
+		classRetryPolicies[string(notificationType)] = policy
+	}
 	worker, workerErr := scheduler.NewWorker(scheduler.Config{
-		Repository:    newNotificationRetryStore(serviceInstance.database),
-		Dispatcher:    newNotificationDispatcher(serviceInstance),
-		Logger:        serviceInstance.logger,
-		Interval:      time.Duration(serviceInstance.retryIntervalSec) * time.Second,
-		MaxRetries:    serviceInstance.maxRetries,
-		SuccessStatus: string(model.StatusSent),
-		FailureStatus: string(model.StatusErrored),
+		Repository:         newNotificationRetryStore(serviceInstance.database, serviceInstance.events),
+		Dispatcher:         newNotificationDispatcher(serviceInstance),
+		Logger:             serviceInstance.logger,
+		Interval:           time.Duration(serviceInstance.retryIntervalSec) * time.Second,
+		PollInterval:       serviceInstance.dispatchPollInterval,
+		Wake:               serviceInstance.dispatchWake,
+		MaxRetries:         serviceInstance.maxRetries,
+		SuccessStatus:      string(model.StatusSent),
+		FailureStatus:      string(model.StatusErrored),
+		ExhaustedStatus:    string(model.StatusDead),
+		ClassRetryPolicies: classRetryPolicies,
+		ClassConcurrency:   classConcurrency,
+		MaxInFlight:        serviceInstance.dispatchMaxInFlight,
+		WorkerID:           serviceInstance.dispatchWorkerID,
+		LeaseDuration:      serviceInstance.dispatchLeaseDuration,
 	})
 	if workerErr != nil {
 		serviceInstance.logger.Error("Failed to initialize retry worker", "error", workerErr)
@@ -275,15 +652,273 @@ func (serviceInstance *notificationServiceImpl) StartRetryWorker(ctx context.Con
 	worker.Run(ctx)
 }
 
+// expandTemplate renders the referenced template into the request's subject and bodies. Template
+// requests must not also carry inline content so the stored notification has a single source.
+func (serviceInstance *notificationServiceImpl) expandTemplate(ctx context.Context, request model.NotificationRequest) (model.NotificationRequest, error) {
+	if request.Subject != "" || request.Message != "" || request.HTMLMessage != "" {
+		return request, fmt.Errorf("%w: template_id cannot be combined with subject, message or html_message", ErrInvalidTemplate)
+	}
+	if request.TemplateVersion < 0 {
+		return request, fmt.Errorf("%w: template_version must not be negative", ErrInvalidTemplate)
+	}
+	template, lookupErr := model.GetTemplate(ctx, serviceInstance.database, strings.TrimSpace(request.TemplateID), request.TemplateVersion)
+	if lookupErr != nil {
+		return request, lookupErr
+	}
+	rendered, renderErr := renderTemplate(*template, request.NotificationType, serviceInstance.templateData(request))
+	if renderErr != nil {
+		return request, renderErr
+	}
+	request.TemplateID = rendered.TemplateID
+	request.TemplateVersion = rendered.Version
+	switch request.NotificationType {
+	case model.NotificationEmail:
+		request.Subject = rendered.Subject
+		request.Message = rendered.PlainBody
+		request.HTMLMessage = rendered.HTMLBody
+	case model.NotificationSMS:
+		request.Message = rendered.SMSBody
+	}
+	return request, nil
+}
+
+// templateData returns the caller's template variables, adding unsubscribe_url for categorized
+// emails unless the caller supplied one. The link identifies a single recipient, so emails with
+// several recipients get an empty unsubscribe_url: a shared link would unsubscribe whoever it was
+// signed for rather than whoever followed it.
+func (serviceInstance *notificationServiceImpl) templateData(request model.NotificationRequest) map[string]string {
+	if request.NotificationType != model.NotificationEmail || request.Category == "" {
+		return request.TemplateData
+	}
+	if _, provided := request.TemplateData[UnsubscribeURLTemplateKey]; provided {
+		return request.TemplateData
+	}
+	unsubscribeURL := serviceInstance.unsubscribeSigner.URL(request.Recipient, request.Category)
+	if unsubscribeURL == "" {
+		return request.TemplateData
+	}
+	if len(request.To)+len(request.Cc)+len(request.Bcc) > 1 {
+		unsubscribeURL = ""
+	}
+	data := make(map[string]string, len(request.TemplateData)+1)
+	for key, value := range request.TemplateData {
+		data[key] = value
+	}
+	data[UnsubscribeURLTemplateKey] = unsubscribeURL
+	return data
+}
+
+// normalizeEmailRecipients trims and de-duplicates the To/Cc/Bcc lists and keeps Recipient in sync
+// with the primary To address so single-recipient callers keep working unchanged.
+func normalizeEmailRecipients(request model.NotificationRequest) model.NotificationRequest {
+	seen := make(map[string]struct{})
+	normalize := func(addresses []string) []string {
+		var normalized []string
+		for _, address := range addresses {
+			trimmed := strings.TrimSpace(address)
+			if trimmed == "" {
+				continue
+			}
+			key := strings.ToLower(trimmed)
+			if _, exists := seen[key]; exists {
+				continue
+			}
+			seen[key] = struct{}{}
+			normalized = append(normalized, trimmed)
+		}
+		return normalized
+	}
+	primaryRecipient := strings.TrimSpace(request.Recipient)
+	toAddresses := request.To
+	if primaryRecipient != "" {
+		toAddresses = append([]string{primaryRecipient}, request.To...)
+	}
+	request.To = normalize(toAddresses)
+	request.Cc = normalize(request.Cc)
+	request.Bcc = normalize(request.Bcc)
+	if len(request.To) > 0 {
+		request.Recipient = request.To[0]
+	}
+	return request
+}
+
+// emailMessageFromNotification assembles the outbound message for a stored notification. Rows
+// persisted before recipient lists existed fall back to the single Recipient column.
+// Recipients skipped as undeliverable are left out of the envelope. Categorized emails with a
+// single envelope recipient carry an unsubscribe link for that recipient; every copy of a message
+// carries the same headers, so messages to several recipients carry none.
+func (serviceInstance *notificationServiceImpl) emailMessageFromNotification(notification model.Notification, attachments []model.EmailAttachment) EmailMessage {
+	toAddresses := deliverableAddresses(notification, model.RecipientTo)
+	if len(notification.Recipients) == 0 && notification.Recipient != "" {
+		toAddresses = []string{notification.Recipient}
+	}
+	message := EmailMessage{
+		To:          toAddresses,
+		Cc:          deliverableAddresses(notification, model.RecipientCc),
+		Bcc:         deliverableAddresses(notification, model.RecipientBcc),
+		Subject:     notification.Subject,
+		Body:        notification.Message,
+		HTMLBody:    notification.HTMLMessage,
+		Attachments: attachments,
+	}
+	if envelopeRecipients := message.EnvelopeRecipients(); notification.Category != "" && len(envelopeRecipients) == 1 {
+		message.ListUnsubscribeURL = serviceInstance.unsubscribeSigner.URL(envelopeRecipients[0], notification.Category)
+	}
+	return message
+}
+
+// deliverableAddresses returns the addresses of the given kind that were not skipped as undeliverable.
+func deliverableAddresses(notification model.Notification, kind model.RecipientKind) []string {
+	var addresses []string
+	for _, recipient := range notification.Recipients {
+		if recipient.Kind == kind && recipient.Status != model.RecipientSkipped {
+			addresses = append(addresses, recipient.Address)
+		}
+	}
+	return addresses
+}
+
+// skipUndeliverableRecipients consults the deliverability records before an email is sent. Addresses
+// marked undeliverable by earlier bounces or complaints are flagged as skipped so the sender leaves
+// them out; ErrRecipientUndeliverable is returned when no recipient remains.
+func (serviceInstance *notificationServiceImpl) skipUndeliverableRecipients(ctx context.Context, notification *model.Notification, attemptedAt time.Time) error {
+	addresses := []string{notification.Recipient}
+	for _, recipient := range notification.Recipients {
+		addresses = append(addresses, recipient.Address)
+	}
+	undeliverable, err := model.FindUndeliverableAddresses(ctx, serviceInstance.database, addresses)
+	if err != nil {
+		return fmt.Errorf("load recipient deliverability: %w", err)
+	}
+	if len(undeliverable) == 0 {
+		return nil
+	}
+
+	if len(notification.Recipients) == 0 {
+		if record, found := undeliverable[model.NormalizeEmailAddress(notification.Recipient)]; found {
+			return fmt.Errorf("%w: %s (%s)", ErrRecipientUndeliverable, notification.Recipient, record.LastFeedbackType)
+		}
+		return nil
+	}
+	remaining := 0
+	for index := range notification.Recipients {
+		recipient := &notification.Recipients[index]
+		record, found := undeliverable[model.NormalizeEmailAddress(recipient.Address)]
+		if !found {
+			if recipient.Status != model.RecipientSkipped {
+				remaining++
+			}
+			continue
+		}
+		recipient.Status = model.RecipientSkipped
+		recipient.Error = fmt.Sprintf("address undeliverable after %s", record.LastFeedbackType)
+		recipient.UpdatedAt = attemptedAt
+		serviceInstance.logger.Warn(
+			"email_recipient_skipped",
+			"notification_id", notification.NotificationID,
+			"recipient", recipient.Address,
+			"last_feedback_type", record.LastFeedbackType,
+		)
+	}
+	if remaining == 0 {
+		return fmt.Errorf("%w: notification %s", ErrRecipientUndeliverable, notification.NotificationID)
+	}
+	return nil
+}
+
+// skipSuppressedRecipients consults the suppression list before a notification is sent. Suppressed
+// email addresses are flagged as skipped; a *SuppressionError is returned when no email recipient
+// remains or the SMS recipient is suppressed.
+func (serviceInstance *notificationServiceImpl) skipSuppressedRecipients(ctx context.Context, notification *model.Notification, now time.Time) error {
+	addresses := []string{notification.Recipient}
+	for _, recipient := range notification.Recipients {
+		addresses = append(addresses, recipient.Address)
+	}
+	suppressions, err := model.FindActiveSuppressions(ctx, serviceInstance.database, notification.NotificationType, notification.Category, addresses, now)
+	if err != nil {
+		return fmt.Errorf("load suppressions: %w", err)
+	}
+	if len(suppressions) == 0 {
+		return nil
+	}
+
+	if len(notification.Recipients) == 0 {
+		suppression, found := suppressions[model.NormalizeSuppressionRecipient(notification.NotificationType, notification.Recipient)]
+		if !found {
+			return nil
+		}
+		return &SuppressionError{Channel: notification.NotificationType, Recipients: []string{notification.Recipient}, Reason: suppression.Reason}
+	}
+	suppressionErr := &SuppressionError{Channel: notification.NotificationType}
+	remaining := 0
+	for index := range notification.Recipients {
+		recipient := &notification.Recipients[index]
+		suppression, found := suppressions[model.NormalizeSuppressionRecipient(notification.NotificationType, recipient.Address)]
+		if !found {
+			if recipient.Status != model.RecipientSkipped {
+				remaining++
+			}
+			continue
+		}
+		recipient.Status = model.RecipientSkipped
+		recipient.Error = fmt.Sprintf("suppressed: %s", suppression.Reason)
+		recipient.UpdatedAt = now
+		suppressionErr.Recipients = append(suppressionErr.Recipients, recipient.Address)
+		if suppressionErr.Reason == "" {
+			suppressionErr.Reason = suppression.Reason
+		}
+		serviceInstance.logger.Warn(
+			"notification_recipient_suppressed",
+			"notification_id", notification.NotificationID,
+			"recipient", recipient.Address,
+			"reason", suppression.Reason,
+		)
+	}
+	if remaining == 0 && len(suppressionErr.Recipients) > 0 {
+		return suppressionErr
+	}
+	return nil
+}
+
+// applyRecipientResults records the per-recipient outcome of a delivery attempt on the notification.
+func applyRecipientResults(notification *model.Notification, result EmailDeliveryResult, dispatchErr error, attemptedAt time.Time) {
+	accepted := make(map[string]struct{}, len(result.AcceptedRecipients))
+	for _, address := range result.AcceptedRecipients {
+		accepted[strings.ToLower(address)] = struct{}{}
+	}
+	rejected := make(map[string]string, len(result.RejectedRecipients))
+	for _, rejection := range result.RejectedRecipients {
+		rejected[strings.ToLower(rejection.Address)] = rejection.Reason
+	}
+	for index := range notification.Recipients {
+		recipient := &notification.Recipients[index]
+		if recipient.Status == model.RecipientSkipped {
+			continue
+		}
+		key := strings.ToLower(recipient.Address)
+		if reason, wasRejected := rejected[key]; wasRejected {
+			recipient.Status = model.RecipientRejected
+			recipient.Error = reason
+		} else if _, wasAccepted := accepted[key]; wasAccepted && dispatchErr == nil {
+			recipient.Status = model.RecipientAccepted
+			recipient.Error = ""
+		} else {
+			recipient.Status = model.RecipientPending
+			recipient.Error = ""
+		}
+		recipient.UpdatedAt = attemptedAt
+	}
+}
+
 func normalizeAttachments(notificationType model.NotificationType, attachments []model.EmailAttachment) ([]model.EmailAttachment, error) {
 	if len(attachments) == 0 {
 		return nil, nil
 	}
 	if notificationType != model.NotificationEmail {
-		return nil, fmt.Errorf("attachments supported only for email notifications")
+		return nil, fmt.Errorf("%w: attachments supported only for email notifications", ErrInvalidNotificationRequest)
 	}
 	if len(attachments) > maxAttachmentCount {
-		return nil, fmt.Errorf("too many attachments: max %d", maxAttachmentCount)
+		return nil, fmt.Errorf("%w: too many attachments: max %d", ErrInvalidNotificationRequest, maxAttachmentCount)
 	}
 
 	totalSize := 0
@@ -291,15 +926,15 @@ func normalizeAttachments(notificationType model.NotificationType, attachments [
 	for idx, attachment := range attachments {
 		filename := strings.TrimSpace(attachment.Filename)
 		if filename == "" {
-			return nil, fmt.Errorf("attachment %d missing filename", idx+1)
+			return nil, fmt.Errorf("%w: attachment %d missing filename", ErrInvalidNotificationRequest, idx+1)
 		}
 		dataCopy := append([]byte(nil), attachment.Data...)
 		payloadSize := len(dataCopy)
 		if payloadSize == 0 {
-			return nil, fmt.Errorf("attachment %q has empty data", filename)
+			return nil, fmt.Errorf("%w: attachment %q has empty data", ErrInvalidNotificationRequest, filename)
 		}
 		if payloadSize > maxAttachmentSizeBytes {
-			return nil, fmt.Errorf("attachment %q exceeds %d bytes", filename, maxAttachmentSizeBytes)
+			return nil, fmt.Errorf("%w: attachment %q exceeds %d bytes", ErrInvalidNotificationRequest, filename, maxAttachmentSizeBytes)
 		}
 		totalSize += payloadSize
 
@@ -315,7 +950,17 @@ func normalizeAttachments(notificationType model.NotificationType, attachments [
 	}
 
 	if totalSize > maxTotalAttachmentSizeBytes {
-		return nil, fmt.Errorf("attachments exceed total limit of %d bytes", maxTotalAttachmentSizeBytes)
+		return nil, fmt.Errorf("%w: attachments exceed total limit of %d bytes", ErrInvalidNotificationRequest, maxTotalAttachmentSizeBytes)
 	}
 	return normalized, nil
 }
+
+// applySmsResult copies the provider's acceptance details onto the notification.
+func applySmsResult(notificationRecord *model.Notification, result SmsDeliveryResult) {
+	notificationRecord.ProviderMessageID = result.ProviderMessageID
+	notificationRecord.Provider = result.Provider
+	notificationRecord.ProviderStatus = result.ProviderStatus
+	notificationRecord.SegmentCount = result.SegmentCount
+	notificationRecord.Price = result.Price
+	notificationRecord.PriceUnit = result.PriceUnit
+}
@@ -47,18 +47,18 @@ func TestListNotificationsFiltersByStatus(t *testing.T) {
 		UpdatedAt:        now.Add(2 * time.Second),
 	})
 
-	responses, err := serviceInstance.ListNotifications(
+	page, err := serviceInstance.ListNotifications(
 		context.Background(),
 		model.NotificationListFilters{Statuses: []model.NotificationStatus{model.StatusQueued, model.StatusErrored, model.StatusErrored}},
 	)
 	if err != nil {
 		t.Fatalf("list error: %v", err)
 	}
-	if len(responses) != 2 {
-		t.Fatalf("expected 2 notifications, got %d", len(responses))
+	if len(page.Notifications) != 2 || page.NextPageToken != "" {
+		t.Fatalf("expected a single page of 2 notifications, got %d (%q)", len(page.Notifications), page.NextPageToken)
 	}
 	statusSet := map[model.NotificationStatus]struct{}{}
-	for _, response := range responses {
+	for _, response := range page.Notifications {
 		statusSet[response.Status] = struct{}{}
 	}
 	if _, ok := statusSet[model.StatusQueued]; !ok {
@@ -69,6 +69,153 @@ func TestListNotificationsFiltersByStatus(t *testing.T) {
 	}
 }
 
+func TestListNotificationsPagesNewestFirst(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := newNotificationServiceForDomainTests(database)
+
+	for _, notificationID := range []string{"notif-1", "notif-2", "notif-3", "notif-4", "notif-5"} {
+		insertNotificationRecord(t, database, model.Notification{
+			NotificationID:   notificationID,
+			NotificationType: model.NotificationEmail,
+			Recipient:        "user@example.com",
+			Message:          "body",
+			Status:           model.StatusQueued,
+		})
+	}
+
+	var listed []string
+	filters := model.NotificationListFilters{PageSize: 2}
+	for pageCount := 1; ; pageCount++ {
+		page, err := serviceInstance.ListNotifications(context.Background(), filters)
+		if err != nil {
+			t.Fatalf("list page %d error: %v", pageCount, err)
+		}
+		for _, response := range page.Notifications {
+			listed = append(listed, response.NotificationID)
+		}
+		if page.NextPageToken == "" {
+			if pageCount != 3 {
+				t.Fatalf("expected 3 pages, got %d", pageCount)
+			}
+			break
+		}
+		// Rows stored after the first page must not shift later pages.
+		if pageCount == 1 {
+			insertNotificationRecord(t, database, model.Notification{NotificationID: "notif-late", NotificationType: model.NotificationSMS, Recipient: "+15550001111", Message: "late", Status: model.StatusQueued})
+		}
+		filters.PageToken = page.NextPageToken
+	}
+
+	expected := []string{"notif-5", "notif-4", "notif-3", "notif-2", "notif-1"}
+	if len(listed) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, listed)
+	}
+	for index := range expected {
+		if listed[index] != expected[index] {
+			t.Fatalf("expected %v, got %v", expected, listed)
+		}
+	}
+
+	for _, invalid := range []model.NotificationListFilters{{PageToken: "not-a-token"}, {PageSize: -1}} {
+		if _, err := serviceInstance.ListNotifications(context.Background(), invalid); !errors.Is(err, model.ErrInvalidNotificationListFilter) {
+			t.Fatalf("expected %+v to be rejected, got %v", invalid, err)
+		}
+	}
+}
+
+func TestListNotificationsAppliesFilters(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := newNotificationServiceForDomainTests(database)
+
+	base := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	scheduled := base.Add(48 * time.Hour)
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID:   "notif-invoice",
+		NotificationType: model.NotificationEmail,
+		Recipient:        "Alice@example.com",
+		Subject:          "Your Invoice",
+		Message:          "Attached",
+		Status:           model.StatusQueued,
+		ScheduledFor:     &scheduled,
+		CreatedAt:        base,
+		Attachments:      []model.NotificationAttachment{{Filename: "invoice.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.7"), SizeBytes: 8}},
+	})
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID:   "notif-discount",
+		NotificationType: model.NotificationEmail,
+		Recipient:        "bob@example.com",
+		Subject:          "News",
+		Message:          "Save 100% today",
+		Status:           model.StatusSent,
+		CreatedAt:        base.Add(time.Hour),
+	})
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID:   "notif-sms",
+		NotificationType: model.NotificationSMS,
+		Recipient:        "+15550001111",
+		Message:          "Your invoice is ready",
+		Status:           model.StatusSent,
+		CreatedAt:        base.Add(2 * time.Hour),
+	})
+
+	createdFrom := base.Add(time.Hour)
+	createdUntil := base.Add(2 * time.Hour)
+	scheduledFrom := base.Add(24 * time.Hour)
+	testCases := []struct {
+		name        string
+		filters     model.NotificationListFilters
+		expectedIDs []string
+	}{
+		{name: "type", filters: model.NotificationListFilters{Types: []model.NotificationType{model.NotificationSMS}}, expectedIDs: []string{"notif-sms"}},
+		{name: "exact recipient", filters: model.NotificationListFilters{Recipient: "bob@example.com"}, expectedIDs: []string{"notif-discount"}},
+		{name: "recipient prefix ignores case", filters: model.NotificationListFilters{RecipientPrefix: "alice@"}, expectedIDs: []string{"notif-invoice"}},
+		{name: "text matches subject or message", filters: model.NotificationListFilters{Text: "INVOICE"}, expectedIDs: []string{"notif-sms", "notif-invoice"}},
+		{name: "text wildcards are literal", filters: model.NotificationListFilters{Text: "100%"}, expectedIDs: []string{"notif-discount"}},
+		{name: "created range excludes its end", filters: model.NotificationListFilters{CreatedAfter: &createdFrom, CreatedBefore: &createdUntil}, expectedIDs: []string{"notif-discount"}},
+		{name: "scheduled range skips unscheduled", filters: model.NotificationListFilters{ScheduledAfter: &scheduledFrom}, expectedIDs: []string{"notif-invoice"}},
+		{name: "filters combine", filters: model.NotificationListFilters{Text: "invoice", Types: []model.NotificationType{model.NotificationEmail}}, expectedIDs: []string{"notif-invoice"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			page, err := serviceInstance.ListNotifications(context.Background(), testCase.filters)
+			if err != nil {
+				t.Fatalf("list error: %v", err)
+			}
+			if len(page.Notifications) != len(testCase.expectedIDs) {
+				t.Fatalf("expected %v, got %d notifications", testCase.expectedIDs, len(page.Notifications))
+			}
+			for index, response := range page.Notifications {
+				if response.NotificationID != testCase.expectedIDs[index] {
+					t.Fatalf("expected %v, got %s at %d", testCase.expectedIDs, response.NotificationID, index)
+				}
+			}
+		})
+	}
+
+	page, err := serviceInstance.ListNotifications(context.Background(), model.NotificationListFilters{Recipient: "Alice@example.com"})
+	if err != nil || len(page.Notifications) != 1 {
+		t.Fatalf("expected the invoice notification, got %+v (%v)", page, err)
+	}
+	attachments := page.Notifications[0].Attachments
+	if len(attachments) != 1 || attachments[0].Filename != "invoice.pdf" || attachments[0].SizeBytes != 8 || len(attachments[0].Data) != 0 {
+		t.Fatalf("expected attachment metadata without data, got %+v", attachments)
+	}
+
+	for _, invalid := range []model.NotificationListFilters{
+		{Types: []model.NotificationType{"fax"}},
+		{CreatedAfter: &createdUntil, CreatedBefore: &createdFrom},
+	} {
+		if _, err := serviceInstance.ListNotifications(context.Background(), invalid); !errors.Is(err, model.ErrInvalidNotificationListFilter) {
+			t.Fatalf("expected %+v to be rejected, got %v", invalid, err)
+		}
+	}
+}
+
 func TestRescheduleNotificationUpdatesQueuedRecord(t *testing.T) {
 	t.Helper()
 
@@ -197,6 +344,89 @@ func TestCancelNotificationRejectsNonQueued(t *testing.T) {
 	}
 }
 
+func TestRequeueNotificationRestoresDeadNotification(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := newNotificationServiceForDomainTests(database)
+	serviceInstance.events = NewNotificationEventBus()
+	serviceInstance.dispatchWake = make(chan struct{}, 1)
+	subscription, err := serviceInstance.events.Subscribe(NotificationWatchFilter{})
+	if err != nil {
+		t.Fatalf("subscribe error: %v", err)
+	}
+	defer subscription.Close()
+
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID:   "notif-dead",
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Message:          "dead",
+		Status:           model.StatusDead,
+		RetryCount:       3,
+		LastError:        "smtp timeout",
+	})
+
+	response, err := serviceInstance.RequeueNotification(context.Background(), "notif-dead")
+	if err != nil {
+		t.Fatalf("requeue error: %v", err)
+	}
+	if response.Status != model.StatusQueued || response.RetryCount != 0 || response.LastError != "smtp timeout" {
+		t.Fatalf("unexpected requeued notification %#v", response)
+	}
+	select {
+	case event := <-subscription.Events():
+		if event.NotificationID != "notif-dead" || event.Status != model.StatusQueued {
+			t.Fatalf("unexpected event %#v", event)
+		}
+	default:
+		t.Fatalf("expected the requeue to be published")
+	}
+	select {
+	case <-serviceInstance.dispatchWake:
+	default:
+		t.Fatalf("expected the requeue to wake the dispatcher")
+	}
+
+	if _, err := serviceInstance.RequeueNotification(context.Background(), "notif-dead"); !errors.Is(err, model.ErrNotificationNotRequeueable) {
+		t.Fatalf("expected a queued notification to be rejected, got %v", err)
+	}
+}
+
+func TestMarkExhaustedNotificationsDeadSweepsLegacyRows(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := newNotificationServiceForDomainTests(database)
+	for _, record := range []model.Notification{
+		{NotificationID: "notif-exhausted", Status: model.StatusErrored, RetryCount: 3},
+		{NotificationID: "notif-legacy", Status: model.StatusFailed, RetryCount: 5},
+		{NotificationID: "notif-retrying", Status: model.StatusErrored, RetryCount: 2},
+	} {
+		record.NotificationType = model.NotificationEmail
+		record.Recipient = "user@example.com"
+		record.Message = "Body"
+		insertNotificationRecord(t, database, record)
+	}
+
+	serviceInstance.markExhaustedNotificationsDead(context.Background())
+
+	expectedStatuses := map[string]model.NotificationStatus{
+		"notif-exhausted": model.StatusDead,
+		"notif-legacy":    model.StatusDead,
+		"notif-retrying":  model.StatusErrored,
+	}
+	for notificationID, expectedStatus := range expectedStatuses {
+		stored, err := model.GetNotificationByID(context.Background(), database, notificationID)
+		if err != nil {
+			t.Fatalf("fetch error: %v", err)
+		}
+		if model.CanonicalStatus(stored.Status) != expectedStatus {
+			t.Fatalf("%s: expected %s, got %s", notificationID, expectedStatus, stored.Status)
+		}
+	}
+}
+
 func newNotificationServiceForDomainTests(database *gorm.DB) *notificationServiceImpl {
 	return &notificationServiceImpl{
 		database:         database,
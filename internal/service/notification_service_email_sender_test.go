@@ -1,11 +1,13 @@
 package service
 
 import (
+	"context"
 	"io"
 	"strconv"
 	"testing"
 
 	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
 	"log/slog"
 )
 
@@ -33,7 +35,7 @@ func TestNewNotificationServiceUsesSMTPEmailSender(t *testing.T) {
 		OperationTimeoutSec:  10,
 	}
 
-	serviceInstance := NewNotificationService(database, logger, configuration)
+	serviceInstance := NewNotificationService(database, logger, configuration, nil)
 	concrete, ok := serviceInstance.(*notificationServiceImpl)
 	if !ok {
 		t.Fatalf("unexpected service implementation type %T", serviceInstance)
@@ -63,3 +65,69 @@ func TestNewNotificationServiceUsesSMTPEmailSender(t *testing.T) {
 		t.Fatalf("SMTP timeouts not applied")
 	}
 }
+
+func TestNewNotificationServiceUsesConfiguredEmailProvider(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	configuration := config.Config{
+		MaxRetries:           3,
+		RetryIntervalSec:     2,
+		EmailProvider:        config.EmailProviderPostmark,
+		FromEmail:            "no-reply@example.com",
+		PostmarkServerToken:  "pm-token",
+		ConnectionTimeoutSec: 5,
+	}
+
+	serviceInstance := NewNotificationService(database, logger, configuration, nil)
+	concrete, ok := serviceInstance.(*notificationServiceImpl)
+	if !ok {
+		t.Fatalf("unexpected service implementation type %T", serviceInstance)
+	}
+	postmarkSender, ok := concrete.emailSender.(*PostmarkEmailSender)
+	if !ok {
+		t.Fatalf("expected PostmarkEmailSender, got %T", concrete.emailSender)
+	}
+	if postmarkSender.Config.ServerToken != "pm-token" || postmarkSender.Config.BaseURL != defaultPostmarkBaseURL {
+		t.Fatalf("unexpected Postmark configuration %+v", postmarkSender.Config)
+	}
+}
+
+func TestSendNotificationStoresEmailProviderMessageID(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	emailSender := &stubEmailSender{providerMessageID: "provider-123", provider: "sendgrid"}
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender:      emailSender,
+		maxRetries:       3,
+		retryIntervalSec: 1,
+	}
+
+	response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "user@example.com",
+		Subject:          "Subject",
+		Message:          "Body",
+	})
+	if err != nil {
+		t.Fatalf("SendNotification error: %v", err)
+	}
+	if response.ProviderMessageID != "provider-123" {
+		t.Fatalf("expected provider message ID in response, got %q", response.ProviderMessageID)
+	}
+	stored, err := model.GetNotificationByID(context.Background(), database, response.NotificationID)
+	if err != nil {
+		t.Fatalf("load notification: %v", err)
+	}
+	if stored.ProviderMessageID != "provider-123" {
+		t.Fatalf("expected stored provider message ID, got %q", stored.ProviderMessageID)
+	}
+	if stored.Provider != "sendgrid" || response.Provider != "sendgrid" {
+		t.Fatalf("expected delivering provider recorded, got stored %q response %q", stored.Provider, response.Provider)
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"log/slog"
+)
+
+func TestSendNotificationPersistsRecipientOutcomes(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name             string
+		rejected         []RecipientRejection
+		expectedStatus   model.NotificationStatus
+		expectedStatuses map[string]model.RecipientStatus
+	}{
+		{
+			name:           "AllAccepted",
+			expectedStatus: model.StatusSent,
+			expectedStatuses: map[string]model.RecipientStatus{
+				"to@example.com":  model.RecipientAccepted,
+				"cc@example.com":  model.RecipientAccepted,
+				"bcc@example.com": model.RecipientAccepted,
+			},
+		},
+		{
+			name:           "PartialRejection",
+			rejected:       []RecipientRejection{{Address: "cc@example.com", Reason: "550 mailbox unavailable"}},
+			expectedStatus: model.StatusSent,
+			expectedStatuses: map[string]model.RecipientStatus{
+				"to@example.com":  model.RecipientAccepted,
+				"cc@example.com":  model.RecipientRejected,
+				"bcc@example.com": model.RecipientAccepted,
+			},
+		},
+		{
+			name: "AllRejected",
+			rejected: []RecipientRejection{
+				{Address: "to@example.com", Reason: "550"},
+				{Address: "cc@example.com", Reason: "550"},
+				{Address: "bcc@example.com", Reason: "550"},
+			},
+			expectedStatus: model.StatusDead,
+			expectedStatuses: map[string]model.RecipientStatus{
+				"to@example.com":  model.RecipientRejected,
+				"cc@example.com":  model.RecipientRejected,
+				"bcc@example.com": model.RecipientRejected,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database := openIsolatedDatabase(t)
+			emailSender := &stubEmailSender{rejectedRecipients: testCase.rejected}
+			serviceInstance := &notificationServiceImpl{
+				database:         database,
+				logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+				emailSender:      emailSender,
+				maxRetries:       3,
+				retryIntervalSec: 1,
+			}
+
+			response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				To:               []string{" to@example.com ", "TO@example.com"},
+				Cc:               []string{"cc@example.com"},
+				Bcc:              []string{"bcc@example.com", ""},
+				Subject:          "Digest",
+				Message:          "Body",
+			})
+			if err != nil {
+				t.Fatalf("send error: %v", err)
+			}
+			if response.Status != testCase.expectedStatus {
+				t.Fatalf("expected status %s, got %s", testCase.expectedStatus, response.Status)
+			}
+			if response.Recipient != "to@example.com" {
+				t.Fatalf("expected primary recipient to be first To address, got %q", response.Recipient)
+			}
+			if len(emailSender.receivedMessages) != 1 {
+				t.Fatalf("expected one dispatch, got %d", len(emailSender.receivedMessages))
+			}
+			dispatched := emailSender.receivedMessages[0]
+			if strings.Join(dispatched.To, ",") != "to@example.com" || strings.Join(dispatched.Cc, ",") != "cc@example.com" || strings.Join(dispatched.Bcc, ",") != "bcc@example.com" {
+				t.Fatalf("unexpected dispatched recipients %#v", dispatched)
+			}
+
+			stored, fetchErr := serviceInstance.GetNotificationStatus(context.Background(), response.NotificationID)
+			if fetchErr != nil {
+				t.Fatalf("fetch error: %v", fetchErr)
+			}
+			if len(stored.To) != 1 || len(stored.Cc) != 1 || len(stored.Bcc) != 1 {
+				t.Fatalf("unexpected stored recipients to=%v cc=%v bcc=%v", stored.To, stored.Cc, stored.Bcc)
+			}
+			if len(stored.RecipientDeliveries) != len(testCase.expectedStatuses) {
+				t.Fatalf("unexpected recipient deliveries %#v", stored.RecipientDeliveries)
+			}
+			for _, delivery := range stored.RecipientDeliveries {
+				if delivery.Status != testCase.expectedStatuses[delivery.Address] {
+					t.Fatalf("recipient %s: expected %s, got %s", delivery.Address, testCase.expectedStatuses[delivery.Address], delivery.Status)
+				}
+				if delivery.Status == model.RecipientRejected && delivery.Error == "" {
+					t.Fatalf("expected rejection reason for %s", delivery.Address)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryWorkerUpdatesRecipientOutcomes(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	emailSender := &stubEmailSender{}
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender:      emailSender,
+		maxRetries:       3,
+		retryIntervalSec: 1,
+	}
+
+	future := time.Now().UTC().Add(5 * time.Minute)
+	response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "to@example.com",
+		Cc:               []string{"cc@example.com"},
+		Subject:          "Digest",
+		Message:          "Body",
+		ScheduledFor:     &future,
+	})
+	if err != nil {
+		t.Fatalf("send error: %v", err)
+	}
+	if len(response.RecipientDeliveries) != 2 || response.RecipientDeliveries[0].Status != model.RecipientPending {
+		t.Fatalf("expected pending recipients for scheduled notification, got %#v", response.RecipientDeliveries)
+	}
+
+	emailSender.rejectedRecipients = []RecipientRejection{{Address: "cc@example.com", Reason: "550 no such user"}}
+	clock := &adjustableClock{now: future.Add(time.Second)}
+	worker := newRetryWorkerForTest(t, serviceInstance, clock)
+	worker.RunOnce(context.Background())
+
+	stored, fetchErr := serviceInstance.GetNotificationStatus(context.Background(), response.NotificationID)
+	if fetchErr != nil {
+		t.Fatalf("fetch error: %v", fetchErr)
+	}
+	if stored.Status != model.StatusSent {
+		t.Fatalf("expected sent status, got %s", stored.Status)
+	}
+	statuses := map[string]model.RecipientStatus{}
+	for _, delivery := range stored.RecipientDeliveries {
+		statuses[delivery.Address] = delivery.Status
+	}
+	if statuses["to@example.com"] != model.RecipientAccepted || statuses["cc@example.com"] != model.RecipientRejected {
+		t.Fatalf("unexpected recipient statuses %#v", statuses)
+	}
+}
+
+func TestSendNotificationRejectsRecipientListsForSms(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := &notificationServiceImpl{
+		database:    database,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender: &stubEmailSender{},
+		smsSender:   &stubSmsSender{},
+		smsEnabled:  true,
+	}
+
+	_, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationSMS,
+		Recipient:        "+15555550100",
+		Cc:               []string{"+15555550101"},
+		Message:          "Body",
+	})
+	if err == nil {
+		t.Fatalf("expected sms with cc recipients to be rejected")
+	}
+}
+
+func TestSendNotificationPersistsHTMLMessage(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name             string
+		notificationType model.NotificationType
+		recipient        string
+		expectError      bool
+	}{
+		{name: "Email", notificationType: model.NotificationEmail, recipient: "user@example.com"},
+		{name: "SmsRejected", notificationType: model.NotificationSMS, recipient: "+15555550100", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database := openIsolatedDatabase(t)
+			emailSender := &stubEmailSender{}
+			serviceInstance := &notificationServiceImpl{
+				database:    database,
+				logger:      slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+				emailSender: emailSender,
+				smsSender:   &stubSmsSender{},
+				smsEnabled:  true,
+			}
+
+			response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+				NotificationType: testCase.notificationType,
+				Recipient:        testCase.recipient,
+				Subject:          "Welcome",
+				Message:          "Welcome aboard",
+				HTMLMessage:      "<h1>Welcome aboard</h1>",
+			})
+			if testCase.expectError {
+				if err == nil {
+					t.Fatalf("expected html_message to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("send error: %v", err)
+			}
+			if len(emailSender.receivedMessages) != 1 || emailSender.receivedMessages[0].HTMLBody != "<h1>Welcome aboard</h1>" {
+				t.Fatalf("expected html body to reach the sender")
+			}
+			stored, fetchErr := model.GetNotificationByID(context.Background(), database, response.NotificationID)
+			if fetchErr != nil {
+				t.Fatalf("fetch error: %v", fetchErr)
+			}
+			if stored.HTMLMessage != "<h1>Welcome aboard</h1>" {
+				t.Fatalf("expected html message to be persisted, got %q", stored.HTMLMessage)
+			}
+		})
+	}
+}
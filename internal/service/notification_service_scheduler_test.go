@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"net/textproto"
 	"testing"
 	"time"
 
@@ -161,11 +163,7 @@ func TestSendNotificationRejectsUnsupportedTypes(t *testing.T) {
 				t.Fatalf("unexpected sms dispatch attempts")
 			}
 
-			pendingNotifications, pendingError := model.GetQueuedOrFailedNotifications(context.Background(), database, 5, time.Now().UTC())
-			if pendingError != nil {
-				t.Fatalf("pending notifications error: %v", pendingError)
-			}
-			if len(pendingNotifications) != 0 {
+			if pendingNotifications := claimDueNotifications(t, database, 5); len(pendingNotifications) != 0 {
 				t.Fatalf("unexpected stored notifications")
 			}
 		})
@@ -265,9 +263,8 @@ func TestSendNotificationValidatesRequiredFields(t *testing.T) {
 		t.Fatalf("expected validation error")
 	}
 
-	_, fetchError := model.GetQueuedOrFailedNotifications(context.Background(), database, 3, time.Now().UTC())
-	if fetchError != nil {
-		t.Fatalf("fetch pending error: %v", fetchError)
+	if pending := claimDueNotifications(t, database, 3); len(pending) != 0 {
+		t.Fatalf("expected the rejected notification not to be stored, got %d pending", len(pending))
 	}
 
 	if emailSender.callCount != 0 || smsSender.callCount != 0 {
@@ -481,6 +478,91 @@ func TestRetryWorkerMarksSmsDisabledAsFailed(t *testing.T) {
 	}
 }
 
+func TestRetryWorkerMarksExhaustedNotificationsDead(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender:      &stubEmailSender{},
+		maxRetries:       2,
+		retryIntervalSec: 1,
+		smsEnabled:       false,
+	}
+
+	now := time.Now().UTC()
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID:   "notif-last-attempt",
+		NotificationType: model.NotificationSMS,
+		Recipient:        "+15555555555",
+		Message:          "Body",
+		Status:           model.StatusErrored,
+		RetryCount:       1,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	})
+
+	clock := &adjustableClock{now: now}
+	worker := newRetryWorkerForTest(t, serviceInstance, clock)
+	worker.RunOnce(context.Background())
+
+	updated, fetchErr := model.GetNotificationByID(context.Background(), database, "notif-last-attempt")
+	if fetchErr != nil {
+		t.Fatalf("fetch notification error: %v", fetchErr)
+	}
+	if updated.Status != model.StatusDead || updated.RetryCount != 2 {
+		t.Fatalf("expected dead notification after the last attempt, got %s/%d", updated.Status, updated.RetryCount)
+	}
+	if updated.LastError != ErrSMSDisabled.Error() {
+		t.Fatalf("expected the attempt error to be stored, got %q", updated.LastError)
+	}
+	if updated.NextAttemptAt != nil {
+		t.Fatalf("expected no further attempt to be scheduled")
+	}
+}
+
+func TestRetryWorkerSkipsRetriesForPermanentFailures(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	emailSender := &stubEmailSender{rejectedRecipients: []RecipientRejection{{Address: "gone@example.com", Reason: "550 mailbox unavailable"}}}
+	serviceInstance := &notificationServiceImpl{
+		database:         database,
+		logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		emailSender:      emailSender,
+		maxRetries:       5,
+		retryIntervalSec: 1,
+	}
+
+	now := time.Now().UTC()
+	insertNotificationRecord(t, database, model.Notification{
+		NotificationID:   "notif-rejected",
+		NotificationType: model.NotificationEmail,
+		Recipient:        "gone@example.com",
+		Subject:          "Subject",
+		Message:          "Body",
+		Status:           model.StatusQueued,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	})
+
+	clock := &adjustableClock{now: now}
+	worker := newRetryWorkerForTest(t, serviceInstance, clock)
+	worker.RunOnce(context.Background())
+
+	updated, fetchErr := model.GetNotificationByID(context.Background(), database, "notif-rejected")
+	if fetchErr != nil {
+		t.Fatalf("fetch notification error: %v", fetchErr)
+	}
+	if updated.Status != model.StatusDead || updated.RetryCount != 5 {
+		t.Fatalf("expected the first rejection to end the notification, got %s/%d", updated.Status, updated.RetryCount)
+	}
+	if emailSender.callCount != 1 {
+		t.Fatalf("expected a single attempt, got %d", emailSender.callCount)
+	}
+}
+
 func TestRetryWorkerDispatchesStoredAttachments(t *testing.T) {
 	t.Helper()
 
@@ -549,14 +631,15 @@ func newRetryWorkerForTest(t *testing.T, serviceInstance *notificationServiceImp
 	t.Helper()
 
 	worker, err := scheduler.NewWorker(scheduler.Config{
-		Repository:    newNotificationRetryStore(serviceInstance.database),
-		Dispatcher:    newNotificationDispatcher(serviceInstance),
-		Logger:        serviceInstance.logger,
-		Interval:      time.Duration(serviceInstance.retryIntervalSec) * time.Second,
-		MaxRetries:    serviceInstance.maxRetries,
-		SuccessStatus: string(model.StatusSent),
-		FailureStatus: string(model.StatusErrored),
-		Clock:         clock,
+		Repository:      newNotificationRetryStore(serviceInstance.database, serviceInstance.events),
+		Dispatcher:      newNotificationDispatcher(serviceInstance),
+		Logger:          serviceInstance.logger,
+		Interval:        time.Duration(serviceInstance.retryIntervalSec) * time.Second,
+		MaxRetries:      serviceInstance.maxRetries,
+		SuccessStatus:   string(model.StatusSent),
+		FailureStatus:   string(model.StatusErrored),
+		ExhaustedStatus: string(model.StatusDead),
+		Clock:           clock,
 	})
 	if err != nil {
 		t.Fatalf("worker init error: %v", err)
@@ -567,23 +650,61 @@ func newRetryWorkerForTest(t *testing.T, serviceInstance *notificationServiceImp
 type stubEmailSender struct {
 	callCount           int
 	receivedAttachments [][]model.EmailAttachment
+	receivedMessages    []EmailMessage
+	rejectedRecipients  []RecipientRejection
+	providerMessageID   string
+	provider            string
 }
 
-func (sender *stubEmailSender) SendEmail(_ context.Context, _ string, _ string, _ string, attachments []model.EmailAttachment) error {
+func (sender *stubEmailSender) SendEmail(_ context.Context, message EmailMessage) (EmailDeliveryResult, error) {
 	sender.callCount++
-	cloned := make([]model.EmailAttachment, len(attachments))
-	copy(cloned, attachments)
+	cloned := make([]model.EmailAttachment, len(message.Attachments))
+	copy(cloned, message.Attachments)
 	sender.receivedAttachments = append(sender.receivedAttachments, cloned)
-	return nil
+	sender.receivedMessages = append(sender.receivedMessages, message)
+
+	rejected := make(map[string]struct{}, len(sender.rejectedRecipients))
+	for _, rejection := range sender.rejectedRecipients {
+		rejected[rejection.Address] = struct{}{}
+	}
+	result := EmailDeliveryResult{RejectedRecipients: sender.rejectedRecipients, ProviderMessageID: sender.providerMessageID, Provider: sender.provider}
+	for _, address := range message.EnvelopeRecipients() {
+		if _, isRejected := rejected[address]; !isRejected {
+			result.AcceptedRecipients = append(result.AcceptedRecipients, address)
+		}
+	}
+	if len(result.AcceptedRecipients) == 0 {
+		return result, fmt.Errorf("%w: %w", ErrAllRecipientsRejected, &textproto.Error{Code: 550, Msg: "5.1.1 mailbox unavailable"})
+	}
+	return result, nil
 }
 
 type stubSmsSender struct {
 	callCount int
 }
 
-func (sender *stubSmsSender) SendSms(_ context.Context, _ string, _ string) (string, error) {
+func (sender *stubSmsSender) SendSms(_ context.Context, _ string, _ string) (SmsDeliveryResult, error) {
 	sender.callCount++
-	return "queued", nil
+	return SmsDeliveryResult{ProviderMessageID: "queued", Provider: "stub"}, nil
+}
+
+// claimDueNotifications leases every notification the dispatch worker would attempt now, through
+// the same query the worker runs.
+func claimDueNotifications(t *testing.T, database *gorm.DB, maxRetries int) []model.Notification {
+	t.Helper()
+
+	now := time.Now().UTC()
+	claimed, err := model.ClaimNotifications(context.Background(), database, model.NotificationClaim{
+		WorkerID:   "test-worker",
+		MaxRetries: maxRetries,
+		Now:        now,
+		LeaseUntil: now.Add(time.Minute),
+		Limit:      100,
+	})
+	if err != nil {
+		t.Fatalf("claim notifications: %v", err)
+	}
+	return claimed
 }
 
 func openIsolatedDatabase(t *testing.T) *gorm.DB {
@@ -594,7 +715,7 @@ func openIsolatedDatabase(t *testing.T) *gorm.DB {
 	if openError != nil {
 		t.Fatalf("sqlite open error: %v", openError)
 	}
-	if migrateError := database.AutoMigrate(&model.Notification{}, &model.NotificationAttachment{}); migrateError != nil {
+	if migrateError := database.AutoMigrate(&model.Notification{}, &model.NotificationAttachment{}, &model.NotificationRecipient{}, &model.Template{}, &model.FeedbackEvent{}, &model.RecipientDeliverability{}, &model.Suppression{}, &model.InboundMessage{}, &model.WebhookSubscription{}, &model.WebhookDelivery{}, &model.NotificationAttempt{}); migrateError != nil {
 		t.Fatalf("migration error: %v", migrateError)
 	}
 	return database
@@ -0,0 +1,499 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/pkg/scheduler"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// OutboundWebhookService manages webhook subscriptions and delivers notification events to them.
+// It observes the notification event bus: each status write that raises an event the subscriptions
+// ask for is stored as one delivery per subscription, and the delivery worker POSTs it.
+type OutboundWebhookService interface {
+	NotificationObserver
+	ListSubscriptions(ctx context.Context) ([]model.WebhookSubscriptionResponse, error)
+	// CreateSubscription stores a subscription and returns it together with its secret, which is
+	// generated when the request leaves it empty.
+	CreateSubscription(ctx context.Context, request model.WebhookSubscriptionRequest) (model.WebhookSubscriptionResponse, error)
+	// UpdateSubscription replaces the URL, event types and description of a subscription, and its
+	// secret when the request carries one.
+	UpdateSubscription(ctx context.Context, subscriptionID string, request model.WebhookSubscriptionRequest) (model.WebhookSubscriptionResponse, error)
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+	ListDeliveries(ctx context.Context, filters model.WebhookDeliveryListFilters) ([]model.WebhookDelivery, error)
+	// ReplayDelivery queues a new delivery of the same event to the same subscription.
+	ReplayDelivery(ctx context.Context, deliveryID string) (model.WebhookDelivery, error)
+	StartDeliveryWorker(ctx context.Context)
+}
+
+var ErrInvalidWebhookSubscription = errors.New("invalid webhook subscription")
+
+// Headers sent with every webhook delivery. The signature is the hex HMAC-SHA256 computed by
+// SignWebhookPayload, prefixed with its scheme version.
+const (
+	WebhookSignatureHeader = "X-Pinguin-Signature"
+	WebhookTimestampHeader = "X-Pinguin-Timestamp"
+	WebhookEventHeader     = "X-Pinguin-Event"
+	WebhookDeliveryHeader  = "X-Pinguin-Delivery"
+
+	webhookSignatureVersion = "v1"
+	// maxWebhookResponseBytes bounds how much of a subscriber's response is read before the
+	// connection is released.
+	maxWebhookResponseBytes = 64 << 10
+)
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of timestamp, a dot, and body, keyed with secret.
+// Receivers recompute it from the X-Pinguin-Timestamp header and the raw request body.
+func SignWebhookPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type outboundWebhookServiceImpl struct {
+	database   *gorm.DB
+	logger     *slog.Logger
+	httpClient *http.Client
+
+	maxAttempts   int
+	retryInterval time.Duration
+	pollInterval  time.Duration
+	maxInFlight   int
+	workerID      string
+	leaseDuration time.Duration
+	wake          chan struct{}
+}
+
+// NewOutboundWebhookService creates an OutboundWebhookService. The delivery worker shares the
+// dispatch worker's poll interval, in-flight cap, worker ID and lease settings.
+func NewOutboundWebhookService(database *gorm.DB, logger *slog.Logger, cfg config.Config) OutboundWebhookService {
+	return &outboundWebhookServiceImpl{
+		database: database,
+		logger:   logger,
+		httpClient: &http.Client{
+			Timeout: time.Duration(cfg.WebhookTimeoutSec) * time.Second,
+			// A redirect is reported as a failed delivery instead of being followed, because
+			// following it would resend the event as a GET.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		maxAttempts:   cfg.WebhookMaxAttempts,
+		retryInterval: time.Duration(cfg.WebhookRetryIntervalSec) * time.Second,
+		pollInterval:  time.Duration(cfg.DispatchPollIntervalMs) * time.Millisecond,
+		maxInFlight:   cfg.DispatchMaxInFlight,
+		workerID:      cfg.DispatchWorkerID,
+		leaseDuration: time.Duration(cfg.DispatchLeaseSec) * time.Second,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+func (serviceInstance *outboundWebhookServiceImpl) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscriptionResponse, error) {
+	subscriptions, err := model.ListWebhookSubscriptions(ctx, serviceInstance.database)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]model.WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, model.NewWebhookSubscriptionResponse(subscription))
+	}
+	return responses, nil
+}
+
+func (serviceInstance *outboundWebhookServiceImpl) CreateSubscription(ctx context.Context, request model.WebhookSubscriptionRequest) (model.WebhookSubscriptionResponse, error) {
+	subscription, err := validateWebhookSubscriptionRequest(request)
+	if err != nil {
+		return model.WebhookSubscriptionResponse{}, err
+	}
+	if subscription.Secret == "" {
+		subscription.Secret = "whsec_" + randomHex(32)
+	}
+	subscription.SubscriptionID = "whsub-" + randomHex(8)
+	if err := model.CreateWebhookSubscription(ctx, serviceInstance.database, &subscription); err != nil {
+		return model.WebhookSubscriptionResponse{}, err
+	}
+	serviceInstance.logger.Info("webhook_subscription_created", "subscription_id", subscription.SubscriptionID, "event_types", subscription.EventTypes)
+	response := model.NewWebhookSubscriptionResponse(subscription)
+	response.Secret = subscription.Secret
+	return response, nil
+}
+
+func (serviceInstance *outboundWebhookServiceImpl) UpdateSubscription(ctx context.Context, subscriptionID string, request model.WebhookSubscriptionRequest) (model.WebhookSubscriptionResponse, error) {
+	updated, err := validateWebhookSubscriptionRequest(request)
+	if err != nil {
+		return model.WebhookSubscriptionResponse{}, err
+	}
+	subscription, err := model.GetWebhookSubscription(ctx, serviceInstance.database, strings.TrimSpace(subscriptionID))
+	if err != nil {
+		return model.WebhookSubscriptionResponse{}, err
+	}
+	subscription.URL = updated.URL
+	subscription.EventTypes = updated.EventTypes
+	subscription.Description = updated.Description
+	if updated.Secret != "" {
+		subscription.Secret = updated.Secret
+	}
+	if err := model.SaveWebhookSubscription(ctx, serviceInstance.database, subscription); err != nil {
+		return model.WebhookSubscriptionResponse{}, err
+	}
+	serviceInstance.logger.Info("webhook_subscription_updated", "subscription_id", subscription.SubscriptionID, "event_types", subscription.EventTypes)
+	response := model.NewWebhookSubscriptionResponse(*subscription)
+	response.Secret = updated.Secret
+	return response, nil
+}
+
+func (serviceInstance *outboundWebhookServiceImpl) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	if err := model.DeleteWebhookSubscription(ctx, serviceInstance.database, strings.TrimSpace(subscriptionID)); err != nil {
+		return err
+	}
+	serviceInstance.logger.Info("webhook_subscription_deleted", "subscription_id", subscriptionID)
+	return nil
+}
+
+func (serviceInstance *outboundWebhookServiceImpl) ListDeliveries(ctx context.Context, filters model.WebhookDeliveryListFilters) ([]model.WebhookDelivery, error) {
+	return model.ListWebhookDeliveries(ctx, serviceInstance.database, filters)
+}
+
+func (serviceInstance *outboundWebhookServiceImpl) ReplayDelivery(ctx context.Context, deliveryID string) (model.WebhookDelivery, error) {
+	original, err := model.GetWebhookDelivery(ctx, serviceInstance.database, strings.TrimSpace(deliveryID))
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	if _, err := model.GetWebhookSubscription(ctx, serviceInstance.database, original.SubscriptionID); err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	now := time.Now().UTC()
+	replay := model.WebhookDelivery{
+		DeliveryID:     "whdel-" + randomHex(12),
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		NotificationID: original.NotificationID,
+		Payload:        original.Payload,
+		Status:         model.WebhookDeliveryPending,
+		ReplayOf:       original.DeliveryID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := model.CreateWebhookDeliveries(ctx, serviceInstance.database, []model.WebhookDelivery{replay}); err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	serviceInstance.logger.Info("webhook_delivery_replayed", "delivery_id", replay.DeliveryID, "replay_of", original.DeliveryID)
+	serviceInstance.wakeWorker()
+	return replay, nil
+}
+
+// ObserveTransition stores a delivery for every subscription that receives an event raised by the
+// status write. A failure to store them is logged; it does not undo the status write.
+func (serviceInstance *outboundWebhookServiceImpl) ObserveTransition(ctx context.Context, previousStatus model.NotificationStatus, record model.Notification) {
+	eventTypes := notificationWebhookEvents(previousStatus, record)
+	if len(eventTypes) == 0 {
+		return
+	}
+	subscriptions, err := model.ListWebhookSubscriptions(ctx, serviceInstance.database)
+	if err != nil {
+		serviceInstance.logger.Error("webhook_subscriptions_load_error", "notification_id", record.NotificationID, "error", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	var deliveries []model.WebhookDelivery
+	for _, eventType := range eventTypes {
+		var receivers []model.WebhookSubscription
+		for _, subscription := range subscriptions {
+			if subscription.Receives(eventType) {
+				receivers = append(receivers, subscription)
+			}
+		}
+		if len(receivers) == 0 {
+			continue
+		}
+		event := model.WebhookEvent{
+			ID:        "evt-" + randomHex(12),
+			Type:      eventType,
+			CreatedAt: now,
+			Data:      model.WebhookEventData{Notification: notificationEvent(record)},
+		}
+		payload, marshalErr := json.Marshal(event)
+		if marshalErr != nil {
+			serviceInstance.logger.Error("webhook_event_encode_error", "notification_id", record.NotificationID, "error", marshalErr)
+			return
+		}
+		for _, subscription := range receivers {
+			deliveries = append(deliveries, model.WebhookDelivery{
+				DeliveryID:     "whdel-" + randomHex(12),
+				SubscriptionID: subscription.SubscriptionID,
+				EventID:        event.ID,
+				EventType:      eventType,
+				NotificationID: record.NotificationID,
+				Payload:        string(payload),
+				Status:         model.WebhookDeliveryPending,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	if err := model.CreateWebhookDeliveries(ctx, serviceInstance.database, deliveries); err != nil {
+		serviceInstance.logger.Error("webhook_deliveries_store_error", "notification_id", record.NotificationID, "error", err)
+		return
+	}
+	serviceInstance.wakeWorker()
+}
+
+// notificationWebhookEvents returns the events a status change raises. A notification that fails
+// its last attempt without having errored before raises both notification.errored and
+// notification.retries_exhausted.
+func notificationWebhookEvents(previousStatus model.NotificationStatus, record model.Notification) []model.WebhookEventType {
+	status := model.CanonicalStatus(record.Status)
+	previous := model.CanonicalStatus(previousStatus)
+	if status == previous {
+		return nil
+	}
+	switch status {
+	case model.StatusSent:
+		return []model.WebhookEventType{model.WebhookEventNotificationSent}
+	case model.StatusErrored:
+		return []model.WebhookEventType{model.WebhookEventNotificationErrored}
+	case model.StatusCancelled:
+		return []model.WebhookEventType{model.WebhookEventNotificationCancelled}
+	case model.StatusDead:
+		if previous == model.StatusErrored {
+			return []model.WebhookEventType{model.WebhookEventNotificationRetriesExhausted}
+		}
+		return []model.WebhookEventType{model.WebhookEventNotificationErrored, model.WebhookEventNotificationRetriesExhausted}
+	default:
+		return nil
+	}
+}
+
+// StartDeliveryWorker delivers pending webhooks until ctx is cancelled. Deliveries to one
+// subscription are attempted one at a time, so a slow endpoint holds up only its own events.
+func (serviceInstance *outboundWebhookServiceImpl) StartDeliveryWorker(ctx context.Context) {
+	worker, workerErr := serviceInstance.newDeliveryWorker(nil)
+	if workerErr != nil {
+		serviceInstance.logger.Error("Failed to initialize webhook delivery worker", "error", workerErr)
+		return
+	}
+	worker.Run(ctx)
+}
+
+// newDeliveryWorker builds the scheduler worker that delivers webhooks; a nil clock uses the
+// system time.
+func (serviceInstance *outboundWebhookServiceImpl) newDeliveryWorker(clock scheduler.Clock) (*scheduler.Worker, error) {
+	return scheduler.NewWorker(scheduler.Config{
+		Repository:    newWebhookDeliveryStore(serviceInstance.database),
+		Dispatcher:    newWebhookDispatcher(serviceInstance),
+		Logger:        serviceInstance.logger,
+		Interval:      serviceInstance.retryInterval,
+		PollInterval:  serviceInstance.pollInterval,
+		Wake:          serviceInstance.wake,
+		MaxRetries:    serviceInstance.maxAttempts,
+		SuccessStatus: string(model.WebhookDeliverySucceeded),
+		FailureStatus: string(model.WebhookDeliveryFailed),
+		MaxInFlight:   serviceInstance.maxInFlight,
+		WorkerID:      serviceInstance.workerID,
+		LeaseDuration: serviceInstance.leaseDuration,
+		Clock:         clock,
+	})
+}
+
+// wakeWorker starts a delivery worker cycle without waiting for the next poll.
+func (serviceInstance *outboundWebhookServiceImpl) wakeWorker() {
+	select {
+	case serviceInstance.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliver POSTs the delivery's payload to subscription and returns the response status.
+func (serviceInstance *outboundWebhookServiceImpl) deliver(ctx context.Context, subscription model.WebhookSubscription, delivery model.WebhookDelivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, string(delivery.EventType))
+	request.Header.Set(WebhookDeliveryHeader, delivery.DeliveryID)
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	request.Header.Set(WebhookSignatureHeader, webhookSignatureVersion+"="+SignWebhookPayload(subscription.Secret, timestamp, []byte(delivery.Payload)))
+
+	response, err := serviceInstance.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxWebhookResponseBytes))
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, fmt.Errorf("webhook endpoint responded with status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+func validateWebhookSubscriptionRequest(request model.WebhookSubscriptionRequest) (model.WebhookSubscription, error) {
+	endpoint := strings.TrimSpace(request.URL)
+	parsedURL, err := url.Parse(endpoint)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return model.WebhookSubscription{}, fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhookSubscription)
+	}
+	var eventTypes []model.WebhookEventType
+	for _, rawEventType := range request.EventTypes {
+		eventType := model.WebhookEventType(strings.ToLower(strings.TrimSpace(string(rawEventType))))
+		if !eventType.IsValid() {
+			return model.WebhookSubscription{}, fmt.Errorf("%w: unsupported event type %q", ErrInvalidWebhookSubscription, rawEventType)
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	if len(eventTypes) == 0 {
+		return model.WebhookSubscription{}, fmt.Errorf("%w: at least one event type is required", ErrInvalidWebhookSubscription)
+	}
+	return model.WebhookSubscription{
+		URL:         endpoint,
+		Secret:      strings.TrimSpace(request.Secret),
+		EventTypes:  model.JoinWebhookEventTypes(eventTypes),
+		Description: strings.TrimSpace(request.Description),
+	}, nil
+}
+
+func randomHex(length int) string {
+	buffer := make([]byte, length)
+	_, _ = rand.Read(buffer)
+	return hex.EncodeToString(buffer)
+}
+
+type webhookDeliveryStore struct {
+	database *gorm.DB
+}
+
+func newWebhookDeliveryStore(database *gorm.DB) *webhookDeliveryStore {
+	return &webhookDeliveryStore{database: database}
+}
+
+func (store *webhookDeliveryStore) ClaimJobs(ctx context.Context, claim scheduler.Claim) ([]scheduler.Job, error) {
+	records, err := model.ClaimWebhookDeliveries(ctx, store.database, model.WebhookDeliveryClaim{
+		WorkerID:              claim.WorkerID,
+		MaxAttempts:           claim.MaxRetries,
+		Now:                   claim.Now,
+		LeaseUntil:            claim.LeaseUntil,
+		Limit:                 claim.Limit,
+		ExcludedSubscriptions: claim.ExcludedClasses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]scheduler.Job, 0, len(records))
+	for index := range records {
+		record := records[index]
+		job := scheduler.Job{
+			ID:         record.DeliveryID,
+			Class:      record.SubscriptionID,
+			RetryCount: record.Attempts,
+			LockedBy:   record.LockedBy,
+			Payload:    &records[index],
+		}
+		if record.LastAttemptedAt != nil {
+			job.LastAttemptedAt = *record.LastAttemptedAt
+		}
+		if record.NextAttemptAt != nil {
+			job.NextAttemptAt = *record.NextAttemptAt
+		}
+		if record.LeaseUntil != nil {
+			job.LeaseUntil = *record.LeaseUntil
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (store *webhookDeliveryStore) ReleaseJob(ctx context.Context, job scheduler.Job) error {
+	return model.ReleaseWebhookDeliveryLease(ctx, store.database, job.ID, job.LockedBy)
+}
+
+func (store *webhookDeliveryStore) ApplyAttemptResult(ctx context.Context, job scheduler.Job, update scheduler.AttemptUpdate) error {
+	record, err := webhookDeliveryFromJob(job)
+	if err != nil {
+		return err
+	}
+	attemptedAt := update.LastAttemptedAt
+	record.Status = model.WebhookDeliveryStatus(update.Status)
+	record.Attempts = update.RetryCount
+	record.LastAttemptedAt = &attemptedAt
+	record.UpdatedAt = attemptedAt
+	record.LockedBy = ""
+	record.LeaseUntil = nil
+	record.NextAttemptAt = nil
+	if !update.NextAttemptAt.IsZero() {
+		nextAttemptAt := update.NextAttemptAt
+		record.NextAttemptAt = &nextAttemptAt
+	}
+	if record.Status == model.WebhookDeliverySucceeded {
+		record.DeliveredAt = &attemptedAt
+	}
+	return model.SaveWebhookDelivery(ctx, store.database, record)
+}
+
+type webhookDispatcher struct {
+	serviceInstance *outboundWebhookServiceImpl
+}
+
+func newWebhookDispatcher(serviceInstance *outboundWebhookServiceImpl) *webhookDispatcher {
+	return &webhookDispatcher{serviceInstance: serviceInstance}
+}
+
+// Attempt sends one delivery. The response status and error are written to the delivery record,
+// which the store saves with the attempt result.
+func (dispatcher *webhookDispatcher) Attempt(ctx context.Context, job scheduler.Job) (scheduler.DispatchResult, error) {
+	delivery, err := webhookDeliveryFromJob(job)
+	if err != nil {
+		return scheduler.DispatchResult{}, err
+	}
+	subscription, err := model.GetWebhookSubscription(ctx, dispatcher.serviceInstance.database, delivery.SubscriptionID)
+	if err != nil {
+		delivery.ResponseStatus = 0
+		delivery.LastError = err.Error()
+		if errors.Is(err, model.ErrWebhookSubscriptionNotFound) {
+			return scheduler.DispatchResult{}, scheduler.Permanent(err)
+		}
+		return scheduler.DispatchResult{}, err
+	}
+	responseStatus, err := dispatcher.serviceInstance.deliver(ctx, *subscription, *delivery)
+	delivery.ResponseStatus = responseStatus
+	if err != nil {
+		delivery.LastError = err.Error()
+		return scheduler.DispatchResult{}, err
+	}
+	delivery.LastError = ""
+	return scheduler.DispatchResult{}, nil
+}
+
+func webhookDeliveryFromJob(job scheduler.Job) (*model.WebhookDelivery, error) {
+	delivery, ok := job.Payload.(*model.WebhookDelivery)
+	if !ok || delivery == nil {
+		return nil, fmt.Errorf("missing webhook delivery payload for job %s", job.ID)
+	}
+	return delivery, nil
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+)
+
+func TestOutboundWebhookSubscriptionValidation(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name        string
+		request     model.WebhookSubscriptionRequest
+		expectError bool
+		expectTypes []model.WebhookEventType
+	}{
+		{
+			name:        "Valid",
+			request:     model.WebhookSubscriptionRequest{URL: " https://hooks.example.com/pinguin ", EventTypes: []model.WebhookEventType{"notification.sent", "NOTIFICATION.CANCELLED", "notification.sent"}},
+			expectTypes: []model.WebhookEventType{model.WebhookEventNotificationSent, model.WebhookEventNotificationCancelled},
+		},
+		{name: "RelativeURL", request: model.WebhookSubscriptionRequest{URL: "/hooks", EventTypes: []model.WebhookEventType{model.WebhookEventNotificationSent}}, expectError: true},
+		{name: "UnsupportedScheme", request: model.WebhookSubscriptionRequest{URL: "ftp://hooks.example.com", EventTypes: []model.WebhookEventType{model.WebhookEventNotificationSent}}, expectError: true},
+		{name: "UnknownEventType", request: model.WebhookSubscriptionRequest{URL: "https://hooks.example.com", EventTypes: []model.WebhookEventType{"notification.delivered"}}, expectError: true},
+		{name: "NoEventTypes", request: model.WebhookSubscriptionRequest{URL: "https://hooks.example.com"}, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			serviceInstance := newOutboundWebhookServiceForTest(openIsolatedDatabase(t))
+			response, err := serviceInstance.CreateSubscription(context.Background(), testCase.request)
+			if testCase.expectError {
+				if !errors.Is(err, ErrInvalidWebhookSubscription) {
+					t.Fatalf("expected an invalid subscription error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("create subscription error: %v", err)
+			}
+			if !strings.HasPrefix(response.SubscriptionID, "whsub-") || !strings.HasPrefix(response.Secret, "whsec_") {
+				t.Fatalf("expected generated identifiers, got %+v", response)
+			}
+			if response.URL != "https://hooks.example.com/pinguin" || len(response.EventTypes) != len(testCase.expectTypes) {
+				t.Fatalf("unexpected subscription %+v", response)
+			}
+			for index, eventType := range testCase.expectTypes {
+				if response.EventTypes[index] != eventType {
+					t.Fatalf("expected event types %v, got %v", testCase.expectTypes, response.EventTypes)
+				}
+			}
+
+			listed, err := serviceInstance.ListSubscriptions(context.Background())
+			if err != nil {
+				t.Fatalf("list subscriptions error: %v", err)
+			}
+			if len(listed) != 1 || listed[0].Secret != "" {
+				t.Fatalf("expected one listed subscription without its secret, got %+v", listed)
+			}
+		})
+	}
+}
+
+func TestOutboundWebhookObserverStoresDeliveries(t *testing.T) {
+	t.Helper()
+
+	ctx := context.Background()
+	database := openIsolatedDatabase(t)
+	serviceInstance := newOutboundWebhookServiceForTest(database)
+	bus := NewNotificationEventBus()
+	bus.Observe(serviceInstance)
+
+	everything, err := serviceInstance.CreateSubscription(ctx, model.WebhookSubscriptionRequest{
+		URL: "https://hooks.example.com/all",
+		EventTypes: []model.WebhookEventType{
+			model.WebhookEventNotificationSent, model.WebhookEventNotificationErrored,
+			model.WebhookEventNotificationCancelled, model.WebhookEventNotificationRetriesExhausted,
+		},
+	})
+	if err != nil {
+		t.Fatalf("create subscription error: %v", err)
+	}
+	exhaustedOnly, err := serviceInstance.CreateSubscription(ctx, model.WebhookSubscriptionRequest{
+		URL:        "https://hooks.example.com/exhausted",
+		EventTypes: []model.WebhookEventType{model.WebhookEventNotificationRetriesExhausted},
+	})
+	if err != nil {
+		t.Fatalf("create subscription error: %v", err)
+	}
+
+	testCases := []struct {
+		name           string
+		previousStatus model.NotificationStatus
+		record         model.Notification
+		expected       map[string][]model.WebhookEventType
+	}{
+		{
+			name:           "Sent",
+			previousStatus: model.StatusQueued,
+			record:         model.Notification{NotificationID: "notif-sent", Status: model.StatusSent},
+			expected:       map[string][]model.WebhookEventType{everything.SubscriptionID: {model.WebhookEventNotificationSent}},
+		},
+		{
+			name:           "FirstError",
+			previousStatus: model.StatusQueued,
+			record:         model.Notification{NotificationID: "notif-errored", Status: model.StatusErrored, RetryCount: 1},
+			expected:       map[string][]model.WebhookEventType{everything.SubscriptionID: {model.WebhookEventNotificationErrored}},
+		},
+		{
+			name:           "RepeatedError",
+			previousStatus: model.StatusErrored,
+			record:         model.Notification{NotificationID: "notif-repeated", Status: model.StatusErrored, RetryCount: 2},
+		},
+		{
+			name:           "RetriesExhausted",
+			previousStatus: model.StatusErrored,
+			record:         model.Notification{NotificationID: "notif-exhausted", Status: model.StatusDead, RetryCount: 3},
+			expected: map[string][]model.WebhookEventType{
+				everything.SubscriptionID:    {model.WebhookEventNotificationRetriesExhausted},
+				exhaustedOnly.SubscriptionID: {model.WebhookEventNotificationRetriesExhausted},
+			},
+		},
+		{
+			name:           "OnlyAttemptFails",
+			previousStatus: model.StatusQueued,
+			record:         model.Notification{NotificationID: "notif-dead", Status: model.StatusDead, RetryCount: 3},
+			expected: map[string][]model.WebhookEventType{
+				everything.SubscriptionID:    {model.WebhookEventNotificationErrored, model.WebhookEventNotificationRetriesExhausted},
+				exhaustedOnly.SubscriptionID: {model.WebhookEventNotificationRetriesExhausted},
+			},
+		},
+		{
+			name:           "Requeued",
+			previousStatus: model.StatusDead,
+			record:         model.Notification{NotificationID: "notif-requeued", Status: model.StatusQueued},
+		},
+		{
+			name:           "Cancelled",
+			previousStatus: model.StatusQueued,
+			record:         model.Notification{NotificationID: "notif-cancelled", Status: model.StatusCancelled},
+			expected:       map[string][]model.WebhookEventType{everything.SubscriptionID: {model.WebhookEventNotificationCancelled}},
+		},
+		{
+			name:           "Delivered",
+			previousStatus: model.StatusSent,
+			record:         model.Notification{NotificationID: "notif-delivered", Status: model.StatusDelivered},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bus.PublishTransition(ctx, testCase.previousStatus, testCase.record)
+
+			deliveries, err := serviceInstance.ListDeliveries(ctx, model.WebhookDeliveryListFilters{NotificationID: testCase.record.NotificationID})
+			if err != nil {
+				t.Fatalf("list deliveries error: %v", err)
+			}
+			received := make(map[string][]model.WebhookEventType)
+			for _, delivery := range deliveries {
+				if delivery.Status != model.WebhookDeliveryPending {
+					t.Fatalf("expected a pending delivery, got %+v", delivery)
+				}
+				var event model.WebhookEvent
+				if err := json.Unmarshal([]byte(delivery.Payload), &event); err != nil {
+					t.Fatalf("payload decode error: %v", err)
+				}
+				if event.ID != delivery.EventID || event.Type != delivery.EventType || event.Data.Notification.NotificationID != testCase.record.NotificationID {
+					t.Fatalf("payload does not match delivery %+v: %+v", delivery, event)
+				}
+				received[delivery.SubscriptionID] = append(received[delivery.SubscriptionID], delivery.EventType)
+			}
+			if len(received) != len(testCase.expected) {
+				t.Fatalf("expected deliveries %v, got %v", testCase.expected, received)
+			}
+			for subscriptionID, eventTypes := range testCase.expected {
+				receivedTypes := received[subscriptionID]
+				slices.Sort(receivedTypes)
+				if !slices.Equal(receivedTypes, eventTypes) {
+					t.Fatalf("expected deliveries %v, got %v", testCase.expected, received)
+				}
+			}
+		})
+	}
+}
+
+func TestOutboundWebhookWorkerDeliversSignedEvents(t *testing.T) {
+	t.Helper()
+
+	ctx := context.Background()
+	var (
+		mutex          sync.Mutex
+		responseStatus = http.StatusNoContent
+		requests       []*http.Request
+		bodies         [][]byte
+	)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests = append(requests, request)
+		bodies = append(bodies, body)
+		writer.WriteHeader(responseStatus)
+	}))
+	defer endpoint.Close()
+
+	database := openIsolatedDatabase(t)
+	serviceInstance := newOutboundWebhookServiceForTest(database)
+	subscription, err := serviceInstance.CreateSubscription(ctx, model.WebhookSubscriptionRequest{
+		URL:        endpoint.URL,
+		Secret:     "whsec_test",
+		EventTypes: []model.WebhookEventType{model.WebhookEventNotificationSent},
+	})
+	if err != nil {
+		t.Fatalf("create subscription error: %v", err)
+	}
+	worker, err := serviceInstance.newDeliveryWorker(nil)
+	if err != nil {
+		t.Fatalf("worker init error: %v", err)
+	}
+
+	serviceInstance.ObserveTransition(ctx, model.StatusQueued, model.Notification{NotificationID: "notif-signed", Status: model.StatusSent})
+	worker.RunOnce(ctx)
+
+	delivered := loadOnlyWebhookDelivery(t, serviceInstance, "notif-signed")
+	if delivered.Status != model.WebhookDeliverySucceeded || delivered.Attempts != 1 || delivered.ResponseStatus != http.StatusNoContent || delivered.DeliveredAt == nil {
+		t.Fatalf("expected a succeeded delivery, got %+v", delivered)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(requests))
+	}
+	request := requests[0]
+	if request.Method != http.MethodPost || request.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected request %s %s", request.Method, request.Header.Get("Content-Type"))
+	}
+	if request.Header.Get(WebhookEventHeader) != string(model.WebhookEventNotificationSent) || request.Header.Get(WebhookDeliveryHeader) != delivered.DeliveryID {
+		t.Fatalf("unexpected event headers %v", request.Header)
+	}
+	expectedSignature := "v1=" + SignWebhookPayload("whsec_test", request.Header.Get(WebhookTimestampHeader), bodies[0])
+	if request.Header.Get(WebhookSignatureHeader) != expectedSignature || string(bodies[0]) != delivered.Payload {
+		t.Fatalf("signature %q does not verify against the body", request.Header.Get(WebhookSignatureHeader))
+	}
+
+	mutex.Lock()
+	responseStatus = http.StatusInternalServerError
+	mutex.Unlock()
+	serviceInstance.ObserveTransition(ctx, model.StatusQueued, model.Notification{NotificationID: "notif-rejected", Status: model.StatusSent})
+	worker.RunOnce(ctx)
+
+	rejected := loadOnlyWebhookDelivery(t, serviceInstance, "notif-rejected")
+	if rejected.Status != model.WebhookDeliveryFailed || rejected.Attempts != 1 || rejected.ResponseStatus != http.StatusInternalServerError {
+		t.Fatalf("expected a failed delivery, got %+v", rejected)
+	}
+	if rejected.NextAttemptAt == nil || !rejected.NextAttemptAt.After(*rejected.LastAttemptedAt) || !strings.Contains(rejected.LastError, "500") {
+		t.Fatalf("expected a scheduled retry with the error recorded, got %+v", rejected)
+	}
+
+	replay, err := serviceInstance.ReplayDelivery(ctx, rejected.DeliveryID)
+	if err != nil {
+		t.Fatalf("replay error: %v", err)
+	}
+	if replay.ReplayOf != rejected.DeliveryID || replay.EventID != rejected.EventID || replay.Payload != rejected.Payload || replay.Status != model.WebhookDeliveryPending {
+		t.Fatalf("unexpected replay %+v", replay)
+	}
+	mutex.Lock()
+	responseStatus = http.StatusOK
+	mutex.Unlock()
+	worker.RunOnce(ctx)
+	replayed, err := model.GetWebhookDelivery(ctx, database, replay.DeliveryID)
+	if err != nil {
+		t.Fatalf("load replay error: %v", err)
+	}
+	if replayed.Status != model.WebhookDeliverySucceeded {
+		t.Fatalf("expected the replay to be delivered, got %+v", replayed)
+	}
+	if _, err := serviceInstance.ReplayDelivery(ctx, "whdel-missing"); !errors.Is(err, model.ErrWebhookDeliveryNotFound) {
+		t.Fatalf("expected a missing delivery to be reported, got %v", err)
+	}
+
+	serviceInstance.ObserveTransition(ctx, model.StatusQueued, model.Notification{NotificationID: "notif-orphaned", Status: model.StatusSent})
+	if err := serviceInstance.DeleteSubscription(ctx, subscription.SubscriptionID); err != nil {
+		t.Fatalf("delete subscription error: %v", err)
+	}
+	worker.RunOnce(ctx)
+
+	orphaned := loadOnlyWebhookDelivery(t, serviceInstance, "notif-orphaned")
+	if orphaned.Status != model.WebhookDeliveryFailed || orphaned.Attempts != serviceInstance.maxAttempts || orphaned.NextAttemptAt != nil {
+		t.Fatalf("expected a deleted subscription to fail the delivery for good, got %+v", orphaned)
+	}
+	if _, err := serviceInstance.ReplayDelivery(ctx, orphaned.DeliveryID); !errors.Is(err, model.ErrWebhookSubscriptionNotFound) {
+		t.Fatalf("expected a replay to a deleted subscription to be rejected, got %v", err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("expected three requests, got %d", len(requests))
+	}
+}
+
+func newOutboundWebhookServiceForTest(database *gorm.DB) *outboundWebhookServiceImpl {
+	return NewOutboundWebhookService(database, newDiscardLogger(), config.Config{
+		WebhookMaxAttempts:      3,
+		WebhookRetryIntervalSec: 30,
+		WebhookTimeoutSec:       5,
+		DispatchLeaseSec:        60,
+	}).(*outboundWebhookServiceImpl)
+}
+
+func loadOnlyWebhookDelivery(t *testing.T, serviceInstance *outboundWebhookServiceImpl, notificationID string) model.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := serviceInstance.ListDeliveries(context.Background(), model.WebhookDeliveryListFilters{NotificationID: notificationID})
+	if err != nil {
+		t.Fatalf("list deliveries error: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("expected one delivery for %s, got %d", notificationID, len(deliveries))
+	}
+	return deliveries[0]
+}
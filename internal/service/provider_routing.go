@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/textproto"
+	"sync"
+	"time"
+
+	"log/slog"
+)
+
+// ErrNoProviderAvailable indicates every provider configured for a channel has an open circuit breaker.
+var ErrNoProviderAvailable = errors.New("no provider available: all circuit breakers are open")
+
+// Circuit breaker states reported through ProviderHealth.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// ProviderHTTPError is returned by HTTP API providers for non-2xx responses.
+type ProviderHTTPError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (providerError *ProviderHTTPError) Error() string {
+	return fmt.Sprintf("%s API error: status %d: %s", providerError.Provider, providerError.StatusCode, providerError.Body)
+}
+
+// Retryable reports whether another attempt, possibly through a different provider, may succeed.
+func (providerError *ProviderHTTPError) Retryable() bool {
+	return providerError.StatusCode >= 500 || providerError.StatusCode == 429
+}
+
+// ProviderHealth describes the circuit breaker of a single provider.
+type ProviderHealth struct {
+	Channel             string     `json:"channel"`
+	Provider            string     `json:"provider"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenUntil           *time.Time `json:"open_until,omitempty"`
+}
+
+// HealthReporter is implemented by components that can describe the health of their providers.
+type HealthReporter interface {
+	ProviderHealth() []ProviderHealth
+}
+
+// shouldFailover reports whether err points at the provider rather than at the message. Transport
+// failures, HTTP 5xx/429, refused credentials (HTTP 401/403 or SMTP AUTH), a refused SMTP sender and
+// transient SMTP 4xx replies move on to the next provider; other client errors and recipient rejections would fail the same way
+// everywhere. Providers report a message they accepted as sent even when its response cannot be
+// read, so such a message is never handed to the next provider.
+func shouldFailover(err error) bool {
+	if err == nil || errors.Is(err, ErrAllRecipientsRejected) {
+		return false
+	}
+	if errors.Is(err, ErrSMTPAuthentication) || errors.Is(err, ErrSMTPSenderRejected) {
+		return true
+	}
+	var providerError *ProviderHTTPError
+	if errors.As(err, &providerError) {
+		return providerError.Retryable() || providerError.StatusCode == http.StatusUnauthorized || providerError.StatusCode == http.StatusForbidden
+	}
+	var smtpReply *textproto.Error
+	if errors.As(err, &smtpReply) {
+		return smtpReply.Code >= 400 && smtpReply.Code < 500
+	}
+	return true
+}
+
+type circuitBreaker struct {
+	mutex               sync.Mutex
+	failureThreshold    int
+	cooldown            time.Duration
+	now                 func() time.Time
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	trialInFlight       bool
+}
+
+func newCircuitBreaker(failureThreshold int, cooldown time.Duration) *circuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = 1
+	}
+	return &circuitBreaker{failureThreshold: failureThreshold, cooldown: cooldown, now: time.Now, state: BreakerClosed}
+}
+
+// allow reports whether a call may proceed. After the cooldown an open breaker admits a single
+// half-open trial; its outcome decides whether the breaker closes or opens again.
+func (breaker *circuitBreaker) allow() bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	switch breaker.state {
+	case BreakerOpen:
+		if breaker.now().Before(breaker.openedAt.Add(breaker.cooldown)) {
+			return false
+		}
+		breaker.state = BreakerHalfOpen
+		breaker.trialInFlight = true
+		return true
+	case BreakerHalfOpen:
+		if breaker.trialInFlight {
+			return false
+		}
+		breaker.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (breaker *circuitBreaker) recordSuccess() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	breaker.state = BreakerClosed
+	breaker.consecutiveFailures = 0
+	breaker.trialInFlight = false
+}
+
+func (breaker *circuitBreaker) recordFailure() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	breaker.consecutiveFailures++
+	breaker.trialInFlight = false
+	if breaker.state == BreakerHalfOpen || breaker.consecutiveFailures >= breaker.failureThreshold {
+		breaker.state = BreakerOpen
+		breaker.openedAt = breaker.now()
+	}
+}
+
+// recordAbandoned ends a half-open trial whose caller gave up before the provider answered, without
+// judging the provider either way.
+func (breaker *circuitBreaker) recordAbandoned() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	breaker.trialInFlight = false
+}
+
+func (breaker *circuitBreaker) snapshot() (string, int, *time.Time) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	if breaker.state != BreakerOpen {
+		return breaker.state, breaker.consecutiveFailures, nil
+	}
+	openUntil := breaker.openedAt.Add(breaker.cooldown).UTC()
+	return breaker.state, breaker.consecutiveFailures, &openUntil
+}
+
+type providerRoute struct {
+	name    string
+	weight  int
+	breaker *circuitBreaker
+}
+
+// providerRouter decides the order in which a channel's providers are attempted and tracks their
+// circuit breakers. The first attempt is drawn by weight when any provider has one; the remaining
+// providers follow in configured order.
+type providerRouter struct {
+	channel string
+	routes  []providerRoute
+	logger  *slog.Logger
+	pick    func(n int) int
+}
+
+func newProviderRouter(channel string, routes []providerRoute, logger *slog.Logger) *providerRouter {
+	return &providerRouter{channel: channel, routes: routes, logger: logger, pick: rand.IntN}
+}
+
+func (router *providerRouter) attemptOrder() []int {
+	order := make([]int, 0, len(router.routes))
+	totalWeight := 0
+	for _, route := range router.routes {
+		totalWeight += route.weight
+	}
+	first := 0
+	if totalWeight > 0 {
+		draw := router.pick(totalWeight)
+		for index, route := range router.routes {
+			if draw < route.weight {
+				first = index
+				break
+			}
+			draw -= route.weight
+		}
+	}
+	order = append(order, first)
+	for index := range router.routes {
+		if index != first {
+			order = append(order, index)
+		}
+	}
+	return order
+}
+
+// dispatch calls attempt for each provider in turn until one succeeds or fails in a way another
+// provider would not fix. Once ctx is done the remaining providers are not tried, and the failure is
+// not held against the provider that was cut short.
+func (router *providerRouter) dispatch(ctx context.Context, attempt func(index int) error) error {
+	var lastErr error
+	for _, index := range router.attemptOrder() {
+		route := router.routes[index]
+		if !route.breaker.allow() {
+			continue
+		}
+		err := attempt(index)
+		if err != nil && ctx.Err() != nil {
+			route.breaker.recordAbandoned()
+			return err
+		}
+		if !shouldFailover(err) {
+			route.breaker.recordSuccess()
+			return err
+		}
+		route.breaker.recordFailure()
+		router.logger.Warn("provider_failover", "channel", router.channel, "provider", route.name, "error", err)
+		lastErr = err
+	}
+	if lastErr == nil {
+		return fmt.Errorf("%s: %w", router.channel, ErrNoProviderAvailable)
+	}
+	return lastErr
+}
+
+func (router *providerRouter) ProviderHealth() []ProviderHealth {
+	health := make([]ProviderHealth, 0, len(router.routes))
+	for _, route := range router.routes {
+		state, failures, openUntil := route.breaker.snapshot()
+		health = append(health, ProviderHealth{
+			Channel:             router.channel,
+			Provider:            route.name,
+			State:               state,
+			ConsecutiveFailures: failures,
+			OpenUntil:           openUntil,
+		})
+	}
+	return health
+}
+
+// FailoverEmailSender delivers through an ordered list of email providers.
+type FailoverEmailSender struct {
+	router  *providerRouter
+	senders []EmailSender
+}
+
+func (senderInstance *FailoverEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	var result EmailDeliveryResult
+	err := senderInstance.router.dispatch(ctx, func(index int) error {
+		var attemptErr error
+		result, attemptErr = senderInstance.senders[index].SendEmail(ctx, message)
+		return attemptErr
+	})
+	return result, err
+}
+
+func (senderInstance *FailoverEmailSender) ProviderHealth() []ProviderHealth {
+	return senderInstance.router.ProviderHealth()
+}
+
+// FailoverSmsSender delivers through an ordered list of SMS providers.
+type FailoverSmsSender struct {
+	router  *providerRouter
+	senders []SmsSender
+}
+
+func (senderInstance *FailoverSmsSender) SendSms(ctx context.Context, recipient string, message string) (SmsDeliveryResult, error) {
+	var result SmsDeliveryResult
+	err := senderInstance.router.dispatch(ctx, func(index int) error {
+		var attemptErr error
+		result, attemptErr = senderInstance.senders[index].SendSms(ctx, recipient, message)
+		return attemptErr
+	})
+	return result, err
+}
+
+func (senderInstance *FailoverSmsSender) ProviderHealth() []ProviderHealth {
+	return senderInstance.router.ProviderHealth()
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+	"time"
+)
+
+type scriptedEmailSender struct {
+	provider  string
+	errs      []error
+	callCount int
+}
+
+func (sender *scriptedEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	var err error
+	if sender.callCount < len(sender.errs) {
+		err = sender.errs[sender.callCount]
+	}
+	sender.callCount++
+	if err != nil {
+		return EmailDeliveryResult{}, err
+	}
+	return EmailDeliveryResult{ProviderMessageID: sender.provider + "-id", Provider: sender.provider}, nil
+}
+
+type scriptedSmsSender struct {
+	provider  string
+	err       error
+	callCount int
+}
+
+func (sender *scriptedSmsSender) SendSms(ctx context.Context, recipient string, message string) (SmsDeliveryResult, error) {
+	sender.callCount++
+	if sender.err != nil {
+		return SmsDeliveryResult{}, sender.err
+	}
+	return SmsDeliveryResult{ProviderMessageID: sender.provider + "-sid", Provider: sender.provider}, nil
+}
+
+func newTestRouter(channel string, threshold int, cooldown time.Duration, names ...string) *providerRouter {
+	routes := make([]providerRoute, 0, len(names))
+	for _, name := range names {
+		routes = append(routes, providerRoute{name: name, breaker: newCircuitBreaker(threshold, cooldown)})
+	}
+	return newProviderRouter(channel, routes, newDiscardLogger())
+}
+
+func TestFailoverEmailSenderFailover(t *testing.T) {
+	t.Helper()
+	testCases := []struct {
+		name              string
+		primaryErr        error
+		expectErr         bool
+		expectProvider    string
+		expectSecondCalls int
+	}{
+		{
+			name:              "ServerErrorFailsOver",
+			primaryErr:        &ProviderHTTPError{Provider: "sendgrid", StatusCode: 503, Body: "unavailable"},
+			expectProvider:    "mailgun",
+			expectSecondCalls: 1,
+		},
+		{
+			name:              "RateLimitFailsOver",
+			primaryErr:        &ProviderHTTPError{Provider: "sendgrid", StatusCode: 429, Body: "slow down"},
+			expectProvider:    "mailgun",
+			expectSecondCalls: 1,
+		},
+		{
+			name:              "TransportErrorFailsOver",
+			primaryErr:        errors.New("dial tcp: connection refused"),
+			expectProvider:    "mailgun",
+			expectSecondCalls: 1,
+		},
+		{
+			name:              "TransientSMTPReplyFailsOver",
+			primaryErr:        &textproto.Error{Code: 421, Msg: "try again later"},
+			expectProvider:    "mailgun",
+			expectSecondCalls: 1,
+		},
+		{
+			name:              "RefusedCredentialsFailOver",
+			primaryErr:        &ProviderHTTPError{Provider: "sendgrid", StatusCode: 401, Body: "invalid api key"},
+			expectProvider:    "mailgun",
+			expectSecondCalls: 1,
+		},
+		{
+			name:              "RefusedSMTPCredentialsFailOver",
+			primaryErr:        fmt.Errorf("%w: %w", ErrSMTPAuthentication, &textproto.Error{Code: 535, Msg: "5.7.8 authentication credentials invalid"}),
+			expectProvider:    "mailgun",
+			expectSecondCalls: 1,
+		},
+		{
+			name:       "ClientErrorDoesNotFailOver",
+			primaryErr: &ProviderHTTPError{Provider: "sendgrid", StatusCode: 400, Body: "bad request"},
+			expectErr:  true,
+		},
+		{
+			name:       "PermanentSMTPReplyDoesNotFailOver",
+			primaryErr: &textproto.Error{Code: 550, Msg: "mailbox unavailable"},
+			expectErr:  true,
+		},
+		{
+			name:       "RejectedRecipientsDoNotFailOver",
+			primaryErr: ErrAllRecipientsRejected,
+			expectErr:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			primary := &scriptedEmailSender{provider: "sendgrid", errs: []error{testCase.primaryErr}}
+			secondary := &scriptedEmailSender{provider: "mailgun"}
+			sender := &FailoverEmailSender{
+				router:  newTestRouter("email", 5, time.Minute, "sendgrid", "mailgun"),
+				senders: []EmailSender{primary, secondary},
+			}
+
+			result, err := sender.SendEmail(context.Background(), EmailMessage{To: []string{"user@example.com"}})
+			if testCase.expectErr {
+				if err == nil {
+					t.Fatalf("expected error, got result %+v", result)
+				}
+				if secondary.callCount != 0 {
+					t.Fatalf("expected no failover, secondary called %d times", secondary.callCount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("send email error: %v", err)
+			}
+			if result.Provider != testCase.expectProvider {
+				t.Fatalf("expected provider %q, got %q", testCase.expectProvider, result.Provider)
+			}
+			if secondary.callCount != testCase.expectSecondCalls {
+				t.Fatalf("expected %d secondary calls, got %d", testCase.expectSecondCalls, secondary.callCount)
+			}
+		})
+	}
+}
+
+// callerGaveUpEmailSender fails after the caller's context has been cancelled, like a provider call
+// cut short by the caller's deadline.
+type callerGaveUpEmailSender struct {
+	cancel    context.CancelFunc
+	callCount int
+}
+
+func (sender *callerGaveUpEmailSender) SendEmail(ctx context.Context, message EmailMessage) (EmailDeliveryResult, error) {
+	sender.callCount++
+	sender.cancel()
+	return EmailDeliveryResult{}, ctx.Err()
+}
+
+func TestProviderRouterStopsWhenCallerGivesUp(t *testing.T) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	primary := &callerGaveUpEmailSender{cancel: cancel}
+	secondary := &scriptedEmailSender{provider: "mailgun"}
+	router := newTestRouter("email", 1, time.Minute, "sendgrid", "mailgun")
+	sender := &FailoverEmailSender{router: router, senders: []EmailSender{primary, secondary}}
+
+	if _, err := sender.SendEmail(ctx, EmailMessage{To: []string{"user@example.com"}}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the caller's cancellation, got %v", err)
+	}
+	if secondary.callCount != 0 {
+		t.Fatalf("expected no failover after the caller gave up, secondary called %d times", secondary.callCount)
+	}
+	for _, health := range sender.ProviderHealth() {
+		if health.State != BreakerClosed || health.ConsecutiveFailures != 0 {
+			t.Fatalf("expected breakers to stay untouched, got %+v", health)
+		}
+	}
+}
+
+func TestProviderRouterWeightedFirstPick(t *testing.T) {
+	t.Helper()
+	testCases := []struct {
+		name          string
+		draw          int
+		expectedOrder []int
+	}{
+		{name: "DrawInFirstWeight", draw: 0, expectedOrder: []int{0, 1, 2}},
+		{name: "DrawInSecondWeight", draw: 3, expectedOrder: []int{1, 0, 2}},
+		{name: "DrawInThirdWeight", draw: 9, expectedOrder: []int{2, 0, 1}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			router := newTestRouter("sms", 5, time.Minute, "twilio", "vonage", "sns")
+			router.routes[0].weight = 3
+			router.routes[1].weight = 6
+			router.routes[2].weight = 1
+			var receivedTotal int
+			router.pick = func(total int) int {
+				receivedTotal = total
+				return testCase.draw
+			}
+
+			order := router.attemptOrder()
+			if receivedTotal != 10 {
+				t.Fatalf("expected total weight 10, got %d", receivedTotal)
+			}
+			if len(order) != len(testCase.expectedOrder) {
+				t.Fatalf("expected order %v, got %v", testCase.expectedOrder, order)
+			}
+			for index := range order {
+				if order[index] != testCase.expectedOrder[index] {
+					t.Fatalf("expected order %v, got %v", testCase.expectedOrder, order)
+				}
+			}
+		})
+	}
+}
+
+func TestProviderRouterUnweightedKeepsConfiguredOrder(t *testing.T) {
+	t.Helper()
+	router := newTestRouter("email", 5, time.Minute, "smtp", "sendgrid")
+	router.pick = func(int) int {
+		t.Fatalf("pick must not be called without weights")
+		return 0
+	}
+	order := router.attemptOrder()
+	if len(order) != 2 || order[0] != 0 || order[1] != 1 {
+		t.Fatalf("expected configured order, got %v", order)
+	}
+}
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	t.Helper()
+	currentTime := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	router := newTestRouter("sms", 2, 30*time.Second, "twilio", "vonage")
+	for _, route := range router.routes {
+		route.breaker.now = func() time.Time { return currentTime }
+	}
+	primary := &scriptedSmsSender{provider: "twilio", err: &ProviderHTTPError{Provider: "twilio", StatusCode: 500, Body: "boom"}}
+	secondary := &scriptedSmsSender{provider: "vonage"}
+	sender := &FailoverSmsSender{router: router, senders: []SmsSender{primary, secondary}}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		result, err := sender.SendSms(context.Background(), "+15555550100", "hello")
+		if err != nil {
+			t.Fatalf("send sms error: %v", err)
+		}
+		if result.Provider != "vonage" {
+			t.Fatalf("expected vonage, got %q", result.Provider)
+		}
+	}
+	if primary.callCount != 2 {
+		t.Fatalf("expected breaker to stop calls after 2 failures, got %d calls", primary.callCount)
+	}
+
+	health := sender.ProviderHealth()
+	if len(health) != 2 {
+		t.Fatalf("expected 2 health entries, got %d", len(health))
+	}
+	if health[0].State != BreakerOpen || health[0].ConsecutiveFailures != 2 || health[0].OpenUntil == nil {
+		t.Fatalf("unexpected primary health %+v", health[0])
+	}
+	if !health[0].OpenUntil.Equal(currentTime.Add(30 * time.Second)) {
+		t.Fatalf("unexpected open_until %v", health[0].OpenUntil)
+	}
+	if health[1].State != BreakerClosed || health[1].Channel != "sms" {
+		t.Fatalf("unexpected secondary health %+v", health[1])
+	}
+
+	currentTime = currentTime.Add(31 * time.Second)
+	primary.err = nil
+	result, err := sender.SendSms(context.Background(), "+15555550100", "hello")
+	if err != nil {
+		t.Fatalf("send sms after cooldown error: %v", err)
+	}
+	if result.Provider != "twilio" {
+		t.Fatalf("expected half-open trial through twilio, got %q", result.Provider)
+	}
+	if state, failures, _ := router.routes[0].breaker.snapshot(); state != BreakerClosed || failures != 0 {
+		t.Fatalf("expected breaker closed after successful trial, got %s with %d failures", state, failures)
+	}
+}
+
+func TestCircuitBreakerReopensAfterFailedTrial(t *testing.T) {
+	t.Helper()
+	currentTime := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(1, 10*time.Second)
+	breaker.now = func() time.Time { return currentTime }
+
+	breaker.recordFailure()
+	if breaker.allow() {
+		t.Fatalf("expected open breaker to reject calls")
+	}
+	currentTime = currentTime.Add(11 * time.Second)
+	if !breaker.allow() {
+		t.Fatalf("expected half-open trial after cooldown")
+	}
+	if breaker.allow() {
+		t.Fatalf("expected a single half-open trial")
+	}
+	breaker.recordFailure()
+	if state, _, _ := breaker.snapshot(); state != BreakerOpen {
+		t.Fatalf("expected breaker to reopen, got %s", state)
+	}
+}
+
+func TestFailoverSmsSenderReportsNoProviderAvailable(t *testing.T) {
+	t.Helper()
+	router := newTestRouter("sms", 1, time.Hour, "twilio")
+	primary := &scriptedSmsSender{provider: "twilio", err: errors.New("connection reset")}
+	sender := &FailoverSmsSender{router: router, senders: []SmsSender{primary}}
+
+	if _, err := sender.SendSms(context.Background(), "+15555550100", "hello"); err == nil {
+		t.Fatalf("expected first failure to surface")
+	}
+	_, err := sender.SendSms(context.Background(), "+15555550100", "hello")
+	if !errors.Is(err, ErrNoProviderAvailable) {
+		t.Fatalf("expected ErrNoProviderAvailable, got %v", err)
+	}
+	if primary.callCount != 1 {
+		t.Fatalf("expected open breaker to skip provider, got %d calls", primary.callCount)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+const defaultMessageBirdBaseURL = "https://rest.messagebird.com"
+
+// MessageBirdConfig configures delivery through the MessageBird Messages API.
+type MessageBirdConfig struct {
+	AccessKey  string
+	Originator string
+	BaseURL    string
+}
+
+// MessageBirdSmsSender delivers SMS through the MessageBird Messages API.
+type MessageBirdSmsSender struct {
+	Config     MessageBirdConfig
+	HTTPClient *http.Client
+	Logger     *slog.Logger
+}
+
+func NewMessageBirdSmsSender(configuration MessageBirdConfig, httpClient *http.Client, logger *slog.Logger) *MessageBirdSmsSender {
+	configuration.BaseURL = resolveBaseURL(configuration.BaseURL, defaultMessageBirdBaseURL)
+	return &MessageBirdSmsSender{Config: configuration, HTTPClient: httpClient, Logger: logger}
+}
+
+type messageBirdMessageRequest struct {
+	Recipients []string `json:"recipients"`
+	Originator string   `json:"originator"`
+	Body       string   `json:"body"`
+}
+
+type messageBirdMessageResponse struct {
+	ID string `json:"id"`
+}
+
+func (senderInstance *MessageBirdSmsSender) SendSms(ctx context.Context, recipient string, message string) (SmsDeliveryResult, error) {
+	body, marshalErr := json.Marshal(messageBirdMessageRequest{
+		Recipients: []string{recipient},
+		Originator: senderInstance.Config.Originator,
+		Body:       message,
+	})
+	if marshalErr != nil {
+		return SmsDeliveryResult{}, fmt.Errorf("encode messagebird request: %w", marshalErr)
+	}
+
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, senderInstance.Config.BaseURL+"/messages", bytes.NewReader(body))
+	if requestErr != nil {
+		return SmsDeliveryResult{}, requestErr
+	}
+	request.Header.Set("Authorization", "AccessKey "+senderInstance.Config.AccessKey)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, responseErr := senderInstance.HTTPClient.Do(request)
+	if responseErr != nil {
+		senderInstance.Logger.Error("MessageBird request error", "error", responseErr)
+		return SmsDeliveryResult{}, responseErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return SmsDeliveryResult{}, providerResponseError(config.SMSProviderMessageBird, response)
+	}
+	var decoded messageBirdMessageResponse
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		logUnreadableAcceptance(senderInstance.Logger, config.SMSProviderMessageBird, err)
+	}
+	return SmsDeliveryResult{ProviderMessageID: decoded.ID, Provider: config.SMSProviderMessageBird}, nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+// ErrSMSProviderNotConfigured reports that the selected SMS provider lacks the credentials it needs.
+var ErrSMSProviderNotConfigured = errors.New("sms provider not configured")
+
+// SmsProviderFactory builds an SmsSender from configuration. Factories return
+// ErrSMSProviderNotConfigured when the credentials they need are absent.
+type SmsProviderFactory func(cfg config.Config, httpClient *http.Client, logger *slog.Logger) (SmsSender, error)
+
+var (
+	smsProviderRegistryMutex sync.RWMutex
+	smsProviderRegistry      = map[string]SmsProviderFactory{
+		config.SMSProviderTwilio:      newTwilioSmsProvider,
+		config.SMSProviderVonage:      newVonageSmsProvider,
+		config.SMSProviderMessageBird: newMessageBirdSmsProvider,
+		config.SMSProviderSNS:         newSNSSmsProvider,
+		config.SMSProviderWebhook:     newWebhookSmsProvider,
+	}
+)
+
+// RegisterSmsProvider makes an SMS provider available under name, replacing any existing
+// registration with the same name.
+func RegisterSmsProvider(name string, factory SmsProviderFactory) {
+	smsProviderRegistryMutex.Lock()
+	defer smsProviderRegistryMutex.Unlock()
+	smsProviderRegistry[name] = factory
+}
+
+// RegisteredSmsProviders returns the names of every registered SMS provider in sorted order.
+func RegisteredSmsProviders() []string {
+	smsProviderRegistryMutex.RLock()
+	defer smsProviderRegistryMutex.RUnlock()
+	names := make([]string, 0, len(smsProviderRegistry))
+	for name := range smsProviderRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSmsSender builds the SmsSender registered under cfg.SMSProvider. When several providers are
+// configured they are wrapped in a FailoverSmsSender that tries them in order behind per-provider
+// circuit breakers.
+func NewSmsSender(cfg config.Config, logger *slog.Logger) (SmsSender, error) {
+	httpClient := &http.Client{Timeout: time.Duration(cfg.ConnectionTimeoutSec) * time.Second}
+	if len(cfg.SMSProviders) <= 1 {
+		return newSmsSenderByName(cfg.SMSProvider, cfg, httpClient, logger)
+	}
+	cooldown := time.Duration(cfg.ProviderBreakerCooldownSec) * time.Second
+	routes := make([]providerRoute, 0, len(cfg.SMSProviders))
+	senders := make([]SmsSender, 0, len(cfg.SMSProviders))
+	for _, configured := range cfg.SMSProviders {
+		sender, err := newSmsSenderByName(configured.Name, cfg, httpClient, logger)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, providerRoute{
+			name:    configured.Name,
+			weight:  configured.Weight,
+			breaker: newCircuitBreaker(cfg.ProviderBreakerThreshold, cooldown),
+		})
+		senders = append(senders, sender)
+	}
+	return &FailoverSmsSender{router: newProviderRouter("sms", routes, logger), senders: senders}, nil
+}
+
+func newSmsSenderByName(providerName string, cfg config.Config, httpClient *http.Client, logger *slog.Logger) (SmsSender, error) {
+	smsProviderRegistryMutex.RLock()
+	factory, found := smsProviderRegistry[providerName]
+	smsProviderRegistryMutex.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("unknown sms provider %q", providerName)
+	}
+	return factory(cfg, httpClient, logger)
+}
+
+func newTwilioSmsProvider(cfg config.Config, httpClient *http.Client, logger *slog.Logger) (SmsSender, error) {
+	if !cfg.TwilioConfigured() {
+		return nil, fmt.Errorf("%w: missing Twilio credentials", ErrSMSProviderNotConfigured)
+	}
+	sender := NewTwilioSmsSender(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioFromNumber, logger, cfg)
+	sender.HTTPClient = httpClient
+	return sender, nil
+}
+
+func newVonageSmsProvider(cfg config.Config, httpClient *http.Client, logger *slog.Logger) (SmsSender, error) {
+	if cfg.VonageAPIKey == "" || cfg.VonageAPISecret == "" || cfg.VonageFromNumber == "" {
+		return nil, fmt.Errorf("%w: missing Vonage credentials", ErrSMSProviderNotConfigured)
+	}
+	return NewVonageSmsSender(VonageConfig{
+		APIKey:     cfg.VonageAPIKey,
+		APISecret:  cfg.VonageAPISecret,
+		FromNumber: cfg.VonageFromNumber,
+		BaseURL:    cfg.VonageBaseURL,
+	}, httpClient, logger), nil
+}
+
+func newMessageBirdSmsProvider(cfg config.Config, httpClient *http.Client, logger *slog.Logger) (SmsSender, error) {
+	if cfg.MessageBirdAccessKey == "" || cfg.MessageBirdOriginator == "" {
+		return nil, fmt.Errorf("%w: missing MessageBird credentials", ErrSMSProviderNotConfigured)
+	}
+	return NewMessageBirdSmsSender(MessageBirdConfig{
+		AccessKey:  cfg.MessageBirdAccessKey,
+		Originator: cfg.MessageBirdOriginator,
+		BaseURL:    cfg.MessageBirdBaseURL,
+	}, httpClient, logger), nil
+}
+
+func newSNSSmsProvider(cfg config.Config, httpClient *http.Client, logger *slog.Logger) (SmsSender, error) {
+	if cfg.SNSRegion == "" || cfg.AWSAccessKeyID == "" || cfg.AWSSecretAccessKey == "" {
+		return nil, fmt.Errorf("%w: missing SNS region or AWS credentials", ErrSMSProviderNotConfigured)
+	}
+	return NewSNSSmsSender(SNSConfig{
+		Region:          cfg.SNSRegion,
+		AccessKeyID:     cfg.AWSAccessKeyID,
+		SecretAccessKey: cfg.AWSSecretAccessKey,
+		SessionToken:    cfg.AWSSessionToken,
+		SenderID:        cfg.SNSSenderID,
+		BaseURL:         cfg.SNSBaseURL,
+	}, httpClient, logger), nil
+}
+
+func newWebhookSmsProvider(cfg config.Config, httpClient *http.Client, logger *slog.Logger) (SmsSender, error) {
+	if cfg.SMSWebhookURL == "" {
+		return nil, fmt.Errorf("%w: missing SMS webhook URL", ErrSMSProviderNotConfigured)
+	}
+	return NewWebhookSmsSender(WebhookSmsConfig{
+		URL:        cfg.SMSWebhookURL,
+		Token:      cfg.SMSWebhookToken,
+		FromNumber: cfg.SMSWebhookFromNumber,
+	}, httpClient, logger), nil
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+func TestSmsProvidersAgainstStandIn(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name              string
+		handler           func(t *testing.T, writer http.ResponseWriter, request *http.Request)
+		newSender         func(baseURL string) SmsSender
+		expectedMessageID string
+	}{
+		{
+			name: "Twilio",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				if request.URL.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+					t.Fatalf("unexpected path %s", request.URL.Path)
+				}
+				user, pass, _ := request.BasicAuth()
+				if user != "AC123" || pass != "token" {
+					t.Fatalf("unexpected basic auth %s:%s", user, pass)
+				}
+				if request.FormValue("To") != "+15550001111" || request.FormValue("Body") != "Hello" {
+					t.Fatalf("unexpected form %v", request.Form)
+				}
+				writer.WriteHeader(http.StatusCreated)
+				_, _ = writer.Write([]byte(`{"sid":"SM1"}`))
+			},
+			newSender: func(baseURL string) SmsSender {
+				return NewTwilioSmsSender("AC123", "token", "+15550000000", newDiscardLogger(), config.Config{TwilioBaseURL: baseURL})
+			},
+			expectedMessageID: "SM1",
+		},
+		{
+			name: "Vonage",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				if request.URL.Path != "/sms/json" {
+					t.Fatalf("unexpected path %s", request.URL.Path)
+				}
+				if request.FormValue("api_key") != "key" || request.FormValue("api_secret") != "secret" {
+					t.Fatalf("unexpected credentials %v", request.Form)
+				}
+				if request.FormValue("to") != "15550001111" || request.FormValue("from") != "15550000000" || request.FormValue("text") != "Hello" {
+					t.Fatalf("unexpected form %v", request.Form)
+				}
+				_, _ = writer.Write([]byte(`{"message-count":"1","messages":[{"to":"15550001111","message-id":"vonage-1","status":"0"}]}`))
+			},
+			newSender: func(baseURL string) SmsSender {
+				return NewVonageSmsSender(VonageConfig{APIKey: "key", APISecret: "secret", FromNumber: "+15550000000", BaseURL: baseURL}, http.DefaultClient, newDiscardLogger())
+			},
+			expectedMessageID: "vonage-1",
+		},
+		{
+			name: "MessageBird",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				if request.URL.Path != "/messages" {
+					t.Fatalf("unexpected path %s", request.URL.Path)
+				}
+				if request.Header.Get("Authorization") != "AccessKey live-key" {
+					t.Fatalf("unexpected authorization %q", request.Header.Get("Authorization"))
+				}
+				var payload messageBirdMessageRequest
+				if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+					t.Fatalf("decode payload: %v", err)
+				}
+				if payload.Originator != "Pinguin" || payload.Recipients[0] != "+15550001111" || payload.Body != "Hello" {
+					t.Fatalf("unexpected payload %+v", payload)
+				}
+				writer.WriteHeader(http.StatusCreated)
+				_, _ = writer.Write([]byte(`{"id":"mb-1","recipients":{"totalCount":1}}`))
+			},
+			newSender: func(baseURL string) SmsSender {
+				return NewMessageBirdSmsSender(MessageBirdConfig{AccessKey: "live-key", Originator: "Pinguin", BaseURL: baseURL}, http.DefaultClient, newDiscardLogger())
+			},
+			expectedMessageID: "mb-1",
+		},
+		{
+			name: "SNS",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				authorization := request.Header.Get("Authorization")
+				if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDTEST/") || !strings.Contains(authorization, "/us-west-2/sns/aws4_request") {
+					t.Fatalf("unexpected authorization %q", authorization)
+				}
+				if request.FormValue("Action") != "Publish" || request.FormValue("PhoneNumber") != "+15550001111" || request.FormValue("Message") != "Hello" {
+					t.Fatalf("unexpected form %v", request.Form)
+				}
+				if request.FormValue("MessageAttributes.entry.2.Value.StringValue") != "PINGUIN" {
+					t.Fatalf("expected sender ID attribute, got %v", request.Form)
+				}
+				writer.Header().Set("Content-Type", "text/xml")
+				_, _ = writer.Write([]byte(`<PublishResponse xmlns="http://sns.amazonaws.com/doc/2010-03-31/"><PublishResult><MessageId>sns-1</MessageId></PublishResult></PublishResponse>`))
+			},
+			newSender: func(baseURL string) SmsSender {
+				return NewSNSSmsSender(SNSConfig{Region: "us-west-2", AccessKeyID: "AKIDTEST", SecretAccessKey: "secret", SenderID: "PINGUIN", BaseURL: baseURL}, http.DefaultClient, newDiscardLogger())
+			},
+			expectedMessageID: "sns-1",
+		},
+		{
+			name: "Webhook",
+			handler: func(t *testing.T, writer http.ResponseWriter, request *http.Request) {
+				t.Helper()
+				if request.URL.Path != "/hooks/sms" {
+					t.Fatalf("unexpected path %s", request.URL.Path)
+				}
+				if request.Header.Get("Authorization") != "Bearer hook-token" {
+					t.Fatalf("unexpected authorization %q", request.Header.Get("Authorization"))
+				}
+				var payload webhookSmsRequest
+				if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+					t.Fatalf("decode payload: %v", err)
+				}
+				if payload.To != "+15550001111" || payload.From != "+15550000000" || payload.Message != "Hello" {
+					t.Fatalf("unexpected payload %+v", payload)
+				}
+				_, _ = writer.Write([]byte(`{"message_id":"hook-1"}`))
+			},
+			newSender: func(baseURL string) SmsSender {
+				return NewWebhookSmsSender(WebhookSmsConfig{URL: baseURL + "/hooks/sms", Token: "hook-token", FromNumber: "+15550000000"}, http.DefaultClient, newDiscardLogger())
+			},
+			expectedMessageID: "hook-1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Helper()
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if request.Method != http.MethodPost {
+					t.Fatalf("expected POST, got %s", request.Method)
+				}
+				testCase.handler(t, writer, request)
+			}))
+			defer server.Close()
+
+			result, err := testCase.newSender(server.URL).SendSms(context.Background(), "+15550001111", "Hello")
+			if err != nil {
+				t.Fatalf("SendSms returned error: %v", err)
+			}
+			if result.ProviderMessageID != testCase.expectedMessageID {
+				t.Fatalf("expected message ID %q, got %q", testCase.expectedMessageID, result.ProviderMessageID)
+			}
+			if result.Provider != strings.ToLower(testCase.name) {
+				t.Fatalf("expected provider %q, got %q", strings.ToLower(testCase.name), result.Provider)
+			}
+		})
+	}
+}
+
+func TestVonageSmsSenderReportsRejectedMessage(t *testing.T) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(`{"message-count":"1","messages":[{"status":"2","error-text":"Missing to param"}]}`))
+	}))
+	defer server.Close()
+
+	sender := NewVonageSmsSender(VonageConfig{APIKey: "key", APISecret: "secret", FromNumber: "1", BaseURL: server.URL}, http.DefaultClient, newDiscardLogger())
+	_, err := sender.SendSms(context.Background(), "+15550001111", "Hello")
+	if err == nil || !strings.Contains(err.Error(), "Missing to param") {
+		t.Fatalf("expected rejection error, got %v", err)
+	}
+}
+
+func TestNewSmsSenderUsesRegistry(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name          string
+		configuration config.Config
+		check         func(sender SmsSender) bool
+		expectedError error
+	}{
+		{
+			name:          "TwilioWithCredentials",
+			configuration: config.Config{SMSProvider: config.SMSProviderTwilio, TwilioAccountSID: "sid", TwilioAuthToken: "token", TwilioFromNumber: "+1"},
+			check:         func(sender SmsSender) bool { _, ok := sender.(*TwilioSmsSender); return ok },
+		},
+		{
+			name:          "TwilioWithoutCredentials",
+			configuration: config.Config{SMSProvider: config.SMSProviderTwilio},
+			expectedError: ErrSMSProviderNotConfigured,
+		},
+		{
+			name:          "Vonage",
+			configuration: config.Config{SMSProvider: config.SMSProviderVonage, VonageAPIKey: "k", VonageAPISecret: "s", VonageFromNumber: "1"},
+			check:         func(sender SmsSender) bool { _, ok := sender.(*VonageSmsSender); return ok },
+		},
+		{
+			name:          "MessageBird",
+			configuration: config.Config{SMSProvider: config.SMSProviderMessageBird, MessageBirdAccessKey: "k", MessageBirdOriginator: "o"},
+			check:         func(sender SmsSender) bool { _, ok := sender.(*MessageBirdSmsSender); return ok },
+		},
+		{
+			name:          "SNS",
+			configuration: config.Config{SMSProvider: config.SMSProviderSNS, SNSRegion: "us-east-1", AWSAccessKeyID: "id", AWSSecretAccessKey: "secret"},
+			check:         func(sender SmsSender) bool { _, ok := sender.(*SNSSmsSender); return ok },
+		},
+		{
+			name:          "Webhook",
+			configuration: config.Config{SMSProvider: config.SMSProviderWebhook, SMSWebhookURL: "http://gateway.local/sms"},
+			check:         func(sender SmsSender) bool { _, ok := sender.(*WebhookSmsSender); return ok },
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Helper()
+			sender, err := NewSmsSender(testCase.configuration, newDiscardLogger())
+			if testCase.expectedError != nil {
+				if !errors.Is(err, testCase.expectedError) {
+					t.Fatalf("expected %v, got %v", testCase.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSmsSender error: %v", err)
+			}
+			if !testCase.check(sender) {
+				t.Fatalf("unexpected sender type %T", sender)
+			}
+		})
+	}
+}
+
+func TestRegisterSmsProviderAddsCustomProvider(t *testing.T) {
+	t.Helper()
+
+	customSender := &stubSmsSender{}
+	RegisterSmsProvider("custom-test", func(config.Config, *http.Client, *slog.Logger) (SmsSender, error) {
+		return customSender, nil
+	})
+	t.Cleanup(func() {
+		smsProviderRegistryMutex.Lock()
+		delete(smsProviderRegistry, "custom-test")
+		smsProviderRegistryMutex.Unlock()
+	})
+
+	found := false
+	for _, name := range RegisteredSmsProviders() {
+		if name == "custom-test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected custom provider to be listed")
+	}
+
+	sender, err := NewSmsSender(config.Config{SMSProvider: "custom-test"}, newDiscardLogger())
+	if err != nil {
+		t.Fatalf("NewSmsSender error: %v", err)
+	}
+	if sender != customSender {
+		t.Fatalf("expected registered sender, got %T", sender)
+	}
+
+	if _, err := NewSmsSender(config.Config{SMSProvider: "missing"}, newDiscardLogger()); err == nil {
+		t.Fatalf("expected error for unknown provider")
+	}
+}
+
+func TestNewNotificationServiceSelectsConfiguredSmsProvider(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	configuration := config.Config{
+		SMTPHost:             "smtp.example.com",
+		SMTPPort:             587,
+		SMSProvider:          config.SMSProviderWebhook,
+		SMSWebhookURL:        "http://gateway.local/sms",
+		ConnectionTimeoutSec: 5,
+	}
+	serviceInstance := NewNotificationService(database, newDiscardLogger(), configuration, nil).(*notificationServiceImpl)
+	if !serviceInstance.smsEnabled {
+		t.Fatalf("expected SMS to be enabled")
+	}
+	if _, ok := serviceInstance.smsSender.(*WebhookSmsSender); !ok {
+		t.Fatalf("expected WebhookSmsSender, got %T", serviceInstance.smsSender)
+	}
+
+	disabled := NewNotificationService(database, newDiscardLogger(), config.Config{SMSProvider: config.SMSProviderTwilio}, nil).(*notificationServiceImpl)
+	if disabled.smsEnabled || disabled.smsSender != nil {
+		t.Fatalf("expected SMS to be disabled without Twilio credentials")
+	}
+}
@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,39 +16,78 @@ import (
 	"log/slog"
 )
 
+var (
+	// ErrInvalidRecipient reports that the provider rejected the destination number itself.
+	ErrInvalidRecipient = errors.New("invalid sms recipient")
+	// ErrRecipientUnsubscribed reports that the recipient opted out of messages from the sender.
+	ErrRecipientUnsubscribed = errors.New("sms recipient unsubscribed")
+)
+
+// SmsDeliveryResult describes an SMS accepted by a provider.
+type SmsDeliveryResult struct {
+	ProviderMessageID string
+	// Provider names the backend that accepted the message, e.g. "twilio".
+	Provider string
+	// ProviderStatus is the provider's own status for the message at acceptance, e.g. "queued".
+	ProviderStatus string
+	SegmentCount   int
+	// Price and PriceUnit are reported verbatim; Twilio usually leaves them empty until the
+	// message has been sent to the carrier.
+	Price     string
+	PriceUnit string
+}
+
 type SmsSender interface {
-	SendSms(ctx context.Context, recipient string, message string) (string, error)
+	SendSms(ctx context.Context, recipient string, message string) (SmsDeliveryResult, error)
 }
 
+const defaultTwilioBaseURL = "https://api.twilio.com"
+
+// TwilioSmsSender delivers SMS through the Twilio Messages API. An empty BaseURL targets the
+// public Twilio API.
 type TwilioSmsSender struct {
 	AccountSID string
 	AuthToken  string
 	FromNumber string
-	HTTPClient *http.Client
-	Logger     *slog.Logger
+	BaseURL    string
+	// StatusCallbackURL, when set, asks Twilio to report delivery status changes to this URL.
+	StatusCallbackURL string
+	HTTPClient        *http.Client
+	Logger            *slog.Logger
 }
 
+// TwilioStatusCallbackPath is where the HTTP server receives Twilio message status callbacks.
+const TwilioStatusCallbackPath = "/webhooks/twilio/status"
+
 func NewTwilioSmsSender(accountSID string, authToken string, fromNumber string, logger *slog.Logger, cfg config.Config) *TwilioSmsSender {
-	return &TwilioSmsSender{
+	sender := &TwilioSmsSender{
 		AccountSID: accountSID,
 		AuthToken:  authToken,
 		FromNumber: fromNumber,
+		BaseURL:    resolveBaseURL(cfg.TwilioBaseURL, defaultTwilioBaseURL),
 		HTTPClient: &http.Client{Timeout: time.Duration(cfg.ConnectionTimeoutSec) * time.Second},
 		Logger:     logger,
 	}
+	if cfg.PublicBaseURL != "" {
+		sender.StatusCallbackURL = cfg.PublicBaseURL + TwilioStatusCallbackPath
+	}
+	return sender
 }
 
-func (senderInstance *TwilioSmsSender) SendSms(ctx context.Context, recipient string, message string) (string, error) {
+func (senderInstance *TwilioSmsSender) SendSms(ctx context.Context, recipient string, message string) (SmsDeliveryResult, error) {
 	formData := url.Values{}
 	formData.Set("To", recipient)
 	formData.Set("From", senderInstance.FromNumber)
 	formData.Set("Body", message)
+	if senderInstance.StatusCallbackURL != "" {
+		formData.Set("StatusCallback", senderInstance.StatusCallbackURL)
+	}
 
-	apiEndpoint := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", senderInstance.AccountSID)
+	apiEndpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", resolveBaseURL(senderInstance.BaseURL, defaultTwilioBaseURL), senderInstance.AccountSID)
 	requestInstance, requestError := http.NewRequestWithContext(ctx, http.MethodPost, apiEndpoint, strings.NewReader(formData.Encode()))
 	if requestError != nil {
 		senderInstance.Logger.Error("Failed to create Twilio request", "error", requestError)
-		return "", requestError
+		return SmsDeliveryResult{}, requestError
 	}
 	requestInstance.SetBasicAuth(senderInstance.AccountSID, senderInstance.AuthToken)
 	requestInstance.Header.Add("Content-Type", "application/x-www-form-urlencoded")
@@ -53,15 +95,94 @@ func (senderInstance *TwilioSmsSender) SendSms(ctx context.Context, recipient st
 	responseInstance, responseError := senderInstance.HTTPClient.Do(requestInstance)
 	if responseError != nil {
 		senderInstance.Logger.Error("Twilio request error", "error", responseError)
-		return "", responseError
+		return SmsDeliveryResult{}, responseError
 	}
 	defer responseInstance.Body.Close()
 
 	responseBody, _ := io.ReadAll(responseInstance.Body)
 	if responseInstance.StatusCode >= 300 {
 		senderInstance.Logger.Error("Twilio API returned error", "status", responseInstance.StatusCode, "body", string(responseBody))
-		return "", fmt.Errorf("twilio API error: %s", string(responseBody))
+		return SmsDeliveryResult{}, newTwilioError(responseInstance.StatusCode, responseBody)
 	}
 
-	return string(responseBody), nil
+	var decoded twilioMessageResponse
+	if err := json.Unmarshal(responseBody, &decoded); err != nil {
+		logUnreadableAcceptance(senderInstance.Logger, config.SMSProviderTwilio, err)
+	} else if decoded.Sid == "" {
+		logUnreadableAcceptance(senderInstance.Logger, config.SMSProviderTwilio, errors.New("response contained no message sid"))
+	}
+	segmentCount, _ := strconv.Atoi(decoded.NumSegments)
+	return SmsDeliveryResult{
+		ProviderMessageID: decoded.Sid,
+		Provider:          config.SMSProviderTwilio,
+		ProviderStatus:    decoded.Status,
+		SegmentCount:      segmentCount,
+		Price:             decoded.Price,
+		PriceUnit:         decoded.PriceUnit,
+	}, nil
+}
+
+type twilioMessageResponse struct {
+	Sid         string `json:"sid"`
+	Status      string `json:"status"`
+	NumSegments string `json:"num_segments"`
+	Price       string `json:"price"`
+	PriceUnit   string `json:"price_unit"`
+}
+
+type twilioErrorResponse struct {
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	MoreInfo string `json:"more_info"`
+}
+
+// twilioRecipientErrors maps Twilio error codes that describe the destination rather than the
+// request to the typed errors callers can match with errors.Is.
+var twilioRecipientErrors = map[int]error{
+	21211: ErrInvalidRecipient,
+	21614: ErrInvalidRecipient,
+	21610: ErrRecipientUnsubscribed,
+}
+
+// TwilioError is a non-2xx Twilio API response. It unwraps to the underlying ProviderHTTPError and,
+// for recipient errors such as 21211 or 21610, to ErrInvalidRecipient or ErrRecipientUnsubscribed.
+type TwilioError struct {
+	Code      int
+	Message   string
+	MoreInfo  string
+	HTTPError *ProviderHTTPError
+}
+
+func newTwilioError(statusCode int, responseBody []byte) *TwilioError {
+	twilioError := &TwilioError{
+		HTTPError: &ProviderHTTPError{Provider: config.SMSProviderTwilio, StatusCode: statusCode, Body: string(responseBody)},
+	}
+	var decoded twilioErrorResponse
+	if json.Unmarshal(responseBody, &decoded) == nil {
+		twilioError.Code = decoded.Code
+		twilioError.Message = decoded.Message
+		twilioError.MoreInfo = decoded.MoreInfo
+	}
+	return twilioError
+}
+
+func (twilioError *TwilioError) Error() string {
+	if twilioError.Code == 0 {
+		return twilioError.HTTPError.Error()
+	}
+	return fmt.Sprintf("twilio API error %d: %s", twilioError.Code, twilioError.Message)
+}
+
+func (twilioError *TwilioError) Unwrap() []error {
+	wrapped := []error{twilioError.HTTPError}
+	if recipientErr, found := twilioRecipientErrors[twilioError.Code]; found {
+		wrapped = append(wrapped, recipientErr)
+	}
+	return wrapped
+}
+
+// isPermanentSmsError reports whether err describes a recipient that will never accept the
+// message, so retrying would only repeat the failure.
+func isPermanentSmsError(err error) bool {
+	return errors.Is(err, ErrInvalidRecipient) || errors.Is(err, ErrRecipientUnsubscribed)
 }
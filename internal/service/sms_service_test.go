@@ -3,8 +3,11 @@ package service
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 
 	"log/slog"
@@ -33,27 +36,31 @@ func TestTwilioSmsSenderSuccess(t *testing.T) {
 			user, pass, _ := req.BasicAuth()
 			captured.auth = user + ":" + pass
 			return &http.Response{
-				StatusCode: 200,
-				Body:       io.NopCloser(bytes.NewBufferString("ok")),
+				StatusCode: 201,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"sid":"SM0123456789abcdef","status":"queued","num_segments":"2","price":null,"price_unit":"USD"}`)),
 				Header:     make(http.Header),
 			}, nil
 		}),
 	}
 
 	sender := &TwilioSmsSender{
-		AccountSID: "sid",
-		AuthToken:  "token",
-		FromNumber: "+1000",
-		HTTPClient: client,
-		Logger:     newDiscardLogger(),
+		AccountSID:        "sid",
+		AuthToken:         "token",
+		FromNumber:        "+1000",
+		StatusCallbackURL: "https://notify.example.com/webhooks/twilio/status",
+		HTTPClient:        client,
+		Logger:            newDiscardLogger(),
 	}
 
 	resp, err := sender.SendSms(context.Background(), "+1222", "Hello")
 	if err != nil {
 		t.Fatalf("SendSms returned error: %v", err)
 	}
-	if resp != "ok" {
-		t.Fatalf("unexpected response %q", resp)
+	if resp.ProviderMessageID != "SM0123456789abcdef" || resp.Provider != "twilio" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if resp.ProviderStatus != "queued" || resp.SegmentCount != 2 || resp.Price != "" || resp.PriceUnit != "USD" {
+		t.Fatalf("unexpected delivery details %+v", resp)
 	}
 	if captured.method != http.MethodPost {
 		t.Fatalf("expected POST, got %s", captured.method)
@@ -64,6 +71,32 @@ func TestTwilioSmsSenderSuccess(t *testing.T) {
 	if captured.body == "" {
 		t.Fatalf("expected body to be populated")
 	}
+	if !strings.Contains(captured.body, "StatusCallback="+url.QueryEscape(sender.StatusCallbackURL)) {
+		t.Fatalf("expected status callback in body %s", captured.body)
+	}
+}
+
+func TestTwilioSmsSenderAcceptsUnreadableSuccessResponse(t *testing.T) {
+	t.Helper()
+	for _, body := range []string{`not json`, `{"status":"queued"}`} {
+		client := &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 201,
+					Body:       io.NopCloser(bytes.NewBufferString(body)),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		}
+		sender := &TwilioSmsSender{AccountSID: "sid", AuthToken: "token", FromNumber: "+1000", HTTPClient: client, Logger: newDiscardLogger()}
+		resp, err := sender.SendSms(context.Background(), "+1222", "Hello")
+		if err != nil {
+			t.Fatalf("body %q: expected an accepted message to be reported as sent, got %v", body, err)
+		}
+		if resp.ProviderMessageID != "" || resp.Provider != "twilio" {
+			t.Fatalf("body %q: unexpected response %+v", body, resp)
+		}
+	}
 }
 
 func TestTwilioSmsSenderErrorStatus(t *testing.T) {
@@ -88,3 +121,77 @@ func TestTwilioSmsSenderErrorStatus(t *testing.T) {
 		t.Fatalf("expected error for non-2xx response")
 	}
 }
+
+func TestTwilioSmsSenderMapsErrorCodes(t *testing.T) {
+	t.Helper()
+	testCases := []struct {
+		name            string
+		statusCode      int
+		body            string
+		expectedErr     error
+		expectPermanent bool
+		expectFailover  bool
+	}{
+		{
+			name:            "InvalidNumber",
+			statusCode:      400,
+			body:            `{"code":21211,"message":"The 'To' number +1222 is not a valid phone number.","more_info":"https://www.twilio.com/docs/errors/21211","status":400}`,
+			expectedErr:     ErrInvalidRecipient,
+			expectPermanent: true,
+		},
+		{
+			name:            "Unsubscribed",
+			statusCode:      400,
+			body:            `{"code":21610,"message":"Attempt to send to unsubscribed recipient","status":400}`,
+			expectedErr:     ErrRecipientUnsubscribed,
+			expectPermanent: true,
+		},
+		{
+			name:       "OtherClientError",
+			statusCode: 400,
+			body:       `{"code":21602,"message":"Message body is required.","status":400}`,
+		},
+		{
+			name:           "CredentialsRefused",
+			statusCode:     401,
+			body:           `{"code":20003,"message":"Authenticate","status":401}`,
+			expectFailover: true,
+		},
+		{
+			name:           "ServerError",
+			statusCode:     503,
+			body:           "upstream unavailable",
+			expectFailover: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: testCase.statusCode,
+						Body:       io.NopCloser(bytes.NewBufferString(testCase.body)),
+						Header:     make(http.Header),
+					}, nil
+				}),
+			}
+			sender := &TwilioSmsSender{AccountSID: "sid", AuthToken: "token", FromNumber: "+1000", HTTPClient: client, Logger: newDiscardLogger()}
+
+			_, err := sender.SendSms(context.Background(), "+1222", "Hello")
+			var twilioError *TwilioError
+			if !errors.As(err, &twilioError) {
+				t.Fatalf("expected TwilioError, got %v", err)
+			}
+			if testCase.expectedErr != nil && !errors.Is(err, testCase.expectedErr) {
+				t.Fatalf("expected %v, got %v", testCase.expectedErr, err)
+			}
+			if isPermanentSmsError(err) != testCase.expectPermanent {
+				t.Fatalf("expected permanent=%v for %v", testCase.expectPermanent, err)
+			}
+			if shouldFailover(err) != testCase.expectFailover {
+				t.Fatalf("expected failover=%v for %v", testCase.expectFailover, err)
+			}
+		})
+	}
+}
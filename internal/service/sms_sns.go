@@ -0,0 +1,84 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+// SNSConfig configures direct-to-phone SMS publishing through Amazon SNS.
+type SNSConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	SenderID        string
+	BaseURL         string
+}
+
+// SNSSmsSender publishes SMS messages through the Amazon SNS Publish action.
+type SNSSmsSender struct {
+	Config     SNSConfig
+	HTTPClient *http.Client
+	Logger     *slog.Logger
+	now        func() time.Time
+}
+
+func NewSNSSmsSender(configuration SNSConfig, httpClient *http.Client, logger *slog.Logger) *SNSSmsSender {
+	configuration.BaseURL = resolveBaseURL(configuration.BaseURL, fmt.Sprintf("https://sns.%s.amazonaws.com", configuration.Region))
+	return &SNSSmsSender{Config: configuration, HTTPClient: httpClient, Logger: logger, now: time.Now}
+}
+
+type snsPublishResponse struct {
+	MessageID string `xml:"PublishResult>MessageId"`
+}
+
+func (senderInstance *SNSSmsSender) SendSms(ctx context.Context, recipient string, message string) (SmsDeliveryResult, error) {
+	formData := url.Values{}
+	formData.Set("Action", "Publish")
+	formData.Set("Version", "2010-03-31")
+	formData.Set("PhoneNumber", recipient)
+	formData.Set("Message", message)
+	formData.Set("MessageAttributes.entry.1.Name", "AWS.SNS.SMS.SMSType")
+	formData.Set("MessageAttributes.entry.1.Value.DataType", "String")
+	formData.Set("MessageAttributes.entry.1.Value.StringValue", "Transactional")
+	if senderInstance.Config.SenderID != "" {
+		formData.Set("MessageAttributes.entry.2.Name", "AWS.SNS.SMS.SenderID")
+		formData.Set("MessageAttributes.entry.2.Value.DataType", "String")
+		formData.Set("MessageAttributes.entry.2.Value.StringValue", senderInstance.Config.SenderID)
+	}
+	body := []byte(formData.Encode())
+
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, senderInstance.Config.BaseURL+"/", bytes.NewReader(body))
+	if requestErr != nil {
+		return SmsDeliveryResult{}, requestErr
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signAWSRequestV4(request, body, awsCredentials{
+		AccessKeyID:     senderInstance.Config.AccessKeyID,
+		SecretAccessKey: senderInstance.Config.SecretAccessKey,
+		SessionToken:    senderInstance.Config.SessionToken,
+	}, senderInstance.Config.Region, "sns", senderInstance.now())
+
+	response, responseErr := senderInstance.HTTPClient.Do(request)
+	if responseErr != nil {
+		senderInstance.Logger.Error("SNS request error", "error", responseErr)
+		return SmsDeliveryResult{}, responseErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return SmsDeliveryResult{}, providerResponseError(config.SMSProviderSNS, response)
+	}
+	var decoded snsPublishResponse
+	if err := xml.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		logUnreadableAcceptance(senderInstance.Logger, config.SMSProviderSNS, err)
+	}
+	return SmsDeliveryResult{ProviderMessageID: decoded.MessageID, Provider: config.SMSProviderSNS}, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+const defaultVonageBaseURL = "https://rest.nexmo.com"
+
+// VonageConfig configures delivery through the Vonage (formerly Nexmo) SMS API.
+type VonageConfig struct {
+	APIKey     string
+	APISecret  string
+	FromNumber string
+	BaseURL    string
+}
+
+// VonageSmsSender delivers SMS through the Vonage SMS API.
+type VonageSmsSender struct {
+	Config     VonageConfig
+	HTTPClient *http.Client
+	Logger     *slog.Logger
+}
+
+func NewVonageSmsSender(configuration VonageConfig, httpClient *http.Client, logger *slog.Logger) *VonageSmsSender {
+	configuration.BaseURL = resolveBaseURL(configuration.BaseURL, defaultVonageBaseURL)
+	return &VonageSmsSender{Config: configuration, HTTPClient: httpClient, Logger: logger}
+}
+
+type vonageSendResponse struct {
+	Messages []struct {
+		MessageID string `json:"message-id"`
+		Status    string `json:"status"`
+		ErrorText string `json:"error-text"`
+	} `json:"messages"`
+}
+
+func (senderInstance *VonageSmsSender) SendSms(ctx context.Context, recipient string, message string) (SmsDeliveryResult, error) {
+	formData := url.Values{}
+	formData.Set("api_key", senderInstance.Config.APIKey)
+	formData.Set("api_secret", senderInstance.Config.APISecret)
+	formData.Set("from", strings.TrimPrefix(senderInstance.Config.FromNumber, "+"))
+	formData.Set("to", strings.TrimPrefix(recipient, "+"))
+	formData.Set("text", message)
+
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, senderInstance.Config.BaseURL+"/sms/json", strings.NewReader(formData.Encode()))
+	if requestErr != nil {
+		return SmsDeliveryResult{}, requestErr
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, responseErr := senderInstance.HTTPClient.Do(request)
+	if responseErr != nil {
+		senderInstance.Logger.Error("Vonage request error", "error", responseErr)
+		return SmsDeliveryResult{}, responseErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return SmsDeliveryResult{}, providerResponseError(config.SMSProviderVonage, response)
+	}
+
+	// Vonage answers 200 even for rejected messages and reports the outcome per message part.
+	var decoded vonageSendResponse
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		logUnreadableAcceptance(senderInstance.Logger, config.SMSProviderVonage, err)
+		return SmsDeliveryResult{Provider: config.SMSProviderVonage}, nil
+	}
+	if len(decoded.Messages) == 0 {
+		logUnreadableAcceptance(senderInstance.Logger, config.SMSProviderVonage, errors.New("response contained no messages"))
+		return SmsDeliveryResult{Provider: config.SMSProviderVonage}, nil
+	}
+	for _, part := range decoded.Messages {
+		if part.Status != "0" {
+			return SmsDeliveryResult{}, fmt.Errorf("vonage API error: status %s: %s", part.Status, part.ErrorText)
+		}
+	}
+	return SmsDeliveryResult{ProviderMessageID: decoded.Messages[0].MessageID, Provider: config.SMSProviderVonage}, nil
+}
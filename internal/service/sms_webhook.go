@@ -0,0 +1,82 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/temirov/pinguin/internal/config"
+	"log/slog"
+)
+
+// WebhookSmsConfig configures the generic webhook SMS provider, which hands each message to an
+// operator-supplied HTTP endpoint such as an internal gateway.
+type WebhookSmsConfig struct {
+	URL        string
+	Token      string
+	FromNumber string
+}
+
+// WebhookSmsSender POSTs {"to","from","message"} as JSON to the configured URL and expects a 2xx
+// response whose JSON body carries the gateway's identifier in "message_id" (or "id").
+type WebhookSmsSender struct {
+	Config     WebhookSmsConfig
+	HTTPClient *http.Client
+	Logger     *slog.Logger
+}
+
+func NewWebhookSmsSender(configuration WebhookSmsConfig, httpClient *http.Client, logger *slog.Logger) *WebhookSmsSender {
+	return &WebhookSmsSender{Config: configuration, HTTPClient: httpClient, Logger: logger}
+}
+
+type webhookSmsRequest struct {
+	To      string `json:"to"`
+	From    string `json:"from,omitempty"`
+	Message string `json:"message"`
+}
+
+type webhookSmsResponse struct {
+	MessageID string `json:"message_id"`
+	ID        string `json:"id"`
+}
+
+func (senderInstance *WebhookSmsSender) SendSms(ctx context.Context, recipient string, message string) (SmsDeliveryResult, error) {
+	body, marshalErr := json.Marshal(webhookSmsRequest{
+		To:      recipient,
+		From:    senderInstance.Config.FromNumber,
+		Message: message,
+	})
+	if marshalErr != nil {
+		return SmsDeliveryResult{}, fmt.Errorf("encode webhook sms request: %w", marshalErr)
+	}
+
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, senderInstance.Config.URL, bytes.NewReader(body))
+	if requestErr != nil {
+		return SmsDeliveryResult{}, requestErr
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if senderInstance.Config.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+senderInstance.Config.Token)
+	}
+
+	response, responseErr := senderInstance.HTTPClient.Do(request)
+	if responseErr != nil {
+		senderInstance.Logger.Error("SMS webhook request error", "error", responseErr)
+		return SmsDeliveryResult{}, responseErr
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return SmsDeliveryResult{}, providerResponseError(config.SMSProviderWebhook, response)
+	}
+	var decoded webhookSmsResponse
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		logUnreadableAcceptance(senderInstance.Logger, config.SMSProviderWebhook, err)
+	}
+	providerMessageID := decoded.MessageID
+	if providerMessageID == "" {
+		providerMessageID = decoded.ID
+	}
+	return SmsDeliveryResult{ProviderMessageID: providerMessageID, Provider: config.SMSProviderWebhook}, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// SuppressionService manages the recipients notifications must not be delivered to.
+type SuppressionService interface {
+	// ListSuppressions returns suppressions matching the filters; expired entries are omitted unless requested.
+	ListSuppressions(ctx context.Context, filters model.SuppressionListFilters) ([]model.Suppression, error)
+	// AddSuppression suppresses a recipient, replacing the reason, source and expiry of an existing entry.
+	AddSuppression(ctx context.Context, request model.SuppressionRequest) (model.Suppression, error)
+	// RemoveSuppression lifts the suppression for a recipient; an empty category removes the
+	// suppression that covers every category.
+	RemoveSuppression(ctx context.Context, channel model.NotificationType, recipient string, category string) error
+}
+
+var (
+	ErrInvalidSuppression = errors.New("invalid suppression")
+	// ErrRecipientSuppressed is matched by every *SuppressionError.
+	ErrRecipientSuppressed = errors.New("recipient suppressed")
+)
+
+// SuppressionError reports that a notification was not sent because its recipients are suppressed.
+type SuppressionError struct {
+	Channel    model.NotificationType
+	Recipients []string
+	Reason     model.SuppressionReason
+}
+
+func (suppressionError *SuppressionError) Error() string {
+	return fmt.Sprintf("%s: %s %s (%s)", ErrRecipientSuppressed, suppressionError.Channel, strings.Join(suppressionError.Recipients, ", "), suppressionError.Reason)
+}
+
+func (suppressionError *SuppressionError) Unwrap() error {
+	return ErrRecipientSuppressed
+}
+
+const defaultSuppressionSource = "api"
+
+type suppressionServiceImpl struct {
+	database *gorm.DB
+	logger   *slog.Logger
+}
+
+// NewSuppressionService creates a SuppressionService backed by the suppressions table.
+func NewSuppressionService(database *gorm.DB, logger *slog.Logger) SuppressionService {
+	return &suppressionServiceImpl{database: database, logger: logger}
+}
+
+func (serviceInstance *suppressionServiceImpl) ListSuppressions(ctx context.Context, filters model.SuppressionListFilters) ([]model.Suppression, error) {
+	return model.ListSuppressions(ctx, serviceInstance.database, filters, time.Now().UTC())
+}
+
+func (serviceInstance *suppressionServiceImpl) AddSuppression(ctx context.Context, request model.SuppressionRequest) (model.Suppression, error) {
+	suppression, validationErr := validateSuppressionRequest(request, time.Now().UTC())
+	if validationErr != nil {
+		return model.Suppression{}, validationErr
+	}
+	if err := model.UpsertSuppression(ctx, serviceInstance.database, &suppression); err != nil {
+		return model.Suppression{}, err
+	}
+	stored, err := model.GetSuppression(ctx, serviceInstance.database, suppression.Channel, suppression.Recipient, suppression.Category)
+	if err != nil {
+		return model.Suppression{}, err
+	}
+	serviceInstance.logger.Info(
+		"suppression_added",
+		"channel", stored.Channel,
+		"category", stored.Category,
+		"reason", stored.Reason,
+		"source", stored.Source,
+	)
+	return *stored, nil
+}
+
+func (serviceInstance *suppressionServiceImpl) RemoveSuppression(ctx context.Context, channel model.NotificationType, recipient string, category string) error {
+	if err := validateSuppressionChannel(channel); err != nil {
+		return err
+	}
+	if strings.TrimSpace(recipient) == "" {
+		return fmt.Errorf("%w: recipient is required", ErrInvalidSuppression)
+	}
+	if err := model.DeleteSuppression(ctx, serviceInstance.database, channel, recipient, model.NormalizeCategory(category)); err != nil {
+		return err
+	}
+	serviceInstance.logger.Info("suppression_removed", "channel", channel, "category", model.NormalizeCategory(category))
+	return nil
+}
+
+func validateSuppressionRequest(request model.SuppressionRequest, now time.Time) (model.Suppression, error) {
+	channel := model.NotificationType(strings.ToLower(strings.TrimSpace(string(request.Channel))))
+	if err := validateSuppressionChannel(channel); err != nil {
+		return model.Suppression{}, err
+	}
+	recipient := model.NormalizeSuppressionRecipient(channel, request.Recipient)
+	if recipient == "" {
+		return model.Suppression{}, fmt.Errorf("%w: recipient is required", ErrInvalidSuppression)
+	}
+	if channel == model.NotificationEmail && !strings.Contains(recipient, "@") {
+		return model.Suppression{}, fmt.Errorf("%w: recipient must be an email address", ErrInvalidSuppression)
+	}
+	reason := model.SuppressionReason(strings.ToLower(strings.TrimSpace(string(request.Reason))))
+	if reason == "" {
+		reason = model.SuppressionManual
+	}
+	if !reason.IsValid() {
+		return model.Suppression{}, fmt.Errorf("%w: unsupported reason %q", ErrInvalidSuppression, request.Reason)
+	}
+	source := strings.TrimSpace(request.Source)
+	if source == "" {
+		source = defaultSuppressionSource
+	}
+	var expiresAt *time.Time
+	if request.ExpiresAt != nil {
+		if !request.ExpiresAt.After(now) {
+			return model.Suppression{}, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidSuppression)
+		}
+		normalizedExpiry := request.ExpiresAt.UTC()
+		expiresAt = &normalizedExpiry
+	}
+	return model.Suppression{
+		Channel:   channel,
+		Recipient: recipient,
+		Category:  model.NormalizeCategory(request.Category),
+		Reason:    reason,
+		Source:    source,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func validateSuppressionChannel(channel model.NotificationType) error {
+	switch channel {
+	case model.NotificationEmail, model.NotificationSMS:
+		return nil
+	default:
+		return fmt.Errorf("%w: channel must be email or sms", ErrInvalidSuppression)
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/pkg/scheduler"
+)
+
+func TestAddSuppressionValidatesRequest(t *testing.T) {
+	t.Helper()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name           string
+		request        model.SuppressionRequest
+		expectInvalid  bool
+		expectedReason model.SuppressionReason
+		expectedSource string
+	}{
+		{name: "DefaultsReasonAndSource", request: model.SuppressionRequest{Channel: model.NotificationEmail, Recipient: "User@Example.com"}, expectedReason: model.SuppressionManual, expectedSource: "api"},
+		{name: "KeepsExplicitValues", request: model.SuppressionRequest{Channel: "SMS", Recipient: "+1 555 000 1111", Reason: "stop", Source: "twilio", ExpiresAt: &future}, expectedReason: model.SuppressionStop, expectedSource: "twilio"},
+		{name: "RejectsUnknownChannel", request: model.SuppressionRequest{Channel: "fax", Recipient: "123"}, expectInvalid: true},
+		{name: "RejectsMissingRecipient", request: model.SuppressionRequest{Channel: model.NotificationEmail, Recipient: "  "}, expectInvalid: true},
+		{name: "RejectsMalformedEmail", request: model.SuppressionRequest{Channel: model.NotificationEmail, Recipient: "user"}, expectInvalid: true},
+		{name: "RejectsUnknownReason", request: model.SuppressionRequest{Channel: model.NotificationEmail, Recipient: "user@example.com", Reason: "because"}, expectInvalid: true},
+		{name: "RejectsPastExpiry", request: model.SuppressionRequest{Channel: model.NotificationEmail, Recipient: "user@example.com", ExpiresAt: &past}, expectInvalid: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			serviceInstance := NewSuppressionService(openIsolatedDatabase(t), newDiscardLogger())
+			suppression, err := serviceInstance.AddSuppression(context.Background(), testCase.request)
+			if testCase.expectInvalid {
+				if !errors.Is(err, ErrInvalidSuppression) {
+					t.Fatalf("expected ErrInvalidSuppression, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("add suppression: %v", err)
+			}
+			if suppression.Reason != testCase.expectedReason || suppression.Source != testCase.expectedSource {
+				t.Fatalf("unexpected suppression %+v", suppression)
+			}
+		})
+	}
+}
+
+func TestSendNotificationRejectsSuppressedRecipients(t *testing.T) {
+	t.Helper()
+	testCases := []struct {
+		name             string
+		request          model.NotificationRequest
+		suppressions     []model.SuppressionRequest
+		expectSuppressed bool
+		expectedStatuses map[string]model.RecipientStatus
+	}{
+		{
+			name: "SkipsSuppressedCc",
+			request: model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				To:               []string{"to@example.com"},
+				Cc:               []string{"cc@example.com"},
+				Subject:          "Digest",
+				Message:          "Body",
+			},
+			suppressions: []model.SuppressionRequest{{Channel: model.NotificationEmail, Recipient: "CC@example.com", Reason: model.SuppressionUnsubscribed}},
+			expectedStatuses: map[string]model.RecipientStatus{
+				"to@example.com": model.RecipientAccepted,
+				"cc@example.com": model.RecipientSkipped,
+			},
+		},
+		{
+			name: "AllEmailRecipientsSuppressed",
+			request: model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				To:               []string{"to@example.com"},
+				Subject:          "Digest",
+				Message:          "Body",
+			},
+			suppressions:     []model.SuppressionRequest{{Channel: model.NotificationEmail, Recipient: "to@example.com"}},
+			expectSuppressed: true,
+			expectedStatuses: map[string]model.RecipientStatus{"to@example.com": model.RecipientSkipped},
+		},
+		{
+			name:             "SmsRecipientSuppressed",
+			request:          model.NotificationRequest{NotificationType: model.NotificationSMS, Recipient: "+15550001111", Message: "Body"},
+			suppressions:     []model.SuppressionRequest{{Channel: model.NotificationSMS, Recipient: "+1 555 000 1111", Reason: model.SuppressionStop}},
+			expectSuppressed: true,
+		},
+		{
+			name:         "OtherChannelIgnored",
+			request:      model.NotificationRequest{NotificationType: model.NotificationSMS, Recipient: "+15550001111", Message: "Body"},
+			suppressions: []model.SuppressionRequest{{Channel: model.NotificationEmail, Recipient: "user@example.com"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database := openIsolatedDatabase(t)
+			suppressionService := NewSuppressionService(database, newDiscardLogger())
+			for _, suppression := range testCase.suppressions {
+				if _, err := suppressionService.AddSuppression(context.Background(), suppression); err != nil {
+					t.Fatalf("seed suppression: %v", err)
+				}
+			}
+			emailSender := &stubEmailSender{}
+			smsSender := &testSmsSender{response: "sid-1"}
+			serviceInstance := &notificationServiceImpl{
+				database:         database,
+				logger:           newDiscardLogger(),
+				emailSender:      emailSender,
+				smsSender:        smsSender,
+				maxRetries:       3,
+				retryIntervalSec: 1,
+				smsEnabled:       true,
+			}
+
+			response, err := serviceInstance.SendNotification(context.Background(), testCase.request)
+			dispatched := len(emailSender.receivedMessages) > 0 || smsSender.called
+			if testCase.expectSuppressed {
+				var suppressionErr *SuppressionError
+				if !errors.As(err, &suppressionErr) || !errors.Is(err, ErrRecipientSuppressed) {
+					t.Fatalf("expected SuppressionError, got %v", err)
+				}
+				if dispatched {
+					t.Fatalf("expected no dispatch for suppressed notification")
+				}
+				if response.Status != model.StatusSuppressed {
+					t.Fatalf("expected suppressed status, got %s", response.Status)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("send error: %v", err)
+				}
+				if !dispatched {
+					t.Fatalf("expected dispatch")
+				}
+			}
+
+			stored, fetchErr := model.GetNotificationByID(context.Background(), database, response.NotificationID)
+			if fetchErr != nil {
+				t.Fatalf("fetch error: %v", fetchErr)
+			}
+			for _, recipient := range stored.Recipients {
+				if recipient.Status != testCase.expectedStatuses[recipient.Address] {
+					t.Fatalf("recipient %s: expected %s, got %s", recipient.Address, testCase.expectedStatuses[recipient.Address], recipient.Status)
+				}
+			}
+			if testCase.expectSuppressed {
+				if pending := claimDueNotifications(t, database, serviceInstance.maxRetries); len(pending) != 0 {
+					t.Fatalf("expected suppressed notification to stay out of the retry queue, got %d", len(pending))
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationDispatcherStopsOnSuppressedRecipient(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	if _, err := NewSuppressionService(database, newDiscardLogger()).AddSuppression(context.Background(), model.SuppressionRequest{
+		Channel:   model.NotificationSMS,
+		Recipient: "+15550001111",
+		Reason:    model.SuppressionStop,
+	}); err != nil {
+		t.Fatalf("seed suppression: %v", err)
+	}
+	sender := &testSmsSender{response: "sid-1"}
+	dispatcher := newNotificationDispatcher(&notificationServiceImpl{
+		database:   database,
+		logger:     newDiscardLogger(),
+		smsSender:  sender,
+		smsEnabled: true,
+	})
+
+	result, err := dispatcher.Attempt(context.Background(), scheduler.Job{Payload: &model.Notification{
+		NotificationType: model.NotificationSMS,
+		Recipient:        "+15550001111",
+		Message:          "Body",
+	}})
+	if !errors.Is(err, ErrRecipientSuppressed) || !scheduler.IsPermanent(err) {
+		t.Fatalf("expected permanent suppression error, got %v", err)
+	}
+	if result.Status != string(model.StatusSuppressed) {
+		t.Fatalf("expected suppressed status, got %q", result.Status)
+	}
+	if sender.called {
+		t.Fatalf("expected sms sender not to be invoked")
+	}
+}
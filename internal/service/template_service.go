@@ -0,0 +1,254 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/temirov/pinguin/internal/model"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// TemplateService manages the named, versioned message templates used by SendNotification.
+type TemplateService interface {
+	// CreateTemplate stores version 1 of a new template.
+	CreateTemplate(ctx context.Context, request model.TemplateRequest) (model.Template, error)
+	// UpdateTemplate stores the request as the next version of an existing template.
+	UpdateTemplate(ctx context.Context, request model.TemplateRequest) (model.Template, error)
+	// GetTemplate returns a specific template version, or the latest one when version is zero.
+	GetTemplate(ctx context.Context, templateID string, version int) (model.Template, error)
+	// ListTemplates returns the latest version of every template.
+	ListTemplates(ctx context.Context) ([]model.Template, error)
+	// DeleteTemplate removes every version of a template.
+	DeleteTemplate(ctx context.Context, templateID string) error
+	// PreviewTemplate renders a template without sending anything. An empty notification type renders every body.
+	PreviewTemplate(ctx context.Context, templateID string, version int, notificationType model.NotificationType, data map[string]string) (model.RenderedTemplate, error)
+}
+
+var (
+	ErrInvalidTemplate = errors.New("invalid template")
+	ErrTemplateExists  = errors.New("template already exists")
+	ErrTemplateRender  = errors.New("template rendering failed")
+)
+
+const maxTemplateIDLength = 128
+
+var templateIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+type templateServiceImpl struct {
+	database *gorm.DB
+	logger   *slog.Logger
+}
+
+// NewTemplateService creates a TemplateService backed by the templates table.
+func NewTemplateService(db *gorm.DB, logger *slog.Logger) TemplateService {
+	return &templateServiceImpl{database: db, logger: logger}
+}
+
+func (serviceInstance *templateServiceImpl) CreateTemplate(ctx context.Context, request model.TemplateRequest) (model.Template, error) {
+	normalized, validationErr := validateTemplateRequest(request)
+	if validationErr != nil {
+		return model.Template{}, validationErr
+	}
+	var created model.Template
+	transactionErr := serviceInstance.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, lookupErr := model.GetTemplate(ctx, tx, normalized.TemplateID, 0); lookupErr == nil {
+			return fmt.Errorf("%w: %s", ErrTemplateExists, normalized.TemplateID)
+		} else if !errors.Is(lookupErr, model.ErrTemplateNotFound) {
+			return lookupErr
+		}
+		created = newTemplateVersion(normalized, 1)
+		return model.CreateTemplate(ctx, tx, &created)
+	})
+	if transactionErr != nil {
+		serviceInstance.logger.Error("Failed to create template", "template_id", normalized.TemplateID, "error", transactionErr)
+		return model.Template{}, transactionErr
+	}
+	serviceInstance.logger.Info("template_created", "template_id", created.TemplateID, "version", created.Version)
+	return created, nil
+}
+
+func (serviceInstance *templateServiceImpl) UpdateTemplate(ctx context.Context, request model.TemplateRequest) (model.Template, error) {
+	normalized, validationErr := validateTemplateRequest(request)
+	if validationErr != nil {
+		return model.Template{}, validationErr
+	}
+	var created model.Template
+	transactionErr := serviceInstance.database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		latest, lookupErr := model.GetTemplate(ctx, tx, normalized.TemplateID, 0)
+		if lookupErr != nil {
+			return lookupErr
+		}
+		created = newTemplateVersion(normalized, latest.Version+1)
+		return model.CreateTemplate(ctx, tx, &created)
+	})
+	if transactionErr != nil {
+		serviceInstance.logger.Error("Failed to update template", "template_id", normalized.TemplateID, "error", transactionErr)
+		return model.Template{}, transactionErr
+	}
+	serviceInstance.logger.Info("template_updated", "template_id", created.TemplateID, "version", created.Version)
+	return created, nil
+}
+
+func (serviceInstance *templateServiceImpl) GetTemplate(ctx context.Context, templateID string, version int) (model.Template, error) {
+	trimmedID := strings.TrimSpace(templateID)
+	if trimmedID == "" {
+		return model.Template{}, fmt.Errorf("%w: template_id is required", ErrInvalidTemplate)
+	}
+	template, err := model.GetTemplate(ctx, serviceInstance.database, trimmedID, version)
+	if err != nil {
+		return model.Template{}, err
+	}
+	return *template, nil
+}
+
+func (serviceInstance *templateServiceImpl) ListTemplates(ctx context.Context) ([]model.Template, error) {
+	templates, err := model.ListLatestTemplates(ctx, serviceInstance.database)
+	if err != nil {
+		serviceInstance.logger.Error("Failed to list templates", "error", err)
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (serviceInstance *templateServiceImpl) DeleteTemplate(ctx context.Context, templateID string) error {
+	trimmedID := strings.TrimSpace(templateID)
+	if trimmedID == "" {
+		return fmt.Errorf("%w: template_id is required", ErrInvalidTemplate)
+	}
+	if err := model.DeleteTemplate(ctx, serviceInstance.database, trimmedID); err != nil {
+		return err
+	}
+	serviceInstance.logger.Info("template_deleted", "template_id", trimmedID)
+	return nil
+}
+
+func (serviceInstance *templateServiceImpl) PreviewTemplate(ctx context.Context, templateID string, version int, notificationType model.NotificationType, data map[string]string) (model.RenderedTemplate, error) {
+	template, err := serviceInstance.GetTemplate(ctx, templateID, version)
+	if err != nil {
+		return model.RenderedTemplate{}, err
+	}
+	return renderTemplate(template, notificationType, data)
+}
+
+func validateTemplateRequest(request model.TemplateRequest) (model.TemplateRequest, error) {
+	request.TemplateID = strings.TrimSpace(request.TemplateID)
+	request.Description = strings.TrimSpace(request.Description)
+	switch {
+	case request.TemplateID == "":
+		return request, fmt.Errorf("%w: template_id is required", ErrInvalidTemplate)
+	case len(request.TemplateID) > maxTemplateIDLength:
+		return request, fmt.Errorf("%w: template_id exceeds %d characters", ErrInvalidTemplate, maxTemplateIDLength)
+	case !templateIDPattern.MatchString(request.TemplateID):
+		return request, fmt.Errorf("%w: template_id may contain only letters, digits, '.', '_' and '-'", ErrInvalidTemplate)
+	case strings.TrimSpace(request.PlainBody) == "" && strings.TrimSpace(request.SMSBody) == "":
+		return request, fmt.Errorf("%w: plain_body or sms_body is required", ErrInvalidTemplate)
+	case strings.TrimSpace(request.HTMLBody) != "" && strings.TrimSpace(request.PlainBody) == "":
+		return request, fmt.Errorf("%w: html_body requires a plain_body fallback", ErrInvalidTemplate)
+	}
+	for fieldName, source := range map[string]string{"subject": request.Subject, "plain_body": request.PlainBody, "sms_body": request.SMSBody} {
+		if _, err := texttemplate.New(fieldName).Parse(source); err != nil {
+			return request, fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, fieldName, err)
+		}
+	}
+	if _, err := htmltemplate.New("html_body").Parse(request.HTMLBody); err != nil {
+		return request, fmt.Errorf("%w: html_body: %v", ErrInvalidTemplate, err)
+	}
+	return request, nil
+}
+
+func newTemplateVersion(request model.TemplateRequest, version int) model.Template {
+	now := time.Now().UTC()
+	return model.Template{
+		TemplateID:  request.TemplateID,
+		Version:     version,
+		Description: request.Description,
+		Subject:     request.Subject,
+		PlainBody:   request.PlainBody,
+		HTMLBody:    request.HTMLBody,
+		SMSBody:     request.SMSBody,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// renderTemplate executes the bodies relevant to the notification type. Referencing a variable that
+// is absent from data is an error rather than an empty substitution.
+func renderTemplate(template model.Template, notificationType model.NotificationType, data map[string]string) (model.RenderedTemplate, error) {
+	if data == nil {
+		data = map[string]string{}
+	}
+	rendered := model.RenderedTemplate{TemplateID: template.TemplateID, Version: template.Version}
+
+	renderEmail := notificationType == model.NotificationEmail || notificationType == ""
+	renderSMS := notificationType == model.NotificationSMS || notificationType == ""
+	switch notificationType {
+	case model.NotificationEmail:
+		if strings.TrimSpace(template.PlainBody) == "" {
+			return model.RenderedTemplate{}, fmt.Errorf("%w: template %s has no email body", ErrTemplateRender, template.TemplateID)
+		}
+	case model.NotificationSMS:
+		if strings.TrimSpace(template.SMSBody) == "" {
+			return model.RenderedTemplate{}, fmt.Errorf("%w: template %s has no sms body", ErrTemplateRender, template.TemplateID)
+		}
+	case "":
+	default:
+		return model.RenderedTemplate{}, fmt.Errorf("unsupported notification type: %s", notificationType)
+	}
+
+	var renderErr error
+	if renderEmail {
+		if rendered.Subject, renderErr = executeTextTemplate("subject", template.Subject, data); renderErr != nil {
+			return model.RenderedTemplate{}, renderErr
+		}
+		if rendered.PlainBody, renderErr = executeTextTemplate("plain_body", template.PlainBody, data); renderErr != nil {
+			return model.RenderedTemplate{}, renderErr
+		}
+		if rendered.HTMLBody, renderErr = executeHTMLTemplate("html_body", template.HTMLBody, data); renderErr != nil {
+			return model.RenderedTemplate{}, renderErr
+		}
+	}
+	if renderSMS {
+		if rendered.SMSBody, renderErr = executeTextTemplate("sms_body", template.SMSBody, data); renderErr != nil {
+			return model.RenderedTemplate{}, renderErr
+		}
+	}
+	return rendered, nil
+}
+
+func executeTextTemplate(fieldName string, source string, data map[string]string) (string, error) {
+	if source == "" {
+		return "", nil
+	}
+	parsed, parseErr := texttemplate.New(fieldName).Option("missingkey=error").Parse(source)
+	if parseErr != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrTemplateRender, fieldName, parseErr)
+	}
+	var output bytes.Buffer
+	if executeErr := parsed.Execute(&output, data); executeErr != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrTemplateRender, fieldName, executeErr)
+	}
+	return output.String(), nil
+}
+
+func executeHTMLTemplate(fieldName string, source string, data map[string]string) (string, error) {
+	if source == "" {
+		return "", nil
+	}
+	parsed, parseErr := htmltemplate.New(fieldName).Option("missingkey=error").Parse(source)
+	if parseErr != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrTemplateRender, fieldName, parseErr)
+	}
+	var output bytes.Buffer
+	if executeErr := parsed.Execute(&output, data); executeErr != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrTemplateRender, fieldName, executeErr)
+	}
+	return output.String(), nil
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/model"
+	"log/slog"
+)
+
+func TestTemplateServiceVersioning(t *testing.T) {
+	t.Helper()
+
+	database := openIsolatedDatabase(t)
+	templateService := NewTemplateService(database, slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})))
+	ctx := context.Background()
+
+	created, createErr := templateService.CreateTemplate(ctx, model.TemplateRequest{TemplateID: "welcome", Subject: "Hi {{.name}}", PlainBody: "Hello {{.name}}"})
+	if createErr != nil {
+		t.Fatalf("create error: %v", createErr)
+	}
+	if created.Version != 1 {
+		t.Fatalf("expected version 1, got %d", created.Version)
+	}
+	if _, duplicateErr := templateService.CreateTemplate(ctx, model.TemplateRequest{TemplateID: "welcome", PlainBody: "Again"}); !errors.Is(duplicateErr, ErrTemplateExists) {
+		t.Fatalf("expected ErrTemplateExists, got %v", duplicateErr)
+	}
+
+	updated, updateErr := templateService.UpdateTemplate(ctx, model.TemplateRequest{TemplateID: "welcome", Subject: "Welcome {{.name}}", PlainBody: "Welcome {{.name}}"})
+	if updateErr != nil {
+		t.Fatalf("update error: %v", updateErr)
+	}
+	if updated.Version != 2 {
+		t.Fatalf("expected version 2, got %d", updated.Version)
+	}
+	if _, missingErr := templateService.UpdateTemplate(ctx, model.TemplateRequest{TemplateID: "missing", PlainBody: "Body"}); !errors.Is(missingErr, model.ErrTemplateNotFound) {
+		t.Fatalf("expected ErrTemplateNotFound, got %v", missingErr)
+	}
+
+	original, getErr := templateService.GetTemplate(ctx, "welcome", 1)
+	if getErr != nil || original.Subject != "Hi {{.name}}" {
+		t.Fatalf("expected version 1 to be retained, got %#v (%v)", original, getErr)
+	}
+	listed, listErr := templateService.ListTemplates(ctx)
+	if listErr != nil || len(listed) != 1 || listed[0].Version != 2 {
+		t.Fatalf("expected latest version only, got %#v (%v)", listed, listErr)
+	}
+
+	if deleteErr := templateService.DeleteTemplate(ctx, "welcome"); deleteErr != nil {
+		t.Fatalf("delete error: %v", deleteErr)
+	}
+	if _, getAfterDeleteErr := templateService.GetTemplate(ctx, "welcome", 0); !errors.Is(getAfterDeleteErr, model.ErrTemplateNotFound) {
+		t.Fatalf("expected deleted template to be missing, got %v", getAfterDeleteErr)
+	}
+}
+
+func TestTemplateServiceValidatesRequests(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name    string
+		request model.TemplateRequest
+	}{
+		{name: "MissingID", request: model.TemplateRequest{PlainBody: "Body"}},
+		{name: "InvalidID", request: model.TemplateRequest{TemplateID: "bad id", PlainBody: "Body"}},
+		{name: "MissingBodies", request: model.TemplateRequest{TemplateID: "empty", Subject: "Subject"}},
+		{name: "HTMLWithoutPlain", request: model.TemplateRequest{TemplateID: "html", HTMLBody: "<p>Hi</p>", SMSBody: "Hi"}},
+		{name: "ParseError", request: model.TemplateRequest{TemplateID: "broken", PlainBody: "Hello {{.name"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			templateService := NewTemplateService(openIsolatedDatabase(t), slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})))
+			if _, err := templateService.CreateTemplate(context.Background(), testCase.request); !errors.Is(err, ErrInvalidTemplate) {
+				t.Fatalf("expected ErrInvalidTemplate, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	t.Helper()
+
+	template := model.Template{
+		TemplateID: "welcome",
+		Version:    3,
+		Subject:    "Welcome {{.name}}",
+		PlainBody:  "Hello {{.name}}",
+		HTMLBody:   "<p>Hello {{.name}}</p>",
+		SMSBody:    "Hi {{.name}}",
+	}
+
+	testCases := []struct {
+		name             string
+		template         model.Template
+		notificationType model.NotificationType
+		data             map[string]string
+		expectError      bool
+		expected         model.RenderedTemplate
+	}{
+		{
+			name:             "EmailEscapesHTML",
+			template:         template,
+			notificationType: model.NotificationEmail,
+			data:             map[string]string{"name": "<Ada>"},
+			expected:         model.RenderedTemplate{TemplateID: "welcome", Version: 3, Subject: "Welcome <Ada>", PlainBody: "Hello <Ada>", HTMLBody: "<p>Hello &lt;Ada&gt;</p>"},
+		},
+		{
+			name:             "SmsOnlyRendersSmsBody",
+			template:         template,
+			notificationType: model.NotificationSMS,
+			data:             map[string]string{"name": "Ada"},
+			expected:         model.RenderedTemplate{TemplateID: "welcome", Version: 3, SMSBody: "Hi Ada"},
+		},
+		{
+			name:             "MissingVariable",
+			template:         template,
+			notificationType: model.NotificationEmail,
+			expectError:      true,
+		},
+		{
+			name:             "SmsTemplateWithoutSmsBody",
+			template:         model.Template{TemplateID: "email-only", PlainBody: "Hello"},
+			notificationType: model.NotificationSMS,
+			expectError:      true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rendered, err := renderTemplate(testCase.template, testCase.notificationType, testCase.data)
+			if testCase.expectError {
+				if !errors.Is(err, ErrTemplateRender) {
+					t.Fatalf("expected ErrTemplateRender, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("render error: %v", err)
+			}
+			if rendered != testCase.expected {
+				t.Fatalf("expected %#v, got %#v", testCase.expected, rendered)
+			}
+		})
+	}
+}
+
+func TestSendNotificationRendersTemplate(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name            string
+		request         model.NotificationRequest
+		expectedErr     error
+		expectedSubject string
+		expectedBody    string
+		expectedHTML    string
+		expectedVersion int
+	}{
+		{
+			name: "LatestVersion",
+			request: model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				Recipient:        "user@example.com",
+				TemplateID:       "welcome",
+				TemplateData:     map[string]string{"name": "Ada"},
+			},
+			expectedSubject: "Welcome Ada",
+			expectedBody:    "Hello again Ada",
+			expectedHTML:    "<p>Hello again Ada</p>",
+			expectedVersion: 2,
+		},
+		{
+			name: "PinnedVersion",
+			request: model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				Recipient:        "user@example.com",
+				TemplateID:       "welcome",
+				TemplateVersion:  1,
+				TemplateData:     map[string]string{"name": "Ada"},
+			},
+			expectedSubject: "Welcome Ada",
+			expectedBody:    "Hello Ada",
+			expectedVersion: 1,
+		},
+		{
+			name: "MissingVariable",
+			request: model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				Recipient:        "user@example.com",
+				TemplateID:       "welcome",
+			},
+			expectedErr: ErrTemplateRender,
+		},
+		{
+			name: "InlineContentConflict",
+			request: model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				Recipient:        "user@example.com",
+				Message:          "Inline",
+				TemplateID:       "welcome",
+				TemplateData:     map[string]string{"name": "Ada"},
+			},
+			expectedErr: ErrInvalidTemplate,
+		},
+		{
+			name: "UnknownTemplate",
+			request: model.NotificationRequest{
+				NotificationType: model.NotificationEmail,
+				Recipient:        "user@example.com",
+				TemplateID:       "missing",
+			},
+			expectedErr: model.ErrTemplateNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			database := openIsolatedDatabase(t)
+			logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+			templateService := NewTemplateService(database, logger)
+			if _, err := templateService.CreateTemplate(context.Background(), model.TemplateRequest{TemplateID: "welcome", Subject: "Welcome {{.name}}", PlainBody: "Hello {{.name}}"}); err != nil {
+				t.Fatalf("create template error: %v", err)
+			}
+			if _, err := templateService.UpdateTemplate(context.Background(), model.TemplateRequest{TemplateID: "welcome", Subject: "Welcome {{.name}}", PlainBody: "Hello again {{.name}}", HTMLBody: "<p>Hello again {{.name}}</p>"}); err != nil {
+				t.Fatalf("update template error: %v", err)
+			}
+
+			emailSender := &stubEmailSender{}
+			serviceInstance := &notificationServiceImpl{
+				database:    database,
+				logger:      logger,
+				emailSender: emailSender,
+				maxRetries:  3,
+			}
+
+			response, err := serviceInstance.SendNotification(context.Background(), testCase.request)
+			if testCase.expectedErr != nil {
+				if !errors.Is(err, testCase.expectedErr) {
+					t.Fatalf("expected %v, got %v", testCase.expectedErr, err)
+				}
+				if len(emailSender.receivedMessages) != 0 {
+					t.Fatalf("expected no dispatch on template failure")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("send error: %v", err)
+			}
+			if response.TemplateID != "welcome" || response.TemplateVersion != testCase.expectedVersion {
+				t.Fatalf("unexpected template reference %s v%d", response.TemplateID, response.TemplateVersion)
+			}
+			if len(emailSender.receivedMessages) != 1 {
+				t.Fatalf("expected one dispatch, got %d", len(emailSender.receivedMessages))
+			}
+			dispatched := emailSender.receivedMessages[0]
+			if dispatched.Subject != testCase.expectedSubject || dispatched.Body != testCase.expectedBody || dispatched.HTMLBody != testCase.expectedHTML {
+				t.Fatalf("unexpected rendered message %#v", dispatched)
+			}
+			if !strings.Contains(response.Message, "Ada") {
+				t.Fatalf("expected rendered message to be persisted, got %q", response.Message)
+			}
+		})
+	}
+}
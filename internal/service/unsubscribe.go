@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/temirov/pinguin/internal/model"
+	"log/slog"
+)
+
+const (
+	// UnsubscribePath is the public route one-click unsubscribe links point at.
+	UnsubscribePath = "/unsubscribe"
+	// UnsubscribeURLTemplateKey is the template variable that receives the recipient's unsubscribe link.
+	UnsubscribeURLTemplateKey = "unsubscribe_url"
+	// listUnsubscribePostValue is the fixed List-Unsubscribe-Post value required by RFC 8058.
+	listUnsubscribePostValue = "List-Unsubscribe=One-Click"
+	unsubscribeSource        = "list-unsubscribe"
+)
+
+// ErrInvalidUnsubscribeToken reports an unsubscribe token that is malformed or carries a bad signature.
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// UnsubscribeClaims identifies the recipient and message category an unsubscribe token was issued for.
+type UnsubscribeClaims struct {
+	Recipient string `json:"r"`
+	Category  string `json:"c,omitempty"`
+}
+
+// UnsubscribeSigner issues and verifies HMAC-signed unsubscribe tokens. Tokens carry no expiry:
+// the link in a delivered email has to keep working for as long as the email is kept.
+type UnsubscribeSigner struct {
+	baseURL    string
+	signingKey []byte
+}
+
+// NewUnsubscribeSigner returns a signer for links under publicBaseURL, or nil when either the
+// base URL or the signing key is empty, which disables unsubscribe links.
+func NewUnsubscribeSigner(publicBaseURL string, signingKey string) *UnsubscribeSigner {
+	publicBaseURL = strings.TrimRight(strings.TrimSpace(publicBaseURL), "/")
+	if publicBaseURL == "" || signingKey == "" {
+		return nil
+	}
+	return &UnsubscribeSigner{baseURL: publicBaseURL, signingKey: []byte(signingKey)}
+}
+
+// Token returns the signed token for an email recipient and category.
+func (signer *UnsubscribeSigner) Token(recipient string, category string) string {
+	payload, _ := json.Marshal(UnsubscribeClaims{
+		Recipient: model.NormalizeEmailAddress(recipient),
+		Category:  model.NormalizeCategory(category),
+	})
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(signer.sign(encodedPayload))
+}
+
+// URL returns the one-click unsubscribe link for an email recipient and category. A nil signer
+// returns an empty string so callers can skip the headers without checking configuration.
+func (signer *UnsubscribeSigner) URL(recipient string, category string) string {
+	if signer == nil || strings.TrimSpace(recipient) == "" {
+		return ""
+	}
+	return signer.baseURL + UnsubscribePath + "?token=" + url.QueryEscape(signer.Token(recipient, category))
+}
+
+// Verify checks the token signature and returns its claims.
+func (signer *UnsubscribeSigner) Verify(token string) (UnsubscribeClaims, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(strings.TrimSpace(token), ".")
+	if !found || encodedPayload == "" {
+		return UnsubscribeClaims{}, ErrInvalidUnsubscribeToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signer.sign(encodedPayload)) {
+		return UnsubscribeClaims{}, ErrInvalidUnsubscribeToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return UnsubscribeClaims{}, ErrInvalidUnsubscribeToken
+	}
+	var claims UnsubscribeClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Recipient == "" {
+		return UnsubscribeClaims{}, ErrInvalidUnsubscribeToken
+	}
+	return claims, nil
+}
+
+func (signer *UnsubscribeSigner) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, signer.signingKey)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
+
+// UnsubscribeService resolves unsubscribe tokens and records the resulting suppressions.
+type UnsubscribeService interface {
+	// Verify returns the recipient and category a token was issued for.
+	Verify(token string) (UnsubscribeClaims, error)
+	// Unsubscribe suppresses the token's recipient for the token's category.
+	Unsubscribe(ctx context.Context, token string) (model.Suppression, error)
+}
+
+type unsubscribeServiceImpl struct {
+	signer             *UnsubscribeSigner
+	suppressionService SuppressionService
+	logger             *slog.Logger
+}
+
+// NewUnsubscribeService creates an UnsubscribeService that writes suppressions through suppressionService.
+func NewUnsubscribeService(signer *UnsubscribeSigner, suppressionService SuppressionService, logger *slog.Logger) UnsubscribeService {
+	return &unsubscribeServiceImpl{signer: signer, suppressionService: suppressionService, logger: logger}
+}
+
+func (serviceInstance *unsubscribeServiceImpl) Verify(token string) (UnsubscribeClaims, error) {
+	return serviceInstance.signer.Verify(token)
+}
+
+func (serviceInstance *unsubscribeServiceImpl) Unsubscribe(ctx context.Context, token string) (model.Suppression, error) {
+	claims, err := serviceInstance.signer.Verify(token)
+	if err != nil {
+		return model.Suppression{}, err
+	}
+	suppression, err := serviceInstance.suppressionService.AddSuppression(ctx, model.SuppressionRequest{
+		Channel:   model.NotificationEmail,
+		Recipient: claims.Recipient,
+		Category:  claims.Category,
+		Reason:    model.SuppressionUnsubscribed,
+		Source:    unsubscribeSource,
+	})
+	if err != nil {
+		return model.Suppression{}, fmt.Errorf("record unsubscribe: %w", err)
+	}
+	serviceInstance.logger.Info("recipient_unsubscribed", "category", suppression.Category)
+	return suppression, nil
+}
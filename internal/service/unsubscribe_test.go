@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/temirov/pinguin/internal/model"
+)
+
+func TestUnsubscribeSignerVerify(t *testing.T) {
+	t.Helper()
+	signer := NewUnsubscribeSigner("https://notify.example.com/", "signing-key")
+	token := signer.Token(" Reader@Example.com ", " Promotions ")
+	encodedPayload, encodedSignature, _ := strings.Cut(token, ".")
+	foreignPayload, _, _ := strings.Cut(signer.Token("other@example.com", "promotions"), ".")
+
+	testCases := []struct {
+		name          string
+		token         string
+		expectInvalid bool
+	}{
+		{name: "Valid", token: token},
+		{name: "TamperedPayload", token: foreignPayload + "." + encodedSignature, expectInvalid: true},
+		{name: "ForeignKey", token: NewUnsubscribeSigner("https://notify.example.com", "other-key").Token("reader@example.com", "promotions"), expectInvalid: true},
+		{name: "MissingSignature", token: encodedPayload, expectInvalid: true},
+		{name: "Empty", expectInvalid: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			claims, err := signer.Verify(testCase.token)
+			if testCase.expectInvalid {
+				if !errors.Is(err, ErrInvalidUnsubscribeToken) {
+					t.Fatalf("expected ErrInvalidUnsubscribeToken, got %v (%+v)", err, claims)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			if claims.Recipient != "reader@example.com" || claims.Category != "promotions" {
+				t.Fatalf("unexpected claims %+v", claims)
+			}
+		})
+	}
+
+	unsubscribeURL := signer.URL("reader@example.com", "promotions")
+	if !strings.HasPrefix(unsubscribeURL, "https://notify.example.com"+UnsubscribePath+"?token=") {
+		t.Fatalf("unexpected unsubscribe url %q", unsubscribeURL)
+	}
+	if NewUnsubscribeSigner("", "signing-key") != nil || NewUnsubscribeSigner("https://notify.example.com", "") != nil {
+		t.Fatalf("expected signer to be disabled without a base url and key")
+	}
+	var disabled *UnsubscribeSigner
+	if disabled.URL("reader@example.com", "promotions") != "" {
+		t.Fatalf("expected disabled signer to return no url")
+	}
+}
+
+func TestUnsubscribeSuppressesCategory(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	signer := NewUnsubscribeSigner("https://notify.example.com", "signing-key")
+	emailSender := &stubEmailSender{}
+	serviceInstance := &notificationServiceImpl{
+		database:          database,
+		logger:            newDiscardLogger(),
+		emailSender:       emailSender,
+		maxRetries:        3,
+		retryIntervalSec:  1,
+		unsubscribeSigner: signer,
+	}
+	if _, err := NewTemplateService(database, newDiscardLogger()).CreateTemplate(context.Background(), model.TemplateRequest{
+		TemplateID: "digest",
+		Subject:    "Weekly digest",
+		PlainBody:  "News. Unsubscribe: {{.unsubscribe_url}}",
+	}); err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+
+	response, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "reader@example.com",
+		Category:         "Promotions",
+		TemplateID:       "digest",
+	})
+	if err != nil {
+		t.Fatalf("send categorized email: %v", err)
+	}
+	if response.Category != "promotions" || len(emailSender.receivedMessages) != 1 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	dispatched := emailSender.receivedMessages[0]
+	if dispatched.ListUnsubscribeURL == "" || !strings.Contains(dispatched.Body, dispatched.ListUnsubscribeURL) {
+		t.Fatalf("expected unsubscribe link in headers and body, got %q / %q", dispatched.ListUnsubscribeURL, dispatched.Body)
+	}
+
+	parsedURL, err := url.Parse(dispatched.ListUnsubscribeURL)
+	if err != nil {
+		t.Fatalf("parse unsubscribe url: %v", err)
+	}
+	unsubscribeService := NewUnsubscribeService(signer, NewSuppressionService(database, newDiscardLogger()), newDiscardLogger())
+	suppression, err := unsubscribeService.Unsubscribe(context.Background(), parsedURL.Query().Get("token"))
+	if err != nil {
+		t.Fatalf("unsubscribe: %v", err)
+	}
+	if suppression.Recipient != "reader@example.com" || suppression.Category != "promotions" ||
+		suppression.Reason != model.SuppressionUnsubscribed || suppression.Source != unsubscribeSource {
+		t.Fatalf("unexpected suppression %+v", suppression)
+	}
+	if _, err := unsubscribeService.Unsubscribe(context.Background(), "forged.token"); !errors.Is(err, ErrInvalidUnsubscribeToken) {
+		t.Fatalf("expected ErrInvalidUnsubscribeToken, got %v", err)
+	}
+
+	_, err = serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		Recipient:        "reader@example.com",
+		Category:         "promotions",
+		Subject:          "Sale",
+		Message:          "Body",
+	})
+	if !errors.Is(err, ErrRecipientSuppressed) {
+		t.Fatalf("expected unsubscribed category to be suppressed, got %v", err)
+	}
+	for _, category := range []string{"receipts", ""} {
+		if _, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+			NotificationType: model.NotificationEmail,
+			Recipient:        "reader@example.com",
+			Category:         category,
+			Subject:          "Receipt",
+			Message:          "Body",
+		}); err != nil {
+			t.Fatalf("expected category %q to be delivered, got %v", category, err)
+		}
+	}
+	if uncategorized := emailSender.receivedMessages[len(emailSender.receivedMessages)-1]; uncategorized.ListUnsubscribeURL != "" {
+		t.Fatalf("expected no unsubscribe link for uncategorized email, got %q", uncategorized.ListUnsubscribeURL)
+	}
+}
+
+func TestUnsubscribeLinkOmittedForSeveralRecipients(t *testing.T) {
+	t.Helper()
+	database := openIsolatedDatabase(t)
+	emailSender := &stubEmailSender{}
+	serviceInstance := &notificationServiceImpl{
+		database:          database,
+		logger:            newDiscardLogger(),
+		emailSender:       emailSender,
+		maxRetries:        3,
+		retryIntervalSec:  1,
+		unsubscribeSigner: NewUnsubscribeSigner("https://notify.example.com", "signing-key"),
+	}
+	if _, err := NewTemplateService(database, newDiscardLogger()).CreateTemplate(context.Background(), model.TemplateRequest{
+		TemplateID: "digest",
+		Subject:    "Weekly digest",
+		PlainBody:  "News.{{if .unsubscribe_url}} Unsubscribe: {{.unsubscribe_url}}{{end}}",
+	}); err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+
+	if _, err := serviceInstance.SendNotification(context.Background(), model.NotificationRequest{
+		NotificationType: model.NotificationEmail,
+		To:               []string{"first@example.com", "second@example.com"},
+		Category:         "promotions",
+		TemplateID:       "digest",
+	}); err != nil {
+		t.Fatalf("send categorized email: %v", err)
+	}
+	if len(emailSender.receivedMessages) != 1 {
+		t.Fatalf("expected one dispatch, got %d", len(emailSender.receivedMessages))
+	}
+	dispatched := emailSender.receivedMessages[0]
+	if dispatched.ListUnsubscribeURL != "" || strings.Contains(dispatched.Body, "unsubscribe") {
+		t.Fatalf("expected no unsubscribe link for two recipients, got %q / %q", dispatched.ListUnsubscribeURL, dispatched.Body)
+	}
+}
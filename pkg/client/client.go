@@ -2,8 +2,10 @@ package client
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 	"time"
@@ -11,8 +13,11 @@ import (
 	"github.com/temirov/pinguin/pkg/grpcapi"
 	"github.com/temirov/pinguin/pkg/grpcutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"log/slog"
 )
 
@@ -129,10 +134,60 @@ func (clientInstance *NotificationClient) Close() error {
 	return clientInstance.conn.Close()
 }
 
+// NewIdempotencyKey returns a random key suitable for NotificationRequest.IdempotencyKey.
+func NewIdempotencyKey() string {
+	return rand.Text()
+}
+
+// withIdempotencyKey returns req unchanged when it carries an idempotency key, and otherwise a copy
+// with a generated key. The caller's message is never modified, so a request value can be reused
+// with different content.
+func withIdempotencyKey(req *grpcapi.NotificationRequest) *grpcapi.NotificationRequest {
+	if req == nil || req.GetIdempotencyKey() != "" {
+		return req
+	}
+	keyed := proto.Clone(req).(*grpcapi.NotificationRequest)
+	keyed.IdempotencyKey = NewIdempotencyKey()
+	return keyed
+}
+
 // SendNotification invokes the SendNotification RPC with the provided context.
+// When req carries no idempotency key the RPC is sent with a generated one; req
+// itself is left untouched. Callers that resubmit after a timeout should set
+// IdempotencyKey themselves (see NewIdempotencyKey) so the retry is deduplicated.
 func (clientInstance *NotificationClient) SendNotification(ctx context.Context, req *grpcapi.NotificationRequest) (*grpcapi.NotificationResponse, error) {
 	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+clientInstance.authToken)
-	resp, err := clientInstance.grpcClient.SendNotification(ctx, req)
+	resp, err := clientInstance.grpcClient.SendNotification(ctx, withIdempotencyKey(req))
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SendNotificationBatch invokes the SendNotificationBatch RPC with the provided context. Requests
+// without an idempotency key are sent with a generated one, as in SendNotification, and the
+// caller's requests are left untouched. Items in the response carry their request's index;
+// rejected items report error and error_code.
+func (clientInstance *NotificationClient) SendNotificationBatch(ctx context.Context, requests []*grpcapi.NotificationRequest) (*grpcapi.NotificationBatchResponse, error) {
+	keyed := make([]*grpcapi.NotificationRequest, len(requests))
+	for index, req := range requests {
+		keyed[index] = withIdempotencyKey(req)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+clientInstance.authToken)
+	resp, err := clientInstance.grpcClient.SendNotificationBatch(ctx, &grpcapi.NotificationBatchRequest{Requests: keyed})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNotificationBatch fetches the notifications stored for a batch, applying
+// the client's default timeout.
+func (clientInstance *NotificationClient) GetNotificationBatch(batchID string) (*grpcapi.GetNotificationBatchResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), clientInstance.settings.OperationTimeout())
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+clientInstance.authToken)
+	resp, err := clientInstance.grpcClient.GetNotificationBatch(ctx, &grpcapi.GetNotificationBatchRequest{BatchId: batchID})
 	if err != nil {
 		return nil, err
 	}
@@ -155,11 +210,22 @@ func (clientInstance *NotificationClient) GetNotificationStatus(notificationID s
 	return resp, nil
 }
 
+// WatchNotifications opens a WatchNotifications stream. The stream lives as long as ctx, which
+// should carry a deadline or be cancelled once the caller stops reading.
+func (clientInstance *NotificationClient) WatchNotifications(ctx context.Context, req *grpcapi.WatchNotificationsRequest) (grpcapi.NotificationService_WatchNotificationsClient, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+clientInstance.authToken)
+	return clientInstance.grpcClient.WatchNotifications(ctx, req)
+}
+
+// sendPollInterval paces status polling against servers without WatchNotifications and
+// resubscription after a watch stream breaks.
 var sendPollInterval = 2 * time.Second
 
-// SendNotificationAndWait issues a SendNotification RPC and polls for its
-// terminal status until it is either sent, fails, or the client's timeout
-// elapses.
+// SendNotificationAndWait issues a SendNotification RPC and watches the notification until it
+// reaches a terminal status or the client's operation timeout elapses. Sent and delivered
+// notifications are returned without an error; dead, cancelled, undelivered, bounced and suppressed
+// ones are returned together with an error wrapping ErrNotificationFailed. Errored notifications are
+// still being retried by the server, so the wait continues through them.
 func (clientInstance *NotificationClient) SendNotificationAndWait(req *grpcapi.NotificationRequest) (*grpcapi.NotificationResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), clientInstance.settings.OperationTimeout())
 	defer cancel()
@@ -169,25 +235,115 @@ func (clientInstance *NotificationClient) SendNotificationAndWait(req *grpcapi.N
 		clientInstance.logger.Error("SendNotification failed", "error", err)
 		return nil, err
 	}
-	pollTimeout := clientInstance.settings.OperationTimeout()
-	startTime := time.Now()
+	return clientInstance.waitForTerminalStatus(ctx, resp)
+}
+
+// ErrNotificationFailed reports a notification that reached a terminal status other than sent or
+// delivered.
+var ErrNotificationFailed = errors.New("notification failed")
+
+// terminalOutcome reports whether status ends a wait and, if so, the error to return with it.
+// The legacy FAILED status, reported by servers that predate errored and dead, ends the wait as a
+// failure too, so callers of those servers are not left waiting for the timeout.
+func terminalOutcome(status grpcapi.Status) (bool, error) {
+	switch status {
+	case grpcapi.Status_SENT, grpcapi.Status_DELIVERED:
+		return true, nil
+	case grpcapi.Status_FAILED, grpcapi.Status_DEAD, grpcapi.Status_CANCELLED, grpcapi.Status_UNDELIVERED, grpcapi.Status_BOUNCED, grpcapi.Status_SUPPRESSED:
+		return true, fmt.Errorf("%w: %s", ErrNotificationFailed, strings.ToLower(status.String()))
+	default:
+		return false, nil
+	}
+}
 
+// waitForTerminalStatus follows resp over WatchNotifications streams, opening a new one when the
+// server ends a stream early. Every stream starts with the current state, so nothing is missed
+// between streams. Servers that predate WatchNotifications are polled instead.
+func (clientInstance *NotificationClient) waitForTerminalStatus(ctx context.Context, resp *grpcapi.NotificationResponse) (*grpcapi.NotificationResponse, error) {
 	for {
-		switch resp.Status {
-		case grpcapi.Status_SENT:
-			return resp, nil
-		case grpcapi.Status_FAILED:
-			return resp, fmt.Errorf("notification failed")
+		if done, outcomeErr := terminalOutcome(resp.GetStatus()); done {
+			return resp, outcomeErr
 		}
 
-		if time.Since(startTime) > pollTimeout {
-			return resp, fmt.Errorf("timeout waiting for notification to be sent")
+		var streamErr error
+		resp, streamErr = clientInstance.followNotification(ctx, resp)
+		if done, outcomeErr := terminalOutcome(resp.GetStatus()); done {
+			return resp, outcomeErr
+		}
+		switch {
+		case ctx.Err() != nil:
+			return resp, fmt.Errorf("timeout waiting for notification to be sent: %w", ctx.Err())
+		case status.Code(streamErr) == codes.Unimplemented:
+			return clientInstance.pollForTerminalStatus(ctx, resp)
+		case streamErr != nil && !isResumableWatchError(streamErr):
+			clientInstance.logger.Error("WatchNotifications failed", "notificationID", resp.GetNotificationId(), "error", streamErr)
+			return resp, streamErr
+		}
+		clientInstance.logger.Debug("Resubscribing to notification", "notificationID", resp.GetNotificationId(), "error", streamErr)
+		select {
+		case <-ctx.Done():
+		case <-time.After(sendPollInterval):
+		}
+	}
+}
+
+// followNotification reads one watch stream until the notification reaches a terminal status or
+// the stream ends. It returns the latest state seen and the error that ended the stream.
+func (clientInstance *NotificationClient) followNotification(ctx context.Context, resp *grpcapi.NotificationResponse) (*grpcapi.NotificationResponse, error) {
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+	stream, err := clientInstance.WatchNotifications(streamCtx, &grpcapi.WatchNotificationsRequest{NotificationId: resp.GetNotificationId()})
+	if err != nil {
+		return resp, err
+	}
+	for {
+		event, recvErr := stream.Recv()
+		if recvErr != nil {
+			return resp, recvErr
+		}
+		if event.GetNotification() == nil {
+			continue
+		}
+		resp = event.GetNotification()
+		if done, _ := terminalOutcome(resp.GetStatus()); done {
+			return resp, nil
 		}
+	}
+}
 
-		time.Sleep(sendPollInterval)
-		statusResp, statusErr := clientInstance.GetNotificationStatus(resp.NotificationId)
+func isResumableWatchError(err error) bool {
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Aborted, codes.Unavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// pollForTerminalStatus polls GetNotificationStatus every sendPollInterval until resp reaches a
+// terminal status or ctx ends.
+func (clientInstance *NotificationClient) pollForTerminalStatus(ctx context.Context, resp *grpcapi.NotificationResponse) (*grpcapi.NotificationResponse, error) {
+	ticker := time.NewTicker(sendPollInterval)
+	defer ticker.Stop()
+	for {
+		if done, outcomeErr := terminalOutcome(resp.GetStatus()); done {
+			return resp, outcomeErr
+		}
+		select {
+		case <-ctx.Done():
+			return resp, fmt.Errorf("timeout waiting for notification to be sent: %w", ctx.Err())
+		case <-ticker.C:
+		}
+		statusCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+clientInstance.authToken)
+		statusResp, statusErr := clientInstance.grpcClient.GetNotificationStatus(statusCtx, &grpcapi.GetNotificationStatusRequest{NotificationId: resp.GetNotificationId()})
 		if statusErr != nil {
-			clientInstance.logger.Error("GetNotificationStatus failed", "notificationID", resp.NotificationId, "error", statusErr)
+			if ctx.Err() != nil {
+				return resp, fmt.Errorf("timeout waiting for notification to be sent: %w", ctx.Err())
+			}
+			clientInstance.logger.Error("GetNotificationStatus failed", "notificationID", resp.GetNotificationId(), "error", statusErr)
 			return nil, statusErr
 		}
 		resp = statusResp
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"net"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/temirov/pinguin/pkg/grpcapi"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewSettingsValidation(t *testing.T) {
@@ -46,9 +49,12 @@ type fakeNotificationServer struct {
 	initialStatus grpcapi.Status
 	polledStatus  grpcapi.Status
 	statusCalls   int
+	receivedKeys  []string
+	batchKeys     [][]string
 }
 
-func (s *fakeNotificationServer) SendNotification(context.Context, *grpcapi.NotificationRequest) (*grpcapi.NotificationResponse, error) {
+func (s *fakeNotificationServer) SendNotification(_ context.Context, req *grpcapi.NotificationRequest) (*grpcapi.NotificationResponse, error) {
+	s.receivedKeys = append(s.receivedKeys, req.GetIdempotencyKey())
 	return &grpcapi.NotificationResponse{
 		NotificationId: "notif-123",
 		Status:         s.initialStatus,
@@ -64,6 +70,24 @@ func (s *fakeNotificationServer) GetNotificationStatus(context.Context, *grpcapi
 	}, nil
 }
 
+func (s *fakeNotificationServer) SendNotificationBatch(_ context.Context, req *grpcapi.NotificationBatchRequest) (*grpcapi.NotificationBatchResponse, error) {
+	keys := make([]string, 0, len(req.GetRequests()))
+	items := make([]*grpcapi.NotificationBatchItem, 0, len(req.GetRequests()))
+	for index, request := range req.GetRequests() {
+		keys = append(keys, request.GetIdempotencyKey())
+		items = append(items, &grpcapi.NotificationBatchItem{Index: int32(index), Notification: &grpcapi.NotificationResponse{BatchId: "batch-1", BatchIndex: int32(index)}})
+	}
+	s.batchKeys = append(s.batchKeys, keys)
+	return &grpcapi.NotificationBatchResponse{BatchId: "batch-1", Items: items, AcceptedCount: int32(len(items))}, nil
+}
+
+func (s *fakeNotificationServer) GetNotificationBatch(_ context.Context, req *grpcapi.GetNotificationBatchRequest) (*grpcapi.GetNotificationBatchResponse, error) {
+	return &grpcapi.GetNotificationBatchResponse{
+		BatchId:       req.GetBatchId(),
+		Notifications: []*grpcapi.NotificationResponse{{NotificationId: "notif-123", BatchId: req.GetBatchId()}},
+	}, nil
+}
+
 func startFakeServer(t *testing.T, srv grpcapi.NotificationServiceServer) (string, func()) {
 	t.Helper()
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
@@ -127,12 +151,99 @@ func TestNotificationClientSendAndWait(t *testing.T) {
 	}
 }
 
+func TestNotificationClientAssignsIdempotencyKeys(t *testing.T) {
+	t.Helper()
+
+	server := &fakeNotificationServer{initialStatus: grpcapi.Status_SENT}
+	address, stop := startFakeServer(t, server)
+	defer stop()
+
+	settings, err := NewSettings(address, "token", 5, 5)
+	if err != nil {
+		t.Fatalf("NewSettings error: %v", err)
+	}
+	clientInstance, err := NewNotificationClient(newTestLogger(), settings)
+	if err != nil {
+		t.Fatalf("NewNotificationClient error: %v", err)
+	}
+	defer clientInstance.Close()
+
+	reused := &grpcapi.NotificationRequest{Message: "first"}
+	for _, message := range []string{"first", "second"} {
+		reused.Message = message
+		if _, sendErr := clientInstance.SendNotification(context.Background(), reused); sendErr != nil {
+			t.Fatalf("SendNotification error: %v", sendErr)
+		}
+	}
+	if reused.GetIdempotencyKey() != "" {
+		t.Fatalf("expected the caller's request to be left unchanged, got key %q", reused.GetIdempotencyKey())
+	}
+	explicit := &grpcapi.NotificationRequest{IdempotencyKey: "caller-key"}
+	if _, sendErr := clientInstance.SendNotification(context.Background(), explicit); sendErr != nil {
+		t.Fatalf("SendNotification error: %v", sendErr)
+	}
+
+	if len(server.receivedKeys) != 3 {
+		t.Fatalf("expected three requests, got %d", len(server.receivedKeys))
+	}
+	if server.receivedKeys[0] == "" || server.receivedKeys[1] == "" || server.receivedKeys[0] == server.receivedKeys[1] {
+		t.Fatalf("expected each send of a keyless request to get its own key, got %v", server.receivedKeys)
+	}
+	if server.receivedKeys[2] != "caller-key" {
+		t.Fatalf("expected caller key to be preserved, got %q", server.receivedKeys[2])
+	}
+	if NewIdempotencyKey() == NewIdempotencyKey() {
+		t.Fatalf("expected generated keys to differ")
+	}
+}
+
+func TestNotificationClientSendBatch(t *testing.T) {
+	t.Helper()
+
+	server := &fakeNotificationServer{}
+	address, stop := startFakeServer(t, server)
+	defer stop()
+
+	settings, err := NewSettings(address, "token", 5, 5)
+	if err != nil {
+		t.Fatalf("NewSettings error: %v", err)
+	}
+	clientInstance, err := NewNotificationClient(newTestLogger(), settings)
+	if err != nil {
+		t.Fatalf("NewNotificationClient error: %v", err)
+	}
+	defer clientInstance.Close()
+
+	requests := []*grpcapi.NotificationRequest{{}, {IdempotencyKey: "caller-key"}}
+	for attempt := 0; attempt < 2; attempt++ {
+		resp, sendErr := clientInstance.SendNotificationBatch(context.Background(), requests)
+		if sendErr != nil || resp.GetBatchId() != "batch-1" || len(resp.GetItems()) != 2 {
+			t.Fatalf("SendNotificationBatch failed: resp=%v err=%v", resp, sendErr)
+		}
+	}
+	first, second := server.batchKeys[0], server.batchKeys[1]
+	if first[0] == "" || first[0] == second[0] {
+		t.Fatalf("expected keyless requests to get a fresh key per send, got %v", server.batchKeys)
+	}
+	if requests[0].GetIdempotencyKey() != "" {
+		t.Fatalf("expected the caller's requests to be left unchanged, got key %q", requests[0].GetIdempotencyKey())
+	}
+	if first[1] != "caller-key" || second[1] != "caller-key" {
+		t.Fatalf("expected caller key to be preserved, got %v", server.batchKeys)
+	}
+
+	batch, err := clientInstance.GetNotificationBatch("batch-1")
+	if err != nil || batch.GetBatchId() != "batch-1" || len(batch.GetNotifications()) != 1 {
+		t.Fatalf("GetNotificationBatch failed: resp=%v err=%v", batch, err)
+	}
+}
+
 func TestNotificationClientFailurePaths(t *testing.T) {
 	t.Helper()
 	t.Cleanup(func() { sendPollInterval = 2 * time.Second })
 	sendPollInterval = 5 * time.Millisecond
 
-	failureAddr := startServerWithStatuses(t, grpcapi.Status_FAILED, grpcapi.Status_FAILED)
+	failureAddr := startServerWithStatuses(t, grpcapi.Status_DEAD, grpcapi.Status_DEAD)
 	settings, err := NewSettings(failureAddr, "token", 5, 1)
 	if err != nil {
 		t.Fatalf("NewSettings error: %v", err)
@@ -144,7 +255,7 @@ func TestNotificationClientFailurePaths(t *testing.T) {
 	defer clientInstance.Close()
 
 	resp, err := clientInstance.SendNotificationAndWait(&grpcapi.NotificationRequest{})
-	if err == nil || resp.Status != grpcapi.Status_FAILED {
+	if err == nil || resp.Status != grpcapi.Status_DEAD {
 		t.Fatalf("expected failure status and error, got resp=%v err=%v", resp, err)
 	}
 
@@ -165,6 +276,149 @@ func TestNotificationClientFailurePaths(t *testing.T) {
 	}
 }
 
+// watchScript is what one WatchNotifications call sends before ending with err.
+type watchScript struct {
+	statuses []grpcapi.Status
+	err      error
+}
+
+type fakeWatchServer struct {
+	fakeNotificationServer
+	scripts    []watchScript
+	watchCalls int
+}
+
+func (s *fakeWatchServer) WatchNotifications(req *grpcapi.WatchNotificationsRequest, stream grpcapi.NotificationService_WatchNotificationsServer) error {
+	s.watchCalls++
+	if len(s.scripts) == 0 {
+		<-stream.Context().Done()
+		return nil
+	}
+	script := s.scripts[0]
+	s.scripts = s.scripts[1:]
+	for index, status := range script.statuses {
+		event := &grpcapi.NotificationEvent{
+			Notification: &grpcapi.NotificationResponse{NotificationId: req.GetNotificationId(), Status: status},
+			Snapshot:     index == 0,
+		}
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+	return script.err
+}
+
+func TestSendNotificationAndWaitFollowsWatchStream(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { sendPollInterval = 2 * time.Second })
+	sendPollInterval = 5 * time.Millisecond
+
+	testCases := []struct {
+		name               string
+		scripts            []watchScript
+		expectedStatus     grpcapi.Status
+		expectFailure      bool
+		expectedWatchCalls int
+	}{
+		{
+			name:               "sent",
+			scripts:            []watchScript{{statuses: []grpcapi.Status{grpcapi.Status_QUEUED, grpcapi.Status_SENT}}},
+			expectedStatus:     grpcapi.Status_SENT,
+			expectedWatchCalls: 1,
+		},
+		{
+			name:               "waits through errored retries",
+			scripts:            []watchScript{{statuses: []grpcapi.Status{grpcapi.Status_QUEUED, grpcapi.Status_ERRORED, grpcapi.Status_ERRORED, grpcapi.Status_SENT}}},
+			expectedStatus:     grpcapi.Status_SENT,
+			expectedWatchCalls: 1,
+		},
+		{
+			name:               "dead is terminal",
+			scripts:            []watchScript{{statuses: []grpcapi.Status{grpcapi.Status_ERRORED, grpcapi.Status_DEAD}}},
+			expectedStatus:     grpcapi.Status_DEAD,
+			expectFailure:      true,
+			expectedWatchCalls: 1,
+		},
+		{
+			name:               "legacy failed is terminal",
+			scripts:            []watchScript{{statuses: []grpcapi.Status{grpcapi.Status_QUEUED, grpcapi.Status_FAILED}}},
+			expectedStatus:     grpcapi.Status_FAILED,
+			expectFailure:      true,
+			expectedWatchCalls: 1,
+		},
+		{
+			name:               "cancelled is terminal",
+			scripts:            []watchScript{{statuses: []grpcapi.Status{grpcapi.Status_CANCELLED}}},
+			expectedStatus:     grpcapi.Status_CANCELLED,
+			expectFailure:      true,
+			expectedWatchCalls: 1,
+		},
+		{
+			name: "resubscribes after the server ends the stream",
+			scripts: []watchScript{
+				{statuses: []grpcapi.Status{grpcapi.Status_QUEUED}, err: status.Error(codes.Aborted, "watch fell behind")},
+				{statuses: []grpcapi.Status{grpcapi.Status_QUEUED}, err: status.Error(codes.Unavailable, "server is shutting down")},
+				{statuses: []grpcapi.Status{grpcapi.Status_DELIVERED}},
+			},
+			expectedStatus:     grpcapi.Status_DELIVERED,
+			expectedWatchCalls: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := &fakeWatchServer{
+				fakeNotificationServer: fakeNotificationServer{initialStatus: grpcapi.Status_QUEUED},
+				scripts:                testCase.scripts,
+			}
+			address, stop := startFakeServer(t, server)
+			defer stop()
+			settings, err := NewSettings(address, "token", 5, 5)
+			if err != nil {
+				t.Fatalf("NewSettings error: %v", err)
+			}
+			clientInstance, err := NewNotificationClient(newTestLogger(), settings)
+			if err != nil {
+				t.Fatalf("NewNotificationClient error: %v", err)
+			}
+			defer clientInstance.Close()
+
+			resp, err := clientInstance.SendNotificationAndWait(&grpcapi.NotificationRequest{})
+			if testCase.expectFailure != errors.Is(err, ErrNotificationFailed) || (!testCase.expectFailure && err != nil) {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if resp.GetStatus() != testCase.expectedStatus {
+				t.Fatalf("expected status %v, got %v", testCase.expectedStatus, resp.GetStatus())
+			}
+			if server.watchCalls != testCase.expectedWatchCalls || server.statusCalls != 0 {
+				t.Fatalf("expected %d watch calls and no polling, got %d watch and %d status calls", testCase.expectedWatchCalls, server.watchCalls, server.statusCalls)
+			}
+		})
+	}
+}
+
+func TestSendNotificationAndWaitTimesOutOnQuietStream(t *testing.T) {
+	t.Helper()
+
+	server := &fakeWatchServer{fakeNotificationServer: fakeNotificationServer{initialStatus: grpcapi.Status_QUEUED}}
+	address, stop := startFakeServer(t, server)
+	defer stop()
+	settings, err := NewSettings(address, "token", 5, 1)
+	if err != nil {
+		t.Fatalf("NewSettings error: %v", err)
+	}
+	clientInstance, err := NewNotificationClient(newTestLogger(), settings)
+	if err != nil {
+		t.Fatalf("NewNotificationClient error: %v", err)
+	}
+	defer clientInstance.Close()
+
+	resp, err := clientInstance.SendNotificationAndWait(&grpcapi.NotificationRequest{})
+	if !errors.Is(err, context.DeadlineExceeded) || resp.GetStatus() != grpcapi.Status_QUEUED {
+		t.Fatalf("expected a timeout with the queued notification, got resp=%v err=%v", resp, err)
+	}
+}
+
 func startServerWithStatuses(t *testing.T, initial, polled grpcapi.Status) string {
 	t.Helper()
 	server := &fakeNotificationServer{
@@ -73,31 +73,46 @@ func (NotificationType) EnumDescriptor() ([]byte, []int) {
 type Status int32
 
 const (
-	Status_QUEUED    Status = 0
-	Status_SENT      Status = 1
-	Status_FAILED    Status = 2
-	Status_UNKNOWN   Status = 3
-	Status_CANCELLED Status = 4
-	Status_ERRORED   Status = 5
+	Status_QUEUED      Status = 0
+	Status_SENT        Status = 1
+	Status_FAILED      Status = 2
+	Status_UNKNOWN     Status = 3
+	Status_CANCELLED   Status = 4
+	Status_ERRORED     Status = 5
+	Status_DELIVERED   Status = 6  // Provider confirmed delivery to the handset or mailbox.
+	Status_UNDELIVERED Status = 7  // Provider reported the message could not be delivered.
+	Status_BOUNCED     Status = 8  // Receiving mail server bounced the email.
+	Status_SUPPRESSED  Status = 9  // Recipient is on the suppression list; nothing was sent.
+	Status_DEAD        Status = 10 // Retries are exhausted; the notification stays put until it is requeued.
 )
 
 // Enum value maps for Status.
 var (
 	Status_name = map[int32]string{
-		0: "QUEUED",
-		1: "SENT",
-		2: "FAILED",
-		3: "UNKNOWN",
-		4: "CANCELLED",
-		5: "ERRORED",
+		0:  "QUEUED",
+		1:  "SENT",
+		2:  "FAILED",
+		3:  "UNKNOWN",
+		4:  "CANCELLED",
+		5:  "ERRORED",
+		6:  "DELIVERED",
+		7:  "UNDELIVERED",
+		8:  "BOUNCED",
+		9:  "SUPPRESSED",
+		10: "DEAD",
 	}
 	Status_value = map[string]int32{
-		"QUEUED":    0,
-		"SENT":      1,
-		"FAILED":    2,
-		"UNKNOWN":   3,
-		"CANCELLED": 4,
-		"ERRORED":   5,
+		"QUEUED":      0,
+		"SENT":        1,
+		"FAILED":      2,
+		"UNKNOWN":     3,
+		"CANCELLED":   4,
+		"ERRORED":     5,
+		"DELIVERED":   6,
+		"UNDELIVERED": 7,
+		"BOUNCED":     8,
+		"SUPPRESSED":  9,
+		"DEAD":        10,
 	}
 )
 
@@ -128,12 +143,117 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return file_pinguin_proto_rawDescGZIP(), []int{1}
 }
 
+// Enumeration for the header an email recipient was supplied in.
+type RecipientKind int32
+
+const (
+	RecipientKind_TO  RecipientKind = 0
+	RecipientKind_CC  RecipientKind = 1
+	RecipientKind_BCC RecipientKind = 2
+)
+
+// Enum value maps for RecipientKind.
+var (
+	RecipientKind_name = map[int32]string{
+		0: "TO",
+		1: "CC",
+		2: "BCC",
+	}
+	RecipientKind_value = map[string]int32{
+		"TO":  0,
+		"CC":  1,
+		"BCC": 2,
+	}
+)
+
+func (x RecipientKind) Enum() *RecipientKind {
+	p := new(RecipientKind)
+	*p = x
+	return p
+}
+
+func (x RecipientKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecipientKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_pinguin_proto_enumTypes[2].Descriptor()
+}
+
+func (RecipientKind) Type() protoreflect.EnumType {
+	return &file_pinguin_proto_enumTypes[2]
+}
+
+func (x RecipientKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecipientKind.Descriptor instead.
+func (RecipientKind) EnumDescriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{2}
+}
+
+// Enumeration for the mail server response to an individual recipient.
+type RecipientStatus int32
+
+const (
+	RecipientStatus_PENDING  RecipientStatus = 0
+	RecipientStatus_ACCEPTED RecipientStatus = 1
+	RecipientStatus_REJECTED RecipientStatus = 2
+	RecipientStatus_SKIPPED  RecipientStatus = 3
+)
+
+// Enum value maps for RecipientStatus.
+var (
+	RecipientStatus_name = map[int32]string{
+		0: "PENDING",
+		1: "ACCEPTED",
+		2: "REJECTED",
+		3: "SKIPPED",
+	}
+	RecipientStatus_value = map[string]int32{
+		"PENDING":  0,
+		"ACCEPTED": 1,
+		"REJECTED": 2,
+		"SKIPPED":  3,
+	}
+)
+
+func (x RecipientStatus) Enum() *RecipientStatus {
+	p := new(RecipientStatus)
+	*p = x
+	return p
+}
+
+func (x RecipientStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecipientStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_pinguin_proto_enumTypes[3].Descriptor()
+}
+
+func (RecipientStatus) Type() protoreflect.EnumType {
+	return &file_pinguin_proto_enumTypes[3]
+}
+
+func (x RecipientStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecipientStatus.Descriptor instead.
+func (RecipientStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{3}
+}
+
 // Attachment metadata for email notifications.
 type EmailAttachment struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Filename    string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data        []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Size of the attachment in bytes; list responses leave data empty and report only the size.
+	SizeBytes     int64 `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -189,6 +309,13 @@ func (x *EmailAttachment) GetData() []byte {
 	return nil
 }
 
+func (x *EmailAttachment) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
 // Request to send a notification.
 type NotificationRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -198,6 +325,16 @@ type NotificationRequest struct {
 	Message          string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 	ScheduledTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
 	Attachments      []*EmailAttachment     `protobuf:"bytes,6,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	To               []string               `protobuf:"bytes,7,rep,name=to,proto3" json:"to,omitempty"`                                                                                                                    // Email only; recipient is treated as the first To address.
+	Cc               []string               `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`                                                                                                                    // Email only.
+	Bcc              []string               `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`                                                                                                                  // Email only; never rendered in message headers.
+	HtmlMessage      string                 `protobuf:"bytes,10,opt,name=html_message,json=htmlMessage,proto3" json:"html_message,omitempty"`                                                                              // Optional HTML alternative to message; email only.
+	TemplateId       string                 `protobuf:"bytes,11,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`                                                                                 // Renders subject and bodies from a stored template instead of message.
+	TemplateVersion  int32                  `protobuf:"varint,12,opt,name=template_version,json=templateVersion,proto3" json:"template_version,omitempty"`                                                                 // Zero selects the latest version.
+	TemplateData     map[string]string      `protobuf:"bytes,13,rep,name=template_data,json=templateData,proto3" json:"template_data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Variables referenced by the template.
+	IdempotencyKey   string                 `protobuf:"bytes,14,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                                                     // Resubmitting the same key and payload returns the original response.
+	Category         string                 `protobuf:"bytes,15,opt,name=category,proto3" json:"category,omitempty"`                                                                                                       // Email only; categorized emails carry one-click unsubscribe headers.
+	DeliveryMode     string                 `protobuf:"bytes,16,opt,name=delivery_mode,json=deliveryMode,proto3" json:"delivery_mode,omitempty"`                                                                           // "enqueue" or "inline"; empty uses the server's DISPATCH_MODE.
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -274,28 +411,184 @@ func (x *NotificationRequest) GetAttachments() []*EmailAttachment {
 	return nil
 }
 
+func (x *NotificationRequest) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *NotificationRequest) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *NotificationRequest) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *NotificationRequest) GetHtmlMessage() string {
+	if x != nil {
+		return x.HtmlMessage
+	}
+	return ""
+}
+
+func (x *NotificationRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *NotificationRequest) GetTemplateVersion() int32 {
+	if x != nil {
+		return x.TemplateVersion
+	}
+	return 0
+}
+
+func (x *NotificationRequest) GetTemplateData() map[string]string {
+	if x != nil {
+		return x.TemplateData
+	}
+	return nil
+}
+
+func (x *NotificationRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *NotificationRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *NotificationRequest) GetDeliveryMode() string {
+	if x != nil {
+		return x.DeliveryMode
+	}
+	return ""
+}
+
+// Delivery outcome for a single email recipient.
+type RecipientDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Kind          RecipientKind          `protobuf:"varint,2,opt,name=kind,proto3,enum=pinguin.RecipientKind" json:"kind,omitempty"`
+	Status        RecipientStatus        `protobuf:"varint,3,opt,name=status,proto3,enum=pinguin.RecipientStatus" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipientDelivery) Reset() {
+	*x = RecipientDelivery{}
+	mi := &file_pinguin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipientDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipientDelivery) ProtoMessage() {}
+
+func (x *RecipientDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipientDelivery.ProtoReflect.Descriptor instead.
+func (*RecipientDelivery) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecipientDelivery) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RecipientDelivery) GetKind() RecipientKind {
+	if x != nil {
+		return x.Kind
+	}
+	return RecipientKind_TO
+}
+
+func (x *RecipientDelivery) GetStatus() RecipientStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RecipientStatus_PENDING
+}
+
+func (x *RecipientDelivery) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 // Response returned after sending (or when retrieving) a notification.
 type NotificationResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId    string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	NotificationType  NotificationType       `protobuf:"varint,2,opt,name=notification_type,json=notificationType,proto3,enum=pinguin.NotificationType" json:"notification_type,omitempty"`
-	Recipient         string                 `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
-	Subject           string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
-	Message           string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
-	Status            Status                 `protobuf:"varint,6,opt,name=status,proto3,enum=pinguin.Status" json:"status,omitempty"`
-	ProviderMessageId string                 `protobuf:"bytes,7,opt,name=provider_message_id,json=providerMessageId,proto3" json:"provider_message_id,omitempty"`
-	RetryCount        int32                  `protobuf:"varint,8,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
-	CreatedAt         string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ScheduledTime     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
-	Attachments       []*EmailAttachment     `protobuf:"bytes,12,rep,name=attachments,proto3" json:"attachments,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId      string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	NotificationType    NotificationType       `protobuf:"varint,2,opt,name=notification_type,json=notificationType,proto3,enum=pinguin.NotificationType" json:"notification_type,omitempty"`
+	Recipient           string                 `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Subject             string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Message             string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Status              Status                 `protobuf:"varint,6,opt,name=status,proto3,enum=pinguin.Status" json:"status,omitempty"`
+	ProviderMessageId   string                 `protobuf:"bytes,7,opt,name=provider_message_id,json=providerMessageId,proto3" json:"provider_message_id,omitempty"`
+	RetryCount          int32                  `protobuf:"varint,8,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	CreatedAt           string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ScheduledTime       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
+	Attachments         []*EmailAttachment     `protobuf:"bytes,12,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	To                  []string               `protobuf:"bytes,13,rep,name=to,proto3" json:"to,omitempty"`
+	Cc                  []string               `protobuf:"bytes,14,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc                 []string               `protobuf:"bytes,15,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	RecipientDeliveries []*RecipientDelivery   `protobuf:"bytes,16,rep,name=recipient_deliveries,json=recipientDeliveries,proto3" json:"recipient_deliveries,omitempty"`
+	HtmlMessage         string                 `protobuf:"bytes,17,opt,name=html_message,json=htmlMessage,proto3" json:"html_message,omitempty"`
+	TemplateId          string                 `protobuf:"bytes,18,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	TemplateVersion     int32                  `protobuf:"varint,19,opt,name=template_version,json=templateVersion,proto3" json:"template_version,omitempty"`
+	IdempotencyKey      string                 `protobuf:"bytes,20,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Provider            string                 `protobuf:"bytes,21,opt,name=provider,proto3" json:"provider,omitempty"`                                   // Provider that delivered the notification, e.g. "smtp" or "twilio".
+	ProviderStatus      string                 `protobuf:"bytes,22,opt,name=provider_status,json=providerStatus,proto3" json:"provider_status,omitempty"` // Provider-reported status at acceptance, e.g. Twilio "queued".
+	SegmentCount        int32                  `protobuf:"varint,23,opt,name=segment_count,json=segmentCount,proto3" json:"segment_count,omitempty"`
+	Price               string                 `protobuf:"bytes,24,opt,name=price,proto3" json:"price,omitempty"`
+	PriceUnit           string                 `protobuf:"bytes,25,opt,name=price_unit,json=priceUnit,proto3" json:"price_unit,omitempty"`
+	Category            string                 `protobuf:"bytes,26,opt,name=category,proto3" json:"category,omitempty"`
+	BatchId             string                 `protobuf:"bytes,27,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`           // Set for notifications submitted through SendNotificationBatch.
+	BatchIndex          int32                  `protobuf:"varint,28,opt,name=batch_index,json=batchIndex,proto3" json:"batch_index,omitempty"` // Position of the notification's request within its batch.
+	LastError           string                 `protobuf:"bytes,29,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`     // Error of the most recent failed attempt.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *NotificationResponse) Reset() {
 	*x = NotificationResponse{}
-	mi := &file_pinguin_proto_msgTypes[2]
+	mi := &file_pinguin_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -307,7 +600,7 @@ func (x *NotificationResponse) String() string {
 func (*NotificationResponse) ProtoMessage() {}
 
 func (x *NotificationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pinguin_proto_msgTypes[2]
+	mi := &file_pinguin_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -320,7 +613,7 @@ func (x *NotificationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationResponse.ProtoReflect.Descriptor instead.
 func (*NotificationResponse) Descriptor() ([]byte, []int) {
-	return file_pinguin_proto_rawDescGZIP(), []int{2}
+	return file_pinguin_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *NotificationResponse) GetNotificationId() string {
@@ -407,6 +700,125 @@ func (x *NotificationResponse) GetAttachments() []*EmailAttachment {
 	return nil
 }
 
+func (x *NotificationResponse) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *NotificationResponse) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *NotificationResponse) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *NotificationResponse) GetRecipientDeliveries() []*RecipientDelivery {
+	if x != nil {
+		return x.RecipientDeliveries
+	}
+	return nil
+}
+
+func (x *NotificationResponse) GetHtmlMessage() string {
+	if x != nil {
+		return x.HtmlMessage
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetTemplateVersion() int32 {
+	if x != nil {
+		return x.TemplateVersion
+	}
+	return 0
+}
+
+func (x *NotificationResponse) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetProviderStatus() string {
+	if x != nil {
+		return x.ProviderStatus
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetSegmentCount() int32 {
+	if x != nil {
+		return x.SegmentCount
+	}
+	return 0
+}
+
+func (x *NotificationResponse) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetPriceUnit() string {
+	if x != nil {
+		return x.PriceUnit
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *NotificationResponse) GetBatchIndex() int32 {
+	if x != nil {
+		return x.BatchIndex
+	}
+	return 0
+}
+
+func (x *NotificationResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
 // Request for retrieving the status.
 type GetNotificationStatusRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -417,7 +829,7 @@ type GetNotificationStatusRequest struct {
 
 func (x *GetNotificationStatusRequest) Reset() {
 	*x = GetNotificationStatusRequest{}
-	mi := &file_pinguin_proto_msgTypes[3]
+	mi := &file_pinguin_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -429,7 +841,7 @@ func (x *GetNotificationStatusRequest) String() string {
 func (*GetNotificationStatusRequest) ProtoMessage() {}
 
 func (x *GetNotificationStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pinguin_proto_msgTypes[3]
+	mi := &file_pinguin_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -442,7 +854,7 @@ func (x *GetNotificationStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNotificationStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetNotificationStatusRequest) Descriptor() ([]byte, []int) {
-	return file_pinguin_proto_rawDescGZIP(), []int{3}
+	return file_pinguin_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetNotificationStatusRequest) GetNotificationId() string {
@@ -452,29 +864,1814 @@ func (x *GetNotificationStatusRequest) GetNotificationId() string {
 	return ""
 }
 
-// Request for listing notifications.
+type GetNotificationAttemptsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetNotificationAttemptsRequest) Reset() {
+	*x = GetNotificationAttemptsRequest{}
+	mi := &file_pinguin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationAttemptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationAttemptsRequest) ProtoMessage() {}
+
+func (x *GetNotificationAttemptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationAttemptsRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationAttemptsRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetNotificationAttemptsRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+// One delivery attempt of a notification.
+type NotificationAttempt struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AttemptedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=attempted_at,json=attemptedAt,proto3" json:"attempted_at,omitempty"`
+	Provider    string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	DurationMs  int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Status the attempt left the notification in.
+	Outcome Status `protobuf:"varint,4,opt,name=outcome,proto3,enum=pinguin.Status" json:"outcome,omitempty"`
+	// recipient, rejected, unavailable, timeout, network, configuration or unknown; empty when the
+	// attempt succeeded.
+	ErrorClass   string `protobuf:"bytes,5,opt,name=error_class,json=errorClass,proto3" json:"error_class,omitempty"`
+	ErrorMessage string `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// Reply code of a failed SMTP conversation, zero otherwise.
+	SmtpReplyCode int32 `protobuf:"varint,7,opt,name=smtp_reply_code,json=smtpReplyCode,proto3" json:"smtp_reply_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationAttempt) Reset() {
+	*x = NotificationAttempt{}
+	mi := &file_pinguin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationAttempt) ProtoMessage() {}
+
+func (x *NotificationAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationAttempt.ProtoReflect.Descriptor instead.
+func (*NotificationAttempt) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NotificationAttempt) GetAttemptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttemptedAt
+	}
+	return nil
+}
+
+func (x *NotificationAttempt) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *NotificationAttempt) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *NotificationAttempt) GetOutcome() Status {
+	if x != nil {
+		return x.Outcome
+	}
+	return Status_QUEUED
+}
+
+func (x *NotificationAttempt) GetErrorClass() string {
+	if x != nil {
+		return x.ErrorClass
+	}
+	return ""
+}
+
+func (x *NotificationAttempt) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *NotificationAttempt) GetSmtpReplyCode() int32 {
+	if x != nil {
+		return x.SmtpReplyCode
+	}
+	return 0
+}
+
+// Attempts of a notification, oldest first.
+type GetNotificationAttemptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempts      []*NotificationAttempt `protobuf:"bytes,1,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationAttemptsResponse) Reset() {
+	*x = GetNotificationAttemptsResponse{}
+	mi := &file_pinguin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationAttemptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationAttemptsResponse) ProtoMessage() {}
+
+func (x *GetNotificationAttemptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationAttemptsResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationAttemptsResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetNotificationAttemptsResponse) GetAttempts() []*NotificationAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+// Request for listing notifications.
 type ListNotificationsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Statuses []Status               `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=pinguin.Status" json:"statuses,omitempty"`
+	Types    []NotificationType     `protobuf:"varint,2,rep,packed,name=types,proto3,enum=pinguin.NotificationType" json:"types,omitempty"`
+	// Exact recipient match.
+	Recipient string `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// Case-insensitive recipient prefix match.
+	RecipientPrefix string `protobuf:"bytes,4,opt,name=recipient_prefix,json=recipientPrefix,proto3" json:"recipient_prefix,omitempty"`
+	// Ranges include their after bound and exclude their before bound.
+	CreatedAfter    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	ScheduledAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=scheduled_after,json=scheduledAfter,proto3" json:"scheduled_after,omitempty"`
+	ScheduledBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=scheduled_before,json=scheduledBefore,proto3" json:"scheduled_before,omitempty"`
+	// Case-insensitive substring of the subject or message.
+	Text string `protobuf:"bytes,9,opt,name=text,proto3" json:"text,omitempty"`
+	// Defaults to 50 and is capped at 500.
+	PageSize int32 `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous response.
+	PageToken     string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_pinguin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListNotificationsRequest) GetStatuses() []Status {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetTypes() []NotificationType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetRecipientPrefix() string {
+	if x != nil {
+		return x.RecipientPrefix
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetScheduledAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAfter
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetScheduledBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledBefore
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNotificationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response containing notifications for list requests.
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Notifications []*NotificationResponse `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_pinguin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*NotificationResponse {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request for streaming notification status transitions. Empty fields match every notification.
+type WatchNotificationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Watch a single notification; the stream starts with its current state.
+	NotificationId string             `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	BatchId        string             `protobuf:"bytes,2,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Statuses       []Status           `protobuf:"varint,3,rep,packed,name=statuses,proto3,enum=pinguin.Status" json:"statuses,omitempty"`
+	Types          []NotificationType `protobuf:"varint,4,rep,packed,name=types,proto3,enum=pinguin.NotificationType" json:"types,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WatchNotificationsRequest) Reset() {
+	*x = WatchNotificationsRequest{}
+	mi := &file_pinguin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNotificationsRequest) ProtoMessage() {}
+
+func (x *WatchNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*WatchNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchNotificationsRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *WatchNotificationsRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *WatchNotificationsRequest) GetStatuses() []Status {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *WatchNotificationsRequest) GetTypes() []NotificationType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+// A notification's state after a status transition. Attachment data is omitted.
+type NotificationEvent struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Notification *NotificationResponse  `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	// Set on the first event of a single-notification watch, which reports the current state
+	// rather than a transition.
+	Snapshot      bool `protobuf:"varint,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationEvent) Reset() {
+	*x = NotificationEvent{}
+	mi := &file_pinguin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationEvent) ProtoMessage() {}
+
+func (x *NotificationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationEvent.ProtoReflect.Descriptor instead.
+func (*NotificationEvent) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NotificationEvent) GetNotification() *NotificationResponse {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *NotificationEvent) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+// Request to reschedule a queued notification.
+type RescheduleNotificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	ScheduledTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RescheduleNotificationRequest) Reset() {
+	*x = RescheduleNotificationRequest{}
+	mi := &file_pinguin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RescheduleNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RescheduleNotificationRequest) ProtoMessage() {}
+
+func (x *RescheduleNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RescheduleNotificationRequest.ProtoReflect.Descriptor instead.
+func (*RescheduleNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RescheduleNotificationRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *RescheduleNotificationRequest) GetScheduledTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledTime
+	}
+	return nil
+}
+
+// Request to cancel a queued notification.
+type CancelNotificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelNotificationRequest) Reset() {
+	*x = CancelNotificationRequest{}
+	mi := &file_pinguin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelNotificationRequest) ProtoMessage() {}
+
+func (x *CancelNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelNotificationRequest.ProtoReflect.Descriptor instead.
+func (*CancelNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelNotificationRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+type RequeueNotificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RequeueNotificationRequest) Reset() {
+	*x = RequeueNotificationRequest{}
+	mi := &file_pinguin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueNotificationRequest) ProtoMessage() {}
+
+func (x *RequeueNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueNotificationRequest.ProtoReflect.Descriptor instead.
+func (*RequeueNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RequeueNotificationRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+// Filters for RequeueNotifications, matched as in ListNotificationsRequest. Statuses may only be
+// DEAD and ERRORED and default to DEAD.
+type RequeueNotificationsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Statuses        []Status               `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=pinguin.Status" json:"statuses,omitempty"`
+	Types           []NotificationType     `protobuf:"varint,2,rep,packed,name=types,proto3,enum=pinguin.NotificationType" json:"types,omitempty"`
+	Recipient       string                 `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	RecipientPrefix string                 `protobuf:"bytes,4,opt,name=recipient_prefix,json=recipientPrefix,proto3" json:"recipient_prefix,omitempty"`
+	CreatedAfter    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Text            string                 `protobuf:"bytes,7,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RequeueNotificationsRequest) Reset() {
+	*x = RequeueNotificationsRequest{}
+	mi := &file_pinguin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueNotificationsRequest) ProtoMessage() {}
+
+func (x *RequeueNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*RequeueNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RequeueNotificationsRequest) GetStatuses() []Status {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *RequeueNotificationsRequest) GetTypes() []NotificationType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *RequeueNotificationsRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *RequeueNotificationsRequest) GetRecipientPrefix() string {
+	if x != nil {
+		return x.RecipientPrefix
+	}
+	return ""
+}
+
+func (x *RequeueNotificationsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *RequeueNotificationsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *RequeueNotificationsRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type RequeueNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequeuedCount int32                  `protobuf:"varint,1,opt,name=requeued_count,json=requeuedCount,proto3" json:"requeued_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueNotificationsResponse) Reset() {
+	*x = RequeueNotificationsResponse{}
+	mi := &file_pinguin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueNotificationsResponse) ProtoMessage() {}
+
+func (x *RequeueNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*RequeueNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RequeueNotificationsResponse) GetRequeuedCount() int32 {
+	if x != nil {
+		return x.RequeuedCount
+	}
+	return 0
+}
+
+// Request submitting many notifications at once. Each request is validated on its own and accepted
+// notifications are queued for the background worker rather than sent inline.
+type NotificationBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*NotificationRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationBatchRequest) Reset() {
+	*x = NotificationBatchRequest{}
+	mi := &file_pinguin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationBatchRequest) ProtoMessage() {}
+
+func (x *NotificationBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationBatchRequest.ProtoReflect.Descriptor instead.
+func (*NotificationBatchRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NotificationBatchRequest) GetRequests() []*NotificationRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// Outcome of one request in a batch. index is the request's position in NotificationBatchRequest;
+// notification is set when it was stored, error and error_code when it was rejected.
+type NotificationBatchItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Notification  *NotificationResponse  `protobuf:"bytes,2,opt,name=notification,proto3" json:"notification,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // gRPC status code name, e.g. "InvalidArgument".
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationBatchItem) Reset() {
+	*x = NotificationBatchItem{}
+	mi := &file_pinguin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationBatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationBatchItem) ProtoMessage() {}
+
+func (x *NotificationBatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationBatchItem.ProtoReflect.Descriptor instead.
+func (*NotificationBatchItem) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NotificationBatchItem) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *NotificationBatchItem) GetNotification() *NotificationResponse {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *NotificationBatchItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *NotificationBatchItem) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type NotificationBatchResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	BatchId       string                   `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Items         []*NotificationBatchItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	AcceptedCount int32                    `protobuf:"varint,3,opt,name=accepted_count,json=acceptedCount,proto3" json:"accepted_count,omitempty"`
+	RejectedCount int32                    `protobuf:"varint,4,opt,name=rejected_count,json=rejectedCount,proto3" json:"rejected_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationBatchResponse) Reset() {
+	*x = NotificationBatchResponse{}
+	mi := &file_pinguin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationBatchResponse) ProtoMessage() {}
+
+func (x *NotificationBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationBatchResponse.ProtoReflect.Descriptor instead.
+func (*NotificationBatchResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NotificationBatchResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *NotificationBatchResponse) GetItems() []*NotificationBatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *NotificationBatchResponse) GetAcceptedCount() int32 {
+	if x != nil {
+		return x.AcceptedCount
+	}
+	return 0
+}
+
+func (x *NotificationBatchResponse) GetRejectedCount() int32 {
+	if x != nil {
+		return x.RejectedCount
+	}
+	return 0
+}
+
+// Request for the notifications stored by an earlier SendNotificationBatch call.
+type GetNotificationBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationBatchRequest) Reset() {
+	*x = GetNotificationBatchRequest{}
+	mi := &file_pinguin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationBatchRequest) ProtoMessage() {}
+
+func (x *GetNotificationBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationBatchRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetNotificationBatchRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+// Notifications stored for a batch, ordered by batch_index.
+type GetNotificationBatchResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	BatchId       string                  `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Notifications []*NotificationResponse `protobuf:"bytes,2,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationBatchResponse) Reset() {
+	*x = GetNotificationBatchResponse{}
+	mi := &file_pinguin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationBatchResponse) ProtoMessage() {}
+
+func (x *GetNotificationBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationBatchResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetNotificationBatchResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *GetNotificationBatchResponse) GetNotifications() []*NotificationResponse {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+// A single version of a named message template.
+type Template struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	PlainBody     string                 `protobuf:"bytes,5,opt,name=plain_body,json=plainBody,proto3" json:"plain_body,omitempty"`
+	HtmlBody      string                 `protobuf:"bytes,6,opt,name=html_body,json=htmlBody,proto3" json:"html_body,omitempty"`
+	SmsBody       string                 `protobuf:"bytes,7,opt,name=sms_body,json=smsBody,proto3" json:"sms_body,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Template) Reset() {
+	*x = Template{}
+	mi := &file_pinguin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Template) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Template) ProtoMessage() {}
+
+func (x *Template) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Template.ProtoReflect.Descriptor instead.
+func (*Template) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Template) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *Template) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Template) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Template) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Template) GetPlainBody() string {
+	if x != nil {
+		return x.PlainBody
+	}
+	return ""
+}
+
+func (x *Template) GetHtmlBody() string {
+	if x != nil {
+		return x.HtmlBody
+	}
+	return ""
+}
+
+func (x *Template) GetSmsBody() string {
+	if x != nil {
+		return x.SmsBody
+	}
+	return ""
+}
+
+func (x *Template) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Template) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// Request to create a template or store a new version of an existing one.
+type TemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	PlainBody     string                 `protobuf:"bytes,4,opt,name=plain_body,json=plainBody,proto3" json:"plain_body,omitempty"`
+	HtmlBody      string                 `protobuf:"bytes,5,opt,name=html_body,json=htmlBody,proto3" json:"html_body,omitempty"`
+	SmsBody       string                 `protobuf:"bytes,6,opt,name=sms_body,json=smsBody,proto3" json:"sms_body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateRequest) Reset() {
+	*x = TemplateRequest{}
+	mi := &file_pinguin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateRequest) ProtoMessage() {}
+
+func (x *TemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateRequest.ProtoReflect.Descriptor instead.
+func (*TemplateRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *TemplateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TemplateRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *TemplateRequest) GetPlainBody() string {
+	if x != nil {
+		return x.PlainBody
+	}
+	return ""
+}
+
+func (x *TemplateRequest) GetHtmlBody() string {
+	if x != nil {
+		return x.HtmlBody
+	}
+	return ""
+}
+
+func (x *TemplateRequest) GetSmsBody() string {
+	if x != nil {
+		return x.SmsBody
+	}
+	return ""
+}
+
+// Request for retrieving a template. A zero version selects the latest version.
+type GetTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateRequest) Reset() {
+	*x = GetTemplateRequest{}
+	mi := &file_pinguin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateRequest) ProtoMessage() {}
+
+func (x *GetTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *GetTemplateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Request for listing the latest version of every template.
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_pinguin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{25}
+}
+
+// Response containing templates for list requests.
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*Template            `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_pinguin_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*Template {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// Request to delete every version of a template.
+type DeleteTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	mi := &file_pinguin_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+// Response returned after deleting a template.
+type DeleteTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateResponse) Reset() {
+	*x = DeleteTemplateResponse{}
+	mi := &file_pinguin_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteTemplateResponse) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+// Request to render a template without sending a notification.
+type PreviewTemplateRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId       string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Version          int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	NotificationType NotificationType       `protobuf:"varint,3,opt,name=notification_type,json=notificationType,proto3,enum=pinguin.NotificationType" json:"notification_type,omitempty"`
+	TemplateData     map[string]string      `protobuf:"bytes,4,rep,name=template_data,json=templateData,proto3" json:"template_data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PreviewTemplateRequest) Reset() {
+	*x = PreviewTemplateRequest{}
+	mi := &file_pinguin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewTemplateRequest) ProtoMessage() {}
+
+func (x *PreviewTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewTemplateRequest.ProtoReflect.Descriptor instead.
+func (*PreviewTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PreviewTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *PreviewTemplateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PreviewTemplateRequest) GetNotificationType() NotificationType {
+	if x != nil {
+		return x.NotificationType
+	}
+	return NotificationType_EMAIL
+}
+
+func (x *PreviewTemplateRequest) GetTemplateData() map[string]string {
+	if x != nil {
+		return x.TemplateData
+	}
+	return nil
+}
+
+// Rendered template content.
+type PreviewTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	PlainBody     string                 `protobuf:"bytes,4,opt,name=plain_body,json=plainBody,proto3" json:"plain_body,omitempty"`
+	HtmlBody      string                 `protobuf:"bytes,5,opt,name=html_body,json=htmlBody,proto3" json:"html_body,omitempty"`
+	SmsBody       string                 `protobuf:"bytes,6,opt,name=sms_body,json=smsBody,proto3" json:"sms_body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewTemplateResponse) Reset() {
+	*x = PreviewTemplateResponse{}
+	mi := &file_pinguin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewTemplateResponse) ProtoMessage() {}
+
+func (x *PreviewTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewTemplateResponse.ProtoReflect.Descriptor instead.
+func (*PreviewTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PreviewTemplateResponse) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *PreviewTemplateResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PreviewTemplateResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *PreviewTemplateResponse) GetPlainBody() string {
+	if x != nil {
+		return x.PlainBody
+	}
+	return ""
+}
+
+func (x *PreviewTemplateResponse) GetHtmlBody() string {
+	if x != nil {
+		return x.HtmlBody
+	}
+	return ""
+}
+
+func (x *PreviewTemplateResponse) GetSmsBody() string {
+	if x != nil {
+		return x.SmsBody
+	}
+	return ""
+}
+
+// A recipient that notifications on a channel must not be delivered to.
+type Suppression struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       NotificationType       `protobuf:"varint,1,opt,name=channel,proto3,enum=pinguin.NotificationType" json:"channel,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // unsubscribed, bounced, complaint, stop or manual.
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unset for permanent suppressions.
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Category      string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"` // Empty for suppressions covering every category.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suppression) Reset() {
+	*x = Suppression{}
+	mi := &file_pinguin_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suppression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suppression) ProtoMessage() {}
+
+func (x *Suppression) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suppression.ProtoReflect.Descriptor instead.
+func (*Suppression) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Suppression) GetChannel() NotificationType {
+	if x != nil {
+		return x.Channel
+	}
+	return NotificationType_EMAIL
+}
+
+func (x *Suppression) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *Suppression) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Suppression) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Suppression) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Suppression) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Suppression) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Suppression) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+// Request for listing suppressions.
+type ListSuppressionsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Channels       []NotificationType     `protobuf:"varint,1,rep,packed,name=channels,proto3,enum=pinguin.NotificationType" json:"channels,omitempty"`
+	IncludeExpired bool                   `protobuf:"varint,2,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSuppressionsRequest) Reset() {
+	*x = ListSuppressionsRequest{}
+	mi := &file_pinguin_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppressionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppressionsRequest) ProtoMessage() {}
+
+func (x *ListSuppressionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppressionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSuppressionsRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListSuppressionsRequest) GetChannels() []NotificationType {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *ListSuppressionsRequest) GetIncludeExpired() bool {
+	if x != nil {
+		return x.IncludeExpired
+	}
+	return false
+}
+
+// Response containing suppressions for list requests.
+type ListSuppressionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Statuses      []Status               `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=pinguin.Status" json:"statuses,omitempty"`
+	Suppressions  []*Suppression         `protobuf:"bytes,1,rep,name=suppressions,proto3" json:"suppressions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotificationsRequest) Reset() {
-	*x = ListNotificationsRequest{}
-	mi := &file_pinguin_proto_msgTypes[4]
+func (x *ListSuppressionsResponse) Reset() {
+	*x = ListSuppressionsResponse{}
+	mi := &file_pinguin_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotificationsRequest) String() string {
+func (x *ListSuppressionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsRequest) ProtoMessage() {}
+func (*ListSuppressionsResponse) ProtoMessage() {}
 
-func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pinguin_proto_msgTypes[4]
+func (x *ListSuppressionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,41 +2682,46 @@ func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
-func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
-	return file_pinguin_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListSuppressionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSuppressionsResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *ListNotificationsRequest) GetStatuses() []Status {
+func (x *ListSuppressionsResponse) GetSuppressions() []*Suppression {
 	if x != nil {
-		return x.Statuses
+		return x.Suppressions
 	}
 	return nil
 }
 
-// Response containing notifications for list requests.
-type ListNotificationsResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Notifications []*NotificationResponse `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+// Request to suppress a recipient; an existing suppression is replaced.
+type AddSuppressionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       NotificationType       `protobuf:"varint,1,opt,name=channel,proto3,enum=pinguin.NotificationType" json:"channel,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Defaults to manual.
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"` // Defaults to api.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"` // Empty suppresses every category.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotificationsResponse) Reset() {
-	*x = ListNotificationsResponse{}
-	mi := &file_pinguin_proto_msgTypes[5]
+func (x *AddSuppressionRequest) Reset() {
+	*x = AddSuppressionRequest{}
+	mi := &file_pinguin_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotificationsResponse) String() string {
+func (x *AddSuppressionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsResponse) ProtoMessage() {}
+func (*AddSuppressionRequest) ProtoMessage() {}
 
-func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pinguin_proto_msgTypes[5]
+func (x *AddSuppressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -530,42 +2732,78 @@ func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
-func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
-	return file_pinguin_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use AddSuppressionRequest.ProtoReflect.Descriptor instead.
+func (*AddSuppressionRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ListNotificationsResponse) GetNotifications() []*NotificationResponse {
+func (x *AddSuppressionRequest) GetChannel() NotificationType {
 	if x != nil {
-		return x.Notifications
+		return x.Channel
+	}
+	return NotificationType_EMAIL
+}
+
+func (x *AddSuppressionRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *AddSuppressionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AddSuppressionRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *AddSuppressionRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
 	}
 	return nil
 }
 
-// Request to reschedule a queued notification.
-type RescheduleNotificationRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	ScheduledTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *AddSuppressionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
 }
 
-func (x *RescheduleNotificationRequest) Reset() {
-	*x = RescheduleNotificationRequest{}
-	mi := &file_pinguin_proto_msgTypes[6]
+// Request to lift the suppression for a recipient.
+type RemoveSuppressionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       NotificationType       `protobuf:"varint,1,opt,name=channel,proto3,enum=pinguin.NotificationType" json:"channel,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSuppressionRequest) Reset() {
+	*x = RemoveSuppressionRequest{}
+	mi := &file_pinguin_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RescheduleNotificationRequest) String() string {
+func (x *RemoveSuppressionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RescheduleNotificationRequest) ProtoMessage() {}
+func (*RemoveSuppressionRequest) ProtoMessage() {}
 
-func (x *RescheduleNotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pinguin_proto_msgTypes[6]
+func (x *RemoveSuppressionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -576,48 +2814,210 @@ func (x *RescheduleNotificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RescheduleNotificationRequest.ProtoReflect.Descriptor instead.
-func (*RescheduleNotificationRequest) Descriptor() ([]byte, []int) {
-	return file_pinguin_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RemoveSuppressionRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSuppressionRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *RescheduleNotificationRequest) GetNotificationId() string {
+func (x *RemoveSuppressionRequest) GetChannel() NotificationType {
 	if x != nil {
-		return x.NotificationId
+		return x.Channel
+	}
+	return NotificationType_EMAIL
+}
+
+func (x *RemoveSuppressionRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
 	}
 	return ""
 }
 
-func (x *RescheduleNotificationRequest) GetScheduledTime() *timestamppb.Timestamp {
+func (x *RemoveSuppressionRequest) GetCategory() string {
 	if x != nil {
-		return x.ScheduledTime
+		return x.Category
+	}
+	return ""
+}
+
+// Response returned after removing a suppression.
+type RemoveSuppressionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       NotificationType       `protobuf:"varint,1,opt,name=channel,proto3,enum=pinguin.NotificationType" json:"channel,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSuppressionResponse) Reset() {
+	*x = RemoveSuppressionResponse{}
+	mi := &file_pinguin_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSuppressionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSuppressionResponse) ProtoMessage() {}
+
+func (x *RemoveSuppressionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSuppressionResponse.ProtoReflect.Descriptor instead.
+func (*RemoveSuppressionResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RemoveSuppressionResponse) GetChannel() NotificationType {
+	if x != nil {
+		return x.Channel
+	}
+	return NotificationType_EMAIL
+}
+
+func (x *RemoveSuppressionResponse) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *RemoveSuppressionResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+// SMS received from a recipient.
+type InboundMessage struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Provider          string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderMessageId string                 `protobuf:"bytes,2,opt,name=provider_message_id,json=providerMessageId,proto3" json:"provider_message_id,omitempty"`
+	FromNumber        string                 `protobuf:"bytes,3,opt,name=from_number,json=fromNumber,proto3" json:"from_number,omitempty"`
+	ToNumber          string                 `protobuf:"bytes,4,opt,name=to_number,json=toNumber,proto3" json:"to_number,omitempty"`
+	Body              string                 `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	Keyword           string                 `protobuf:"bytes,6,opt,name=keyword,proto3" json:"keyword,omitempty"` // stop, start or help when the body is a carrier keyword; empty otherwise.
+	ReceivedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InboundMessage) Reset() {
+	*x = InboundMessage{}
+	mi := &file_pinguin_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboundMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboundMessage) ProtoMessage() {}
+
+func (x *InboundMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboundMessage.ProtoReflect.Descriptor instead.
+func (*InboundMessage) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *InboundMessage) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetProviderMessageId() string {
+	if x != nil {
+		return x.ProviderMessageId
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetFromNumber() string {
+	if x != nil {
+		return x.FromNumber
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetToNumber() string {
+	if x != nil {
+		return x.ToNumber
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *InboundMessage) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
 	}
 	return nil
 }
 
-// Request to cancel a queued notification.
-type CancelNotificationRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	NotificationId string                 `protobuf:"bytes,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// Request for listing inbound messages, newest first.
+type ListInboundMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromNumber    string                 `protobuf:"bytes,1,opt,name=from_number,json=fromNumber,proto3" json:"from_number,omitempty"` // Optional sender filter.
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                            // Defaults to 100.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelNotificationRequest) Reset() {
-	*x = CancelNotificationRequest{}
-	mi := &file_pinguin_proto_msgTypes[7]
+func (x *ListInboundMessagesRequest) Reset() {
+	*x = ListInboundMessagesRequest{}
+	mi := &file_pinguin_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelNotificationRequest) String() string {
+func (x *ListInboundMessagesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelNotificationRequest) ProtoMessage() {}
+func (*ListInboundMessagesRequest) ProtoMessage() {}
 
-func (x *CancelNotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pinguin_proto_msgTypes[7]
+func (x *ListInboundMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,34 +3028,108 @@ func (x *CancelNotificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelNotificationRequest.ProtoReflect.Descriptor instead.
-func (*CancelNotificationRequest) Descriptor() ([]byte, []int) {
-	return file_pinguin_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListInboundMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListInboundMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *CancelNotificationRequest) GetNotificationId() string {
+func (x *ListInboundMessagesRequest) GetFromNumber() string {
 	if x != nil {
-		return x.NotificationId
+		return x.FromNumber
 	}
 	return ""
 }
 
+func (x *ListInboundMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Response containing inbound messages for list requests.
+type ListInboundMessagesResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InboundMessages []*InboundMessage      `protobuf:"bytes,1,rep,name=inbound_messages,json=inboundMessages,proto3" json:"inbound_messages,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListInboundMessagesResponse) Reset() {
+	*x = ListInboundMessagesResponse{}
+	mi := &file_pinguin_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboundMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboundMessagesResponse) ProtoMessage() {}
+
+func (x *ListInboundMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pinguin_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboundMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListInboundMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_pinguin_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListInboundMessagesResponse) GetInboundMessages() []*InboundMessage {
+	if x != nil {
+		return x.InboundMessages
+	}
+	return nil
+}
+
 var File_pinguin_proto protoreflect.FileDescriptor
 
 const file_pinguin_proto_rawDesc = "" +
 	"\n" +
-	"\rpinguin.proto\x12\apinguin\x1a\x1fgoogle/protobuf/timestamp.proto\"d\n" +
+	"\rpinguin.proto\x12\apinguin\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\x01\n" +
 	"\x0fEmailAttachment\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
 	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
-	"\x04data\x18\x03 \x01(\fR\x04data\"\xae\x02\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\"\xcf\x05\n" +
 	"\x13NotificationRequest\x12F\n" +
 	"\x11notification_type\x18\x01 \x01(\x0e2\x19.pinguin.NotificationTypeR\x10notificationType\x12\x1c\n" +
 	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x18\n" +
 	"\asubject\x18\x03 \x01(\tR\asubject\x12\x18\n" +
 	"\amessage\x18\x04 \x01(\tR\amessage\x12A\n" +
 	"\x0escheduled_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rscheduledTime\x12:\n" +
-	"\vattachments\x18\x06 \x03(\v2\x18.pinguin.EmailAttachmentR\vattachments\"\x90\x04\n" +
+	"\vattachments\x18\x06 \x03(\v2\x18.pinguin.EmailAttachmentR\vattachments\x12\x0e\n" +
+	"\x02to\x18\a \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12!\n" +
+	"\fhtml_message\x18\n" +
+	" \x01(\tR\vhtmlMessage\x12\x1f\n" +
+	"\vtemplate_id\x18\v \x01(\tR\n" +
+	"templateId\x12)\n" +
+	"\x10template_version\x18\f \x01(\x05R\x0ftemplateVersion\x12S\n" +
+	"\rtemplate_data\x18\r \x03(\v2..pinguin.NotificationRequest.TemplateDataEntryR\ftemplateData\x12'\n" +
+	"\x0fidempotency_key\x18\x0e \x01(\tR\x0eidempotencyKey\x12\x1a\n" +
+	"\bcategory\x18\x0f \x01(\tR\bcategory\x12#\n" +
+	"\rdelivery_mode\x18\x10 \x01(\tR\fdeliveryMode\x1a?\n" +
+	"\x11TemplateDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x01\n" +
+	"\x11RecipientDelivery\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12*\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x16.pinguin.RecipientKindR\x04kind\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.pinguin.RecipientStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xbf\b\n" +
 	"\x14NotificationResponse\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\x12F\n" +
 	"\x11notification_type\x18\x02 \x01(\x0e2\x19.pinguin.NotificationTypeR\x10notificationType\x12\x1c\n" +
@@ -672,21 +3146,210 @@ const file_pinguin_proto_rawDesc = "" +
 	"updated_at\x18\n" +
 	" \x01(\tR\tupdatedAt\x12A\n" +
 	"\x0escheduled_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\rscheduledTime\x12:\n" +
-	"\vattachments\x18\f \x03(\v2\x18.pinguin.EmailAttachmentR\vattachments\"G\n" +
+	"\vattachments\x18\f \x03(\v2\x18.pinguin.EmailAttachmentR\vattachments\x12\x0e\n" +
+	"\x02to\x18\r \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\x0e \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\x0f \x03(\tR\x03bcc\x12M\n" +
+	"\x14recipient_deliveries\x18\x10 \x03(\v2\x1a.pinguin.RecipientDeliveryR\x13recipientDeliveries\x12!\n" +
+	"\fhtml_message\x18\x11 \x01(\tR\vhtmlMessage\x12\x1f\n" +
+	"\vtemplate_id\x18\x12 \x01(\tR\n" +
+	"templateId\x12)\n" +
+	"\x10template_version\x18\x13 \x01(\x05R\x0ftemplateVersion\x12'\n" +
+	"\x0fidempotency_key\x18\x14 \x01(\tR\x0eidempotencyKey\x12\x1a\n" +
+	"\bprovider\x18\x15 \x01(\tR\bprovider\x12'\n" +
+	"\x0fprovider_status\x18\x16 \x01(\tR\x0eproviderStatus\x12#\n" +
+	"\rsegment_count\x18\x17 \x01(\x05R\fsegmentCount\x12\x14\n" +
+	"\x05price\x18\x18 \x01(\tR\x05price\x12\x1d\n" +
+	"\n" +
+	"price_unit\x18\x19 \x01(\tR\tpriceUnit\x12\x1a\n" +
+	"\bcategory\x18\x1a \x01(\tR\bcategory\x12\x19\n" +
+	"\bbatch_id\x18\x1b \x01(\tR\abatchId\x12\x1f\n" +
+	"\vbatch_index\x18\x1c \x01(\x05R\n" +
+	"batchIndex\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x1d \x01(\tR\tlastError\"G\n" +
 	"\x1cGetNotificationStatusRequest\x12'\n" +
-	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"G\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"I\n" +
+	"\x1eGetNotificationAttemptsRequest\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"\xaa\x02\n" +
+	"\x13NotificationAttempt\x12=\n" +
+	"\fattempted_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vattemptedAt\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\x12)\n" +
+	"\aoutcome\x18\x04 \x01(\x0e2\x0f.pinguin.StatusR\aoutcome\x12\x1f\n" +
+	"\verror_class\x18\x05 \x01(\tR\n" +
+	"errorClass\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x12&\n" +
+	"\x0fsmtp_reply_code\x18\a \x01(\x05R\rsmtpReplyCode\"[\n" +
+	"\x1fGetNotificationAttemptsResponse\x128\n" +
+	"\battempts\x18\x01 \x03(\v2\x1c.pinguin.NotificationAttemptR\battempts\"\xa1\x04\n" +
 	"\x18ListNotificationsRequest\x12+\n" +
-	"\bstatuses\x18\x01 \x03(\x0e2\x0f.pinguin.StatusR\bstatuses\"`\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x0f.pinguin.StatusR\bstatuses\x12/\n" +
+	"\x05types\x18\x02 \x03(\x0e2\x19.pinguin.NotificationTypeR\x05types\x12\x1c\n" +
+	"\trecipient\x18\x03 \x01(\tR\trecipient\x12)\n" +
+	"\x10recipient_prefix\x18\x04 \x01(\tR\x0frecipientPrefix\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12C\n" +
+	"\x0fscheduled_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0escheduledAfter\x12E\n" +
+	"\x10scheduled_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0fscheduledBefore\x12\x12\n" +
+	"\x04text\x18\t \x01(\tR\x04text\x12\x1b\n" +
+	"\tpage_size\x18\n" +
+	" \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\"\x88\x01\n" +
 	"\x19ListNotificationsResponse\x12C\n" +
-	"\rnotifications\x18\x01 \x03(\v2\x1d.pinguin.NotificationResponseR\rnotifications\"\x8b\x01\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1d.pinguin.NotificationResponseR\rnotifications\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xbd\x01\n" +
+	"\x19WatchNotificationsRequest\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\x12\x19\n" +
+	"\bbatch_id\x18\x02 \x01(\tR\abatchId\x12+\n" +
+	"\bstatuses\x18\x03 \x03(\x0e2\x0f.pinguin.StatusR\bstatuses\x12/\n" +
+	"\x05types\x18\x04 \x03(\x0e2\x19.pinguin.NotificationTypeR\x05types\"r\n" +
+	"\x11NotificationEvent\x12A\n" +
+	"\fnotification\x18\x01 \x01(\v2\x1d.pinguin.NotificationResponseR\fnotification\x12\x1a\n" +
+	"\bsnapshot\x18\x02 \x01(\bR\bsnapshot\"\x8b\x01\n" +
 	"\x1dRescheduleNotificationRequest\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\x12A\n" +
 	"\x0escheduled_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rscheduledTime\"D\n" +
 	"\x19CancelNotificationRequest\x12'\n" +
-	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId*&\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"E\n" +
+	"\x1aRequeueNotificationRequest\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\"\xdc\x02\n" +
+	"\x1bRequeueNotificationsRequest\x12+\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x0f.pinguin.StatusR\bstatuses\x12/\n" +
+	"\x05types\x18\x02 \x03(\x0e2\x19.pinguin.NotificationTypeR\x05types\x12\x1c\n" +
+	"\trecipient\x18\x03 \x01(\tR\trecipient\x12)\n" +
+	"\x10recipient_prefix\x18\x04 \x01(\tR\x0frecipientPrefix\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x12\n" +
+	"\x04text\x18\a \x01(\tR\x04text\"E\n" +
+	"\x1cRequeueNotificationsResponse\x12%\n" +
+	"\x0erequeued_count\x18\x01 \x01(\x05R\rrequeuedCount\"T\n" +
+	"\x18NotificationBatchRequest\x128\n" +
+	"\brequests\x18\x01 \x03(\v2\x1c.pinguin.NotificationRequestR\brequests\"\xa5\x01\n" +
+	"\x15NotificationBatchItem\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12A\n" +
+	"\fnotification\x18\x02 \x01(\v2\x1d.pinguin.NotificationResponseR\fnotification\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\xba\x01\n" +
+	"\x19NotificationBatchResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x124\n" +
+	"\x05items\x18\x02 \x03(\v2\x1e.pinguin.NotificationBatchItemR\x05items\x12%\n" +
+	"\x0eaccepted_count\x18\x03 \x01(\x05R\racceptedCount\x12%\n" +
+	"\x0erejected_count\x18\x04 \x01(\x05R\rrejectedCount\"8\n" +
+	"\x1bGetNotificationBatchRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\"~\n" +
+	"\x1cGetNotificationBatchResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12C\n" +
+	"\rnotifications\x18\x02 \x03(\v2\x1d.pinguin.NotificationResponseR\rnotifications\"\x96\x02\n" +
+	"\bTemplate\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x1d\n" +
+	"\n" +
+	"plain_body\x18\x05 \x01(\tR\tplainBody\x12\x1b\n" +
+	"\thtml_body\x18\x06 \x01(\tR\bhtmlBody\x12\x19\n" +
+	"\bsms_body\x18\a \x01(\tR\asmsBody\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\"\xc5\x01\n" +
+	"\x0fTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x1d\n" +
+	"\n" +
+	"plain_body\x18\x04 \x01(\tR\tplainBody\x12\x1b\n" +
+	"\thtml_body\x18\x05 \x01(\tR\bhtmlBody\x12\x19\n" +
+	"\bsms_body\x18\x06 \x01(\tR\asmsBody\"O\n" +
+	"\x12GetTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x16\n" +
+	"\x14ListTemplatesRequest\"H\n" +
+	"\x15ListTemplatesResponse\x12/\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x11.pinguin.TemplateR\ttemplates\"8\n" +
+	"\x15DeleteTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\"9\n" +
+	"\x16DeleteTemplateResponse\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\"\xb4\x02\n" +
+	"\x16PreviewTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12F\n" +
+	"\x11notification_type\x18\x03 \x01(\x0e2\x19.pinguin.NotificationTypeR\x10notificationType\x12V\n" +
+	"\rtemplate_data\x18\x04 \x03(\v21.pinguin.PreviewTemplateRequest.TemplateDataEntryR\ftemplateData\x1a?\n" +
+	"\x11TemplateDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc5\x01\n" +
+	"\x17PreviewTemplateResponse\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x1d\n" +
+	"\n" +
+	"plain_body\x18\x04 \x01(\tR\tplainBody\x12\x1b\n" +
+	"\thtml_body\x18\x05 \x01(\tR\bhtmlBody\x12\x19\n" +
+	"\bsms_body\x18\x06 \x01(\tR\asmsBody\"\xa5\x02\n" +
+	"\vSuppression\x123\n" +
+	"\achannel\x18\x01 \x01(\x0e2\x19.pinguin.NotificationTypeR\achannel\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x1a\n" +
+	"\bcategory\x18\b \x01(\tR\bcategory\"y\n" +
+	"\x17ListSuppressionsRequest\x125\n" +
+	"\bchannels\x18\x01 \x03(\x0e2\x19.pinguin.NotificationTypeR\bchannels\x12'\n" +
+	"\x0finclude_expired\x18\x02 \x01(\bR\x0eincludeExpired\"T\n" +
+	"\x18ListSuppressionsResponse\x128\n" +
+	"\fsuppressions\x18\x01 \x03(\v2\x14.pinguin.SuppressionR\fsuppressions\"\xf1\x01\n" +
+	"\x15AddSuppressionRequest\x123\n" +
+	"\achannel\x18\x01 \x01(\x0e2\x19.pinguin.NotificationTypeR\achannel\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\"\x89\x01\n" +
+	"\x18RemoveSuppressionRequest\x123\n" +
+	"\achannel\x18\x01 \x01(\x0e2\x19.pinguin.NotificationTypeR\achannel\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\"\x8a\x01\n" +
+	"\x19RemoveSuppressionResponse\x123\n" +
+	"\achannel\x18\x01 \x01(\x0e2\x19.pinguin.NotificationTypeR\achannel\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\"\x85\x02\n" +
+	"\x0eInboundMessage\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12.\n" +
+	"\x13provider_message_id\x18\x02 \x01(\tR\x11providerMessageId\x12\x1f\n" +
+	"\vfrom_number\x18\x03 \x01(\tR\n" +
+	"fromNumber\x12\x1b\n" +
+	"\tto_number\x18\x04 \x01(\tR\btoNumber\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\tR\x04body\x12\x18\n" +
+	"\akeyword\x18\x06 \x01(\tR\akeyword\x12;\n" +
+	"\vreceived_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\"S\n" +
+	"\x1aListInboundMessagesRequest\x12\x1f\n" +
+	"\vfrom_number\x18\x01 \x01(\tR\n" +
+	"fromNumber\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"a\n" +
+	"\x1bListInboundMessagesResponse\x12B\n" +
+	"\x10inbound_messages\x18\x01 \x03(\v2\x17.pinguin.InboundMessageR\x0finboundMessages*&\n" +
 	"\x10NotificationType\x12\t\n" +
 	"\x05EMAIL\x10\x00\x12\a\n" +
-	"\x03SMS\x10\x01*S\n" +
+	"\x03SMS\x10\x01*\x9a\x01\n" +
 	"\x06Status\x12\n" +
 	"\n" +
 	"\x06QUEUED\x10\x00\x12\b\n" +
@@ -695,13 +3358,48 @@ const file_pinguin_proto_rawDesc = "" +
 	"\x06FAILED\x10\x02\x12\v\n" +
 	"\aUNKNOWN\x10\x03\x12\r\n" +
 	"\tCANCELLED\x10\x04\x12\v\n" +
-	"\aERRORED\x10\x052\xdb\x03\n" +
+	"\aERRORED\x10\x05\x12\r\n" +
+	"\tDELIVERED\x10\x06\x12\x0f\n" +
+	"\vUNDELIVERED\x10\a\x12\v\n" +
+	"\aBOUNCED\x10\b\x12\x0e\n" +
+	"\n" +
+	"SUPPRESSED\x10\t\x12\b\n" +
+	"\x04DEAD\x10\n" +
+	"*(\n" +
+	"\rRecipientKind\x12\x06\n" +
+	"\x02TO\x10\x00\x12\x06\n" +
+	"\x02CC\x10\x01\x12\a\n" +
+	"\x03BCC\x10\x02*G\n" +
+	"\x0fRecipientStatus\x12\v\n" +
+	"\aPENDING\x10\x00\x12\f\n" +
+	"\bACCEPTED\x10\x01\x12\f\n" +
+	"\bREJECTED\x10\x02\x12\v\n" +
+	"\aSKIPPED\x10\x032\xa6\b\n" +
 	"\x13NotificationService\x12O\n" +
 	"\x10SendNotification\x12\x1c.pinguin.NotificationRequest\x1a\x1d.pinguin.NotificationResponse\x12]\n" +
-	"\x15GetNotificationStatus\x12%.pinguin.GetNotificationStatusRequest\x1a\x1d.pinguin.NotificationResponse\x12Z\n" +
+	"\x15GetNotificationStatus\x12%.pinguin.GetNotificationStatusRequest\x1a\x1d.pinguin.NotificationResponse\x12l\n" +
+	"\x17GetNotificationAttempts\x12'.pinguin.GetNotificationAttemptsRequest\x1a(.pinguin.GetNotificationAttemptsResponse\x12Z\n" +
 	"\x11ListNotifications\x12!.pinguin.ListNotificationsRequest\x1a\".pinguin.ListNotificationsResponse\x12_\n" +
 	"\x16RescheduleNotification\x12&.pinguin.RescheduleNotificationRequest\x1a\x1d.pinguin.NotificationResponse\x12W\n" +
-	"\x12CancelNotification\x12\".pinguin.CancelNotificationRequest\x1a\x1d.pinguin.NotificationResponseB0Z.github.com/temirov/pinguin/pkg/grpcapi;grpcapib\x06proto3"
+	"\x12CancelNotification\x12\".pinguin.CancelNotificationRequest\x1a\x1d.pinguin.NotificationResponse\x12Y\n" +
+	"\x13RequeueNotification\x12#.pinguin.RequeueNotificationRequest\x1a\x1d.pinguin.NotificationResponse\x12c\n" +
+	"\x14RequeueNotifications\x12$.pinguin.RequeueNotificationsRequest\x1a%.pinguin.RequeueNotificationsResponse\x12^\n" +
+	"\x15SendNotificationBatch\x12!.pinguin.NotificationBatchRequest\x1a\".pinguin.NotificationBatchResponse\x12c\n" +
+	"\x14GetNotificationBatch\x12$.pinguin.GetNotificationBatchRequest\x1a%.pinguin.GetNotificationBatchResponse\x12V\n" +
+	"\x12WatchNotifications\x12\".pinguin.WatchNotificationsRequest\x1a\x1a.pinguin.NotificationEvent0\x012\xc7\x03\n" +
+	"\x0fTemplateService\x12=\n" +
+	"\x0eCreateTemplate\x12\x18.pinguin.TemplateRequest\x1a\x11.pinguin.Template\x12=\n" +
+	"\x0eUpdateTemplate\x12\x18.pinguin.TemplateRequest\x1a\x11.pinguin.Template\x12=\n" +
+	"\vGetTemplate\x12\x1b.pinguin.GetTemplateRequest\x1a\x11.pinguin.Template\x12N\n" +
+	"\rListTemplates\x12\x1d.pinguin.ListTemplatesRequest\x1a\x1e.pinguin.ListTemplatesResponse\x12Q\n" +
+	"\x0eDeleteTemplate\x12\x1e.pinguin.DeleteTemplateRequest\x1a\x1f.pinguin.DeleteTemplateResponse\x12T\n" +
+	"\x0fPreviewTemplate\x12\x1f.pinguin.PreviewTemplateRequest\x1a .pinguin.PreviewTemplateResponse2\x91\x02\n" +
+	"\x12SuppressionService\x12W\n" +
+	"\x10ListSuppressions\x12 .pinguin.ListSuppressionsRequest\x1a!.pinguin.ListSuppressionsResponse\x12F\n" +
+	"\x0eAddSuppression\x12\x1e.pinguin.AddSuppressionRequest\x1a\x14.pinguin.Suppression\x12Z\n" +
+	"\x11RemoveSuppression\x12!.pinguin.RemoveSuppressionRequest\x1a\".pinguin.RemoveSuppressionResponse2y\n" +
+	"\x15InboundMessageService\x12`\n" +
+	"\x13ListInboundMessages\x12#.pinguin.ListInboundMessagesRequest\x1a$.pinguin.ListInboundMessagesResponseB0Z.github.com/temirov/pinguin/pkg/grpcapi;grpcapib\x06proto3"
 
 var (
 	file_pinguin_proto_rawDescOnce sync.Once
@@ -715,47 +3413,151 @@ func file_pinguin_proto_rawDescGZIP() []byte {
 	return file_pinguin_proto_rawDescData
 }
 
-var file_pinguin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_pinguin_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_pinguin_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_pinguin_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_pinguin_proto_goTypes = []any{
-	(NotificationType)(0),                 // 0: pinguin.NotificationType
-	(Status)(0),                           // 1: pinguin.Status
-	(*EmailAttachment)(nil),               // 2: pinguin.EmailAttachment
-	(*NotificationRequest)(nil),           // 3: pinguin.NotificationRequest
-	(*NotificationResponse)(nil),          // 4: pinguin.NotificationResponse
-	(*GetNotificationStatusRequest)(nil),  // 5: pinguin.GetNotificationStatusRequest
-	(*ListNotificationsRequest)(nil),      // 6: pinguin.ListNotificationsRequest
-	(*ListNotificationsResponse)(nil),     // 7: pinguin.ListNotificationsResponse
-	(*RescheduleNotificationRequest)(nil), // 8: pinguin.RescheduleNotificationRequest
-	(*CancelNotificationRequest)(nil),     // 9: pinguin.CancelNotificationRequest
-	(*timestamppb.Timestamp)(nil),         // 10: google.protobuf.Timestamp
+	(NotificationType)(0),                   // 0: pinguin.NotificationType
+	(Status)(0),                             // 1: pinguin.Status
+	(RecipientKind)(0),                      // 2: pinguin.RecipientKind
+	(RecipientStatus)(0),                    // 3: pinguin.RecipientStatus
+	(*EmailAttachment)(nil),                 // 4: pinguin.EmailAttachment
+	(*NotificationRequest)(nil),             // 5: pinguin.NotificationRequest
+	(*RecipientDelivery)(nil),               // 6: pinguin.RecipientDelivery
+	(*NotificationResponse)(nil),            // 7: pinguin.NotificationResponse
+	(*GetNotificationStatusRequest)(nil),    // 8: pinguin.GetNotificationStatusRequest
+	(*GetNotificationAttemptsRequest)(nil),  // 9: pinguin.GetNotificationAttemptsRequest
+	(*NotificationAttempt)(nil),             // 10: pinguin.NotificationAttempt
+	(*GetNotificationAttemptsResponse)(nil), // 11: pinguin.GetNotificationAttemptsResponse
+	(*ListNotificationsRequest)(nil),        // 12: pinguin.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),       // 13: pinguin.ListNotificationsResponse
+	(*WatchNotificationsRequest)(nil),       // 14: pinguin.WatchNotificationsRequest
+	(*NotificationEvent)(nil),               // 15: pinguin.NotificationEvent
+	(*RescheduleNotificationRequest)(nil),   // 16: pinguin.RescheduleNotificationRequest
+	(*CancelNotificationRequest)(nil),       // 17: pinguin.CancelNotificationRequest
+	(*RequeueNotificationRequest)(nil),      // 18: pinguin.RequeueNotificationRequest
+	(*RequeueNotificationsRequest)(nil),     // 19: pinguin.RequeueNotificationsRequest
+	(*RequeueNotificationsResponse)(nil),    // 20: pinguin.RequeueNotificationsResponse
+	(*NotificationBatchRequest)(nil),        // 21: pinguin.NotificationBatchRequest
+	(*NotificationBatchItem)(nil),           // 22: pinguin.NotificationBatchItem
+	(*NotificationBatchResponse)(nil),       // 23: pinguin.NotificationBatchResponse
+	(*GetNotificationBatchRequest)(nil),     // 24: pinguin.GetNotificationBatchRequest
+	(*GetNotificationBatchResponse)(nil),    // 25: pinguin.GetNotificationBatchResponse
+	(*Template)(nil),                        // 26: pinguin.Template
+	(*TemplateRequest)(nil),                 // 27: pinguin.TemplateRequest
+	(*GetTemplateRequest)(nil),              // 28: pinguin.GetTemplateRequest
+	(*ListTemplatesRequest)(nil),            // 29: pinguin.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),           // 30: pinguin.ListTemplatesResponse
+	(*DeleteTemplateRequest)(nil),           // 31: pinguin.DeleteTemplateRequest
+	(*DeleteTemplateResponse)(nil),          // 32: pinguin.DeleteTemplateResponse
+	(*PreviewTemplateRequest)(nil),          // 33: pinguin.PreviewTemplateRequest
+	(*PreviewTemplateResponse)(nil),         // 34: pinguin.PreviewTemplateResponse
+	(*Suppression)(nil),                     // 35: pinguin.Suppression
+	(*ListSuppressionsRequest)(nil),         // 36: pinguin.ListSuppressionsRequest
+	(*ListSuppressionsResponse)(nil),        // 37: pinguin.ListSuppressionsResponse
+	(*AddSuppressionRequest)(nil),           // 38: pinguin.AddSuppressionRequest
+	(*RemoveSuppressionRequest)(nil),        // 39: pinguin.RemoveSuppressionRequest
+	(*RemoveSuppressionResponse)(nil),       // 40: pinguin.RemoveSuppressionResponse
+	(*InboundMessage)(nil),                  // 41: pinguin.InboundMessage
+	(*ListInboundMessagesRequest)(nil),      // 42: pinguin.ListInboundMessagesRequest
+	(*ListInboundMessagesResponse)(nil),     // 43: pinguin.ListInboundMessagesResponse
+	nil,                                     // 44: pinguin.NotificationRequest.TemplateDataEntry
+	nil,                                     // 45: pinguin.PreviewTemplateRequest.TemplateDataEntry
+	(*timestamppb.Timestamp)(nil),           // 46: google.protobuf.Timestamp
 }
 var file_pinguin_proto_depIdxs = []int32{
 	0,  // 0: pinguin.NotificationRequest.notification_type:type_name -> pinguin.NotificationType
-	10, // 1: pinguin.NotificationRequest.scheduled_time:type_name -> google.protobuf.Timestamp
-	2,  // 2: pinguin.NotificationRequest.attachments:type_name -> pinguin.EmailAttachment
-	0,  // 3: pinguin.NotificationResponse.notification_type:type_name -> pinguin.NotificationType
-	1,  // 4: pinguin.NotificationResponse.status:type_name -> pinguin.Status
-	10, // 5: pinguin.NotificationResponse.scheduled_time:type_name -> google.protobuf.Timestamp
-	2,  // 6: pinguin.NotificationResponse.attachments:type_name -> pinguin.EmailAttachment
-	1,  // 7: pinguin.ListNotificationsRequest.statuses:type_name -> pinguin.Status
-	4,  // 8: pinguin.ListNotificationsResponse.notifications:type_name -> pinguin.NotificationResponse
-	10, // 9: pinguin.RescheduleNotificationRequest.scheduled_time:type_name -> google.protobuf.Timestamp
-	3,  // 10: pinguin.NotificationService.SendNotification:input_type -> pinguin.NotificationRequest
-	5,  // 11: pinguin.NotificationService.GetNotificationStatus:input_type -> pinguin.GetNotificationStatusRequest
-	6,  // 12: pinguin.NotificationService.ListNotifications:input_type -> pinguin.ListNotificationsRequest
-	8,  // 13: pinguin.NotificationService.RescheduleNotification:input_type -> pinguin.RescheduleNotificationRequest
-	9,  // 14: pinguin.NotificationService.CancelNotification:input_type -> pinguin.CancelNotificationRequest
-	4,  // 15: pinguin.NotificationService.SendNotification:output_type -> pinguin.NotificationResponse
-	4,  // 16: pinguin.NotificationService.GetNotificationStatus:output_type -> pinguin.NotificationResponse
-	7,  // 17: pinguin.NotificationService.ListNotifications:output_type -> pinguin.ListNotificationsResponse
-	4,  // 18: pinguin.NotificationService.RescheduleNotification:output_type -> pinguin.NotificationResponse
-	4,  // 19: pinguin.NotificationService.CancelNotification:output_type -> pinguin.NotificationResponse
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	46, // 1: pinguin.NotificationRequest.scheduled_time:type_name -> google.protobuf.Timestamp
+	4,  // 2: pinguin.NotificationRequest.attachments:type_name -> pinguin.EmailAttachment
+	44, // 3: pinguin.NotificationRequest.template_data:type_name -> pinguin.NotificationRequest.TemplateDataEntry
+	2,  // 4: pinguin.RecipientDelivery.kind:type_name -> pinguin.RecipientKind
+	3,  // 5: pinguin.RecipientDelivery.status:type_name -> pinguin.RecipientStatus
+	0,  // 6: pinguin.NotificationResponse.notification_type:type_name -> pinguin.NotificationType
+	1,  // 7: pinguin.NotificationResponse.status:type_name -> pinguin.Status
+	46, // 8: pinguin.NotificationResponse.scheduled_time:type_name -> google.protobuf.Timestamp
+	4,  // 9: pinguin.NotificationResponse.attachments:type_name -> pinguin.EmailAttachment
+	6,  // 10: pinguin.NotificationResponse.recipient_deliveries:type_name -> pinguin.RecipientDelivery
+	46, // 11: pinguin.NotificationAttempt.attempted_at:type_name -> google.protobuf.Timestamp
+	1,  // 12: pinguin.NotificationAttempt.outcome:type_name -> pinguin.Status
+	10, // 13: pinguin.GetNotificationAttemptsResponse.attempts:type_name -> pinguin.NotificationAttempt
+	1,  // 14: pinguin.ListNotificationsRequest.statuses:type_name -> pinguin.Status
+	0,  // 15: pinguin.ListNotificationsRequest.types:type_name -> pinguin.NotificationType
+	46, // 16: pinguin.ListNotificationsRequest.created_after:type_name -> google.protobuf.Timestamp
+	46, // 17: pinguin.ListNotificationsRequest.created_before:type_name -> google.protobuf.Timestamp
+	46, // 18: pinguin.ListNotificationsRequest.scheduled_after:type_name -> google.protobuf.Timestamp
+	46, // 19: pinguin.ListNotificationsRequest.scheduled_before:type_name -> google.protobuf.Timestamp
+	7,  // 20: pinguin.ListNotificationsResponse.notifications:type_name -> pinguin.NotificationResponse
+	1,  // 21: pinguin.WatchNotificationsRequest.statuses:type_name -> pinguin.Status
+	0,  // 22: pinguin.WatchNotificationsRequest.types:type_name -> pinguin.NotificationType
+	7,  // 23: pinguin.NotificationEvent.notification:type_name -> pinguin.NotificationResponse
+	46, // 24: pinguin.RescheduleNotificationRequest.scheduled_time:type_name -> google.protobuf.Timestamp
+	1,  // 25: pinguin.RequeueNotificationsRequest.statuses:type_name -> pinguin.Status
+	0,  // 26: pinguin.RequeueNotificationsRequest.types:type_name -> pinguin.NotificationType
+	46, // 27: pinguin.RequeueNotificationsRequest.created_after:type_name -> google.protobuf.Timestamp
+	46, // 28: pinguin.RequeueNotificationsRequest.created_before:type_name -> google.protobuf.Timestamp
+	5,  // 29: pinguin.NotificationBatchRequest.requests:type_name -> pinguin.NotificationRequest
+	7,  // 30: pinguin.NotificationBatchItem.notification:type_name -> pinguin.NotificationResponse
+	22, // 31: pinguin.NotificationBatchResponse.items:type_name -> pinguin.NotificationBatchItem
+	7,  // 32: pinguin.GetNotificationBatchResponse.notifications:type_name -> pinguin.NotificationResponse
+	26, // 33: pinguin.ListTemplatesResponse.templates:type_name -> pinguin.Template
+	0,  // 34: pinguin.PreviewTemplateRequest.notification_type:type_name -> pinguin.NotificationType
+	45, // 35: pinguin.PreviewTemplateRequest.template_data:type_name -> pinguin.PreviewTemplateRequest.TemplateDataEntry
+	0,  // 36: pinguin.Suppression.channel:type_name -> pinguin.NotificationType
+	46, // 37: pinguin.Suppression.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 38: pinguin.ListSuppressionsRequest.channels:type_name -> pinguin.NotificationType
+	35, // 39: pinguin.ListSuppressionsResponse.suppressions:type_name -> pinguin.Suppression
+	0,  // 40: pinguin.AddSuppressionRequest.channel:type_name -> pinguin.NotificationType
+	46, // 41: pinguin.AddSuppressionRequest.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 42: pinguin.RemoveSuppressionRequest.channel:type_name -> pinguin.NotificationType
+	0,  // 43: pinguin.RemoveSuppressionResponse.channel:type_name -> pinguin.NotificationType
+	46, // 44: pinguin.InboundMessage.received_at:type_name -> google.protobuf.Timestamp
+	41, // 45: pinguin.ListInboundMessagesResponse.inbound_messages:type_name -> pinguin.InboundMessage
+	5,  // 46: pinguin.NotificationService.SendNotification:input_type -> pinguin.NotificationRequest
+	8,  // 47: pinguin.NotificationService.GetNotificationStatus:input_type -> pinguin.GetNotificationStatusRequest
+	9,  // 48: pinguin.NotificationService.GetNotificationAttempts:input_type -> pinguin.GetNotificationAttemptsRequest
+	12, // 49: pinguin.NotificationService.ListNotifications:input_type -> pinguin.ListNotificationsRequest
+	16, // 50: pinguin.NotificationService.RescheduleNotification:input_type -> pinguin.RescheduleNotificationRequest
+	17, // 51: pinguin.NotificationService.CancelNotification:input_type -> pinguin.CancelNotificationRequest
+	18, // 52: pinguin.NotificationService.RequeueNotification:input_type -> pinguin.RequeueNotificationRequest
+	19, // 53: pinguin.NotificationService.RequeueNotifications:input_type -> pinguin.RequeueNotificationsRequest
+	21, // 54: pinguin.NotificationService.SendNotificationBatch:input_type -> pinguin.NotificationBatchRequest
+	24, // 55: pinguin.NotificationService.GetNotificationBatch:input_type -> pinguin.GetNotificationBatchRequest
+	14, // 56: pinguin.NotificationService.WatchNotifications:input_type -> pinguin.WatchNotificationsRequest
+	27, // 57: pinguin.TemplateService.CreateTemplate:input_type -> pinguin.TemplateRequest
+	27, // 58: pinguin.TemplateService.UpdateTemplate:input_type -> pinguin.TemplateRequest
+	28, // 59: pinguin.TemplateService.GetTemplate:input_type -> pinguin.GetTemplateRequest
+	29, // 60: pinguin.TemplateService.ListTemplates:input_type -> pinguin.ListTemplatesRequest
+	31, // 61: pinguin.TemplateService.DeleteTemplate:input_type -> pinguin.DeleteTemplateRequest
+	33, // 62: pinguin.TemplateService.PreviewTemplate:input_type -> pinguin.PreviewTemplateRequest
+	36, // 63: pinguin.SuppressionService.ListSuppressions:input_type -> pinguin.ListSuppressionsRequest
+	38, // 64: pinguin.SuppressionService.AddSuppression:input_type -> pinguin.AddSuppressionRequest
+	39, // 65: pinguin.SuppressionService.RemoveSuppression:input_type -> pinguin.RemoveSuppressionRequest
+	42, // 66: pinguin.InboundMessageService.ListInboundMessages:input_type -> pinguin.ListInboundMessagesRequest
+	7,  // 67: pinguin.NotificationService.SendNotification:output_type -> pinguin.NotificationResponse
+	7,  // 68: pinguin.NotificationService.GetNotificationStatus:output_type -> pinguin.NotificationResponse
+	11, // 69: pinguin.NotificationService.GetNotificationAttempts:output_type -> pinguin.GetNotificationAttemptsResponse
+	13, // 70: pinguin.NotificationService.ListNotifications:output_type -> pinguin.ListNotificationsResponse
+	7,  // 71: pinguin.NotificationService.RescheduleNotification:output_type -> pinguin.NotificationResponse
+	7,  // 72: pinguin.NotificationService.CancelNotification:output_type -> pinguin.NotificationResponse
+	7,  // 73: pinguin.NotificationService.RequeueNotification:output_type -> pinguin.NotificationResponse
+	20, // 74: pinguin.NotificationService.RequeueNotifications:output_type -> pinguin.RequeueNotificationsResponse
+	23, // 75: pinguin.NotificationService.SendNotificationBatch:output_type -> pinguin.NotificationBatchResponse
+	25, // 76: pinguin.NotificationService.GetNotificationBatch:output_type -> pinguin.GetNotificationBatchResponse
+	15, // 77: pinguin.NotificationService.WatchNotifications:output_type -> pinguin.NotificationEvent
+	26, // 78: pinguin.TemplateService.CreateTemplate:output_type -> pinguin.Template
+	26, // 79: pinguin.TemplateService.UpdateTemplate:output_type -> pinguin.Template
+	26, // 80: pinguin.TemplateService.GetTemplate:output_type -> pinguin.Template
+	30, // 81: pinguin.TemplateService.ListTemplates:output_type -> pinguin.ListTemplatesResponse
+	32, // 82: pinguin.TemplateService.DeleteTemplate:output_type -> pinguin.DeleteTemplateResponse
+	34, // 83: pinguin.TemplateService.PreviewTemplate:output_type -> pinguin.PreviewTemplateResponse
+	37, // 84: pinguin.SuppressionService.ListSuppressions:output_type -> pinguin.ListSuppressionsResponse
+	35, // 85: pinguin.SuppressionService.AddSuppression:output_type -> pinguin.Suppression
+	40, // 86: pinguin.SuppressionService.RemoveSuppression:output_type -> pinguin.RemoveSuppressionResponse
+	43, // 87: pinguin.InboundMessageService.ListInboundMessages:output_type -> pinguin.ListInboundMessagesResponse
+	67, // [67:88] is the sub-list for method output_type
+	46, // [46:67] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_pinguin_proto_init() }
@@ -768,10 +3570,10 @@ func file_pinguin_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pinguin_proto_rawDesc), len(file_pinguin_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      4,
+			NumMessages:   42,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   4,
 		},
 		GoTypes:           file_pinguin_proto_goTypes,
 		DependencyIndexes: file_pinguin_proto_depIdxs,
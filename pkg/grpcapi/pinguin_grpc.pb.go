@@ -19,11 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NotificationService_SendNotification_FullMethodName       = "/pinguin.NotificationService/SendNotification"
-	NotificationService_GetNotificationStatus_FullMethodName  = "/pinguin.NotificationService/GetNotificationStatus"
-	NotificationService_ListNotifications_FullMethodName      = "/pinguin.NotificationService/ListNotifications"
-	NotificationService_RescheduleNotification_FullMethodName = "/pinguin.NotificationService/RescheduleNotification"
-	NotificationService_CancelNotification_FullMethodName     = "/pinguin.NotificationService/CancelNotification"
+	NotificationService_SendNotification_FullMethodName        = "/pinguin.NotificationService/SendNotification"
+	NotificationService_GetNotificationStatus_FullMethodName   = "/pinguin.NotificationService/GetNotificationStatus"
+	NotificationService_GetNotificationAttempts_FullMethodName = "/pinguin.NotificationService/GetNotificationAttempts"
+	NotificationService_ListNotifications_FullMethodName       = "/pinguin.NotificationService/ListNotifications"
+	NotificationService_RescheduleNotification_FullMethodName  = "/pinguin.NotificationService/RescheduleNotification"
+	NotificationService_CancelNotification_FullMethodName      = "/pinguin.NotificationService/CancelNotification"
+	NotificationService_RequeueNotification_FullMethodName     = "/pinguin.NotificationService/RequeueNotification"
+	NotificationService_RequeueNotifications_FullMethodName    = "/pinguin.NotificationService/RequeueNotifications"
+	NotificationService_SendNotificationBatch_FullMethodName   = "/pinguin.NotificationService/SendNotificationBatch"
+	NotificationService_GetNotificationBatch_FullMethodName    = "/pinguin.NotificationService/GetNotificationBatch"
+	NotificationService_WatchNotifications_FullMethodName      = "/pinguin.NotificationService/WatchNotifications"
 )
 
 // NotificationServiceClient is the client API for NotificationService service.
@@ -34,9 +40,19 @@ const (
 type NotificationServiceClient interface {
 	SendNotification(ctx context.Context, in *NotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
 	GetNotificationStatus(ctx context.Context, in *GetNotificationStatusRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
+	GetNotificationAttempts(ctx context.Context, in *GetNotificationAttemptsRequest, opts ...grpc.CallOption) (*GetNotificationAttemptsResponse, error)
 	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
 	RescheduleNotification(ctx context.Context, in *RescheduleNotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
 	CancelNotification(ctx context.Context, in *CancelNotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
+	// Moves a dead or errored notification back to queued with a fresh retry budget.
+	RequeueNotification(ctx context.Context, in *RequeueNotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
+	// Requeues every dead or errored notification matching the filters.
+	RequeueNotifications(ctx context.Context, in *RequeueNotificationsRequest, opts ...grpc.CallOption) (*RequeueNotificationsResponse, error)
+	SendNotificationBatch(ctx context.Context, in *NotificationBatchRequest, opts ...grpc.CallOption) (*NotificationBatchResponse, error)
+	GetNotificationBatch(ctx context.Context, in *GetNotificationBatchRequest, opts ...grpc.CallOption) (*GetNotificationBatchResponse, error)
+	// Streams status transitions until the client cancels. The stream ends with ABORTED when the
+	// client falls behind and UNAVAILABLE when the server shuts down; clients should resubscribe.
+	WatchNotifications(ctx context.Context, in *WatchNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NotificationEvent], error)
 }
 
 type notificationServiceClient struct {
@@ -67,6 +83,16 @@ func (c *notificationServiceClient) GetNotificationStatus(ctx context.Context, i
 	return out, nil
 }
 
+func (c *notificationServiceClient) GetNotificationAttempts(ctx context.Context, in *GetNotificationAttemptsRequest, opts ...grpc.CallOption) (*GetNotificationAttemptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotificationAttemptsResponse)
+	err := c.cc.Invoke(ctx, NotificationService_GetNotificationAttempts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *notificationServiceClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListNotificationsResponse)
@@ -97,6 +123,65 @@ func (c *notificationServiceClient) CancelNotification(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *notificationServiceClient) RequeueNotification(ctx context.Context, in *RequeueNotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationResponse)
+	err := c.cc.Invoke(ctx, NotificationService_RequeueNotification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) RequeueNotifications(ctx context.Context, in *RequeueNotificationsRequest, opts ...grpc.CallOption) (*RequeueNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequeueNotificationsResponse)
+	err := c.cc.Invoke(ctx, NotificationService_RequeueNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) SendNotificationBatch(ctx context.Context, in *NotificationBatchRequest, opts ...grpc.CallOption) (*NotificationBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationBatchResponse)
+	err := c.cc.Invoke(ctx, NotificationService_SendNotificationBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) GetNotificationBatch(ctx context.Context, in *GetNotificationBatchRequest, opts ...grpc.CallOption) (*GetNotificationBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotificationBatchResponse)
+	err := c.cc.Invoke(ctx, NotificationService_GetNotificationBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) WatchNotifications(ctx context.Context, in *WatchNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NotificationEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NotificationService_ServiceDesc.Streams[0], NotificationService_WatchNotifications_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchNotificationsRequest, NotificationEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NotificationService_WatchNotificationsClient = grpc.ServerStreamingClient[NotificationEvent]
+
 // NotificationServiceServer is the server API for NotificationService service.
 // All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility.
@@ -105,9 +190,19 @@ func (c *notificationServiceClient) CancelNotification(ctx context.Context, in *
 type NotificationServiceServer interface {
 	SendNotification(context.Context, *NotificationRequest) (*NotificationResponse, error)
 	GetNotificationStatus(context.Context, *GetNotificationStatusRequest) (*NotificationResponse, error)
+	GetNotificationAttempts(context.Context, *GetNotificationAttemptsRequest) (*GetNotificationAttemptsResponse, error)
 	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
 	RescheduleNotification(context.Context, *RescheduleNotificationRequest) (*NotificationResponse, error)
 	CancelNotification(context.Context, *CancelNotificationRequest) (*NotificationResponse, error)
+	// Moves a dead or errored notification back to queued with a fresh retry budget.
+	RequeueNotification(context.Context, *RequeueNotificationRequest) (*NotificationResponse, error)
+	// Requeues every dead or errored notification matching the filters.
+	RequeueNotifications(context.Context, *RequeueNotificationsRequest) (*RequeueNotificationsResponse, error)
+	SendNotificationBatch(context.Context, *NotificationBatchRequest) (*NotificationBatchResponse, error)
+	GetNotificationBatch(context.Context, *GetNotificationBatchRequest) (*GetNotificationBatchResponse, error)
+	// Streams status transitions until the client cancels. The stream ends with ABORTED when the
+	// client falls behind and UNAVAILABLE when the server shuts down; clients should resubscribe.
+	WatchNotifications(*WatchNotificationsRequest, grpc.ServerStreamingServer[NotificationEvent]) error
 	mustEmbedUnimplementedNotificationServiceServer()
 }
 
@@ -124,6 +219,9 @@ func (UnimplementedNotificationServiceServer) SendNotification(context.Context,
 func (UnimplementedNotificationServiceServer) GetNotificationStatus(context.Context, *GetNotificationStatusRequest) (*NotificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationStatus not implemented")
 }
+func (UnimplementedNotificationServiceServer) GetNotificationAttempts(context.Context, *GetNotificationAttemptsRequest) (*GetNotificationAttemptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationAttempts not implemented")
+}
 func (UnimplementedNotificationServiceServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
 }
@@ -133,6 +231,21 @@ func (UnimplementedNotificationServiceServer) RescheduleNotification(context.Con
 func (UnimplementedNotificationServiceServer) CancelNotification(context.Context, *CancelNotificationRequest) (*NotificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelNotification not implemented")
 }
+func (UnimplementedNotificationServiceServer) RequeueNotification(context.Context, *RequeueNotificationRequest) (*NotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueNotification not implemented")
+}
+func (UnimplementedNotificationServiceServer) RequeueNotifications(context.Context, *RequeueNotificationsRequest) (*RequeueNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueNotifications not implemented")
+}
+func (UnimplementedNotificationServiceServer) SendNotificationBatch(context.Context, *NotificationBatchRequest) (*NotificationBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendNotificationBatch not implemented")
+}
+func (UnimplementedNotificationServiceServer) GetNotificationBatch(context.Context, *GetNotificationBatchRequest) (*GetNotificationBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationBatch not implemented")
+}
+func (UnimplementedNotificationServiceServer) WatchNotifications(*WatchNotificationsRequest, grpc.ServerStreamingServer[NotificationEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNotifications not implemented")
+}
 func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 func (UnimplementedNotificationServiceServer) testEmbeddedByValue()                             {}
 
@@ -190,6 +303,24 @@ func _NotificationService_GetNotificationStatus_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_GetNotificationAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationAttemptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).GetNotificationAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_GetNotificationAttempts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).GetNotificationAttempts(ctx, req.(*GetNotificationAttemptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NotificationService_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListNotificationsRequest)
 	if err := dec(in); err != nil {
@@ -244,6 +375,89 @@ func _NotificationService_CancelNotification_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_RequeueNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).RequeueNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_RequeueNotification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).RequeueNotification(ctx, req.(*RequeueNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_RequeueNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).RequeueNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_RequeueNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).RequeueNotifications(ctx, req.(*RequeueNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_SendNotificationBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).SendNotificationBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_SendNotificationBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).SendNotificationBatch(ctx, req.(*NotificationBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_GetNotificationBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).GetNotificationBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_GetNotificationBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).GetNotificationBatch(ctx, req.(*GetNotificationBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_WatchNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NotificationServiceServer).WatchNotifications(m, &grpc.GenericServerStream[WatchNotificationsRequest, NotificationEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NotificationService_WatchNotificationsServer = grpc.ServerStreamingServer[NotificationEvent]
+
 // NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +473,10 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNotificationStatus",
 			Handler:    _NotificationService_GetNotificationStatus_Handler,
 		},
+		{
+			MethodName: "GetNotificationAttempts",
+			Handler:    _NotificationService_GetNotificationAttempts_Handler,
+		},
 		{
 			MethodName: "ListNotifications",
 			Handler:    _NotificationService_ListNotifications_Handler,
@@ -271,6 +489,612 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelNotification",
 			Handler:    _NotificationService_CancelNotification_Handler,
 		},
+		{
+			MethodName: "RequeueNotification",
+			Handler:    _NotificationService_RequeueNotification_Handler,
+		},
+		{
+			MethodName: "RequeueNotifications",
+			Handler:    _NotificationService_RequeueNotifications_Handler,
+		},
+		{
+			MethodName: "SendNotificationBatch",
+			Handler:    _NotificationService_SendNotificationBatch_Handler,
+		},
+		{
+			MethodName: "GetNotificationBatch",
+			Handler:    _NotificationService_GetNotificationBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNotifications",
+			Handler:       _NotificationService_WatchNotifications_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pinguin.proto",
+}
+
+const (
+	TemplateService_CreateTemplate_FullMethodName  = "/pinguin.TemplateService/CreateTemplate"
+	TemplateService_UpdateTemplate_FullMethodName  = "/pinguin.TemplateService/UpdateTemplate"
+	TemplateService_GetTemplate_FullMethodName     = "/pinguin.TemplateService/GetTemplate"
+	TemplateService_ListTemplates_FullMethodName   = "/pinguin.TemplateService/ListTemplates"
+	TemplateService_DeleteTemplate_FullMethodName  = "/pinguin.TemplateService/DeleteTemplate"
+	TemplateService_PreviewTemplate_FullMethodName = "/pinguin.TemplateService/PreviewTemplate"
+)
+
+// TemplateServiceClient is the client API for TemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TemplateService manages the versioned templates referenced by notification requests.
+type TemplateServiceClient interface {
+	CreateTemplate(ctx context.Context, in *TemplateRequest, opts ...grpc.CallOption) (*Template, error)
+	UpdateTemplate(ctx context.Context, in *TemplateRequest, opts ...grpc.CallOption) (*Template, error)
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*Template, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	PreviewTemplate(ctx context.Context, in *PreviewTemplateRequest, opts ...grpc.CallOption) (*PreviewTemplateResponse, error)
+}
+
+type templateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTemplateServiceClient(cc grpc.ClientConnInterface) TemplateServiceClient {
+	return &templateServiceClient{cc}
+}
+
+func (c *templateServiceClient) CreateTemplate(ctx context.Context, in *TemplateRequest, opts ...grpc.CallOption) (*Template, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Template)
+	err := c.cc.Invoke(ctx, TemplateService_CreateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) UpdateTemplate(ctx context.Context, in *TemplateRequest, opts ...grpc.CallOption) (*Template, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Template)
+	err := c.cc.Invoke(ctx, TemplateService_UpdateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*Template, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Template)
+	err := c.cc.Invoke(ctx, TemplateService_GetTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, TemplateService_ListTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, TemplateService_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) PreviewTemplate(ctx context.Context, in *PreviewTemplateRequest, opts ...grpc.CallOption) (*PreviewTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewTemplateResponse)
+	err := c.cc.Invoke(ctx, TemplateService_PreviewTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TemplateServiceServer is the server API for TemplateService service.
+// All implementations must embed UnimplementedTemplateServiceServer
+// for forward compatibility.
+//
+// TemplateService manages the versioned templates referenced by notification requests.
+type TemplateServiceServer interface {
+	CreateTemplate(context.Context, *TemplateRequest) (*Template, error)
+	UpdateTemplate(context.Context, *TemplateRequest) (*Template, error)
+	GetTemplate(context.Context, *GetTemplateRequest) (*Template, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	PreviewTemplate(context.Context, *PreviewTemplateRequest) (*PreviewTemplateResponse, error)
+	mustEmbedUnimplementedTemplateServiceServer()
+}
+
+// UnimplementedTemplateServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTemplateServiceServer struct{}
+
+func (UnimplementedTemplateServiceServer) CreateTemplate(context.Context, *TemplateRequest) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) UpdateTemplate(context.Context, *TemplateRequest) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) GetTemplate(context.Context, *GetTemplateRequest) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedTemplateServiceServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) PreviewTemplate(context.Context, *PreviewTemplateRequest) (*PreviewTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) mustEmbedUnimplementedTemplateServiceServer() {}
+func (UnimplementedTemplateServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeTemplateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TemplateServiceServer will
+// result in compilation errors.
+type UnsafeTemplateServiceServer interface {
+	mustEmbedUnimplementedTemplateServiceServer()
+}
+
+func RegisterTemplateServiceServer(s grpc.ServiceRegistrar, srv TemplateServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTemplateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TemplateService_ServiceDesc, srv)
+}
+
+func _TemplateService_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_CreateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).CreateTemplate(ctx, req.(*TemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_UpdateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).UpdateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_UpdateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).UpdateTemplate(ctx, req.(*TemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_GetTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).GetTemplate(ctx, req.(*GetTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_PreviewTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).PreviewTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_PreviewTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).PreviewTemplate(ctx, req.(*PreviewTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TemplateService_ServiceDesc is the grpc.ServiceDesc for TemplateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TemplateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pinguin.TemplateService",
+	HandlerType: (*TemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _TemplateService_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateTemplate",
+			Handler:    _TemplateService_UpdateTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _TemplateService_GetTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _TemplateService_ListTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _TemplateService_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "PreviewTemplate",
+			Handler:    _TemplateService_PreviewTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pinguin.proto",
+}
+
+const (
+	SuppressionService_ListSuppressions_FullMethodName  = "/pinguin.SuppressionService/ListSuppressions"
+	SuppressionService_AddSuppression_FullMethodName    = "/pinguin.SuppressionService/AddSuppression"
+	SuppressionService_RemoveSuppression_FullMethodName = "/pinguin.SuppressionService/RemoveSuppression"
+)
+
+// SuppressionServiceClient is the client API for SuppressionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SuppressionService manages the recipients that notifications are never delivered to.
+type SuppressionServiceClient interface {
+	ListSuppressions(ctx context.Context, in *ListSuppressionsRequest, opts ...grpc.CallOption) (*ListSuppressionsResponse, error)
+	AddSuppression(ctx context.Context, in *AddSuppressionRequest, opts ...grpc.CallOption) (*Suppression, error)
+	RemoveSuppression(ctx context.Context, in *RemoveSuppressionRequest, opts ...grpc.CallOption) (*RemoveSuppressionResponse, error)
+}
+
+type suppressionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSuppressionServiceClient(cc grpc.ClientConnInterface) SuppressionServiceClient {
+	return &suppressionServiceClient{cc}
+}
+
+func (c *suppressionServiceClient) ListSuppressions(ctx context.Context, in *ListSuppressionsRequest, opts ...grpc.CallOption) (*ListSuppressionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSuppressionsResponse)
+	err := c.cc.Invoke(ctx, SuppressionService_ListSuppressions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suppressionServiceClient) AddSuppression(ctx context.Context, in *AddSuppressionRequest, opts ...grpc.CallOption) (*Suppression, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Suppression)
+	err := c.cc.Invoke(ctx, SuppressionService_AddSuppression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suppressionServiceClient) RemoveSuppression(ctx context.Context, in *RemoveSuppressionRequest, opts ...grpc.CallOption) (*RemoveSuppressionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveSuppressionResponse)
+	err := c.cc.Invoke(ctx, SuppressionService_RemoveSuppression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SuppressionServiceServer is the server API for SuppressionService service.
+// All implementations must embed UnimplementedSuppressionServiceServer
+// for forward compatibility.
+//
+// SuppressionService manages the recipients that notifications are never delivered to.
+type SuppressionServiceServer interface {
+	ListSuppressions(context.Context, *ListSuppressionsRequest) (*ListSuppressionsResponse, error)
+	AddSuppression(context.Context, *AddSuppressionRequest) (*Suppression, error)
+	RemoveSuppression(context.Context, *RemoveSuppressionRequest) (*RemoveSuppressionResponse, error)
+	mustEmbedUnimplementedSuppressionServiceServer()
+}
+
+// UnimplementedSuppressionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSuppressionServiceServer struct{}
+
+func (UnimplementedSuppressionServiceServer) ListSuppressions(context.Context, *ListSuppressionsRequest) (*ListSuppressionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuppressions not implemented")
+}
+func (UnimplementedSuppressionServiceServer) AddSuppression(context.Context, *AddSuppressionRequest) (*Suppression, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSuppression not implemented")
+}
+func (UnimplementedSuppressionServiceServer) RemoveSuppression(context.Context, *RemoveSuppressionRequest) (*RemoveSuppressionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSuppression not implemented")
+}
+func (UnimplementedSuppressionServiceServer) mustEmbedUnimplementedSuppressionServiceServer() {}
+func (UnimplementedSuppressionServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeSuppressionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SuppressionServiceServer will
+// result in compilation errors.
+type UnsafeSuppressionServiceServer interface {
+	mustEmbedUnimplementedSuppressionServiceServer()
+}
+
+func RegisterSuppressionServiceServer(s grpc.ServiceRegistrar, srv SuppressionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSuppressionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SuppressionService_ServiceDesc, srv)
+}
+
+func _SuppressionService_ListSuppressions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuppressionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuppressionServiceServer).ListSuppressions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuppressionService_ListSuppressions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuppressionServiceServer).ListSuppressions(ctx, req.(*ListSuppressionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuppressionService_AddSuppression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSuppressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuppressionServiceServer).AddSuppression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuppressionService_AddSuppression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuppressionServiceServer).AddSuppression(ctx, req.(*AddSuppressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuppressionService_RemoveSuppression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSuppressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuppressionServiceServer).RemoveSuppression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuppressionService_RemoveSuppression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuppressionServiceServer).RemoveSuppression(ctx, req.(*RemoveSuppressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SuppressionService_ServiceDesc is the grpc.ServiceDesc for SuppressionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SuppressionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pinguin.SuppressionService",
+	HandlerType: (*SuppressionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSuppressions",
+			Handler:    _SuppressionService_ListSuppressions_Handler,
+		},
+		{
+			MethodName: "AddSuppression",
+			Handler:    _SuppressionService_AddSuppression_Handler,
+		},
+		{
+			MethodName: "RemoveSuppression",
+			Handler:    _SuppressionService_RemoveSuppression_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pinguin.proto",
+}
+
+const (
+	InboundMessageService_ListInboundMessages_FullMethodName = "/pinguin.InboundMessageService/ListInboundMessages"
+)
+
+// InboundMessageServiceClient is the client API for InboundMessageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// InboundMessageService exposes SMS received from recipients, including STOP/START/HELP replies.
+type InboundMessageServiceClient interface {
+	ListInboundMessages(ctx context.Context, in *ListInboundMessagesRequest, opts ...grpc.CallOption) (*ListInboundMessagesResponse, error)
+}
+
+type inboundMessageServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInboundMessageServiceClient(cc grpc.ClientConnInterface) InboundMessageServiceClient {
+	return &inboundMessageServiceClient{cc}
+}
+
+func (c *inboundMessageServiceClient) ListInboundMessages(ctx context.Context, in *ListInboundMessagesRequest, opts ...grpc.CallOption) (*ListInboundMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInboundMessagesResponse)
+	err := c.cc.Invoke(ctx, InboundMessageService_ListInboundMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InboundMessageServiceServer is the server API for InboundMessageService service.
+// All implementations must embed UnimplementedInboundMessageServiceServer
+// for forward compatibility.
+//
+// InboundMessageService exposes SMS received from recipients, including STOP/START/HELP replies.
+type InboundMessageServiceServer interface {
+	ListInboundMessages(context.Context, *ListInboundMessagesRequest) (*ListInboundMessagesResponse, error)
+	mustEmbedUnimplementedInboundMessageServiceServer()
+}
+
+// UnimplementedInboundMessageServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInboundMessageServiceServer struct{}
+
+func (UnimplementedInboundMessageServiceServer) ListInboundMessages(context.Context, *ListInboundMessagesRequest) (*ListInboundMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInboundMessages not implemented")
+}
+func (UnimplementedInboundMessageServiceServer) mustEmbedUnimplementedInboundMessageServiceServer() {}
+func (UnimplementedInboundMessageServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeInboundMessageServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InboundMessageServiceServer will
+// result in compilation errors.
+type UnsafeInboundMessageServiceServer interface {
+	mustEmbedUnimplementedInboundMessageServiceServer()
+}
+
+func RegisterInboundMessageServiceServer(s grpc.ServiceRegistrar, srv InboundMessageServiceServer) {
+	// If the following call pancis, it indicates UnimplementedInboundMessageServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InboundMessageService_ServiceDesc, srv)
+}
+
+func _InboundMessageService_ListInboundMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInboundMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InboundMessageServiceServer).ListInboundMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InboundMessageService_ListInboundMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InboundMessageServiceServer).ListInboundMessages(ctx, req.(*ListInboundMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InboundMessageService_ServiceDesc is the grpc.ServiceDesc for InboundMessageService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InboundMessageService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pinguin.InboundMessageService",
+	HandlerType: (*InboundMessageServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListInboundMessages",
+			Handler:    _InboundMessageService_ListInboundMessages_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pinguin.proto",
@@ -143,3 +143,126 @@ func TestUnimplementedServerResponses(t *testing.T) {
 	server.mustEmbedUnimplementedNotificationServiceServer()
 	server.testEmbeddedByValue()
 }
+
+func TestTemplateServiceClientCoverage(t *testing.T) {
+	t.Helper()
+	connection := &fakeConn{}
+	client := NewTemplateServiceClient(connection)
+	ctx := context.Background()
+	if _, err := client.CreateTemplate(ctx, &TemplateRequest{}); err != nil {
+		t.Fatalf("CreateTemplate error: %v", err)
+	}
+	if _, err := client.UpdateTemplate(ctx, &TemplateRequest{}); err != nil {
+		t.Fatalf("UpdateTemplate error: %v", err)
+	}
+	if _, err := client.GetTemplate(ctx, &GetTemplateRequest{}); err != nil {
+		t.Fatalf("GetTemplate error: %v", err)
+	}
+	if _, err := client.ListTemplates(ctx, &ListTemplatesRequest{}); err != nil {
+		t.Fatalf("ListTemplates error: %v", err)
+	}
+	if _, err := client.DeleteTemplate(ctx, &DeleteTemplateRequest{}); err != nil {
+		t.Fatalf("DeleteTemplate error: %v", err)
+	}
+	if _, err := client.PreviewTemplate(ctx, &PreviewTemplateRequest{}); err != nil {
+		t.Fatalf("PreviewTemplate error: %v", err)
+	}
+	if connection.lastMethod != TemplateService_PreviewTemplate_FullMethodName {
+		t.Fatalf("unexpected last method %q", connection.lastMethod)
+	}
+}
+
+type templateCoverageServer struct {
+	UnimplementedTemplateServiceServer
+	calledMethods map[string]bool
+}
+
+func (s *templateCoverageServer) record(method string) {
+	if s.calledMethods == nil {
+		s.calledMethods = map[string]bool{}
+	}
+	s.calledMethods[method] = true
+}
+
+func (s *templateCoverageServer) CreateTemplate(context.Context, *TemplateRequest) (*Template, error) {
+	s.record("create")
+	return &Template{TemplateId: "welcome"}, nil
+}
+
+func (s *templateCoverageServer) UpdateTemplate(context.Context, *TemplateRequest) (*Template, error) {
+	s.record("update")
+	return &Template{TemplateId: "welcome"}, nil
+}
+
+func (s *templateCoverageServer) GetTemplate(context.Context, *GetTemplateRequest) (*Template, error) {
+	s.record("get")
+	return &Template{TemplateId: "welcome"}, nil
+}
+
+func (s *templateCoverageServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	s.record("list")
+	return &ListTemplatesResponse{}, nil
+}
+
+func (s *templateCoverageServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	s.record("delete")
+	return &DeleteTemplateResponse{}, nil
+}
+
+func (s *templateCoverageServer) PreviewTemplate(context.Context, *PreviewTemplateRequest) (*PreviewTemplateResponse, error) {
+	s.record("preview")
+	return &PreviewTemplateResponse{}, nil
+}
+
+func TestTemplateServiceServerHandlers(t *testing.T) {
+	t.Helper()
+	server := &templateCoverageServer{}
+	ctx := context.Background()
+	decoder := func(interface{}) error { return nil }
+
+	handlers := map[string]func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error){
+		"create":  _TemplateService_CreateTemplate_Handler,
+		"update":  _TemplateService_UpdateTemplate_Handler,
+		"get":     _TemplateService_GetTemplate_Handler,
+		"list":    _TemplateService_ListTemplates_Handler,
+		"delete":  _TemplateService_DeleteTemplate_Handler,
+		"preview": _TemplateService_PreviewTemplate_Handler,
+	}
+	for name, handler := range handlers {
+		if _, err := handler(server, ctx, decoder, nil); err != nil {
+			t.Fatalf("%s handler error: %v", name, err)
+		}
+		if !server.calledMethods[name] {
+			t.Fatalf("expected %s server method to be called", name)
+		}
+	}
+
+	grpcServer := grpc.NewServer()
+	RegisterTemplateServiceServer(grpcServer, server)
+	grpcServer.Stop()
+}
+
+func TestUnimplementedTemplateServerResponses(t *testing.T) {
+	t.Helper()
+	server := UnimplementedTemplateServiceServer{}
+	ctx := context.Background()
+	assertUnimplemented := func(err error) {
+		if err == nil || status.Code(err) != codes.Unimplemented {
+			t.Fatalf("expected unimplemented error, got %v", err)
+		}
+	}
+	_, err := server.CreateTemplate(ctx, &TemplateRequest{})
+	assertUnimplemented(err)
+	_, err = server.UpdateTemplate(ctx, &TemplateRequest{})
+	assertUnimplemented(err)
+	_, err = server.GetTemplate(ctx, &GetTemplateRequest{})
+	assertUnimplemented(err)
+	_, err = server.ListTemplates(ctx, &ListTemplatesRequest{})
+	assertUnimplemented(err)
+	_, err = server.DeleteTemplate(ctx, &DeleteTemplateRequest{})
+	assertUnimplemented(err)
+	_, err = server.PreviewTemplate(ctx, &PreviewTemplateRequest{})
+	assertUnimplemented(err)
+	server.mustEmbedUnimplementedTemplateServiceServer()
+	server.testEmbeddedByValue()
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy decides how long a job waits after a failed attempt before it is attempted again.
+type RetryPolicy interface {
+	NextDelay(retry Retry) time.Duration
+}
+
+// Retry describes a failed attempt to a RetryPolicy. RetryCount counts the attempts made so far,
+// including the failed one. PreviousDelay is how long the job was scheduled to wait before the
+// failed attempt, and zero when that attempt was its first.
+type Retry struct {
+	Job           Job
+	RetryCount    int
+	PreviousDelay time.Duration
+}
+
+// Jitter selects how ExponentialBackoff randomizes its delays, so that jobs failing together do
+// not all come back at the same moment.
+type Jitter string
+
+const (
+	// JitterNone waits exactly the exponential delay.
+	JitterNone Jitter = "none"
+	// JitterFull waits a random delay between zero and the exponential delay.
+	JitterFull Jitter = "full"
+	// JitterDecorrelated waits a random delay between BaseDelay and three times the previous delay,
+	// so the delay grows with each failure without following a fixed schedule.
+	JitterDecorrelated Jitter = "decorrelated"
+)
+
+// ExponentialBackoff waits BaseDelay * 2^n after the nth failed attempt, randomized by Jitter.
+// A positive MaxDelay caps every delay; otherwise growth stops after maxBackoffShift doublings.
+// The zero Jitter behaves like JitterNone.
+type ExponentialBackoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Jitter    Jitter
+}
+
+func (policy ExponentialBackoff) NextDelay(retry Retry) time.Duration {
+	switch policy.Jitter {
+	case JitterFull:
+		return randomDuration(0, policy.exponential(retry.RetryCount))
+	case JitterDecorrelated:
+		previous := max(retry.PreviousDelay, policy.BaseDelay)
+		upper := policy.exponential(maxBackoffShift)
+		if previous < upper/3 {
+			upper = previous * 3
+		}
+		return policy.capped(randomDuration(policy.BaseDelay, upper))
+	default:
+		return policy.exponential(retry.RetryCount)
+	}
+}
+
+// exponential returns the capped delay before the attempt that follows retryCount attempts.
+func (policy ExponentialBackoff) exponential(retryCount int) time.Duration {
+	shift := min(max(retryCount, 0), maxBackoffShift)
+	return policy.capped(policy.BaseDelay * time.Duration(1<<uint(shift)))
+}
+
+func (policy ExponentialBackoff) capped(delay time.Duration) time.Duration {
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return delay
+}
+
+// randomDuration returns a uniformly distributed duration in [low, high].
+func randomDuration(low time.Duration, high time.Duration) time.Duration {
+	if high <= low {
+		return low
+	}
+	return low + rand.N(high-low+1)
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoffNextDelay(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name          string
+		policy        ExponentialBackoff
+		retry         Retry
+		expectedLower time.Duration
+		expectedUpper time.Duration
+	}{
+		{
+			name:          "DoublesPerAttempt",
+			policy:        ExponentialBackoff{BaseDelay: time.Second},
+			retry:         Retry{RetryCount: 3},
+			expectedLower: 8 * time.Second,
+			expectedUpper: 8 * time.Second,
+		},
+		{
+			name:          "ZeroJitterMatchesNone",
+			policy:        ExponentialBackoff{BaseDelay: time.Second, Jitter: JitterNone},
+			retry:         Retry{RetryCount: 1},
+			expectedLower: 2 * time.Second,
+			expectedUpper: 2 * time.Second,
+		},
+		{
+			name:          "CappedByMaxDelay",
+			policy:        ExponentialBackoff{BaseDelay: time.Second, MaxDelay: 10 * time.Second},
+			retry:         Retry{RetryCount: 8},
+			expectedLower: 10 * time.Second,
+			expectedUpper: 10 * time.Second,
+		},
+		{
+			name:          "GrowthStopsWithoutMaxDelay",
+			policy:        ExponentialBackoff{BaseDelay: time.Second},
+			retry:         Retry{RetryCount: 100},
+			expectedLower: time.Second << maxBackoffShift,
+			expectedUpper: time.Second << maxBackoffShift,
+		},
+		{
+			name:          "FullJitterStaysBelowExponentialDelay",
+			policy:        ExponentialBackoff{BaseDelay: time.Second, MaxDelay: 30 * time.Second, Jitter: JitterFull},
+			retry:         Retry{RetryCount: 4},
+			expectedLower: 0,
+			expectedUpper: 16 * time.Second,
+		},
+		{
+			name:          "DecorrelatedJitterStartsFromBaseDelay",
+			policy:        ExponentialBackoff{BaseDelay: time.Second, Jitter: JitterDecorrelated},
+			retry:         Retry{RetryCount: 1},
+			expectedLower: time.Second,
+			expectedUpper: 3 * time.Second,
+		},
+		{
+			name:          "DecorrelatedJitterTriplesPreviousDelay",
+			policy:        ExponentialBackoff{BaseDelay: time.Second, Jitter: JitterDecorrelated},
+			retry:         Retry{RetryCount: 4, PreviousDelay: 5 * time.Second},
+			expectedLower: time.Second,
+			expectedUpper: 15 * time.Second,
+		},
+		{
+			name:          "DecorrelatedJitterCappedByMaxDelay",
+			policy:        ExponentialBackoff{BaseDelay: time.Second, MaxDelay: 4 * time.Second, Jitter: JitterDecorrelated},
+			retry:         Retry{RetryCount: 4, PreviousDelay: 5 * time.Second},
+			expectedLower: time.Second,
+			expectedUpper: 4 * time.Second,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			for sample := 0; sample < 200; sample++ {
+				delay := testCase.policy.NextDelay(testCase.retry)
+				if delay < testCase.expectedLower || delay > testCase.expectedUpper {
+					t.Fatalf("expected a delay within [%s, %s], got %s", testCase.expectedLower, testCase.expectedUpper, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestExponentialBackoffJitterSpreadsDelays(t *testing.T) {
+	t.Helper()
+
+	for _, jitter := range []Jitter{JitterFull, JitterDecorrelated} {
+		t.Run(string(jitter), func(t *testing.T) {
+			policy := ExponentialBackoff{BaseDelay: time.Second, MaxDelay: time.Hour, Jitter: jitter}
+			delays := make(map[time.Duration]struct{})
+			for sample := 0; sample < 50; sample++ {
+				delays[policy.NextDelay(Retry{RetryCount: 5, PreviousDelay: 20 * time.Second})] = struct{}{}
+			}
+			if len(delays) < 2 {
+				t.Fatalf("expected jittered delays to differ, got %v", delays)
+			}
+		})
+	}
+}
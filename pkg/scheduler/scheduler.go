@@ -2,44 +2,116 @@ package scheduler
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"sort"
+	"sync"
 	"time"
 )
 
-// Repository exposes persistence hooks for fetching pending jobs and recording attempt results.
+// Repository exposes persistence hooks for leasing due jobs and recording attempt results. Leases
+// let several workers share one queue: a job claimed by one worker is invisible to the others until
+// its lease is released or expires.
 type Repository interface {
-	PendingJobs(ctx context.Context, maxRetries int, now time.Time) ([]Job, error)
+	// ClaimJobs atomically leases up to claim.Limit due jobs that are not leased by another worker,
+	// or whose lease has expired, and returns them.
+	ClaimJobs(ctx context.Context, claim Claim) ([]Job, error)
+	// ReleaseJob drops the lease on a claimed job that the worker decided not to attempt.
+	ReleaseJob(ctx context.Context, job Job) error
+	// ApplyAttemptResult records the outcome of an attempt and releases the job's lease. Results for
+	// jobs the worker no longer leases should be dropped so they do not overwrite newer state.
 	ApplyAttemptResult(ctx context.Context, job Job, update AttemptUpdate) error
 }
 
+// Claim describes one ClaimJobs call. Jobs of ExcludedClasses are left for a later claim.
+type Claim struct {
+	WorkerID        string
+	MaxRetries      int
+	Now             time.Time
+	LeaseUntil      time.Time
+	Limit           int
+	ExcludedClasses []string
+}
+
 // Dispatcher performs the effectful work for a job (sending an email, firing an SMS, etc.).
+// Dispatchers that also implement ErrorClassifier decide which of their errors are worth retrying.
 type Dispatcher interface {
 	Attempt(ctx context.Context, job Job) (DispatchResult, error)
 }
 
+// ErrorClassifier reports whether another attempt at job could succeed after it failed with err.
+// A failure that is not retryable spends the remaining retry budget at once, exactly like an error
+// wrapped with Permanent, which is never retried regardless of the classifier.
+type ErrorClassifier interface {
+	Retryable(job Job, err error) bool
+}
+
 // Job represents a scheduled unit of work alongside metadata the scheduler needs for backoff decisions.
+// Class groups jobs that share a concurrency limit and retry policy, such as all jobs sent through
+// one channel. NextAttemptAt is the persisted AttemptUpdate.NextAttemptAt of the last failed attempt.
 type Job struct {
 	ID              string
+	Class           string
 	ScheduledFor    *time.Time
 	RetryCount      int
 	LastAttemptedAt time.Time
-	Payload         any
+	NextAttemptAt   time.Time
+	// LockedBy and LeaseUntil describe the lease held on the job when it was claimed.
+	LockedBy   string
+	LeaseUntil time.Time
+	Payload    any
 }
 
 // DispatchResult carries dispatcher-supplied metadata, including status overrides and provider IDs.
 type DispatchResult struct {
 	Status            string
 	ProviderMessageID string
+	Provider          string
 }
 
 // AttemptUpdate describes the mutation that must be persisted after a dispatch attempt.
+// NextAttemptAt is when a failed job becomes due again; it is zero when no retry is planned.
+// Duration is how long Dispatcher.Attempt ran, and Err is the error it returned.
 type AttemptUpdate struct {
 	Status            string
 	ProviderMessageID string
+	Provider          string
 	RetryCount        int
 	LastAttemptedAt   time.Time
+	NextAttemptAt     time.Time
+	Duration          time.Duration
+	Err               error
+}
+
+// permanentError marks a dispatch failure that no amount of retrying will fix.
+type permanentError struct {
+	err error
+}
+
+func (wrapped *permanentError) Error() string {
+	return wrapped.err.Error()
+}
+
+func (wrapped *permanentError) Unwrap() error {
+	return wrapped.err
+}
+
+// Permanent wraps err so the worker records the failure without scheduling further retries.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err, or any error it wraps, was marked with Permanent.
+func IsPermanent(err error) bool {
+	var wrapped *permanentError
+	return errors.As(err, &wrapped)
 }
 
 // Clock abstracts time acquisition for deterministic tests.
@@ -47,16 +119,44 @@ type Clock interface {
 	Now() time.Time
 }
 
-// Config contains all inputs required to construct a Worker.
+// Config contains all inputs required to construct a Worker. PollInterval (defaulting to Interval)
+// is how often the worker looks for due jobs, and a send on Wake starts a cycle straight away.
+//
+// A failed attempt is recorded with FailureStatus. When it spends the last of MaxRetries, it is
+// recorded with ExhaustedStatus instead, if one is set. The wait before the next attempt comes from
+// the ClassRetryPolicies entry of the job's class or, without one, from RetryPolicy, which defaults
+// to an ExponentialBackoff from Interval without jitter.
+//
+// Jobs are attempted concurrently. ClassConcurrency limits the attempts running at once for each
+// Job.Class, with DefaultConcurrency (1 when unset) applying to classes without an entry, and
+// MaxInFlight caps attempts across all classes (0 leaves only the per-class limits).
+//
+// WorkerID identifies the worker's leases and defaults to the host name, process ID, and a random
+// suffix. Claimed jobs are leased for LeaseDuration (5 minutes by default), which must outlast an
+// attempt, and at most ClaimLimit jobs (100 by default) are claimed per cycle.
 type Config struct {
-	Repository    Repository
-	Dispatcher    Dispatcher
-	Logger        *slog.Logger
-	Interval      time.Duration
-	MaxRetries    int
-	SuccessStatus string
-	FailureStatus string
-	Clock         Clock
+	Repository      Repository
+	Dispatcher      Dispatcher
+	Logger          *slog.Logger
+	Interval        time.Duration
+	PollInterval    time.Duration
+	Wake            <-chan struct{}
+	MaxRetries      int
+	SuccessStatus   string
+	FailureStatus   string
+	ExhaustedStatus string
+	Clock           Clock
+
+	RetryPolicy        RetryPolicy
+	ClassRetryPolicies map[string]RetryPolicy
+
+	ClassConcurrency   map[string]int
+	DefaultConcurrency int
+	MaxInFlight        int
+
+	WorkerID      string
+	LeaseDuration time.Duration
+	ClaimLimit    int
 }
 
 type systemClock struct{}
@@ -65,19 +165,45 @@ func (systemClock) Now() time.Time {
 	return time.Now().UTC()
 }
 
-// Worker orchestrates scheduled retries with exponential backoff and contextual logging.
+// Worker orchestrates scheduled retries with configurable backoff and contextual logging.
 type Worker struct {
-	repository    Repository
-	dispatcher    Dispatcher
-	logger        *slog.Logger
-	interval      time.Duration
-	maxRetries    int
-	successStatus string
-	failureStatus string
-	clock         Clock
+	repository      Repository
+	dispatcher      Dispatcher
+	classifier      ErrorClassifier
+	logger          *slog.Logger
+	interval        time.Duration
+	pollInterval    time.Duration
+	wake            <-chan struct{}
+	maxRetries      int
+	successStatus   string
+	failureStatus   string
+	exhaustedStatus string
+	clock           Clock
+
+	retryPolicy        RetryPolicy
+	classRetryPolicies map[string]RetryPolicy
+
+	classConcurrency   map[string]int
+	defaultConcurrency int
+	inFlightSlots      chan struct{}
+
+	workerID      string
+	leaseDuration time.Duration
+	claimLimit    int
+
+	// mutex guards the per-class slots and the jobs claimed by a cycle until their attempt ends.
+	mutex         sync.Mutex
+	classSlots    map[string]chan struct{}
+	claimedJobs   map[string]struct{}
+	activeClasses map[string]struct{}
+	waitGroup     sync.WaitGroup
 }
 
-const maxBackoffShift = 20
+const (
+	maxBackoffShift      = 20
+	defaultLeaseDuration = 5 * time.Minute
+	defaultClaimLimit    = 100
+)
 
 var errInvalidConfig = errors.New("invalid scheduler config")
 
@@ -89,48 +215,132 @@ func NewWorker(cfg Config) (*Worker, error) {
 	if cfg.Interval <= 0 {
 		return nil, fmt.Errorf("%w: interval must be positive", errInvalidConfig)
 	}
+	if cfg.PollInterval < 0 {
+		return nil, fmt.Errorf("%w: poll interval must not be negative", errInvalidConfig)
+	}
+	pollInterval := cfg.PollInterval
+	if pollInterval == 0 {
+		pollInterval = cfg.Interval
+	}
 	if cfg.MaxRetries <= 0 {
 		return nil, fmt.Errorf("%w: max retries must be positive", errInvalidConfig)
 	}
 	if cfg.SuccessStatus == "" || cfg.FailureStatus == "" {
 		return nil, fmt.Errorf("%w: success and failure statuses are required", errInvalidConfig)
 	}
+	if cfg.DefaultConcurrency < 0 || cfg.MaxInFlight < 0 {
+		return nil, fmt.Errorf("%w: concurrency limits must not be negative", errInvalidConfig)
+	}
+	classConcurrency := make(map[string]int, len(cfg.ClassConcurrency))
+	for class, limit := range cfg.ClassConcurrency {
+		if limit <= 0 {
+			return nil, fmt.Errorf("%w: concurrency for class %q must be positive", errInvalidConfig, class)
+		}
+		classConcurrency[class] = limit
+	}
+	defaultConcurrency := cfg.DefaultConcurrency
+	if defaultConcurrency == 0 {
+		defaultConcurrency = 1
+	}
+	if cfg.LeaseDuration < 0 || cfg.ClaimLimit < 0 {
+		return nil, fmt.Errorf("%w: lease duration and claim limit must not be negative", errInvalidConfig)
+	}
+	leaseDuration := cfg.LeaseDuration
+	if leaseDuration == 0 {
+		leaseDuration = defaultLeaseDuration
+	}
+	claimLimit := cfg.ClaimLimit
+	if claimLimit == 0 {
+		claimLimit = defaultClaimLimit
+	}
+	workerID := cfg.WorkerID
+	if workerID == "" {
+		workerID = generateWorkerID()
+	}
+	var inFlightSlots chan struct{}
+	if cfg.MaxInFlight > 0 {
+		inFlightSlots = make(chan struct{}, cfg.MaxInFlight)
+	}
 	clock := cfg.Clock
 	if clock == nil {
 		clock = systemClock{}
 	}
+	retryPolicy := cfg.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = ExponentialBackoff{BaseDelay: cfg.Interval}
+	}
+	classRetryPolicies := make(map[string]RetryPolicy, len(cfg.ClassRetryPolicies))
+	for class, policy := range cfg.ClassRetryPolicies {
+		if policy == nil {
+			return nil, fmt.Errorf("%w: retry policy for class %q must not be nil", errInvalidConfig, class)
+		}
+		classRetryPolicies[class] = policy
+	}
+	classifier, _ := cfg.Dispatcher.(ErrorClassifier)
 	return &Worker{
-		repository:    cfg.Repository,
-		dispatcher:    cfg.Dispatcher,
-		logger:        cfg.Logger,
-		interval:      cfg.Interval,
-		maxRetries:    cfg.MaxRetries,
-		successStatus: cfg.SuccessStatus,
-		failureStatus: cfg.FailureStatus,
-		clock:         clock,
+		repository:      cfg.Repository,
+		dispatcher:      cfg.Dispatcher,
+		classifier:      classifier,
+		logger:          cfg.Logger,
+		interval:        cfg.Interval,
+		pollInterval:    pollInterval,
+		wake:            cfg.Wake,
+		maxRetries:      cfg.MaxRetries,
+		successStatus:   cfg.SuccessStatus,
+		failureStatus:   cfg.FailureStatus,
+		exhaustedStatus: cfg.ExhaustedStatus,
+		clock:           clock,
+
+		retryPolicy:        retryPolicy,
+		classRetryPolicies: classRetryPolicies,
+
+		classConcurrency:   classConcurrency,
+		defaultConcurrency: defaultConcurrency,
+		inFlightSlots:      inFlightSlots,
+		workerID:           workerID,
+		leaseDuration:      leaseDuration,
+		claimLimit:         claimLimit,
+		classSlots:         make(map[string]chan struct{}),
+		claimedJobs:        make(map[string]struct{}),
+		activeClasses:      make(map[string]struct{}),
 	}, nil
 }
 
-// Run executes the retry loop until the provided context is canceled.
+// Run executes the retry loop until the provided context is canceled. A nil Wake channel blocks
+// forever, leaving the ticker as the only trigger. Cancellation stops new attempts; Run returns once
+// the attempts already in flight have finished and recorded their results.
 func (worker *Worker) Run(ctx context.Context) {
-	ticker := time.NewTicker(worker.interval)
+	ticker := time.NewTicker(worker.pollInterval)
 	defer ticker.Stop()
 
-	worker.logger.Info("scheduler_worker_started", "interval", worker.interval, "max_retries", worker.maxRetries)
+	worker.logger.Info(
+		"scheduler_worker_started",
+		"interval", worker.interval,
+		"poll_interval", worker.pollInterval,
+		"max_retries", worker.maxRetries,
+		"default_concurrency", worker.defaultConcurrency,
+		"max_in_flight", cap(worker.inFlightSlots),
+		"worker_id", worker.workerID,
+	)
 	for {
 		select {
 		case <-ctx.Done():
+			worker.waitGroup.Wait()
 			worker.logger.Info("scheduler_worker_stopped")
 			return
 		case <-ticker.C:
 			worker.runCycle(ctx)
+		case <-worker.wake:
+			worker.runCycle(ctx)
 		}
 	}
 }
 
-// RunOnce executes a single scheduler cycle. This is primarily used in tests.
+// RunOnce executes a single scheduler cycle and waits for its attempts to finish. This is primarily
+// used in tests.
 func (worker *Worker) RunOnce(ctx context.Context) {
 	worker.runCycle(ctx)
+	worker.waitGroup.Wait()
 }
 
 func (worker *Worker) runCycle(ctx context.Context) {
@@ -138,43 +348,176 @@ func (worker *Worker) runCycle(ctx context.Context) {
 		return
 	}
 
+	// Each class is fed by its own goroutine so a class whose slots are taken by slow attempts does
+	// not hold back the others. Classes that are still being fed are not claimed again until their
+	// feeder has started every job it holds.
+	worker.mutex.Lock()
+	excludedClasses := make([]string, 0, len(worker.activeClasses))
+	for class := range worker.activeClasses {
+		excludedClasses = append(excludedClasses, class)
+	}
+	worker.mutex.Unlock()
+	sort.Strings(excludedClasses)
+
 	now := worker.clock.Now()
-	pendingJobs, pendingErr := worker.repository.PendingJobs(ctx, worker.maxRetries, now)
-	if pendingErr != nil {
-		worker.logger.Error("scheduler_pending_jobs_error", "error", pendingErr)
+	claimedJobs, claimErr := worker.repository.ClaimJobs(ctx, Claim{
+		WorkerID:        worker.workerID,
+		MaxRetries:      worker.maxRetries,
+		Now:             now,
+		LeaseUntil:      now.Add(worker.leaseDuration),
+		Limit:           worker.claimLimit,
+		ExcludedClasses: excludedClasses,
+	})
+	if claimErr != nil {
+		worker.logger.Error("scheduler_claim_jobs_error", "error", claimErr)
 		return
 	}
 
-	for _, job := range pendingJobs {
-		if ctx.Err() != nil {
-			return
+	jobsByClass := make(map[string][]Job)
+	var classOrder []string
+	var skippedJobs []Job
+	worker.mutex.Lock()
+	for _, job := range claimedJobs {
+		if _, inFlight := worker.claimedJobs[job.ID]; inFlight {
+			// The lease ran out while this worker was still attempting the job; the running attempt
+			// records the outcome.
+			continue
 		}
-		if !worker.shouldAttempt(job, now) {
+		if _, active := worker.activeClasses[job.Class]; active || !worker.shouldAttempt(job, now) {
+			skippedJobs = append(skippedJobs, job)
 			continue
 		}
-		worker.executeJob(ctx, job, now)
+		if _, seen := jobsByClass[job.Class]; !seen {
+			classOrder = append(classOrder, job.Class)
+		}
+		worker.claimedJobs[job.ID] = struct{}{}
+		jobsByClass[job.Class] = append(jobsByClass[job.Class], job)
+	}
+	for _, class := range classOrder {
+		worker.activeClasses[class] = struct{}{}
+	}
+	worker.mutex.Unlock()
+
+	for _, job := range skippedJobs {
+		worker.releaseJob(ctx, job)
+	}
+	for _, class := range classOrder {
+		worker.waitGroup.Add(1)
+		go worker.feedClass(ctx, class, jobsByClass[class])
+	}
+}
+
+func (worker *Worker) releaseJob(ctx context.Context, job Job) {
+	if releaseErr := worker.repository.ReleaseJob(context.WithoutCancel(ctx), job); releaseErr != nil {
+		worker.logger.Error("scheduler_release_job_error", "job_id", job.ID, "error", releaseErr)
+	}
+}
+
+// feedClass starts the attempts for one class as its slots free up. Attempts run on a context that
+// survives cancellation so that a shutdown lets them finish and record their outcome.
+func (worker *Worker) feedClass(ctx context.Context, class string, jobs []Job) {
+	defer worker.waitGroup.Done()
+	classSlots := worker.slotsForClass(class)
+	attemptCtx := context.WithoutCancel(ctx)
+
+	launched := 0
+	defer func() {
+		worker.mutex.Lock()
+		for _, job := range jobs[launched:] {
+			delete(worker.claimedJobs, job.ID)
+		}
+		delete(worker.activeClasses, class)
+		worker.mutex.Unlock()
+		// Jobs that never started go back to the queue for any worker to claim.
+		for _, job := range jobs[launched:] {
+			worker.releaseJob(ctx, job)
+		}
+	}()
+
+	for _, job := range jobs {
+		select {
+		case classSlots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		if worker.inFlightSlots != nil {
+			select {
+			case worker.inFlightSlots <- struct{}{}:
+			case <-ctx.Done():
+				<-classSlots
+				return
+			}
+		}
+		launched++
+
+		worker.waitGroup.Add(1)
+		go func(job Job) {
+			defer worker.waitGroup.Done()
+			defer func() {
+				worker.mutex.Lock()
+				delete(worker.claimedJobs, job.ID)
+				worker.mutex.Unlock()
+				if worker.inFlightSlots != nil {
+					<-worker.inFlightSlots
+				}
+				<-classSlots
+			}()
+			worker.executeJob(attemptCtx, job, worker.clock.Now())
+		}(job)
+	}
+}
+
+func (worker *Worker) slotsForClass(class string) chan struct{} {
+	worker.mutex.Lock()
+	defer worker.mutex.Unlock()
+	slots, exists := worker.classSlots[class]
+	if !exists {
+		limit, configured := worker.classConcurrency[class]
+		if !configured {
+			limit = worker.defaultConcurrency
+		}
+		slots = make(chan struct{}, limit)
+		worker.classSlots[class] = slots
 	}
+	return slots
 }
 
 func (worker *Worker) shouldAttempt(job Job, now time.Time) bool {
 	if job.ScheduledFor != nil && now.Before(job.ScheduledFor.UTC()) {
 		return false
 	}
+	if !job.NextAttemptAt.IsZero() {
+		return !now.Before(job.NextAttemptAt.UTC())
+	}
 	if job.RetryCount <= 0 || job.LastAttemptedAt.IsZero() {
 		return true
 	}
-	shift := job.RetryCount
-	if shift > maxBackoffShift {
-		shift = maxBackoffShift
-	}
-	backoff := worker.interval * time.Duration(1<<uint(shift))
-	nextAttempt := job.LastAttemptedAt.UTC().Add(backoff)
+	// Jobs failed before their next attempt time was persisted are due once the policy's delay
+	// after their last attempt has passed.
+	nextAttempt := job.LastAttemptedAt.UTC().Add(worker.retryPolicyFor(job).NextDelay(Retry{Job: job, RetryCount: job.RetryCount}))
 	return !now.Before(nextAttempt)
 }
 
+func (worker *Worker) retryPolicyFor(job Job) RetryPolicy {
+	if policy, configured := worker.classRetryPolicies[job.Class]; configured {
+		return policy
+	}
+	return worker.retryPolicy
+}
+
+// retryable reports whether the error of a failed attempt leaves room for another attempt.
+func (worker *Worker) retryable(job Job, dispatchErr error) bool {
+	if IsPermanent(dispatchErr) {
+		return false
+	}
+	return worker.classifier == nil || worker.classifier.Retryable(job, dispatchErr)
+}
+
 func (worker *Worker) executeJob(ctx context.Context, job Job, now time.Time) {
 	attemptedAt := now.UTC()
+	startedAt := worker.clock.Now()
 	result, dispatchErr := worker.dispatcher.Attempt(ctx, job)
+	duration := worker.clock.Now().Sub(startedAt)
 
 	status := result.Status
 	if status == "" {
@@ -191,14 +534,38 @@ func (worker *Worker) executeJob(ctx context.Context, job Job, now time.Time) {
 	update := AttemptUpdate{
 		Status:            status,
 		ProviderMessageID: result.ProviderMessageID,
+		Provider:          result.Provider,
 		RetryCount:        job.RetryCount + 1,
 		LastAttemptedAt:   attemptedAt,
+		Duration:          duration,
+		Err:               dispatchErr,
+	}
+	permanentFailure := dispatchErr != nil && !worker.retryable(job, dispatchErr)
+	if permanentFailure && update.RetryCount < worker.maxRetries {
+		// Exhausting the retry budget keeps the job out of ClaimJobs from now on.
+		update.RetryCount = worker.maxRetries
+	}
+	if dispatchErr != nil {
+		switch {
+		case update.RetryCount < worker.maxRetries:
+			retry := Retry{Job: job, RetryCount: update.RetryCount}
+			if !job.NextAttemptAt.IsZero() && !job.LastAttemptedAt.IsZero() {
+				retry.PreviousDelay = job.NextAttemptAt.Sub(job.LastAttemptedAt)
+			}
+			update.NextAttemptAt = attemptedAt.Add(worker.retryPolicyFor(job).NextDelay(retry))
+		case status == worker.failureStatus && worker.exhaustedStatus != "":
+			update.Status = worker.exhaustedStatus
+		}
 	}
 
 	if applyErr := worker.repository.ApplyAttemptResult(ctx, job, update); applyErr != nil {
 		worker.logger.Error("scheduler_apply_attempt_error", "job_id", job.ID, "error", applyErr)
 	}
 
+	if permanentFailure {
+		worker.logger.Error("scheduler_dispatch_permanent_failure", "job_id", job.ID, "error", dispatchErr)
+		return
+	}
 	if dispatchErr != nil {
 		worker.logger.Error("scheduler_dispatch_error", "job_id", job.ID, "error", dispatchErr)
 		return
@@ -206,3 +573,15 @@ func (worker *Worker) executeJob(ctx context.Context, job Job, now time.Time) {
 
 	worker.logger.Info("scheduler_dispatch_success", "job_id", job.ID, "status", status)
 }
+
+func generateWorkerID() string {
+	hostname, hostnameErr := os.Hostname()
+	if hostnameErr != nil || hostname == "" {
+		hostname = "worker"
+	}
+	suffix := make([]byte, 4)
+	if _, randomErr := rand.Read(suffix); randomErr != nil {
+		return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
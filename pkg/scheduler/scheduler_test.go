@@ -2,8 +2,11 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"sync"
 	"testing"
 	"time"
 )
@@ -132,26 +135,649 @@ func TestWorkerDefaultsFailureStatusOnError(t *testing.T) {
 	}
 }
 
+func TestWorkerExhaustsRetriesOnPermanentError(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name               string
+		dispatchErr        error
+		expectedRetryCount int
+	}{
+		{name: "PermanentErrorExhaustsBudget", dispatchErr: Permanent(assertionError("invalid number")), expectedRetryCount: 5},
+		{name: "WrappedPermanentErrorExhaustsBudget", dispatchErr: fmt.Errorf("sms: %w", Permanent(assertionError("unsubscribed"))), expectedRetryCount: 5},
+		{name: "TransientErrorCountsOneAttempt", dispatchErr: assertionError("timeout"), expectedRetryCount: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			now := time.Now().UTC()
+			repo := &fakeRepository{jobs: []Job{{ID: "job-permanent", RetryCount: 1, LastAttemptedAt: now.Add(-time.Hour)}}}
+			dispatcher := &fakeDispatcher{errors: []error{testCase.dispatchErr}}
+
+			worker := newTestWorker(t, repo, dispatcher, now)
+			worker.RunOnce(context.Background())
+
+			if len(repo.updates) != 1 {
+				t.Fatalf("expected one repository update, got %d", len(repo.updates))
+			}
+			if repo.updates[0].Status != "failed" {
+				t.Fatalf("expected failure status, got %s", repo.updates[0].Status)
+			}
+			if repo.updates[0].RetryCount != testCase.expectedRetryCount {
+				t.Fatalf("expected retry count %d, got %d", testCase.expectedRetryCount, repo.updates[0].RetryCount)
+			}
+		})
+	}
+}
+
+func TestWorkerSkipsRetriesForErrorsClassifiedPermanent(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name               string
+		retryable          bool
+		expectedRetryCount int
+		expectNextAttempt  bool
+	}{
+		{name: "PermanentError", retryable: false, expectedRetryCount: 5, expectNextAttempt: false},
+		{name: "RetryableError", retryable: true, expectedRetryCount: 2, expectNextAttempt: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			now := time.Now().UTC()
+			dispatchErr := assertionError("550 mailbox unavailable")
+			repo := &fakeRepository{jobs: []Job{{ID: "job-classified", Class: "email", RetryCount: 1, LastAttemptedAt: now.Add(-time.Hour)}}}
+			dispatcher := &classifyingDispatcher{fakeDispatcher: fakeDispatcher{errors: []error{dispatchErr}}, retryable: testCase.retryable}
+
+			worker := newTestWorker(t, repo, dispatcher, now)
+			worker.RunOnce(context.Background())
+
+			if len(repo.updates) != 1 {
+				t.Fatalf("expected one repository update, got %d", len(repo.updates))
+			}
+			update := repo.updates[0]
+			if update.RetryCount != testCase.expectedRetryCount {
+				t.Fatalf("expected retry count %d, got %d", testCase.expectedRetryCount, update.RetryCount)
+			}
+			if update.NextAttemptAt.IsZero() == testCase.expectNextAttempt {
+				t.Fatalf("expected next attempt scheduled=%t, got %v", testCase.expectNextAttempt, update.NextAttemptAt)
+			}
+			if len(dispatcher.classified) != 1 || !errors.Is(dispatcher.classified[0], dispatchErr) {
+				t.Fatalf("expected the dispatch error to be classified, got %v", dispatcher.classified)
+			}
+		})
+	}
+}
+
+func TestWorkerSchedulesRetriesWithClassPolicy(t *testing.T) {
+	t.Helper()
+
+	now := time.Now().UTC()
+	lastAttemptedAt := now.Add(-time.Minute)
+	repo := &fakeRepository{jobs: []Job{
+		{ID: "job-sms", Class: "sms", RetryCount: 2, LastAttemptedAt: lastAttemptedAt, NextAttemptAt: lastAttemptedAt.Add(40 * time.Second)},
+		{ID: "job-email", Class: "email", RetryCount: 1},
+	}}
+	dispatcher := &fakeDispatcher{errors: []error{assertionError("timeout"), assertionError("timeout")}}
+	smsPolicy := &recordingRetryPolicy{delay: 7 * time.Minute}
+	defaultPolicy := &recordingRetryPolicy{delay: 3 * time.Minute}
+
+	worker, err := NewWorker(Config{
+		Repository:         repo,
+		Dispatcher:         dispatcher,
+		Logger:             slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		Interval:           time.Second,
+		MaxRetries:         5,
+		SuccessStatus:      "sent",
+		FailureStatus:      "failed",
+		Clock:              fixedClock{now: now},
+		RetryPolicy:        defaultPolicy,
+		ClassRetryPolicies: map[string]RetryPolicy{"sms": smsPolicy},
+		// One attempt at a time keeps the fake dispatcher single-threaded.
+		MaxInFlight: 1,
+	})
+	if err != nil {
+		t.Fatalf("new worker error: %v", err)
+	}
+	worker.RunOnce(context.Background())
+
+	nextAttempts := make(map[string]time.Time)
+	for index, job := range repo.appliedJobs {
+		nextAttempts[job.ID] = repo.updates[index].NextAttemptAt
+	}
+	if !nextAttempts["job-sms"].Equal(now.Add(7 * time.Minute)) {
+		t.Fatalf("expected the SMS job to follow its class policy, got %v", nextAttempts["job-sms"])
+	}
+	if !nextAttempts["job-email"].Equal(now.Add(3 * time.Minute)) {
+		t.Fatalf("expected the email job to follow the default policy, got %v", nextAttempts["job-email"])
+	}
+	if len(smsPolicy.retries) != 1 || smsPolicy.retries[0].RetryCount != 3 || smsPolicy.retries[0].PreviousDelay != 40*time.Second {
+		t.Fatalf("expected the SMS policy to see the third attempt after a 40s delay, got %+v", smsPolicy.retries)
+	}
+	if len(defaultPolicy.retries) != 1 || defaultPolicy.retries[0].PreviousDelay != 0 {
+		t.Fatalf("expected the default policy to see no previous delay, got %+v", defaultPolicy.retries)
+	}
+}
+
+func TestWorkerHonoursPersistedNextAttempt(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name           string
+		job            Job
+		expectDispatch bool
+	}{
+		{
+			name:           "DueBeforeExponentialBackoff",
+			job:            Job{ID: "job-jittered", RetryCount: 4, LastAttemptedAt: time.Now().UTC().Add(-2 * time.Second), NextAttemptAt: time.Now().UTC().Add(-time.Second)},
+			expectDispatch: true,
+		},
+		{
+			name:           "NotDueAfterExponentialBackoff",
+			job:            Job{ID: "job-capped", RetryCount: 1, LastAttemptedAt: time.Now().UTC().Add(-time.Hour), NextAttemptAt: time.Now().UTC().Add(time.Hour)},
+			expectDispatch: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &fakeRepository{jobs: []Job{testCase.job}}
+			dispatcher := &fakeDispatcher{}
+
+			worker := newTestWorker(t, repo, dispatcher, time.Now().UTC())
+			worker.RunOnce(context.Background())
+
+			if dispatched := len(dispatcher.calls) == 1; dispatched != testCase.expectDispatch {
+				t.Fatalf("expected dispatch=%t, got %d calls", testCase.expectDispatch, len(dispatcher.calls))
+			}
+		})
+	}
+}
+
+func TestWorkerRecordsAttemptDuration(t *testing.T) {
+	t.Helper()
+
+	clock := &manualClock{now: time.Now().UTC()}
+	repo := &fakeRepository{jobs: []Job{{ID: "job-timed"}}}
+	dispatcher := &slowDispatcher{clock: clock, duration: 1500 * time.Millisecond}
+	worker, err := NewWorker(Config{
+		Repository:    repo,
+		Dispatcher:    dispatcher,
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		Interval:      time.Second,
+		MaxRetries:    3,
+		SuccessStatus: "sent",
+		FailureStatus: "failed",
+		Clock:         clock,
+	})
+	if err != nil {
+		t.Fatalf("new worker error: %v", err)
+	}
+	worker.RunOnce(context.Background())
+
+	if len(repo.updates) != 1 || repo.updates[0].Duration != 1500*time.Millisecond {
+		t.Fatalf("expected the attempt duration to be recorded, got %+v", repo.updates)
+	}
+}
+
+func TestWorkerRecordsExhaustedStatus(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name           string
+		retryCount     int
+		result         DispatchResult
+		dispatchErr    error
+		expectedStatus string
+		expectNextTry  bool
+	}{
+		{name: "RetriesLeft", retryCount: 1, dispatchErr: assertionError("timeout"), expectedStatus: "failed", expectNextTry: true},
+		{name: "LastAttemptFails", retryCount: 4, dispatchErr: assertionError("timeout"), expectedStatus: "dead"},
+		{name: "PermanentFailure", retryCount: 1, dispatchErr: Permanent(assertionError("invalid number")), expectedStatus: "dead"},
+		{name: "DispatcherStatusWins", retryCount: 4, result: DispatchResult{Status: "suppressed"}, dispatchErr: Permanent(assertionError("suppressed")), expectedStatus: "suppressed"},
+		{name: "LastAttemptSucceeds", retryCount: 4, expectedStatus: "sent"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			now := time.Now().UTC()
+			repo := &fakeRepository{jobs: []Job{{ID: "job-exhausted", RetryCount: testCase.retryCount, LastAttemptedAt: now.Add(-time.Hour)}}}
+			dispatcher := &fakeDispatcher{results: []DispatchResult{testCase.result}, errors: []error{testCase.dispatchErr}}
+
+			worker, err := NewWorker(Config{
+				Repository:      repo,
+				Dispatcher:      dispatcher,
+				Logger:          slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+				Interval:        time.Second,
+				MaxRetries:      5,
+				SuccessStatus:   "sent",
+				FailureStatus:   "failed",
+				ExhaustedStatus: "dead",
+				Clock:           fixedClock{now: now},
+			})
+			if err != nil {
+				t.Fatalf("new worker error: %v", err)
+			}
+			worker.RunOnce(context.Background())
+
+			if len(repo.updates) != 1 {
+				t.Fatalf("expected one repository update, got %d", len(repo.updates))
+			}
+			update := repo.updates[0]
+			if update.Status != testCase.expectedStatus || !errors.Is(update.Err, testCase.dispatchErr) {
+				t.Fatalf("expected %s with error %v, got %s with %v", testCase.expectedStatus, testCase.dispatchErr, update.Status, update.Err)
+			}
+			if update.NextAttemptAt.IsZero() == testCase.expectNextTry {
+				t.Fatalf("expected next attempt scheduled=%t, got %v", testCase.expectNextTry, update.NextAttemptAt)
+			}
+		})
+	}
+}
+
+func TestPermanentPreservesWrappedError(t *testing.T) {
+	t.Helper()
+
+	cause := assertionError("invalid number")
+	wrapped := Permanent(cause)
+	if !errors.Is(wrapped, cause) || wrapped.Error() != "invalid number" {
+		t.Fatalf("expected Permanent to wrap the cause, got %v", wrapped)
+	}
+	if Permanent(nil) != nil {
+		t.Fatalf("expected Permanent(nil) to be nil")
+	}
+	if IsPermanent(cause) {
+		t.Fatalf("expected unwrapped error to be transient")
+	}
+}
+
+func TestWorkerRunsCycleOnWake(t *testing.T) {
+	t.Helper()
+
+	wake := make(chan struct{}, 1)
+	dispatcher := &signallingDispatcher{attempted: make(chan string, 1)}
+	worker, err := NewWorker(Config{
+		Repository:    &fakeRepository{jobs: []Job{{ID: "job-enqueued"}}},
+		Dispatcher:    dispatcher,
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		Interval:      time.Hour,
+		Wake:          wake,
+		MaxRetries:    5,
+		SuccessStatus: "sent",
+		FailureStatus: "failed",
+	})
+	if err != nil {
+		t.Fatalf("new worker error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Run(ctx)
+
+	wake <- struct{}{}
+	select {
+	case jobID := <-dispatcher.attempted:
+		if jobID != "job-enqueued" {
+			t.Fatalf("unexpected job %s", jobID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected wake to trigger a cycle before the hourly poll")
+	}
+}
+
+func TestNewWorkerRejectsNegativePollInterval(t *testing.T) {
+	t.Helper()
+
+	_, err := NewWorker(Config{
+		Repository:    &fakeRepository{},
+		Dispatcher:    &fakeDispatcher{},
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		Interval:      time.Second,
+		PollInterval:  -time.Second,
+		MaxRetries:    5,
+		SuccessStatus: "sent",
+		FailureStatus: "failed",
+	})
+	if !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("expected errInvalidConfig, got %v", err)
+	}
+}
+
+func TestWorkerLimitsConcurrencyPerClassAndInFlight(t *testing.T) {
+	t.Helper()
+
+	now := time.Now().UTC()
+	var jobs []Job
+	for index := 0; index < 6; index++ {
+		jobs = append(jobs, Job{ID: fmt.Sprintf("email-%d", index), Class: "email"})
+		jobs = append(jobs, Job{ID: fmt.Sprintf("sms-%d", index), Class: "sms"})
+	}
+
+	testCases := []struct {
+		name               string
+		classConcurrency   map[string]int
+		maxInFlight        int
+		expectedEmailLimit int
+		expectedSmsLimit   int
+		expectedTotalLimit int
+	}{
+		{name: "PerClassLimits", classConcurrency: map[string]int{"email": 2, "sms": 3}, expectedEmailLimit: 2, expectedSmsLimit: 3, expectedTotalLimit: 5},
+		{name: "InFlightCap", classConcurrency: map[string]int{"email": 3, "sms": 3}, maxInFlight: 2, expectedEmailLimit: 2, expectedSmsLimit: 2, expectedTotalLimit: 2},
+		{name: "DefaultsToOnePerClass", expectedEmailLimit: 1, expectedSmsLimit: 1, expectedTotalLimit: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &fakeRepository{jobs: jobs}
+			dispatcher := newGatedDispatcher()
+			worker, err := NewWorker(Config{
+				Repository:       repo,
+				Dispatcher:       dispatcher,
+				Logger:           slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+				Interval:         time.Second,
+				MaxRetries:       5,
+				SuccessStatus:    "sent",
+				FailureStatus:    "failed",
+				Clock:            fixedClock{now: now},
+				ClassConcurrency: testCase.classConcurrency,
+				MaxInFlight:      testCase.maxInFlight,
+			})
+			if err != nil {
+				t.Fatalf("new worker error: %v", err)
+			}
+
+			worker.RunOnce(context.Background())
+
+			if repo.updateCount() != len(jobs) {
+				t.Fatalf("expected every job to be attempted once, got %d updates", repo.updateCount())
+			}
+			if dispatcher.maxRunning["email"] > testCase.expectedEmailLimit || dispatcher.maxRunning["sms"] > testCase.expectedSmsLimit {
+				t.Fatalf("class limits exceeded: %+v", dispatcher.maxRunning)
+			}
+			if dispatcher.maxTotalRunning > testCase.expectedTotalLimit {
+				t.Fatalf("expected at most %d attempts in flight, got %d", testCase.expectedTotalLimit, dispatcher.maxTotalRunning)
+			}
+		})
+	}
+}
+
+func TestWorkerKeepsOtherClassesMovingAndDrainsOnShutdown(t *testing.T) {
+	t.Helper()
+
+	repo := &fakeRepository{jobs: []Job{
+		{ID: "email-hung", Class: "email"},
+		{ID: "sms-1", Class: "sms"},
+	}}
+	dispatcher := newGatedDispatcher("email")
+	wake := make(chan struct{}, 1)
+	worker, err := NewWorker(Config{
+		Repository:    repo,
+		Dispatcher:    dispatcher,
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		Interval:      time.Hour,
+		Wake:          wake,
+		MaxRetries:    5,
+		SuccessStatus: "sent",
+		FailureStatus: "failed",
+		Clock:         fixedClock{now: time.Now().UTC()},
+	})
+	if err != nil {
+		t.Fatalf("new worker error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(stopped)
+	}()
+
+	wake <- struct{}{}
+	startedClasses := map[string]bool{}
+	for len(startedClasses) < 2 {
+		select {
+		case job := <-dispatcher.started:
+			startedClasses[job.Class] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the sms job to run while the email attempt hangs, started %v", startedClasses)
+		}
+	}
+
+	// A second cycle must not pick up the email job that is still in flight.
+	wake <- struct{}{}
+	select {
+	case job := <-dispatcher.started:
+		if job.ID == "email-hung" {
+			t.Fatalf("expected the in-flight email job to stay claimed")
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+		t.Fatalf("expected Run to wait for the in-flight email attempt")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(dispatcher.gates["email"])
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected Run to return once the in-flight attempt finished")
+	}
+	if dispatcher.attemptCount("email-hung") != 1 {
+		t.Fatalf("expected a single attempt for the hung email job, got %d", dispatcher.attemptCount("email-hung"))
+	}
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	for _, job := range repo.appliedJobs {
+		if job.ID == "email-hung" {
+			return
+		}
+	}
+	t.Fatalf("expected the drained attempt to record its result, got %+v", repo.appliedJobs)
+}
+
+func TestWorkerLeasesClaimedJobsAndReleasesSkippedOnes(t *testing.T) {
+	t.Helper()
+
+	now := time.Now().UTC()
+	future := now.Add(time.Hour)
+	repo := &fakeRepository{jobs: []Job{
+		{ID: "job-due", Class: "email"},
+		{ID: "job-backing-off", Class: "email", RetryCount: 2, LastAttemptedAt: now.Add(-time.Second)},
+		{ID: "job-future", Class: "sms", ScheduledFor: &future},
+	}}
+	dispatcher := &fakeDispatcher{errors: []error{assertionError("smtp unavailable")}}
+	worker, err := NewWorker(Config{
+		Repository:    repo,
+		Dispatcher:    dispatcher,
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+		Interval:      time.Second,
+		MaxRetries:    5,
+		SuccessStatus: "sent",
+		FailureStatus: "failed",
+		Clock:         fixedClock{now: now},
+		WorkerID:      "worker-a",
+		LeaseDuration: time.Minute,
+		ClaimLimit:    10,
+	})
+	if err != nil {
+		t.Fatalf("new worker error: %v", err)
+	}
+
+	worker.RunOnce(context.Background())
+
+	if len(repo.claims) != 1 {
+		t.Fatalf("expected one claim, got %d", len(repo.claims))
+	}
+	claim := repo.claims[0]
+	if claim.WorkerID != "worker-a" || !claim.LeaseUntil.Equal(now.Add(time.Minute)) || claim.Limit != 10 || claim.MaxRetries != 5 {
+		t.Fatalf("unexpected claim %+v", claim)
+	}
+	if len(dispatcher.calls) != 1 || dispatcher.calls[0].ID != "job-due" || dispatcher.calls[0].LockedBy != "worker-a" {
+		t.Fatalf("expected only the due job to be attempted under the lease, got %+v", dispatcher.calls)
+	}
+	released := map[string]bool{}
+	for _, job := range repo.releasedJobs {
+		released[job.ID] = true
+	}
+	if len(released) != 2 || !released["job-backing-off"] || !released["job-future"] {
+		t.Fatalf("expected skipped jobs to be released, got %+v", repo.releasedJobs)
+	}
+	update := repo.updates[0]
+	if !update.NextAttemptAt.Equal(now.Add(2 * time.Second)) {
+		t.Fatalf("expected the failed job to be due again after its backoff, got %v", update.NextAttemptAt)
+	}
+}
+
+func TestNewWorkerRejectsInvalidConcurrency(t *testing.T) {
+	t.Helper()
+
+	testCases := []struct {
+		name   string
+		mutate func(cfg *Config)
+	}{
+		{name: "ZeroClassLimit", mutate: func(cfg *Config) { cfg.ClassConcurrency = map[string]int{"email": 0} }},
+		{name: "NegativeDefault", mutate: func(cfg *Config) { cfg.DefaultConcurrency = -1 }},
+		{name: "NegativeInFlight", mutate: func(cfg *Config) { cfg.MaxInFlight = -1 }},
+		{name: "NegativeLease", mutate: func(cfg *Config) { cfg.LeaseDuration = -time.Second }},
+		{name: "NegativeClaimLimit", mutate: func(cfg *Config) { cfg.ClaimLimit = -1 }},
+		{name: "NilClassRetryPolicy", mutate: func(cfg *Config) { cfg.ClassRetryPolicies = map[string]RetryPolicy{"sms": nil} }},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := Config{
+				Repository:    &fakeRepository{},
+				Dispatcher:    &fakeDispatcher{},
+				Logger:        slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{})),
+				Interval:      time.Second,
+				MaxRetries:    5,
+				SuccessStatus: "sent",
+				FailureStatus: "failed",
+			}
+			testCase.mutate(&cfg)
+			if _, err := NewWorker(cfg); !errors.Is(err, errInvalidConfig) {
+				t.Fatalf("expected errInvalidConfig, got %v", err)
+			}
+		})
+	}
+}
+
 // Helpers.
 
 type fakeRepository struct {
-	jobs        []Job
-	updates     []AttemptUpdate
-	appliedJobs []Job
+	mutex        sync.Mutex
+	jobs         []Job
+	updates      []AttemptUpdate
+	appliedJobs  []Job
+	releasedJobs []Job
+	claims       []Claim
 }
 
-func (repo *fakeRepository) PendingJobs(_ context.Context, _ int, _ time.Time) ([]Job, error) {
-	cloned := make([]Job, len(repo.jobs))
-	copy(cloned, repo.jobs)
-	return cloned, nil
+func (repo *fakeRepository) ClaimJobs(_ context.Context, claim Claim) ([]Job, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.claims = append(repo.claims, claim)
+	excluded := make(map[string]struct{}, len(claim.ExcludedClasses))
+	for _, class := range claim.ExcludedClasses {
+		excluded[class] = struct{}{}
+	}
+	var claimed []Job
+	for _, job := range repo.jobs {
+		if _, skip := excluded[job.Class]; skip {
+			continue
+		}
+		job.LockedBy = claim.WorkerID
+		job.LeaseUntil = claim.LeaseUntil
+		claimed = append(claimed, job)
+	}
+	return claimed, nil
+}
+
+func (repo *fakeRepository) ReleaseJob(_ context.Context, job Job) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.releasedJobs = append(repo.releasedJobs, job)
+	return nil
 }
 
 func (repo *fakeRepository) ApplyAttemptResult(_ context.Context, job Job, update AttemptUpdate) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
 	repo.appliedJobs = append(repo.appliedJobs, job)
 	repo.updates = append(repo.updates, update)
 	return nil
 }
 
+func (repo *fakeRepository) updateCount() int {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	return len(repo.updates)
+}
+
+// gatedDispatcher blocks attempts of the classes listed in gates until the gate is closed and
+// records how many attempts ran at once, per class and overall.
+type gatedDispatcher struct {
+	mutex           sync.Mutex
+	gates           map[string]chan struct{}
+	started         chan Job
+	attempts        map[string]int
+	running         map[string]int
+	maxRunning      map[string]int
+	totalRunning    int
+	maxTotalRunning int
+}
+
+func newGatedDispatcher(gatedClasses ...string) *gatedDispatcher {
+	dispatcher := &gatedDispatcher{
+		gates:      make(map[string]chan struct{}),
+		started:    make(chan Job, 64),
+		attempts:   make(map[string]int),
+		running:    make(map[string]int),
+		maxRunning: make(map[string]int),
+	}
+	for _, class := range gatedClasses {
+		dispatcher.gates[class] = make(chan struct{})
+	}
+	return dispatcher
+}
+
+func (dispatcher *gatedDispatcher) Attempt(_ context.Context, job Job) (DispatchResult, error) {
+	dispatcher.mutex.Lock()
+	dispatcher.attempts[job.ID]++
+	dispatcher.running[job.Class]++
+	dispatcher.totalRunning++
+	if dispatcher.running[job.Class] > dispatcher.maxRunning[job.Class] {
+		dispatcher.maxRunning[job.Class] = dispatcher.running[job.Class]
+	}
+	if dispatcher.totalRunning > dispatcher.maxTotalRunning {
+		dispatcher.maxTotalRunning = dispatcher.totalRunning
+	}
+	gate := dispatcher.gates[job.Class]
+	dispatcher.mutex.Unlock()
+	dispatcher.started <- job
+
+	if gate != nil {
+		<-gate
+	} else {
+		// Give other attempts a chance to overlap so the concurrency limits are exercised.
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	dispatcher.mutex.Lock()
+	dispatcher.running[job.Class]--
+	dispatcher.totalRunning--
+	dispatcher.mutex.Unlock()
+	return DispatchResult{}, nil
+}
+
+func (dispatcher *gatedDispatcher) attemptCount(jobID string) int {
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+	return dispatcher.attempts[jobID]
+}
+
 type fakeDispatcher struct {
 	results []DispatchResult
 	errors  []error
@@ -173,6 +799,53 @@ func (dispatcher *fakeDispatcher) Attempt(_ context.Context, job Job) (DispatchR
 	return result, err
 }
 
+type classifyingDispatcher struct {
+	fakeDispatcher
+	retryable  bool
+	classified []error
+}
+
+func (dispatcher *classifyingDispatcher) Retryable(_ Job, err error) bool {
+	dispatcher.classified = append(dispatcher.classified, err)
+	return dispatcher.retryable
+}
+
+type recordingRetryPolicy struct {
+	delay   time.Duration
+	mutex   sync.Mutex
+	retries []Retry
+}
+
+func (policy *recordingRetryPolicy) NextDelay(retry Retry) time.Duration {
+	policy.mutex.Lock()
+	defer policy.mutex.Unlock()
+	policy.retries = append(policy.retries, retry)
+	return policy.delay
+}
+
+type signallingDispatcher struct {
+	attempted chan string
+}
+
+func (dispatcher *signallingDispatcher) Attempt(_ context.Context, job Job) (DispatchResult, error) {
+	select {
+	case dispatcher.attempted <- job.ID:
+	default:
+	}
+	return DispatchResult{}, nil
+}
+
+// slowDispatcher succeeds after moving clock forward by duration.
+type slowDispatcher struct {
+	clock    *manualClock
+	duration time.Duration
+}
+
+func (dispatcher *slowDispatcher) Attempt(context.Context, Job) (DispatchResult, error) {
+	dispatcher.clock.advance(dispatcher.duration)
+	return DispatchResult{}, nil
+}
+
 type fixedClock struct {
 	now time.Time
 }
@@ -181,6 +854,23 @@ func (clock fixedClock) Now() time.Time {
 	return clock.now
 }
 
+type manualClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (clock *manualClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.now
+}
+
+func (clock *manualClock) advance(delta time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = clock.now.Add(delta)
+}
+
 func newTestWorker(t *testing.T, repo Repository, dispatcher Dispatcher, now time.Time) *Worker {
 	t.Helper()
 
@@ -89,6 +89,40 @@ test.describe('Dashboard', () => {
     await expect(page.locator('.status-badge')).toHaveAttribute('data-variant', 'cancelled');
   });
 
+  test('searches notifications and loads further pages', async ({ page, request }) => {
+    const now = new Date().toISOString();
+    const notification = (id: string, subject: string) => ({
+      notification_id: id,
+      notification_type: 'email',
+      recipient: `${id}@example.com`,
+      subject,
+      message: 'Hello',
+      status: 'queued',
+      created_at: now,
+      updated_at: now,
+      scheduled_for: now,
+      retry_count: 0,
+    });
+    await resetNotifications(request, {
+      pageSize: 2,
+      notifications: [
+        notification('notif-1', 'Invoice March'),
+        notification('notif-2', 'Welcome'),
+        notification('notif-3', 'Invoice April'),
+      ],
+    });
+    await configureRuntime(page, { authenticated: false });
+    await loginAndVisitDashboard(page);
+    await expect(page.getByTestId('notification-row')).toHaveCount(2);
+    await page.getByTestId('notifications-load-more').click();
+    await expect(page.getByTestId('notification-row')).toHaveCount(3);
+    await expect(page.getByTestId('notifications-load-more')).toHaveCount(0);
+
+    await page.getByTestId('notifications-search').fill('invoice');
+    await expect(page.getByTestId('notification-row')).toHaveCount(2);
+    await expect(page.getByTestId('notifications-load-more')).toHaveCount(0);
+  });
+
   test('renders notification table and allows cancel', async ({ page }) => {
     await configureRuntime(page, { authenticated: false });
     await loginAndVisitDashboard(page);
@@ -98,6 +132,81 @@ test.describe('Dashboard', () => {
     await expectToast(page, 'Notification cancelled');
   });
 
+  test('retries a dead notification', async ({ page, request }) => {
+    const now = new Date().toISOString();
+    await resetNotifications(request, {
+      notifications: [
+        {
+          notification_id: 'notif-dead',
+          notification_type: 'email',
+          recipient: 'dead@example.com',
+          subject: 'Dead',
+          message: 'Hello',
+          status: 'dead',
+          created_at: now,
+          updated_at: now,
+          scheduled_for: null,
+          retry_count: 3,
+          last_error: 'smtp timeout',
+        },
+      ],
+    });
+    await configureRuntime(page, { authenticated: false });
+    await loginAndVisitDashboard(page);
+    await expect(page.locator('.status-badge')).toHaveAttribute('data-variant', 'dead');
+    await expect(page.locator('.status-badge')).toHaveAttribute('title', 'smtp timeout');
+    await page.getByRole('button', { name: 'Retry' }).click();
+    await expectToast(page, 'Notification requeued');
+    await expect(page.locator('.status-badge')).toHaveAttribute('data-variant', 'queued');
+  });
+
+  test('shows delivery attempts in the details dialog', async ({ page, request }) => {
+    const now = new Date().toISOString();
+    await resetNotifications(request, {
+      notifications: [
+        {
+          notification_id: 'notif-attempts',
+          notification_type: 'email',
+          recipient: 'attempts@example.com',
+          subject: 'Attempts',
+          message: 'Hello',
+          status: 'errored',
+          created_at: now,
+          updated_at: now,
+          scheduled_for: null,
+          retry_count: 1,
+          last_error: 'all recipients rejected: 550 mailbox unavailable',
+        },
+      ],
+      attempts: {
+        'notif-attempts': [
+          {
+            notification_id: 'notif-attempts',
+            attempted_at: now,
+            provider: 'smtp',
+            duration_ms: 812,
+            outcome: 'errored',
+            error_class: 'recipient',
+            error_message: 'all recipients rejected: 550 mailbox unavailable',
+            smtp_reply_code: 550,
+          },
+        ],
+      },
+    });
+    await configureRuntime(page, { authenticated: false });
+    await loginAndVisitDashboard(page);
+    await page.getByTestId('notification-details').click();
+    const dialog = page.getByTestId('notification-details-dialog');
+    await expect(dialog).toBeVisible();
+    await expect(dialog).toContainText('attempts@example.com');
+    const attemptRow = dialog.getByTestId('notification-attempt-row');
+    await expect(attemptRow).toHaveCount(1);
+    await expect(attemptRow).toContainText('812 ms');
+    await expect(attemptRow).toContainText('Recipient (SMTP 550)');
+    await dialog.getByRole('button', { name: 'Close' }).click();
+    await expect(dialog).toBeHidden();
+  });
+
   test('shows error toast when list request fails', async ({ page, request }) => {
     await resetNotifications(request, { failList: true });
     await configureRuntime(page, { authenticated: false });
@@ -169,4 +278,81 @@ test.describe('Dashboard', () => {
     await page.getByRole('button', { name: 'Cancel' }).click();
     await expectToast(page, 'Unable to cancel notification.');
   });
+
+  test('adds and removes a suppressed recipient', async ({ page, request }) => {
+    await resetNotifications(request, {
+      suppressions: [
+        {
+          channel: 'sms',
+          recipient: '+15550001111',
+          reason: 'stop',
+          source: 'twilio',
+          created_at: new Date().toISOString(),
+          updated_at: new Date().toISOString(),
+        },
+      ],
+    });
+    await configureRuntime(page, { authenticated: false });
+    await loginAndVisitDashboard(page);
+    const panel = page.getByTestId('suppressions-panel');
+    await expect(panel.getByTestId('suppression-row')).toHaveCount(1);
+    await expect(panel.getByTestId('suppression-row').first()).toContainText('STOP reply');
+    await expect(panel.getByTestId('suppression-row').first()).toContainText('All categories');
+
+    await panel.getByLabel('Recipient').fill('Blocked@Example.com');
+    await panel.getByLabel('Category (optional)').fill('Promotions');
+    await panel.getByLabel('Reason').selectOption('unsubscribed');
+    await panel.getByRole('button', { name: 'Suppress' }).click();
+    await expectToast(page, 'Recipient suppressed');
+    await expect(panel.getByTestId('suppression-row')).toHaveCount(2);
+    await expect(
+      panel.getByTestId('suppression-row').filter({ hasText: 'blocked@example.com' }),
+    ).toContainText('promotions');
+
+    page.once('dialog', (dialog) => dialog.accept());
+    await panel
+      .getByTestId('suppression-row')
+      .filter({ hasText: 'blocked@example.com' })
+      .getByRole('button', { name: 'Remove' })
+      .click();
+    await expectToast(page, 'Suppression removed');
+    await expect(panel.getByTestId('suppression-row')).toHaveCount(1);
+  });
+
+  test('lists inbound SMS and filters by sender', async ({ page, request }) => {
+    const receivedAt = new Date().toISOString();
+    await resetNotifications(request, {
+      inboundMessages: [
+        {
+          provider: 'twilio',
+          provider_message_id: 'SM1',
+          from_number: '+15550001111',
+          to_number: '+15559990000',
+          body: 'STOP',
+          keyword: 'stop',
+          received_at: receivedAt,
+        },
+        {
+          provider: 'twilio',
+          provider_message_id: 'SM2',
+          from_number: '+15550002222',
+          to_number: '+15559990000',
+          body: 'Running late',
+          received_at: receivedAt,
+        },
+      ],
+    });
+    await configureRuntime(page, { authenticated: false });
+    await loginAndVisitDashboard(page);
+    const panel = page.getByTestId('inbound-messages-panel');
+    await expect(panel.getByTestId('inbound-message-row')).toHaveCount(2);
+    await expect(
+      panel.getByTestId('inbound-message-row').filter({ hasText: '+15550001111' }),
+    ).toContainText('STOP');
+
+    await panel.getByTestId('inbound-from-filter').fill('+15550002222');
+    await panel.getByRole('button', { name: 'Refresh' }).click();
+    await expect(panel.getByTestId('inbound-message-row')).toHaveCount(1);
+    await expect(panel.getByTestId('inbound-message-row').first()).toContainText('Running late');
+  });
 });
@@ -35,7 +35,7 @@ func TestScheduledEmailDispatchesAfterWorkerRuns(t *testing.T) {
 		OperationTimeoutSec:  5,
 	}
 
-	notificationService := service.NewNotificationServiceWithSenders(database, logger, cfg, emailSender, nil)
+	notificationService := service.NewNotificationServiceWithSenders(database, logger, cfg, emailSender, nil, nil)
 	scheduledFor := time.Now().UTC().Add(2 * time.Second)
 
 	response, err := notificationService.SendNotification(context.Background(), model.NotificationRequest{
@@ -82,7 +82,7 @@ func openIntegrationDatabase(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("sqlite open error: %v", err)
 	}
-	if migrateErr := database.AutoMigrate(&model.Notification{}, &model.NotificationAttachment{}); migrateErr != nil {
+	if migrateErr := database.AutoMigrate(&model.Notification{}, &model.NotificationAttachment{}, &model.NotificationRecipient{}, &model.Template{}, &model.FeedbackEvent{}, &model.RecipientDeliverability{}, &model.Suppression{}, &model.InboundMessage{}); migrateErr != nil {
 		t.Fatalf("migration error: %v", migrateErr)
 	}
 	return database
@@ -99,13 +99,13 @@ func newRecordingEmailSender() *recordingEmailSender {
 	}
 }
 
-func (sender *recordingEmailSender) SendEmail(_ context.Context, _ string, _ string, _ string, _ []model.EmailAttachment) error {
+func (sender *recordingEmailSender) SendEmail(_ context.Context, message service.EmailMessage) (service.EmailDeliveryResult, error) {
 	sender.callCount.Add(1)
 	select {
 	case sender.delivered <- time.Now().UTC():
 	default:
 	}
-	return nil
+	return service.EmailDeliveryResult{AcceptedRecipients: message.EnvelopeRecipients()}, nil
 }
 
 func (sender *recordingEmailSender) CallCount() int {
@@ -0,0 +1,103 @@
+package integrationtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/temirov/pinguin/internal/config"
+	"github.com/temirov/pinguin/internal/db"
+	"github.com/temirov/pinguin/internal/model"
+	"github.com/temirov/pinguin/internal/service"
+	"log/slog"
+)
+
+func TestWorkersSharingOneDatabaseSendEachNotificationOnce(t *testing.T) {
+	t.Helper()
+
+	const (
+		workerCount       = 3
+		notificationCount = 30
+	)
+	databasePath := filepath.Join(t.TempDir(), "shared.db")
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	emailSender := &countingEmailSender{subjects: make(map[string]int)}
+
+	services := make([]service.NotificationService, 0, workerCount)
+	for index := 0; index < workerCount; index++ {
+		// Every instance opens its own connection, as separate Pinguin processes would.
+		database, err := db.InitDB(databasePath, logger)
+		if err != nil {
+			t.Fatalf("init database error: %v", err)
+		}
+		services = append(services, service.NewNotificationServiceWithSenders(database, logger, config.Config{
+			MaxRetries:             3,
+			RetryIntervalSec:       1,
+			DispatchMode:           config.DispatchModeEnqueue,
+			DispatchPollIntervalMs: 20,
+			DispatchWorkerID:       fmt.Sprintf("worker-%d", index),
+			DispatchLeaseSec:       60,
+		}, emailSender, nil, nil))
+	}
+
+	notificationIDs := make([]string, 0, notificationCount)
+	for index := 0; index < notificationCount; index++ {
+		response, err := services[index%workerCount].SendNotification(context.Background(), model.NotificationRequest{
+			NotificationType: model.NotificationEmail,
+			Recipient:        "user@example.com",
+			Subject:          fmt.Sprintf("Message %d", index),
+			Message:          "Hello from Pinguin",
+		})
+		if err != nil {
+			t.Fatalf("send notification error: %v", err)
+		}
+		notificationIDs = append(notificationIDs, response.NotificationID)
+	}
+
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for _, notificationService := range services {
+		workers.Add(1)
+		go func(notificationService service.NotificationService) {
+			defer workers.Done()
+			notificationService.StartRetryWorker(workerCtx)
+		}(notificationService)
+	}
+
+	for _, notificationID := range notificationIDs {
+		waitForNotificationStatus(t, services[0], notificationID, model.StatusSent, 10*time.Second)
+	}
+	// Give the workers a few more polls to show that no sent notification is picked up again.
+	time.Sleep(200 * time.Millisecond)
+	cancelWorkers()
+	workers.Wait()
+
+	for index := 0; index < notificationCount; index++ {
+		subject := fmt.Sprintf("Message %d", index)
+		if sends := emailSender.Count(subject); sends != 1 {
+			t.Fatalf("expected %q to be sent exactly once, got %d sends", subject, sends)
+		}
+	}
+}
+
+type countingEmailSender struct {
+	mutex    sync.Mutex
+	subjects map[string]int
+}
+
+func (sender *countingEmailSender) SendEmail(_ context.Context, message service.EmailMessage) (service.EmailDeliveryResult, error) {
+	sender.mutex.Lock()
+	sender.subjects[message.Subject]++
+	sender.mutex.Unlock()
+	return service.EmailDeliveryResult{AcceptedRecipients: message.EnvelopeRecipients()}, nil
+}
+
+func (sender *countingEmailSender) Count(subject string) int {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+	return sender.subjects[subject]
+}
@@ -14,6 +14,7 @@ const runtimeConfig = {
   apiBaseUrl: `http://${HOST}:${PORT}/api`,
 };
 
+const DEFAULT_PAGE_SIZE = 50;
 let serverState = createDefaultState();
 const nonceStore = new Map();
 const NONCE_TTL_MS = 2 * 60 * 1000;
@@ -21,9 +22,13 @@ const NONCE_TTL_MS = 2 * 60 * 1000;
 function createDefaultState() {
   return {
     notifications: defaultNotifications(),
+    suppressions: [],
+    inboundMessages: [],
+    attempts: {},
     failList: false,
     failReschedule: false,
     failCancel: false,
+    pageSize: DEFAULT_PAGE_SIZE,
   };
 }
 
@@ -54,9 +59,16 @@ function applyOverrides(payload) {
   } else {
     serverState.notifications = defaultNotifications();
   }
+  serverState.suppressions = Array.isArray(payload.suppressions) ? payload.suppressions : [];
+  serverState.inboundMessages = Array.isArray(payload.inboundMessages)
+    ? payload.inboundMessages
+    : [];
+  serverState.attempts =
+    payload.attempts && typeof payload.attempts === 'object' ? payload.attempts : {};
   serverState.failList = Boolean(payload.failList);
   serverState.failReschedule = Boolean(payload.failReschedule);
   serverState.failCancel = Boolean(payload.failCancel);
+  serverState.pageSize = Number(payload.pageSize) > 0 ? Number(payload.pageSize) : DEFAULT_PAGE_SIZE;
   nonceStore.clear();
 }
 
@@ -126,8 +138,21 @@ const server = http.createServer(async (req, res) => {
       return;
     }
     const statuses = url.searchParams.getAll('status').filter(Boolean);
-    const filtered = filterNotifications(serverState.notifications, statuses);
-    sendJson(res, 200, { notifications: filtered });
+    const search = (url.searchParams.get('q') || '').toLowerCase();
+    const filtered = filterNotifications(serverState.notifications, statuses).filter(
+      (item) =>
+        !search ||
+        String(item.subject || '').toLowerCase().includes(search) ||
+        String(item.message || '').toLowerCase().includes(search),
+    );
+    // Page tokens are plain offsets here; the real API keeps them opaque.
+    const offset = Number(url.searchParams.get('page_token')) || 0;
+    const pageSize = Number(url.searchParams.get('page_size')) || serverState.pageSize;
+    const nextOffset = offset + pageSize;
+    sendJson(res, 200, {
+      notifications: filtered.slice(offset, nextOffset),
+      next_page_token: nextOffset < filtered.length ? String(nextOffset) : '',
+    });
     return;
   }
 
@@ -167,6 +192,97 @@ const server = http.createServer(async (req, res) => {
     return;
   }
 
+  const retryMatch = url.pathname.match(/^\/api\/notifications\/([^/]+)\/retry$/);
+  if (retryMatch && req.method === 'POST') {
+    serverState.notifications = serverState.notifications.map((item) => {
+      if (item.notification_id === retryMatch[1]) {
+        return { ...item, status: 'queued', retry_count: 0, updated_at: new Date().toISOString() };
+      }
+      return item;
+    });
+    const updated = serverState.notifications.find((item) => item.notification_id === retryMatch[1]);
+    sendJson(res, 200, updated || {});
+    return;
+  }
+
+  const attemptsMatch = url.pathname.match(/^\/api\/notifications\/([^/]+)\/attempts$/);
+  if (attemptsMatch && req.method === 'GET') {
+    const notificationId = decodeURIComponent(attemptsMatch[1]);
+    if (!serverState.notifications.some((item) => item.notification_id === notificationId)) {
+      sendJson(res, 404, { error: 'notification not found' });
+      return;
+    }
+    sendJson(res, 200, { attempts: serverState.attempts[notificationId] || [] });
+    return;
+  }
+
+  if (req.method === 'GET' && url.pathname === '/api/inbound-messages') {
+    const fromNumber = url.searchParams.get('from');
+    const filtered = fromNumber
+      ? serverState.inboundMessages.filter((item) => item.from_number === fromNumber)
+      : serverState.inboundMessages;
+    sendJson(res, 200, { inbound_messages: filtered });
+    return;
+  }
+
+  if (req.method === 'GET' && url.pathname === '/api/suppressions') {
+    const channel = url.searchParams.get('channel');
+    const filtered = channel
+      ? serverState.suppressions.filter((item) => item.channel === channel)
+      : serverState.suppressions;
+    sendJson(res, 200, { suppressions: filtered });
+    return;
+  }
+
+  if (req.method === 'POST' && url.pathname === '/api/suppressions') {
+    const body = await readJson(req);
+    if (!body.recipient || !['email', 'sms'].includes(body.channel)) {
+      sendJson(res, 400, { error: 'invalid suppression' });
+      return;
+    }
+    const now = new Date().toISOString();
+    const suppression = {
+      channel: body.channel,
+      recipient: String(body.recipient).toLowerCase(),
+      category: String(body.category || '').trim().toLowerCase(),
+      reason: body.reason || 'manual',
+      source: body.source || 'dashboard',
+      expires_at: body.expires_at || undefined,
+      created_at: now,
+      updated_at: now,
+    };
+    serverState.suppressions = serverState.suppressions
+      .filter(
+        (item) =>
+          !(
+            item.channel === suppression.channel &&
+            item.recipient === suppression.recipient &&
+            (item.category || '') === suppression.category
+          ),
+      )
+      .concat(suppression);
+    sendJson(res, 201, suppression);
+    return;
+  }
+
+  const suppressionMatch = url.pathname.match(/^\/api\/suppressions\/([^/]+)\/([^/]+)$/);
+  if (suppressionMatch && req.method === 'DELETE') {
+    const channel = decodeURIComponent(suppressionMatch[1]);
+    const recipient = decodeURIComponent(suppressionMatch[2]);
+    const category = (url.searchParams.get('category') || '').trim().toLowerCase();
+    const remaining = serverState.suppressions.filter(
+      (item) =>
+        !(item.channel === channel && item.recipient === recipient && (item.category || '') === category),
+    );
+    if (remaining.length === serverState.suppressions.length) {
+      sendJson(res, 404, { error: 'suppression not found' });
+      return;
+    }
+    serverState.suppressions = remaining;
+    sendJson(res, 204, null);
+    return;
+  }
+
   if (url.pathname === '/auth/nonce' && req.method === 'POST') {
     const token = issueNonce();
     sendJson(res, 200, { nonce: token });
@@ -3,6 +3,8 @@ import Alpine from 'https://cdn.jsdelivr.net/npm/alpinejs@3.13.5/dist/module.esm
 import { RUNTIME_CONFIG, STRINGS } from './constants.js';
 import { createApiClient } from './core/apiClient.js';
 import { createNotificationsTable } from './ui/notificationsTable.js';
+import { createSuppressionsPanel } from './ui/suppressionsPanel.js';
+import { createInboundMessagesPanel } from './ui/inboundMessagesPanel.js';
 import { dispatchRefresh } from './core/events.js';
 import { createToastCenter } from './ui/toastCenter.js';
 
@@ -22,6 +24,20 @@ Alpine.data('notificationsTable', () =>
     actions: STRINGS.actions,
   }),
 );
+Alpine.data('suppressionsPanel', () =>
+  createSuppressionsPanel({
+    apiClient,
+    strings: STRINGS.suppressions,
+    actions: STRINGS.actions,
+  }),
+);
+Alpine.data('inboundMessagesPanel', () =>
+  createInboundMessagesPanel({
+    apiClient,
+    strings: STRINGS.inboundMessages,
+    actions: STRINGS.actions,
+  }),
+);
 Alpine.data('toastCenter', () => createToastCenter());
 
 Alpine.start();
@@ -89,8 +89,34 @@ export const STRINGS = Object.freeze({
     cancelSuccess: "Notification cancelled",
     cancelConfirm: "Cancel this queued notification?",
     cancelError: "Unable to cancel notification.",
+    retrySuccess: "Notification requeued",
+    retryError: "Unable to retry notification.",
     rescheduleError: "Unable to reschedule notification.",
     loadError: "Unable to load notifications.",
+    searchPlaceholder: "Search subject or message",
+    detailsDialogTitle: "Notification details",
+    attemptsTitle: "Delivery attempts",
+    attemptsEmptyState: "No delivery attempts yet.",
+    attemptsLoadError: "Unable to load delivery attempts.",
+  },
+  suppressions: {
+    title: "Suppressed recipients",
+    subtitle: "Notifications to these addresses and numbers are rejected until the entry is removed or expires.",
+    emptyState: "No suppressed recipients.",
+    addSuccess: "Recipient suppressed",
+    removeSuccess: "Suppression removed",
+    removeConfirm: "Allow notifications to this recipient again?",
+    addError: "Unable to suppress recipient.",
+    removeError: "Unable to remove suppression.",
+    loadError: "Unable to load suppressions.",
+    allCategories: "All categories",
+  },
+  inboundMessages: {
+    title: "Inbound SMS",
+    subtitle: "Replies received from recipients. STOP and START keywords update SMS suppressions automatically.",
+    emptyState: "No inbound messages yet.",
+    loadError: "Unable to load inbound messages.",
+    noKeyword: "—",
   },
   auth: {
     signingIn: "Preparing secure session…",
@@ -102,23 +128,68 @@ export const STRINGS = Object.freeze({
     refresh: "Refresh",
     reschedule: "Reschedule",
     cancel: "Cancel",
+    retry: "Retry",
     saveChanges: "Save changes",
     close: "Close",
     logout: "Log out",
+    suppress: "Suppress",
+    remove: "Remove",
+    loadMore: "Load more",
+    details: "Details",
   },
 });
 
 export const STATUS_LABELS = Object.freeze({
   queued: "Queued",
   sent: "Sent",
+  delivered: "Delivered",
+  undelivered: "Undelivered",
+  bounced: "Bounced",
   errored: "Errored",
+  dead: "Dead",
   cancelled: "Cancelled",
+  suppressed: "Suppressed",
 });
 
 export const STATUS_OPTIONS = Object.freeze([
   { value: "all", label: "All statuses" },
   { value: "queued", label: STATUS_LABELS.queued },
   { value: "sent", label: STATUS_LABELS.sent },
+  { value: "delivered", label: STATUS_LABELS.delivered },
+  { value: "undelivered", label: STATUS_LABELS.undelivered },
+  { value: "bounced", label: STATUS_LABELS.bounced },
   { value: "errored", label: STATUS_LABELS.errored },
+  { value: "dead", label: STATUS_LABELS.dead },
   { value: "cancelled", label: STATUS_LABELS.cancelled },
+  { value: "suppressed", label: STATUS_LABELS.suppressed },
+]);
+
+export const ATTEMPT_ERROR_CLASS_LABELS = Object.freeze({
+  recipient: "Recipient",
+  rejected: "Rejected",
+  unavailable: "Provider unavailable",
+  timeout: "Timeout",
+  network: "Network",
+  configuration: "Configuration",
+  unknown: "Unknown",
+});
+
+export const SUPPRESSION_CHANNEL_OPTIONS = Object.freeze([
+  { value: "all", label: "All channels" },
+  { value: "email", label: "Email" },
+  { value: "sms", label: "SMS" },
 ]);
+
+export const SUPPRESSION_REASON_LABELS = Object.freeze({
+  unsubscribed: "Unsubscribed",
+  bounced: "Bounced",
+  complaint: "Complaint",
+  stop: "STOP reply",
+  manual: "Manual",
+});
+
+export const INBOUND_KEYWORD_LABELS = Object.freeze({
+  stop: "STOP",
+  start: "START",
+  help: "HELP",
+});
@@ -2,6 +2,9 @@
 import { RUNTIME_CONFIG } from '../constants.js';
 
 /** @typedef {import('../types.d.js').NotificationItem} NotificationItem */
+/** @typedef {import('../types.d.js').SuppressionItem} SuppressionItem */
+/** @typedef {import('../types.d.js').InboundMessageItem} InboundMessageItem */
+/** @typedef {import('../types.d.js').NotificationAttemptItem} NotificationAttemptItem */
 
 function getFetcher() {
   if (typeof window !== 'undefined' && typeof window.apiFetch === 'function') {
@@ -32,6 +35,52 @@ function mapNotification(raw) {
     updatedAt: raw.updated_at,
     scheduledFor: raw.scheduled_for || raw.scheduled_time || null,
     retryCount: raw.retry_count ?? 0,
+    lastError: raw.last_error || '',
+  };
+}
+
+function mapNotificationAttempt(raw) {
+  if (!raw) {
+    return null;
+  }
+  return {
+    attemptedAt: raw.attempted_at,
+    provider: raw.provider || '',
+    durationMs: raw.duration_ms ?? 0,
+    outcome: raw.outcome,
+    errorClass: raw.error_class || '',
+    errorMessage: raw.error_message || '',
+    smtpReplyCode: raw.smtp_reply_code ?? 0,
+  };
+}
+
+function mapSuppression(raw) {
+  if (!raw) {
+    return null;
+  }
+  return {
+    channel: raw.channel,
+    recipient: raw.recipient,
+    category: raw.category || '',
+    reason: raw.reason,
+    source: raw.source || '',
+    expiresAt: raw.expires_at || null,
+    createdAt: raw.created_at,
+  };
+}
+
+function mapInboundMessage(raw) {
+  if (!raw) {
+    return null;
+  }
+  return {
+    provider: raw.provider || '',
+    providerMessageId: raw.provider_message_id || '',
+    fromNumber: raw.from_number,
+    toNumber: raw.to_number || '',
+    body: raw.body || '',
+    keyword: raw.keyword || '',
+    receivedAt: raw.received_at,
   };
 }
 
@@ -59,17 +108,32 @@ export function createApiClient(baseUrl = RUNTIME_CONFIG.apiBaseUrl) {
   }
 
   return {
-    async listNotifications(statuses = []) {
+    /**
+     * @param {{ statuses?: string[], search?: string, pageToken?: string, pageSize?: number }} [filters]
+     * @returns {Promise<import('../types.d.js').NotificationPage>}
+     */
+    async listNotifications(filters = {}) {
+      const { statuses = [], search = '', pageToken = '', pageSize = 0 } = filters;
       const query = new URLSearchParams();
       statuses.filter(Boolean).forEach((status) => {
         query.append('status', String(status));
       });
+      if (search.trim()) {
+        query.set('q', search.trim());
+      }
+      if (pageToken) {
+        query.set('page_token', pageToken);
+      }
+      if (pageSize > 0) {
+        query.set('page_size', String(pageSize));
+      }
       const suffix = query.toString() ? `?${query.toString()}` : '';
       const payload = await request(`/notifications${suffix}`, { method: 'GET', headers: {} });
       const items = Array.isArray(payload?.notifications) ? payload.notifications : [];
-      return /** @type {NotificationItem[]} */ (
-        items.map(mapNotification).filter(Boolean)
-      );
+      return {
+        notifications: /** @type {NotificationItem[]} */ (items.map(mapNotification).filter(Boolean)),
+        nextPageToken: typeof payload?.next_page_token === 'string' ? payload.next_page_token : '',
+      };
     },
     async rescheduleNotification(notificationId, scheduledIsoString) {
       const payload = await request(
@@ -87,5 +151,51 @@ export function createApiClient(baseUrl = RUNTIME_CONFIG.apiBaseUrl) {
       });
       return mapNotification(payload);
     },
+    async retryNotification(notificationId) {
+      const payload = await request(`/notifications/${encodeURIComponent(notificationId)}/retry`, {
+        method: 'POST',
+      });
+      return mapNotification(payload);
+    },
+    async getNotificationAttempts(notificationId) {
+      const payload = await request(`/notifications/${encodeURIComponent(notificationId)}/attempts`, {
+        method: 'GET',
+        headers: {},
+      });
+      const items = Array.isArray(payload?.attempts) ? payload.attempts : [];
+      return /** @type {NotificationAttemptItem[]} */ (items.map(mapNotificationAttempt).filter(Boolean));
+    },
+    async listSuppressions(channel = '') {
+      const suffix = channel ? `?channel=${encodeURIComponent(channel)}` : '';
+      const payload = await request(`/suppressions${suffix}`, { method: 'GET', headers: {} });
+      const items = Array.isArray(payload?.suppressions) ? payload.suppressions : [];
+      return /** @type {SuppressionItem[]} */ (items.map(mapSuppression).filter(Boolean));
+    },
+    async addSuppression({ channel, recipient, category, reason, expiresAt }) {
+      const payload = await request('/suppressions', {
+        method: 'POST',
+        body: JSON.stringify({
+          channel,
+          recipient,
+          reason,
+          ...(category ? { category } : {}),
+          ...(expiresAt ? { expires_at: expiresAt } : {}),
+        }),
+      });
+      return mapSuppression(payload);
+    },
+    async removeSuppression(channel, recipient, category = '') {
+      const suffix = category ? `?category=${encodeURIComponent(category)}` : '';
+      await request(
+        `/suppressions/${encodeURIComponent(channel)}/${encodeURIComponent(recipient)}${suffix}`,
+        { method: 'DELETE' },
+      );
+    },
+    async listInboundMessages(fromNumber = '') {
+      const suffix = fromNumber ? `?from=${encodeURIComponent(fromNumber)}` : '';
+      const payload = await request(`/inbound-messages${suffix}`, { method: 'GET', headers: {} });
+      const items = Array.isArray(payload?.inbound_messages) ? payload.inbound_messages : [];
+      return /** @type {InboundMessageItem[]} */ (items.map(mapInboundMessage).filter(Boolean));
+    },
   };
 }
@@ -1,7 +1,7 @@
 // @ts-check
 
 /**
- * @typedef {"queued" | "sent" | "errored" | "cancelled"} NotificationStatusKey
+ * @typedef {"queued" | "sent" | "delivered" | "undelivered" | "bounced" | "errored" | "dead" | "cancelled" | "suppressed"} NotificationStatusKey
  */
 
 /**
@@ -16,6 +16,28 @@
  * @property {string} updatedAt
  * @property {string | null} scheduledFor
  * @property {number} retryCount
+ * @property {string} lastError Error of the most recent failed attempt; empty when none.
+ */
+
+/**
+ * @typedef {"recipient" | "rejected" | "unavailable" | "timeout" | "network" | "configuration" | "unknown"} AttemptErrorClassKey
+ */
+
+/**
+ * @typedef {Object} NotificationAttemptItem
+ * @property {string} attemptedAt
+ * @property {string} provider Empty when the attempt never reached a provider.
+ * @property {number} durationMs
+ * @property {NotificationStatusKey} outcome Status the attempt left the notification in.
+ * @property {AttemptErrorClassKey | ""} errorClass Empty for successful attempts.
+ * @property {string} errorMessage
+ * @property {number} smtpReplyCode Zero unless an SMTP server rejected the attempt.
+ */
+
+/**
+ * @typedef {Object} NotificationPage
+ * @property {NotificationItem[]} notifications
+ * @property {string} nextPageToken Empty on the last page.
  */
 
 /**
@@ -23,3 +45,33 @@
  * @property {NotificationStatusKey | "all"} value
  * @property {string} label
  */
+
+/**
+ * @typedef {"unsubscribed" | "bounced" | "complaint" | "stop" | "manual"} SuppressionReasonKey
+ */
+
+/**
+ * @typedef {Object} SuppressionItem
+ * @property {"email" | "sms"} channel
+ * @property {string} recipient
+ * @property {string} category Empty when the suppression covers every category.
+ * @property {SuppressionReasonKey} reason
+ * @property {string} source
+ * @property {string | null} expiresAt
+ * @property {string} createdAt
+ */
+
+/**
+ * @typedef {"" | "stop" | "start" | "help"} InboundKeywordKey
+ */
+
+/**
+ * @typedef {Object} InboundMessageItem
+ * @property {string} provider
+ * @property {string} providerMessageId
+ * @property {string} fromNumber
+ * @property {string} toNumber
+ * @property {string} body
+ * @property {InboundKeywordKey} keyword Empty when the message is not a carrier keyword.
+ * @property {string} receivedAt
+ */
@@ -0,0 +1,83 @@
+// @ts-check
+import { INBOUND_KEYWORD_LABELS } from '../constants.js';
+import { DOM_EVENTS, dispatchToast, listen } from '../core/events.js';
+
+/** @typedef {import('../types.d.js').InboundMessageItem} InboundMessageItem */
+
+/**
+ * @param {{
+ *   apiClient: ReturnType<typeof import('../core/apiClient.js').createApiClient>,
+ *   strings: typeof import('../constants.js').STRINGS.inboundMessages,
+ *   actions: typeof import('../constants.js').STRINGS.actions,
+ * }} options
+ */
+export function createInboundMessagesPanel(options) {
+  const { apiClient, strings, actions } = options;
+  const authStore = () => window.Alpine.store('auth');
+
+  return {
+    strings,
+    actions,
+    messages: /** @type {InboundMessageItem[]} */ ([]),
+    fromFilter: '',
+    isLoading: false,
+    errorMessage: '',
+    stopListening: null,
+    init() {
+      this.refreshIfAuthenticated();
+      this.$watch(
+        () => authStore().isAuthenticated,
+        (isAuthenticated) => {
+          if (isAuthenticated) {
+            this.loadMessages();
+          } else {
+            this.messages = [];
+          }
+        },
+      );
+      this.stopListening = listen(DOM_EVENTS.refresh, () => {
+        if (authStore().isAuthenticated) {
+          this.loadMessages();
+        }
+      });
+    },
+    async loadMessages() {
+      if (!authStore().isAuthenticated) {
+        return;
+      }
+      this.isLoading = true;
+      this.errorMessage = '';
+      try {
+        this.messages = await apiClient.listInboundMessages(this.fromFilter.trim());
+      } catch (error) {
+        this.errorMessage = this.strings.loadError;
+        dispatchToast({ variant: 'error', message: this.errorMessage });
+      } finally {
+        this.isLoading = false;
+      }
+    },
+    async refreshIfAuthenticated() {
+      if (authStore().isAuthenticated) {
+        await this.loadMessages();
+      }
+    },
+    formatKeyword(keyword) {
+      return INBOUND_KEYWORD_LABELS[keyword] || this.strings.noKeyword;
+    },
+    formatReceivedAt(isoString) {
+      if (!isoString) {
+        return '—';
+      }
+      const date = new Date(isoString);
+      if (Number.isNaN(date.getTime())) {
+        return '—';
+      }
+      return date.toLocaleString();
+    },
+    $cleanup() {
+      if (typeof this.stopListening === 'function') {
+        this.stopListening();
+      }
+    },
+  };
+}
@@ -1,8 +1,9 @@
 // @ts-check
-import { STATUS_LABELS, STATUS_OPTIONS } from '../constants.js';
+import { ATTEMPT_ERROR_CLASS_LABELS, STATUS_LABELS, STATUS_OPTIONS } from '../constants.js';
 import { DOM_EVENTS, dispatchToast, listen } from '../core/events.js';
 
 /** @typedef {import('../types.d.js').NotificationItem} NotificationItem */
+/** @typedef {import('../types.d.js').NotificationAttemptItem} NotificationAttemptItem */
 
 const inputFormatter = {
   toControlValue(isoString) {
@@ -46,13 +47,19 @@ export function createNotificationsTable(options) {
     actions,
     notifications: /** @type {NotificationItem[]} */ ([]),
     statusFilter: 'all',
+    searchQuery: '',
+    nextPageToken: '',
     isLoading: false,
+    isLoadingMore: false,
     errorMessage: '',
     scheduleDialogVisible: false,
     scheduleForm: {
       id: '',
       scheduledTime: '',
     },
+    detailsNotification: /** @type {NotificationItem | null} */ (null),
+    detailsAttempts: /** @type {NotificationAttemptItem[]} */ ([]),
+    isLoadingAttempts: false,
     stopListening: null,
     STATUS_OPTIONS,
     init() {
@@ -64,6 +71,7 @@ export function createNotificationsTable(options) {
             this.loadNotifications();
           } else {
             this.notifications = [];
+            this.nextPageToken = '';
           }
         },
       );
@@ -80,8 +88,9 @@ export function createNotificationsTable(options) {
       this.isLoading = true;
       this.errorMessage = '';
       try {
-        const statuses = this.statusFilter === 'all' ? [] : [this.statusFilter];
-        this.notifications = await apiClient.listNotifications(statuses);
+        const page = await apiClient.listNotifications(this.currentFilters());
+        this.notifications = page.notifications;
+        this.nextPageToken = page.nextPageToken;
       } catch (error) {
         this.errorMessage = this.strings.loadError;
         dispatchToast({ variant: 'error', message: this.errorMessage });
@@ -89,6 +98,31 @@ export function createNotificationsTable(options) {
         this.isLoading = false;
       }
     },
+    async loadMoreNotifications() {
+      if (!authStore().isAuthenticated || !this.nextPageToken || this.isLoadingMore) {
+        return;
+      }
+      this.isLoadingMore = true;
+      try {
+        const page = await apiClient.listNotifications({
+          ...this.currentFilters(),
+          pageToken: this.nextPageToken,
+        });
+        this.notifications = [...this.notifications, ...page.notifications];
+        this.nextPageToken = page.nextPageToken;
+      } catch (error) {
+        this.errorMessage = this.strings.loadError;
+        dispatchToast({ variant: 'error', message: this.errorMessage });
+      } finally {
+        this.isLoadingMore = false;
+      }
+    },
+    currentFilters() {
+      return {
+        statuses: this.statusFilter === 'all' ? [] : [this.statusFilter],
+        search: this.searchQuery,
+      };
+    },
     async refreshIfAuthenticated() {
       if (authStore().isAuthenticated) {
         await this.loadNotifications();
@@ -107,6 +141,37 @@ export function createNotificationsTable(options) {
       }
       return date.toLocaleString();
     },
+    formatAttemptError(attempt) {
+      if (!attempt.errorClass) {
+        return '—';
+      }
+      const label = ATTEMPT_ERROR_CLASS_LABELS[attempt.errorClass] || attempt.errorClass;
+      return attempt.smtpReplyCode ? `${label} (SMTP ${attempt.smtpReplyCode})` : label;
+    },
+    async openDetailsDialog(notification) {
+      this.detailsNotification = notification;
+      this.detailsAttempts = [];
+      const dialog = this.$refs.detailsDialog;
+      if (dialog && typeof dialog.showModal === 'function') {
+        dialog.showModal();
+      }
+      this.isLoadingAttempts = true;
+      try {
+        this.detailsAttempts = await apiClient.getNotificationAttempts(notification.id);
+      } catch (error) {
+        dispatchToast({ variant: 'error', message: this.strings.attemptsLoadError });
+      } finally {
+        this.isLoadingAttempts = false;
+      }
+    },
+    closeDetailsDialog() {
+      this.detailsNotification = null;
+      this.detailsAttempts = [];
+      const dialog = this.$refs.detailsDialog;
+      if (dialog && typeof dialog.close === 'function') {
+        dialog.close();
+      }
+    },
     openScheduleDialog(notification) {
       this.scheduleForm.id = notification.id;
       this.scheduleForm.scheduledTime = inputFormatter.toControlValue(notification.scheduledFor);
@@ -160,6 +225,22 @@ export function createNotificationsTable(options) {
         this.isLoading = false;
       }
     },
+    async retryNotification(notificationId) {
+      if (!authStore().isAuthenticated) {
+        return;
+      }
+      this.isLoading = true;
+      try {
+        await apiClient.retryNotification(notificationId);
+        await this.loadNotifications();
+        dispatchToast({ variant: 'success', message: this.strings.retrySuccess });
+      } catch (error) {
+        this.errorMessage = this.strings.retryError;
+        dispatchToast({ variant: 'error', message: this.errorMessage });
+      } finally {
+        this.isLoading = false;
+      }
+    },
     $cleanup() {
       if (typeof this.stopListening === 'function') {
         this.stopListening();
@@ -0,0 +1,143 @@
+// @ts-check
+import { SUPPRESSION_CHANNEL_OPTIONS, SUPPRESSION_REASON_LABELS } from '../constants.js';
+import { DOM_EVENTS, dispatchToast, listen } from '../core/events.js';
+
+/** @typedef {import('../types.d.js').SuppressionItem} SuppressionItem */
+
+const emptyForm = () => ({
+  channel: 'email',
+  recipient: '',
+  category: '',
+  reason: 'manual',
+  expiresAt: '',
+});
+
+/**
+ * @param {{
+ *   apiClient: ReturnType<typeof import('../core/apiClient.js').createApiClient>,
+ *   strings: typeof import('../constants.js').STRINGS.suppressions,
+ *   actions: typeof import('../constants.js').STRINGS.actions,
+ * }} options
+ */
+export function createSuppressionsPanel(options) {
+  const { apiClient, strings, actions } = options;
+  const authStore = () => window.Alpine.store('auth');
+
+  return {
+    strings,
+    actions,
+    suppressions: /** @type {SuppressionItem[]} */ ([]),
+    channelFilter: 'all',
+    isLoading: false,
+    errorMessage: '',
+    form: emptyForm(),
+    stopListening: null,
+    CHANNEL_OPTIONS: SUPPRESSION_CHANNEL_OPTIONS,
+    REASON_OPTIONS: Object.entries(SUPPRESSION_REASON_LABELS).map(([value, label]) => ({
+      value,
+      label,
+    })),
+    init() {
+      this.refreshIfAuthenticated();
+      this.$watch(
+        () => authStore().isAuthenticated,
+        (isAuthenticated) => {
+          if (isAuthenticated) {
+            this.loadSuppressions();
+          } else {
+            this.suppressions = [];
+          }
+        },
+      );
+      this.stopListening = listen(DOM_EVENTS.refresh, () => {
+        if (authStore().isAuthenticated) {
+          this.loadSuppressions();
+        }
+      });
+    },
+    async loadSuppressions() {
+      if (!authStore().isAuthenticated) {
+        return;
+      }
+      this.isLoading = true;
+      this.errorMessage = '';
+      try {
+        const channel = this.channelFilter === 'all' ? '' : this.channelFilter;
+        this.suppressions = await apiClient.listSuppressions(channel);
+      } catch (error) {
+        this.errorMessage = this.strings.loadError;
+        dispatchToast({ variant: 'error', message: this.errorMessage });
+      } finally {
+        this.isLoading = false;
+      }
+    },
+    async refreshIfAuthenticated() {
+      if (authStore().isAuthenticated) {
+        await this.loadSuppressions();
+      }
+    },
+    formatCategory(category) {
+      return category || this.strings.allCategories;
+    },
+    formatReason(reason) {
+      return SUPPRESSION_REASON_LABELS[reason] || reason;
+    },
+    formatExpiry(isoString) {
+      if (!isoString) {
+        return 'Never';
+      }
+      const date = new Date(isoString);
+      if (Number.isNaN(date.getTime())) {
+        return '—';
+      }
+      return date.toLocaleString();
+    },
+    async submitSuppression(event) {
+      event?.preventDefault();
+      let expiresAt = null;
+      if (this.form.expiresAt) {
+        const date = new Date(this.form.expiresAt);
+        expiresAt = Number.isNaN(date.getTime()) ? null : date.toISOString();
+      }
+      try {
+        await apiClient.addSuppression({
+          channel: this.form.channel,
+          recipient: this.form.recipient.trim(),
+          category: this.form.category.trim(),
+          reason: this.form.reason,
+          expiresAt,
+        });
+        this.form = emptyForm();
+        await this.loadSuppressions();
+        dispatchToast({ variant: 'success', message: this.strings.addSuccess });
+      } catch (error) {
+        this.errorMessage = error?.message || this.strings.addError;
+        dispatchToast({ variant: 'error', message: this.strings.addError });
+      }
+    },
+    async removeSuppression(item) {
+      if (!authStore().isAuthenticated) {
+        return;
+      }
+      if (!window.confirm(this.strings.removeConfirm)) {
+        return;
+      }
+      this.isLoading = true;
+      try {
+        await apiClient.removeSuppression(item.channel, item.recipient, item.category);
+        await this.loadSuppressions();
+        dispatchToast({ variant: 'success', message: this.strings.removeSuccess });
+      } catch (error) {
+        this.errorMessage = this.strings.removeError;
+        dispatchToast({ variant: 'error', message: this.errorMessage });
+      } finally {
+        this.isLoading = false;
+      }
+    },
+    $cleanup() {
+      if (typeof this.stopListening === 'function') {
+        this.stopListening();
+      }
+    },
+  };
+}